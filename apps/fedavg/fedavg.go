@@ -0,0 +1,286 @@
+// Package fedavg provides a ready-made encrypted federated averaging (FedAvg) aggregation
+// built only on the public APIs of the library: clients contribute model updates weighted
+// by their private local sample counts, the aggregator computes the weighted average under
+// encryption (including the division by the encrypted total count and, optionally, the
+// clipping of the contributions via approximate comparisons), and the aggregate is released
+// through a t-out-of-N threshold decryption.
+//
+// Like the code in the examples package, this package is meant as a reference implementation
+// and a starting point for experiments. It should not be depended upon and may at any time
+// be changed or removed.
+package fedavg
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/comparison"
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/inverse"
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/minimax"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+var (
+	// ParamsN14 is a preset parameter set for clipping-free FedAvg aggregation: the moduli
+	// chain provides the depth of the Goldschmidt division by the encrypted total count
+	// and of the final weighting product, without bootstrapping.
+	ParamsN14 = ckks.ParametersLiteral{
+		LogN:            14,
+		LogQ:            []int{55, 45, 45, 45, 45, 45, 45, 45},
+		LogP:            []int{61},
+		LogDefaultScale: 45,
+	}
+
+	// ParamsN16Clipping is a preset parameter set for FedAvg aggregation with clipping: the
+	// deeper moduli chain additionally accommodates the evaluation of the default composite
+	// sign polynomial used by the approximate comparisons.
+	ParamsN16Clipping = ckks.ParametersLiteral{
+		LogN: 16,
+		LogQ: []int{60, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45,
+			45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45},
+		LogP:            []int{61, 61},
+		LogDefaultScale: 45,
+	}
+)
+
+// Parameters bundles the CKKS cryptographic parameters with the public FedAvg configuration.
+type Parameters struct {
+	ckks.Parameters
+
+	// MaxSamples is a public upper bound on the total number of training samples across all
+	// clients. Sample counts are encrypted normalized by MaxSamples so that the encrypted
+	// division operates on the interval (0, 1].
+	MaxSamples int
+
+	// Clip is the L-infinity bound to which the weighted client contributions are clipped
+	// by the aggregator. A value of 0 disables clipping. When enabled, it must lie in (0, 1]
+	// and the weighted contributions must lie in [-1, 1] (see [comparison.Evaluator.Max]).
+	Clip float64
+}
+
+// NewParameters instantiates the FedAvg parameters from a [ckks.ParametersLiteral] preset, a
+// public bound on the total number of samples and a clipping bound (0 to disable clipping).
+func NewParameters(literal ckks.ParametersLiteral, maxSamples int, clip float64) (Parameters, error) {
+
+	if maxSamples < 1 {
+		return Parameters{}, fmt.Errorf("maxSamples must be at least 1 but is %d", maxSamples)
+	}
+
+	if clip < 0 || clip > 1 {
+		return Parameters{}, fmt.Errorf("clip must lie in [0, 1] but is %f", clip)
+	}
+
+	params, err := ckks.NewParametersFromLiteral(literal)
+	if err != nil {
+		return Parameters{}, err
+	}
+
+	return Parameters{Parameters: params, MaxSamples: maxSamples, Clip: clip}, nil
+}
+
+// Client encrypts the weighted model updates of a single federated learning participant.
+type Client struct {
+	params Parameters
+	ecd    *ckks.Encoder
+	enc    *rlwe.Encryptor
+}
+
+// NewClient instantiates a new [Client] encrypting under the collective public key pk.
+func NewClient(params Parameters, pk *rlwe.PublicKey) *Client {
+	return &Client{
+		params: params,
+		ecd:    ckks.NewEncoder(params.Parameters),
+		enc:    rlwe.NewEncryptor(params.Parameters, pk),
+	}
+}
+
+// EncryptUpdate encrypts the client's model update weighted by its local sample count:
+// it returns Enc(count/MaxSamples * update) and Enc(count/MaxSamples), hiding both the
+// update and the sample count from the aggregator. The update values must lie in [-1, 1].
+func (c *Client) EncryptUpdate(update []float64, count int) (ctUpdate, ctCount *rlwe.Ciphertext, err error) {
+
+	if count < 1 || count > c.params.MaxSamples {
+		return nil, nil, fmt.Errorf("count must lie in [1, %d] but is %d", c.params.MaxSamples, count)
+	}
+
+	if len(update) > c.params.MaxSlots() {
+		return nil, nil, fmt.Errorf("update has %d values but parameters only provide %d slots", len(update), c.params.MaxSlots())
+	}
+
+	weight := float64(count) / float64(c.params.MaxSamples)
+
+	weighted := make([]float64, len(update))
+	for i := range update {
+		weighted[i] = weight * update[i]
+	}
+
+	ptUpdate := ckks.NewPlaintext(c.params.Parameters, c.params.MaxLevel())
+	if err = c.ecd.Encode(weighted, ptUpdate); err != nil {
+		return nil, nil, err
+	}
+
+	if ctUpdate, err = c.enc.EncryptNew(ptUpdate); err != nil {
+		return nil, nil, err
+	}
+
+	weights := make([]float64, c.params.MaxSlots())
+	for i := range weights {
+		weights[i] = weight
+	}
+
+	ptCount := ckks.NewPlaintext(c.params.Parameters, c.params.MaxLevel())
+	if err = c.ecd.Encode(weights, ptCount); err != nil {
+		return nil, nil, err
+	}
+
+	if ctCount, err = c.enc.EncryptNew(ptCount); err != nil {
+		return nil, nil, err
+	}
+
+	return
+}
+
+// Aggregator computes the encrypted weighted average of the clients' contributions.
+type Aggregator struct {
+	Parameters Parameters
+	*ckks.Evaluator
+	InvEval inverse.Evaluator
+	CmpEval *comparison.Evaluator
+}
+
+// NewAggregator instantiates a new [Aggregator] from a [minimax.Evaluator]. The underlying
+// [ckks.Evaluator] must hold a relinearization key and, for the standard ring, the Galois key
+// for complex conjugation. The minimax evaluator's bootstrapper is used whenever the moduli
+// chain does not provide enough levels (it may be nil with a deep enough chain and clipping
+// disabled).
+func NewAggregator(params Parameters, minimaxEvl *minimax.Evaluator) *Aggregator {
+	return &Aggregator{
+		Parameters: params,
+		Evaluator:  minimaxEvl.Evaluator,
+		InvEval:    inverse.NewEvaluator(params.Parameters, minimaxEvl),
+		CmpEval:    comparison.NewEvaluator(params.Parameters, minimaxEvl),
+	}
+}
+
+// Clip clips each slot of the weighted contribution ct to [-Clip, Clip] by evaluating
+// min(max(ct, -Clip), Clip) with approximate comparisons.
+func (agg *Aggregator) Clip(ct *rlwe.Ciphertext) (clipped *rlwe.Ciphertext, err error) {
+
+	lo, err := agg.constantNew(-agg.Parameters.Clip, ct)
+	if err != nil {
+		return nil, err
+	}
+
+	if clipped, err = agg.CmpEval.Max(ct, lo); err != nil {
+		return nil, fmt.Errorf("max: %w", err)
+	}
+
+	hi, err := agg.constantNew(agg.Parameters.Clip, clipped)
+	if err != nil {
+		return nil, err
+	}
+
+	if clipped, err = agg.CmpEval.Min(clipped, hi); err != nil {
+		return nil, fmt.Errorf("min: %w", err)
+	}
+
+	return
+}
+
+// Aggregate computes Enc(sum_i(count_i * update_i) / sum_i(count_i)) from the clients'
+// weighted contributions (as returned by [Client.EncryptUpdate]). If the clipping bound is
+// non-zero, each weighted contribution is first clipped to [-Clip, Clip].
+func (agg *Aggregator) Aggregate(updates, counts []*rlwe.Ciphertext) (avg *rlwe.Ciphertext, err error) {
+
+	if len(updates) == 0 {
+		return nil, fmt.Errorf("at least one client contribution is required")
+	}
+
+	if len(updates) != len(counts) {
+		return nil, fmt.Errorf("%d updates but %d counts", len(updates), len(counts))
+	}
+
+	var sumU *rlwe.Ciphertext
+	for i := range updates {
+
+		u := updates[i]
+
+		if agg.Parameters.Clip != 0 {
+			if u, err = agg.Clip(u); err != nil {
+				return nil, fmt.Errorf("clip update %d: %w", i, err)
+			}
+		}
+
+		if i == 0 {
+			sumU = u.CopyNew()
+		} else if err = agg.Add(sumU, u, sumU); err != nil {
+			return nil, err
+		}
+	}
+
+	sumN := counts[0].CopyNew()
+	for i := 1; i < len(counts); i++ {
+		if err = agg.Add(sumN, counts[i], sumN); err != nil {
+			return nil, err
+		}
+	}
+
+	// 1/(sum_i(count_i)/MaxSamples), with the total count at least 1 sample
+	var inv *rlwe.Ciphertext
+	if inv, err = agg.InvEval.GoldschmidtDivisionNew(sumN, -math.Log2(float64(agg.Parameters.MaxSamples))); err != nil {
+		return nil, fmt.Errorf("division by the total count: %w", err)
+	}
+
+	// The division and the clipping may return their result at the lowest level;
+	// bootstrap the operands if the final weighting product could not be rescaled
+	// otherwise.
+	if btp := agg.InvEval.BtsEval; btp != nil {
+		minLevel := agg.Parameters.LevelsConsumedPerRescaling() + btp.MinimumInputLevel()
+		if inv.Level() < minLevel {
+			if inv, err = btp.Bootstrap(inv); err != nil {
+				return nil, err
+			}
+		}
+		if sumU.Level() < minLevel {
+			if sumU, err = btp.Bootstrap(sumU); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if avg, err = agg.MulRelinNew(sumU, inv); err != nil {
+		return nil, err
+	}
+
+	if err = agg.Rescale(avg, avg); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// constantNew returns a transparent (unencrypted) ciphertext holding the constant c in every
+// slot, at the level and scale of the template ciphertext.
+func (agg *Aggregator) constantNew(c float64, template *rlwe.Ciphertext) (ct *rlwe.Ciphertext, err error) {
+
+	params := agg.Parameters
+
+	values := make([]float64, params.MaxSlots())
+	for i := range values {
+		values[i] = c
+	}
+
+	pt := ckks.NewPlaintext(params.Parameters, template.Level())
+	pt.Scale = template.Scale
+
+	if err = agg.Encoder.Encode(values, pt); err != nil {
+		return nil, err
+	}
+
+	ct = rlwe.NewCiphertext(params.Parameters, 1, template.Level())
+	*ct.MetaData = *pt.MetaData
+	ct.Value[0].Copy(pt.Value)
+
+	return
+}
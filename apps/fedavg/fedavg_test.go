@@ -0,0 +1,130 @@
+package fedavg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/bootstrapping"
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/minimax"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/multiparty"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+func TestFedAvg(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	params, err := NewParameters(ckks.ParametersLiteral{
+		LogN:            10,
+		LogQ:            []int{55, 45, 45, 45, 45, 45, 45, 45, 45, 45},
+		LogP:            []int{61},
+		LogDefaultScale: 45,
+	}, 8, 0.05)
+	require.NoError(t, err)
+
+	nParties := 3
+	threshold := 2
+
+	// Collective key generation, simulated in-process: the ideal secret key is the
+	// sum of the parties' secret keys.
+	kgen := rlwe.NewKeyGenerator(params.Parameters)
+
+	skShares := make([]*rlwe.SecretKey, nParties)
+	skIdeal := rlwe.NewSecretKey(params.Parameters)
+	for i := range skShares {
+		skShares[i] = kgen.GenSecretKeyNew()
+		params.RingQP().Add(skIdeal.Value, skShares[i].Value, skIdeal.Value)
+	}
+
+	pk := kgen.GenPublicKeyNew(skIdeal)
+	evk := rlwe.NewMemEvaluationKeySet(kgen.GenRelinearizationKeyNew(skIdeal),
+		kgen.GenGaloisKeyNew(params.GaloisElementForComplexConjugation(), skIdeal))
+
+	// Clients encrypt their weighted updates
+	updates := [][]float64{
+		{0.02, -0.04, 0.06},
+		{-0.01, 0.03, -0.05},
+		{0.04, 0.02, -0.02},
+	}
+	counts := []int{1, 3, 2}
+
+	ctUpdates := make([]*rlwe.Ciphertext, nParties)
+	ctCounts := make([]*rlwe.Ciphertext, nParties)
+	for i := range updates {
+		client := NewClient(params, pk)
+		ctUpdates[i], ctCounts[i], err = client.EncryptUpdate(updates[i], counts[i])
+		require.NoError(t, err)
+	}
+
+	// Aggregation, with a secret-key bootstrapper standing in for the full bootstrapping
+	eval := ckks.NewEvaluator(params.Parameters, evk)
+	btp := bootstrapping.NewSecretKeyBootstrapper(params.Parameters, skIdeal)
+	agg := NewAggregator(params, minimax.NewEvaluator(params.Parameters, eval, btp))
+
+	ctAvg, err := agg.Aggregate(ctUpdates, ctCounts)
+	require.NoError(t, err)
+
+	// Threshold setup and decryption with threshold-out-of-nParties parties
+	points := make([]multiparty.ShamirPublicPoint, nParties)
+	for i := range points {
+		points[i] = multiparty.ShamirPublicPoint(i + 1)
+	}
+
+	parties := make([]*Party, nParties)
+	for i := range parties {
+		parties[i], err = NewParty(params, points[i], points, threshold, skShares[i])
+		require.NoError(t, err)
+	}
+
+	received := make([]map[multiparty.ShamirPublicPoint]multiparty.ShamirSecretShareQP, nParties)
+	for i, p := range parties {
+		received[i], err = p.GenShamirShares(threshold, points)
+		require.NoError(t, err)
+	}
+
+	for i, p := range parties {
+		for j := range parties {
+			p.AggregateShamirShares(received[j][points[i]])
+		}
+	}
+
+	active := parties[:threshold]
+	activePoints := points[:threshold]
+
+	shares := make([]multiparty.KeySwitchShare, len(active))
+	for i, p := range active {
+		shares[i] = p.GenDecryptionShare(ctAvg, activePoints)
+	}
+
+	have := make([]float64, params.MaxSlots())
+	require.NoError(t, active[0].Finalize(ctAvg, shares, have))
+
+	// Expected weighted average with clipped contributions
+	clip := func(x float64) float64 {
+		if x > params.Clip {
+			return params.Clip
+		}
+		if x < -params.Clip {
+			return -params.Clip
+		}
+		return x
+	}
+
+	var totalCount float64
+	for _, n := range counts {
+		totalCount += float64(n)
+	}
+
+	for j := range updates[0] {
+		var want float64
+		for i := range updates {
+			want += clip(float64(counts[i]) / float64(params.MaxSamples) * updates[i][j])
+		}
+		want /= totalCount / float64(params.MaxSamples)
+
+		// The tolerance is dominated by the noise flooding of the key-switch
+		// to the zero key, not by the precision of the aggregation circuit.
+		require.InDelta(t, want, have[j], 1e-2)
+	}
+}
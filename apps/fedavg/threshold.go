@@ -0,0 +1,134 @@
+package fedavg
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/multiparty"
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// Party holds the threshold secret-key material of one of the N aggregation parties. The
+// collective secret key is the sum of the parties' secret keys and any subset of at least
+// `threshold` parties can jointly release an aggregate: each active party generates a
+// key-switch share towards the zero key (see [Party.GenDecryptionShare]) so that the
+// switched ciphertext becomes publicly decodable.
+type Party struct {
+	Point multiparty.ShamirPublicPoint
+
+	params        Parameters
+	thresholdizer multiparty.Thresholdizer
+	combiner      multiparty.Combiner
+	cks           multiparty.KeySwitchProtocol
+
+	sk   *rlwe.SecretKey
+	tsks multiparty.ShamirSecretShareQP
+	tsk  *rlwe.SecretKey
+	zero *rlwe.SecretKey
+
+	ecd *ckks.Encoder
+	dec *rlwe.Decryptor
+}
+
+// NewParty instantiates a new [Party] from its secret-key share sk of the collective key.
+// point must be the party's own Shamir public point and points the public points of all N
+// parties (its own included).
+func NewParty(params Parameters, point multiparty.ShamirPublicPoint, points []multiparty.ShamirPublicPoint, threshold int, sk *rlwe.SecretKey) (*Party, error) {
+
+	if threshold < 1 || threshold > len(points) {
+		return nil, fmt.Errorf("threshold must lie in [1, %d] but is %d", len(points), threshold)
+	}
+
+	cks, err := multiparty.NewKeySwitchProtocol(params.Parameters, ring.DiscreteGaussian{Sigma: 1 << 30, Bound: 6 * (1 << 30)})
+	if err != nil {
+		return nil, err
+	}
+
+	thresholdizer := multiparty.NewThresholdizer(params.Parameters)
+
+	combiner, err := multiparty.NewCombiner(*params.GetRLWEParameters(), point, points, threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	zero := rlwe.NewSecretKey(params.Parameters)
+
+	return &Party{
+		Point:         point,
+		params:        params,
+		thresholdizer: thresholdizer,
+		combiner:      combiner,
+		cks:           cks,
+		sk:            sk,
+		tsks:          thresholdizer.AllocateThresholdSecretShare(),
+		tsk:           rlwe.NewSecretKey(params.Parameters),
+		zero:          zero,
+		ecd:           ckks.NewEncoder(params.Parameters),
+		dec:           rlwe.NewDecryptor(params.Parameters, zero),
+	}, nil
+}
+
+// GenShamirShares secret-shares the party's secret key among all N parties and returns one
+// [multiparty.ShamirSecretShareQP] per Shamir public point, to be sent to the corresponding
+// party over a private channel.
+func (p *Party) GenShamirShares(threshold int, points []multiparty.ShamirPublicPoint) (shares map[multiparty.ShamirPublicPoint]multiparty.ShamirSecretShareQP, err error) {
+
+	gen, err := p.thresholdizer.GenShamirPolynomialQP(threshold, p.sk)
+	if err != nil {
+		return nil, err
+	}
+
+	shares = make(map[multiparty.ShamirPublicPoint]multiparty.ShamirSecretShareQP, len(points))
+	for _, point := range points {
+		share := p.thresholdizer.AllocateThresholdSecretShare()
+		p.thresholdizer.GenShamirSecretShareQP(point, gen, &share)
+		shares[point] = share
+	}
+
+	return
+}
+
+// AggregateShamirShares aggregates the Shamir shares that the party received from all N
+// parties (its own included) into its share of the collective secret key. It must be called
+// once, with all N shares, before any call to [Party.GenDecryptionShare].
+func (p *Party) AggregateShamirShares(shares ...multiparty.ShamirSecretShareQP) {
+	for _, share := range shares {
+		p.thresholdizer.AggregateShares(p.tsks, share, &p.tsks)
+	}
+}
+
+// GenDecryptionShare generates the party's key-switch share for the public release of ct.
+// activePoints must be the Shamir public points of the at least `threshold` active parties,
+// p.Point included, and must be identical across all active parties.
+func (p *Party) GenDecryptionShare(ct *rlwe.Ciphertext, activePoints []multiparty.ShamirPublicPoint) multiparty.KeySwitchShare {
+	p.combiner.GenAdditiveShareQP(activePoints, p.Point, p.tsks, p.tsk)
+	share := p.cks.AllocateShare(ct.Level())
+	p.cks.GenShare(p.tsk, p.zero, ct, &share)
+	return share
+}
+
+// Finalize combines the decryption shares of the active parties, switches ct to the zero key
+// and decodes the aggregate on values (see [ckks.Encoder.Decode] for the accepted types).
+// Since the output key is zero, the switched ciphertext is publicly decodable: any party
+// (or an external receiver holding the shares) can finalize.
+func (p *Party) Finalize(ct *rlwe.Ciphertext, shares []multiparty.KeySwitchShare, values interface{}) (err error) {
+
+	if len(shares) == 0 {
+		return fmt.Errorf("at least one decryption share is required")
+	}
+
+	combined := p.cks.AllocateShare(ct.Level())
+	for _, share := range shares {
+		if err = p.cks.AggregateShares(combined, share, &combined); err != nil {
+			return err
+		}
+	}
+
+	out := ct.CopyNew()
+	if err = p.cks.KeySwitch(ct, combined, out); err != nil {
+		return err
+	}
+
+	return p.ecd.Decode(p.dec.DecryptNew(out), values)
+}
@@ -0,0 +1,195 @@
+// Package blindeval implements an OPRF-style blind function evaluation
+// protocol on top of the BFV scheme: a client holds a private input, a server
+// holds a secret function given as a polynomial (or a look-up table, see
+// [InterpolateLUT]) over Z_t, and a single round of interaction yields the
+// encrypted evaluation of the function on the input without either party
+// learning the other's data.
+//
+// The client encrypts its input under its own key and sends the ciphertext
+// together with its public encryption material ([Client.EvaluationKeys]); the
+// server evaluates its polynomial homomorphically and sanitizes the result
+// before responding: the response is re-randomized with a fresh encryption of
+// zero under the client's public key and flooded with noise, so that its
+// distribution is statistically independent of the server's polynomial beyond
+// the evaluated value. The flooding distribution must be chosen according to
+// the desired statistical security and the remaining noise budget of the
+// parameters.
+//
+// The protocol is secure against semi-honest parties. The multiplicative
+// depth of the evaluation still leaks ceil(log2(deg+1)); a server wishing to
+// hide the degree of its polynomial can pad it with zero leading coefficients
+// to a fixed public degree.
+package blindeval
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/bgv/polynomial"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+// Client is the party holding the private input of the blind evaluation. It
+// owns the secret key of the protocol: the server only ever sees the public
+// material returned by [Client.EvaluationKeys].
+type Client struct {
+	params bgv.Parameters
+	ecd    *bgv.Encoder
+	enc    *rlwe.Encryptor
+	dec    *rlwe.Decryptor
+	pk     *rlwe.PublicKey
+	rlk    *rlwe.RelinearizationKey
+}
+
+// NewClient instantiates a new [Client], generating a fresh secret key, public
+// key and relinearization key for the given parameters.
+func NewClient(params bgv.Parameters) *Client {
+
+	kgen := bgv.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	pk := kgen.GenPublicKeyNew(sk)
+
+	return &Client{
+		params: params,
+		ecd:    bgv.NewEncoder(params),
+		enc:    bgv.NewEncryptor(params, pk),
+		dec:    bgv.NewDecryptor(params, sk),
+		pk:     pk,
+		rlk:    kgen.GenRelinearizationKeyNew(sk),
+	}
+}
+
+// EvaluationKeys returns the public material the server requires: the public
+// key, used to re-randomize the response, and the relinearization key, used by
+// the polynomial evaluation.
+func (c *Client) EvaluationKeys() (pk *rlwe.PublicKey, rlk *rlwe.RelinearizationKey) {
+	return c.pk, c.rlk
+}
+
+// QueryNew encodes and encrypts the input values into the query ciphertext of
+// the protocol. The function is evaluated slot-wise, so up to MaxSlots inputs
+// can be batched in a single query.
+func (c *Client) QueryNew(values []uint64) (query *rlwe.Ciphertext, err error) {
+
+	pt := bgv.NewPlaintext(c.params, c.params.MaxLevel())
+
+	if err = c.ecd.Encode(values, pt); err != nil {
+		return nil, fmt.Errorf("cannot QueryNew: %w", err)
+	}
+
+	if query, err = c.enc.EncryptNew(pt); err != nil {
+		return nil, fmt.Errorf("cannot QueryNew: %w", err)
+	}
+
+	return
+}
+
+// Recover decrypts and decodes the server response into values, yielding the
+// slot-wise evaluation of the server's polynomial on the queried inputs.
+func (c *Client) Recover(response *rlwe.Ciphertext, values []uint64) (err error) {
+
+	if err = c.ecd.Decode(c.dec.DecryptNew(response), values); err != nil {
+		return fmt.Errorf("cannot Recover: %w", err)
+	}
+
+	return
+}
+
+// Server is the party holding the secret function of the blind evaluation, as
+// the coefficients of a polynomial over Z_t. A Server is not safe for
+// concurrent use.
+type Server struct {
+	params       bgv.Parameters
+	eval         *polynomial.Evaluator
+	f            polynomial.Polynomial
+	enc          *rlwe.Encryptor
+	noiseSampler ring.Sampler
+	buf          ring.Poly
+}
+
+// NewServer instantiates a new [Server] evaluating the secret polynomial with
+// the given coefficients (coeffs[i] is the coefficient of X^{i}) under the
+// client's public material, using BFV-style (scale-invariant) tensoring. The
+// noise flooding distribution sanitizes the responses and must be of type
+// [ring.DiscreteGaussian], with a standard deviation chosen according to the
+// desired statistical security and the noise budget of the parameters.
+func NewServer(params bgv.Parameters, coeffs []uint64, pk *rlwe.PublicKey, rlk *rlwe.RelinearizationKey, noiseFlooding ring.DistributionParameters) (*Server, error) {
+
+	if len(coeffs) < 2 {
+		return nil, fmt.Errorf("cannot NewServer: the polynomial must have degree at least 1 but has %d coefficients", len(coeffs))
+	}
+
+	if _, ok := noiseFlooding.(ring.DiscreteGaussian); !ok {
+		return nil, fmt.Errorf("cannot NewServer: invalid distribution type, expected %T but got %T", ring.DiscreteGaussian{}, noiseFlooding)
+	}
+
+	prng, err := sampling.NewPRNG()
+	if err != nil {
+		return nil, fmt.Errorf("cannot NewServer: %w", err)
+	}
+
+	noiseSampler, err := ring.NewSampler(prng, params.RingQ(), noiseFlooding, false)
+	if err != nil {
+		return nil, fmt.Errorf("cannot NewServer: %w", err)
+	}
+
+	return &Server{
+		params:       params,
+		eval:         polynomial.NewEvaluator(params, bgv.NewEvaluator(params, rlwe.NewMemEvaluationKeySet(rlk), true)),
+		f:            polynomial.NewPolynomial(coeffs),
+		enc:          bgv.NewEncryptor(params, pk),
+		noiseSampler: noiseSampler,
+		buf:          params.RingQ().NewPoly(),
+	}, nil
+}
+
+// EvaluateNew evaluates the secret polynomial on the query ciphertext and
+// returns the sanitized response (see [Server.Sanitize]).
+func (s *Server) EvaluateNew(query *rlwe.Ciphertext) (response *rlwe.Ciphertext, err error) {
+
+	if response, err = s.eval.Evaluate(query, s.f, s.params.DefaultScale()); err != nil {
+		return nil, fmt.Errorf("cannot EvaluateNew: %w", err)
+	}
+
+	if err = s.Sanitize(response); err != nil {
+		return nil, fmt.Errorf("cannot EvaluateNew: %w", err)
+	}
+
+	return
+}
+
+// Sanitize re-randomizes ct in place with a fresh encryption of zero under the
+// client's public key and adds the flooding noise, so that the response
+// distribution is statistically independent of the evaluated polynomial
+// beyond the encrypted result.
+func (s *Server) Sanitize(ct *rlwe.Ciphertext) (err error) {
+
+	if ct.Degree() != 1 {
+		return fmt.Errorf("cannot Sanitize: ciphertext degree must be 1 but is %d", ct.Degree())
+	}
+
+	level := ct.Level()
+	ringQ := s.params.RingQ().AtLevel(level)
+
+	zero := rlwe.NewCiphertext(s.params, 1, level)
+	*zero.MetaData = *ct.MetaData
+
+	if err = s.enc.EncryptZero(zero); err != nil {
+		return fmt.Errorf("cannot Sanitize: %w", err)
+	}
+
+	ringQ.Add(ct.Value[0], zero.Value[0], ct.Value[0])
+	ringQ.Add(ct.Value[1], zero.Value[1], ct.Value[1])
+
+	s.noiseSampler.AtLevel(level).Read(s.buf)
+
+	if ct.IsNTT {
+		ringQ.NTT(s.buf, s.buf)
+	}
+
+	ringQ.Add(ct.Value[0], s.buf, ct.Value[0])
+
+	return
+}
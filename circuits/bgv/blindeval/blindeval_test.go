@@ -0,0 +1,138 @@
+package blindeval
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+// Insecure parameters used for the sole purpose of fast testing: look-up
+// tables interpolate to polynomials of degree up to t-1, so the tests use a
+// small plaintext modulus.
+var testParametersLiteral = bgv.ParametersLiteral{
+	LogN:             7,
+	LogQ:             []int{60, 60, 60, 60, 60, 60, 60, 60, 60},
+	LogP:             []int{61},
+	PlaintextModulus: 0x101, // t = 257, compatible with N = 128
+}
+
+func TestInterpolateLUT(t *testing.T) {
+
+	const q = 17
+
+	values := make([]uint64, q)
+	for z := range values {
+		values[z] = sampling.RandUint64() % q
+	}
+
+	coeffs, err := InterpolateLUT(values)
+	require.NoError(t, err)
+
+	evaluate := func(x uint64) (y uint64) {
+		for i := len(coeffs) - 1; i >= 0; i-- {
+			y = (y*x + coeffs[i]) % q
+		}
+		return
+	}
+
+	for z := uint64(0); z < q; z++ {
+		require.Equal(t, values[z], evaluate(z))
+	}
+
+	// Non-reduced value
+	_, err = InterpolateLUT([]uint64{q, 0, 0})
+	require.Error(t, err)
+
+	// Modulus too large
+	_, err = InterpolateLUT(make([]uint64, MaxPlaintextModulus+2))
+	require.Error(t, err)
+}
+
+func TestBlindEvaluation(t *testing.T) {
+
+	params, err := bgv.NewParametersFromLiteral(testParametersLiteral)
+	require.NoError(t, err)
+
+	T := params.PlaintextModulus()
+	slots := params.MaxSlots()
+
+	client := NewClient(params)
+	pk, rlk := client.EvaluationKeys()
+
+	noiseFlooding := ring.DiscreteGaussian{Sigma: 1 << 30, Bound: 6 << 30}
+
+	inputs := make([]uint64, slots)
+	for i := range inputs {
+		inputs[i] = sampling.RandUint64() % T
+	}
+
+	query, err := client.QueryNew(inputs)
+	require.NoError(t, err)
+
+	t.Run("Polynomial", func(t *testing.T) {
+
+		// Secret polynomial f(x) = 3x^3 + 2x + 7 mod t
+		coeffs := []uint64{7, 2, 0, 3}
+
+		server, err := NewServer(params, coeffs, pk, rlk, noiseFlooding)
+		require.NoError(t, err)
+
+		response, err := server.EvaluateNew(query)
+		require.NoError(t, err)
+
+		have := make([]uint64, slots)
+		require.NoError(t, client.Recover(response, have))
+
+		for i := range have {
+			x := inputs[i]
+			want := (((3*x%T)*x%T)*x%T + 2*x%T + 7) % T
+			require.Equal(t, want, have[i])
+		}
+	})
+
+	t.Run("LUT", func(t *testing.T) {
+
+		values := make([]uint64, T)
+		for z := range values {
+			values[z] = sampling.RandUint64() % T
+		}
+
+		coeffs, err := InterpolateLUT(values)
+		require.NoError(t, err)
+
+		server, err := NewServer(params, coeffs, pk, rlk, noiseFlooding)
+		require.NoError(t, err)
+
+		response, err := server.EvaluateNew(query)
+		require.NoError(t, err)
+
+		have := make([]uint64, slots)
+		require.NoError(t, client.Recover(response, have))
+
+		for i := range have {
+			require.Equal(t, values[inputs[i]], have[i])
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+
+		// Constant polynomial
+		_, err := NewServer(params, []uint64{1}, pk, rlk, noiseFlooding)
+		require.Error(t, err)
+
+		// Invalid flooding distribution
+		_, err = NewServer(params, []uint64{1, 2}, pk, rlk, ring.Ternary{P: 0.5})
+		require.Error(t, err)
+
+		// Sanitizing a non-relinearized ciphertext
+		server, err := NewServer(params, []uint64{1, 2}, pk, rlk, noiseFlooding)
+		require.NoError(t, err)
+		ct := query.CopyNew()
+		ct.Value = append(ct.Value, ct.Value[0])
+		require.Error(t, server.Sanitize(ct))
+	})
+}
@@ -0,0 +1,62 @@
+package blindeval
+
+import (
+	"fmt"
+)
+
+// MaxPlaintextModulus is the largest plaintext modulus for which a look-up
+// table is interpolated: the interpolation is quadratic in t and produces a
+// polynomial of degree up to t-1, so the approach is practical for small
+// plaintext moduli only.
+const MaxPlaintextModulus = 1 << 17
+
+// InterpolateLUT returns the coefficients over Z_t of the interpolation
+// polynomial of the look-up table f: Z_t -> Z_t with f(z) = values[z], so
+// that an arbitrary secret function can be blindly evaluated with [Server].
+// The plaintext modulus t = len(values) must be an odd prime (which the bgv
+// parameters enforce) and must not exceed [MaxPlaintextModulus].
+//
+// The interpolation uses the closed form of the Lagrange basis over the full
+// domain Z_t: with P(x) = x^t - x and P'(z) = -1 mod t, the interpolation of
+// f is f(x) = -sum_z f(z) * Q_z(x) with Q_z(x) = P(x)/(x - z), where
+// Q_0(x) = x^{t-1} - 1 and Q_z(x) = sum_{i=1}^{t-1} z^{t-1-i} x^i for z != 0.
+func InterpolateLUT(values []uint64) (coeffs []uint64, err error) {
+
+	t := uint64(len(values))
+
+	if t > MaxPlaintextModulus {
+		return nil, fmt.Errorf("cannot InterpolateLUT: plaintext modulus %d is larger than %d", t, MaxPlaintextModulus)
+	}
+
+	for z, v := range values {
+		if v >= t {
+			return nil, fmt.Errorf("cannot InterpolateLUT: values[%d] = %d is not reduced modulo %d", z, v, t)
+		}
+	}
+
+	coeffs = make([]uint64, t)
+
+	// acc[i] = sum_{z != 0} f(z) * z^{t-1-i} for i in [1, t-1].
+	for z := uint64(1); z < t; z++ {
+		pow := uint64(1)
+		for i := t - 1; i >= 1; i-- {
+			coeffs[i] = (coeffs[i] + values[z]*pow) % t
+			pow = (pow * z) % t
+		}
+	}
+
+	// f(x) = -f(0)*(x^{t-1} - 1) - sum_{i=1}^{t-1} acc[i] x^i.
+	for i := uint64(1); i < t; i++ {
+		coeffs[i] = (t - coeffs[i]) % t
+	}
+	coeffs[t-1] = (coeffs[t-1] + (t - values[0])) % t
+	coeffs[0] = values[0]
+
+	// Trims the zero leading coefficients to minimize the evaluation depth.
+	deg := len(coeffs) - 1
+	for deg > 1 && coeffs[deg] == 0 {
+		deg--
+	}
+
+	return coeffs[:deg+1], nil
+}
@@ -0,0 +1,144 @@
+// Package comparison implements exact homomorphic comparisons for the BGV/BFV schemes.
+// The sign function over Z_t is evaluated exactly as an interpolation polynomial of
+// degree t-2, so the approach is practical for small plaintext moduli only: both the
+// interpolation (quadratic in t) and the homomorphic evaluation (square root of t
+// ciphertext multiplications) grow with t.
+package comparison
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/bgv/polynomial"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+)
+
+// MaxPlaintextModulus is the largest plaintext modulus for which the sign
+// polynomial is generated: the quadratic interpolation cost and the degree
+// t-2 of the polynomial make larger moduli impractical.
+const MaxPlaintextModulus = 1 << 17
+
+// Evaluator is an evaluator providing an API for exact homomorphic comparisons.
+// All fields of this struct are public, enabling custom instantiations.
+type Evaluator struct {
+	Parameters bgv.Parameters
+	*polynomial.Evaluator
+	// SignPolynomial is the interpolation polynomial of the sign function over
+	// Z_t: f(0) = 0, f(z) = 1 for z in [1, (t-1)/2] and f(z) = t-1 otherwise.
+	SignPolynomial polynomial.Polynomial
+}
+
+// NewEvaluator instantiates a new comparison Evaluator from a [polynomial.Evaluator].
+// Returns an error if the plaintext modulus exceeds [MaxPlaintextModulus].
+func NewEvaluator(params bgv.Parameters, eval *polynomial.Evaluator) (*Evaluator, error) {
+
+	signPoly, err := GenSignPolynomial(params.PlaintextModulus())
+	if err != nil {
+		return nil, fmt.Errorf("cannot NewEvaluator: %w", err)
+	}
+
+	return &Evaluator{
+		Parameters:     params,
+		Evaluator:      eval,
+		SignPolynomial: polynomial.NewPolynomial(signPoly),
+	}, nil
+}
+
+// GenSignPolynomial returns the coefficients over Z_t of the interpolation
+// polynomial of the sign function on the centered representation of Z_t:
+// f(z) = 1 for z in [1, (t-1)/2], f(z) = t-1 (i.e. -1) for z in [(t+1)/2, t-1]
+// and f(0) = 0. The plaintext modulus t must be an odd prime (which the bgv
+// parameters enforce) not larger than [MaxPlaintextModulus].
+func GenSignPolynomial(t uint64) (coeffs []uint64, err error) {
+
+	if t > MaxPlaintextModulus {
+		return nil, fmt.Errorf("cannot GenSignPolynomial: plaintext modulus %d is larger than %d", t, MaxPlaintextModulus)
+	}
+
+	// Lagrange interpolation over the full domain Z_t: with P(x) = x^t - x =
+	// prod_{z in Z_t} (x - z) and P'(z) = -1 mod t, the interpolation of f is
+	// f(x) = -sum_z f(z) * Q_z(x) with Q_z(x) = P(x)/(x - z). For z != 0 the
+	// quotient has the closed form Q_z(x) = sum_{i=1}^{t-1} z^{t-1-i} x^i.
+	// Since f is odd and Q_{-z} has the coefficients of Q_z with alternating
+	// signs, f(z)*Q_z(x) + f(-z)*Q_{-z}(x) cancels the even-degree
+	// coefficients and doubles the odd-degree ones, so only the positive half
+	// of the domain has to be visited.
+	coeffs = make([]uint64, t-1)
+
+	half := (t - 1) >> 1
+
+	for z := uint64(1); z <= half; z++ {
+		pw := uint64(1)
+		for i := t - 2; i >= 1; i-- {
+			pw = (pw * z) % t // z^{t-1-i}
+			if i&1 == 1 {
+				coeffs[i] = (coeffs[i] + 2*(t-pw)) % t
+			}
+		}
+	}
+
+	return
+}
+
+// Sign evaluates f(x) = 1 if x > 0, -1 if x < 0, else 0, where the sign is
+// taken on the centered representation of Z_t.
+// This will ensure that sign.Scale = params.DefaultScale().
+func (eval Evaluator) Sign(op0 *rlwe.Ciphertext) (sign *rlwe.Ciphertext, err error) {
+	return eval.Evaluate(op0, eval.SignPolynomial, eval.Parameters.DefaultScale())
+}
+
+// Max returns the element-wise maximum of op0 and op1, computed exactly as
+// (op0 + op1 + sign(op0-op1)*(op0-op1))/2. The comparison is taken on the
+// centered representation of Z_t, so the user must ensure that the difference
+// of any two compared values lies in (-(t-1)/2, (t-1)/2).
+func (eval Evaluator) Max(op0, op1 *rlwe.Ciphertext) (max *rlwe.Ciphertext, err error) {
+	return eval.extremum(op0, op1, true)
+}
+
+// Min returns the element-wise minimum of op0 and op1, computed exactly as
+// (op0 + op1 - sign(op0-op1)*(op0-op1))/2. The comparison is taken on the
+// centered representation of Z_t, so the user must ensure that the difference
+// of any two compared values lies in (-(t-1)/2, (t-1)/2).
+func (eval Evaluator) Min(op0, op1 *rlwe.Ciphertext) (min *rlwe.Ciphertext, err error) {
+	return eval.extremum(op0, op1, false)
+}
+
+func (eval Evaluator) extremum(op0, op1 *rlwe.Ciphertext, max bool) (opOut *rlwe.Ciphertext, err error) {
+
+	var diff *rlwe.Ciphertext
+	if diff, err = eval.SubNew(op0, op1); err != nil {
+		return nil, fmt.Errorf("eval.SubNew: %w", err)
+	}
+
+	var sign *rlwe.Ciphertext
+	if sign, err = eval.Sign(diff); err != nil {
+		return nil, fmt.Errorf("eval.Sign: %w", err)
+	}
+
+	// |op0 - op1| = sign(op0 - op1) * (op0 - op1)
+	var abs *rlwe.Ciphertext
+	if abs, err = eval.MulRelinNew(sign, diff); err != nil {
+		return nil, fmt.Errorf("eval.MulRelinNew: %w", err)
+	}
+
+	if opOut, err = eval.AddNew(op0, op1); err != nil {
+		return nil, fmt.Errorf("eval.AddNew: %w", err)
+	}
+
+	if max {
+		err = eval.Add(opOut, abs, opOut)
+	} else {
+		err = eval.Sub(opOut, abs, opOut)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot extremum: %w", err)
+	}
+
+	// Exact division by 2 in Z_t.
+	t := eval.Parameters.PlaintextModulus()
+	if err = eval.Mul(opOut, (t+1)>>1, opOut); err != nil {
+		return nil, fmt.Errorf("eval.Mul: %w", err)
+	}
+
+	return
+}
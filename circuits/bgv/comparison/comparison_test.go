@@ -0,0 +1,130 @@
+package comparison
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/bgv/polynomial"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// Insecure parameters used for the sole purpose of fast testing: the sign
+// polynomial has degree t-2, so the tests use a small plaintext modulus.
+var testParametersLiteral = bgv.ParametersLiteral{
+	LogN:             7,
+	LogQ:             []int{60, 60, 60, 60, 60, 60, 60, 60, 60},
+	LogP:             []int{61},
+	PlaintextModulus: 0x101, // t = 257, compatible with N = 128
+}
+
+func TestGenSignPolynomial(t *testing.T) {
+
+	const q = 17
+
+	coeffs, err := GenSignPolynomial(q)
+	require.NoError(t, err)
+
+	evaluate := func(x uint64) (y uint64) {
+		for i := len(coeffs) - 1; i >= 0; i-- {
+			y = (y*x + coeffs[i]) % q
+		}
+		return
+	}
+
+	require.Equal(t, uint64(0), evaluate(0))
+	for z := uint64(1); z <= (q-1)/2; z++ {
+		require.Equal(t, uint64(1), evaluate(z))
+		require.Equal(t, uint64(q-1), evaluate(q-z))
+	}
+
+	_, err = GenSignPolynomial(MaxPlaintextModulus + 2)
+	require.Error(t, err)
+}
+
+func TestComparisons(t *testing.T) {
+
+	tc := bgv.NewTestContext(testParametersLiteral, true) // scale-invariant multiplication, i.e. BFV
+
+	params := tc.Params
+	slots := params.MaxSlots()
+	half := (params.PlaintextModulus() - 1) >> 1
+
+	eval, err := NewEvaluator(params, polynomial.NewEvaluator(params, tc.Evl))
+	require.NoError(t, err)
+
+	encrypt := func(values []uint64) *rlwe.Ciphertext {
+		pt := bgv.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, tc.Ecd.Encode(values, pt))
+		ct, err := tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+		return ct
+	}
+
+	decrypt := func(ct *rlwe.Ciphertext) []uint64 {
+		values := make([]uint64, slots)
+		require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(ct), values))
+		return values
+	}
+
+	// Values in [0, (t-1)/2] so that all the differences lie in the centered
+	// representation of Z_t.
+	values0 := make([]uint64, slots)
+	values1 := make([]uint64, slots)
+	for i := range values0 {
+		values0[i] = uint64(i) % (half + 1)
+		values1[i] = uint64(3*i+1) % (half + 1)
+	}
+
+	ct0 := encrypt(values0)
+	ct1 := encrypt(values1)
+
+	t.Run("Sign", func(t *testing.T) {
+
+		diff, err := eval.SubNew(ct0, ct1)
+		require.NoError(t, err)
+
+		sign, err := eval.Sign(diff)
+		require.NoError(t, err)
+
+		want := make([]uint64, slots)
+		for i := range want {
+			switch {
+			case values0[i] > values1[i]:
+				want[i] = 1
+			case values0[i] < values1[i]:
+				want[i] = params.PlaintextModulus() - 1
+			}
+		}
+
+		require.Equal(t, want, decrypt(sign))
+	})
+
+	t.Run("Max", func(t *testing.T) {
+
+		max, err := eval.Max(ct0, ct1)
+		require.NoError(t, err)
+
+		want := make([]uint64, slots)
+		for i := range want {
+			want[i] = utils.Max(values0[i], values1[i])
+		}
+
+		require.Equal(t, want, decrypt(max))
+	})
+
+	t.Run("Min", func(t *testing.T) {
+
+		min, err := eval.Min(ct0, ct1)
+		require.NoError(t, err)
+
+		want := make([]uint64, slots)
+		for i := range want {
+			want[i] = utils.Min(values0[i], values1[i])
+		}
+
+		require.Equal(t, want, decrypt(min))
+	})
+}
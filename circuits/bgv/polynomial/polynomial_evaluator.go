@@ -6,6 +6,7 @@ import (
 	"github.com/tuneinsight/lattigo/v6/circuits/common/polynomial"
 	"github.com/tuneinsight/lattigo/v6/core/rlwe"
 	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
 )
 
 type Evaluator struct {
@@ -50,6 +51,15 @@ func (eval Evaluator) Evaluate(ct *rlwe.Ciphertext, p interface{}, targetScale r
 	return eval.Evaluator.Evaluate(ct, phe, targetScale, 1, &simEvaluator{eval.Parameters, eval.InvariantTensoring})
 }
 
+// EvaluateSymmetric evaluates a polynomial on the input Ciphertext, exploiting the odd or
+// even symmetry of the polynomial when present: the evaluation is carried out in X^2 on a
+// polynomial of half the degree, halving the number of non-scalar multiplications. The
+// detected symmetry and the saved depth are returned along with the result; without
+// symmetry, the method falls back to [Evaluator.Evaluate].
+func (eval Evaluator) EvaluateSymmetric(ct *rlwe.Ciphertext, p bignum.Polynomial, targetScale rlwe.Scale) (opOut *rlwe.Ciphertext, report polynomial.SymmetryReport, err error) {
+	return eval.Evaluator.EvaluateSymmetric(ct, p, targetScale, 1, &simEvaluator{eval.Parameters, eval.InvariantTensoring})
+}
+
 // EvaluateFromPowerBasis evaluates a polynomial using the provided [polynomial.PowerBasis], holding pre-computed powers of X.
 // This method is the same as Evaluate except that the encrypted input is a PowerBasis.
 // See [Evaluate] for additional information.
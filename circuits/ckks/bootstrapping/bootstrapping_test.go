@@ -54,7 +54,7 @@ func TestBootstrapping(t *testing.T) {
 
 		t.Logf("ParamsN2: LogN=%d/LogSlots=%d/LogQP=%f", params.LogN(), params.LogMaxSlots(), params.LogQP())
 
-		sk := rlwe.NewKeyGenerator(btpParams.BootstrappingParameters).GenSecretKeyNew()
+		sk := rlwe.NewKeyGenerator(params).GenSecretKeyNew()
 
 		t.Log("Generating Bootstrapping Keys")
 		btpKeys, _, err := btpParams.GenEvaluationKeys(sk)
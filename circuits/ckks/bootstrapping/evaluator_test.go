@@ -160,7 +160,7 @@ func testRawCircuit(params ckks.Parameters, btpParams Parameters, level int, t *
 
 	t.Run(ParamsToString(params, btpParams.LogMaxSlots(), ""), func(t *testing.T) {
 
-		kgen := rlwe.NewKeyGenerator(btpParams.BootstrappingParameters)
+		kgen := rlwe.NewKeyGenerator(params)
 		sk := kgen.GenSecretKeyNew()
 		encoder := ckks.NewEncoder(params)
 
@@ -260,7 +260,7 @@ func testRawCircuitHighPrecision(paramSet defaultParametersLiteral, t *testing.T
 
 		t.Run(ParamsToString(params, btpParams.LogMaxSlots(), ""), func(t *testing.T) {
 
-			kgen := rlwe.NewKeyGenerator(btpParams.BootstrappingParameters)
+			kgen := rlwe.NewKeyGenerator(params)
 			sk := kgen.GenSecretKeyNew()
 			encoder := ckks.NewEncoder(params)
 			encryptor := rlwe.NewEncryptor(params, sk)
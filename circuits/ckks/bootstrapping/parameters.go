@@ -8,6 +8,7 @@ import (
 
 	"github.com/tuneinsight/lattigo/v6/circuits/ckks/dft"
 	"github.com/tuneinsight/lattigo/v6/circuits/ckks/mod1"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
 	"github.com/tuneinsight/lattigo/v6/ring"
 	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
 	"github.com/tuneinsight/lattigo/v6/utils"
@@ -455,16 +456,19 @@ func (p *Parameters) UnmarshalJSON(data []byte) (err error) {
 }
 
 // GaloisElements returns the list of Galois elements required to evaluate the bootstrapping.
-func (p Parameters) GaloisElements(params ckks.Parameters) (galEls []uint64) {
+// It implements [rlwe.GaloisElementsProvider].
+func (p Parameters) GaloisElements(params rlwe.ParameterProvider) (galEls []uint64) {
 
-	logN := params.LogN()
+	rp := params.GetRLWEParameters()
+
+	logN := rp.LogN()
 
 	// List of the rotation key values to needed for the bootstrap
 	keys := make(map[uint64]bool)
 
 	//SubSum rotation needed X -> Y^slots rotations
 	for i := p.LogMaxDimensions().Cols; i < logN-1; i++ {
-		keys[params.GaloisElement(1<<i)] = true
+		keys[rp.GaloisElement(1<<i)] = true
 	}
 
 	for _, galEl := range p.CoeffsToSlotsParameters.GaloisElements(params) {
@@ -475,7 +479,7 @@ func (p Parameters) GaloisElements(params ckks.Parameters) (galEls []uint64) {
 		keys[galEl] = true
 	}
 
-	keys[params.GaloisElementForComplexConjugation()] = true
+	keys[rp.GaloisElementOrderTwoOrthogonalSubgroup()] = true
 
 	return utils.GetSortedKeys(keys)
 }
@@ -0,0 +1,142 @@
+package bootstrapping
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// StreamResult is the result of the bootstrapping of one ciphertext of a stream processed
+// by [Evaluator.BootstrapStream]. Index is the position of the ciphertext in the input
+// stream (results are delivered in input order, so indices are strictly increasing).
+type StreamResult struct {
+	Index      int
+	Ciphertext *rlwe.Ciphertext
+	Err        error
+}
+
+// BootstrapStream bootstraps a stream of ciphertexts with a two-stage software pipeline:
+// a first worker evaluates ScaleDown, ModUp and CoeffsToSlots (the homomorphic encoding)
+// while a second worker evaluates EvalMod and SlotsToCoeffs, so that the CoeffsToSlots of
+// ciphertext i+1 overlaps with the EvalMod of ciphertext i. For a long enough stream this
+// roughly doubles the bootstrapping throughput on machines with spare cores, at the cost
+// of the memory of one additional evaluator [Evaluator.ShallowCopy].
+//
+// The method returns immediately: ciphertexts are read from in until the channel is closed,
+// and one [StreamResult] per input ciphertext is delivered on the returned channel, in
+// input order. The returned channel is closed once all results have been delivered. A
+// failed ciphertext is reported through the Err field of its result; the stream keeps
+// processing the subsequent ciphertexts.
+//
+// The per-ciphertext circuit and its requirements are those of [Evaluator.Bootstrap].
+// The method returns an error if the evaluator is instantiated for the conjugate-invariant
+// variant (which processes ciphertexts in pairs) or for iterated/128-bit-precision
+// bootstrapping (which requires multiple passes per ciphertext); in these cases
+// [Evaluator.BootstrapMany] must be used instead.
+func (eval Evaluator) BootstrapStream(in <-chan *rlwe.Ciphertext) (<-chan StreamResult, error) {
+
+	if eval.ResidualParameters.RingType() == ring.ConjugateInvariant {
+		return nil, fmt.Errorf("cannot BootstrapStream: the conjugate-invariant variant processes ciphertexts in pairs, use BootstrapMany instead")
+	}
+
+	if eval.IterationsParameters != nil || eval.ResidualParameters.PrecisionMode() == ckks.PREC128 {
+		return nil, fmt.Errorf("cannot BootstrapStream: iterated and 128-bit precision bootstrapping require multiple passes per ciphertext, use BootstrapMany instead")
+	}
+
+	type job struct {
+		index          int
+		logSlots       int
+		ctReal, ctImag *rlwe.Ciphertext
+		err            error
+	}
+
+	// A capacity of one allows the encoding stage to work one ciphertext ahead of the
+	// modular-reduction stage.
+	mid := make(chan job, 1)
+	out := make(chan StreamResult, 1)
+
+	front := eval.ShallowCopy()
+	back := eval.ShallowCopy()
+
+	// Stage 1: ring-degree switch, ScaleDown, ModUp and CoeffsToSlots.
+	go func() {
+
+		defer close(mid)
+
+		var index int
+
+		for ct := range in {
+
+			j := job{index: index, logSlots: ct.LogSlots()}
+			index++
+
+			var ctN2 *rlwe.Ciphertext
+			cts, err := front.PackAndSwitchN1ToN2([]rlwe.Ciphertext{*ct})
+			if err == nil {
+				ctN2 = &cts[0]
+			}
+
+			if err == nil {
+				ctN2, _, err = front.ScaleDown(ctN2)
+			}
+
+			if err == nil {
+				ctN2, err = front.ModUp(ctN2)
+			}
+
+			if err == nil {
+				j.ctReal, j.ctImag, err = front.CoeffsToSlots(ctN2)
+			}
+
+			j.err = err
+
+			mid <- j
+		}
+	}()
+
+	// Stage 2: EvalMod, SlotsToCoeffs and ring-degree switch back.
+	go func() {
+
+		defer close(out)
+
+		for j := range mid {
+
+			if j.err != nil {
+				out <- StreamResult{Index: j.index, Err: fmt.Errorf("cannot BootstrapStream: %w", j.err)}
+				continue
+			}
+
+			ctReal, err := back.EvalMod(j.ctReal)
+
+			ctImag := j.ctImag
+			if err == nil && ctImag != nil {
+				ctImag, err = back.EvalMod(ctImag)
+			}
+
+			var ctOut *rlwe.Ciphertext
+			if err == nil {
+				ctOut, err = back.SlotsToCoeffs(ctReal, ctImag)
+			}
+
+			if err == nil {
+				var cts []rlwe.Ciphertext
+				if cts, err = back.UnpackAndSwitchN2Tn1([]rlwe.Ciphertext{*ctOut}, j.logSlots, 1); err == nil {
+					ctOut = &cts[0]
+				}
+			}
+
+			if err != nil {
+				out <- StreamResult{Index: j.index, Err: fmt.Errorf("cannot BootstrapStream: %w", err)}
+				continue
+			}
+
+			ctOut.Scale = back.ResidualParameters.DefaultScale()
+
+			out <- StreamResult{Index: j.index, Ciphertext: ctOut}
+		}
+	}()
+
+	return out, nil
+}
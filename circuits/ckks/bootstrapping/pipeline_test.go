@@ -0,0 +1,124 @@
+package bootstrapping
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestBootstrapStream(t *testing.T) {
+
+	if runtime.GOARCH == "wasm" {
+		t.Skip("skipping bootstrapping tests for GOARCH=wasm")
+	}
+
+	paramSet := DefaultParametersDense[0]
+
+	if !*flagLongTest {
+		paramSet.SchemeParams.LogN -= 3
+	}
+
+	paramSet.BootstrappingParams.LogN = utils.Pointy(paramSet.SchemeParams.LogN)
+
+	LogSlots := paramSet.SchemeParams.LogN - 1
+	paramSet.BootstrappingParams.LogSlots = &LogSlots
+
+	level := utils.Min(1, len(paramSet.SchemeParams.LogQ))
+	paramSet.SchemeParams.LogQ = paramSet.SchemeParams.LogQ[:level+1]
+
+	params, err := ckks.NewParametersFromLiteral(paramSet.SchemeParams)
+	require.NoError(t, err)
+
+	btpParams, err := NewParametersFromLiteral(params, paramSet.BootstrappingParams)
+	require.NoError(t, err)
+
+	// Insecure params for fast testing only
+	if !*flagLongTest {
+		// Corrects the message ratio to take into account the smaller number of slots and keep the same precision
+		btpParams.Mod1ParametersLiteral.LogMessageRatio += utils.Min(utils.Max(15-LogSlots, 0), 8)
+	}
+
+	kgen := rlwe.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	encoder := ckks.NewEncoder(params)
+	encryptor := rlwe.NewEncryptor(params, sk)
+	decryptor := rlwe.NewDecryptor(params, sk)
+
+	evk, _, err := btpParams.GenEvaluationKeys(sk)
+	require.NoError(t, err)
+
+	eval, err := NewEvaluator(btpParams, evk)
+	require.NoError(t, err)
+
+	n := 3
+
+	valuesWant := make([][]complex128, n)
+	in := make(chan *rlwe.Ciphertext, n)
+
+	for i := range valuesWant {
+
+		values := make([]complex128, 1<<btpParams.LogMaxSlots())
+		for j := range values {
+			values[j] = sampling.RandComplex128(-1, 1)
+		}
+		valuesWant[i] = values
+
+		plaintext := ckks.NewPlaintext(params, 0)
+		plaintext.Scale = params.DefaultScale()
+		plaintext.LogDimensions = btpParams.LogMaxDimensions()
+		require.NoError(t, encoder.Encode(values, plaintext))
+
+		ct, err := encryptor.EncryptNew(plaintext)
+		require.NoError(t, err)
+		in <- ct
+	}
+	close(in)
+
+	out, err := eval.BootstrapStream(in)
+	require.NoError(t, err)
+
+	var count int
+	for res := range out {
+		require.NoError(t, res.Err)
+		require.Equal(t, count, res.Index)
+		require.Equal(t, level, res.Ciphertext.Level())
+		require.Equal(t, params.DefaultScale(), res.Ciphertext.Scale)
+		verifyTestVectors(params, encoder, decryptor, valuesWant[res.Index], res.Ciphertext, t)
+		count++
+	}
+	require.Equal(t, n, count)
+
+	t.Run("WrongRingDegree", func(t *testing.T) {
+
+		// An input ciphertext at the wrong ring degree must be reported through
+		// the Err field of its result instead of crashing the pipeline.
+		badParams, err := ckks.NewParametersFromLiteral(ckks.ParametersLiteral{
+			LogN:            params.LogN() - 1,
+			LogQ:            []int{55},
+			LogP:            []int{61},
+			LogDefaultScale: params.LogDefaultScale(),
+		})
+		require.NoError(t, err)
+
+		inBad := make(chan *rlwe.Ciphertext, 1)
+		inBad <- ckks.NewCiphertext(badParams, 1, 0)
+		close(inBad)
+
+		outBad, err := eval.BootstrapStream(inBad)
+		require.NoError(t, err)
+
+		res, ok := <-outBad
+		require.True(t, ok)
+		require.Error(t, res.Err)
+		require.Nil(t, res.Ciphertext)
+
+		_, ok = <-outBad
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,186 @@
+package bootstrapping
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// PresetName identifies one of the pre-defined bootstrapping presets (see [Presets]).
+type PresetName string
+
+const (
+	// PresetNameConservative selects a dense-secret preset (H=N/2 as main secret),
+	// which avoids relying on the hardness of the sparse-secret RLWE problem.
+	PresetNameConservative = PresetName("conservative")
+	// PresetNameBalanced selects a sparse-secret preset (H=192 as main secret)
+	// with the same precision and residual levels as the conservative preset
+	// but a smaller total modulus, hence faster and with smaller keys.
+	PresetNameBalanced = PresetName("balanced")
+	// PresetNameFast selects a sparse-secret preset with half the residual
+	// ring degree (LogN=15), trading precision and residual levels for a
+	// cheaper circuit and much smaller keys.
+	PresetNameFast = PresetName("fast")
+)
+
+// PresetMetadata carries the published, machine-readable characteristics of a
+// bootstrapping preset, enabling programmatic preset selection without
+// instantiating the parameters. Precision and failure probability are the
+// values reported in https://eprint.iacr.org/2022/024 for the underlying
+// parameter sets.
+type PresetMetadata struct {
+	// LogN is the base-two logarithm of the ring degree of the residual
+	// parameters, i.e. of the bootstrapped ciphertexts. The bootstrapping
+	// circuit itself always runs at the default ring degree ([DefaultLogN]),
+	// with ring-degree switching when LogN is smaller.
+	LogN int
+	// LogSlots is the base-two logarithm of the number of slots for which
+	// Precision and LogFailureProbability are reported.
+	LogSlots int
+	// MainSecretHammingWeight is the Hamming weight of the main secret
+	// (N/2 for a dense secret).
+	MainSecretHammingWeight int
+	// Precision is the expected precision, in bits, of the bootstrapped values.
+	Precision float64
+	// LogFailureProbability is the base-two logarithm of the bound on the
+	// bootstrapping failure probability.
+	LogFailureProbability float64
+	// LevelsRecovered is the number of levels available for the residual
+	// circuit after a bootstrapping.
+	LevelsRecovered int
+}
+
+// Preset is a named, ready-to-use bootstrapping parameterization together
+// with its published metadata, removing the need to hand-tune the many
+// interacting fields of the [ckks.ParametersLiteral] and [ParametersLiteral]
+// structs. Presets are selected programmatically with [GetPreset] or directly
+// from [Presets].
+type Preset struct {
+	// Name identifies the preset.
+	Name PresetName
+	// Metadata carries the published characteristics of the preset.
+	Metadata PresetMetadata
+	// SchemeParams is the [ckks.ParametersLiteral] of the residual parameters.
+	SchemeParams ckks.ParametersLiteral
+	// BootstrappingParams is the [ParametersLiteral] of the bootstrapping circuit.
+	BootstrappingParams ParametersLiteral
+}
+
+var (
+	// PresetConservative is the preset selected by [PresetNameConservative],
+	// backed by the default parameters N16QP1788H32768H32.
+	PresetConservative = Preset{
+		Name: PresetNameConservative,
+		Metadata: PresetMetadata{
+			LogN:                    16,
+			LogSlots:                15,
+			MainSecretHammingWeight: 32768,
+			Precision:               29.8,
+			LogFailureProbability:   -138.7,
+			LevelsRecovered:         9,
+		},
+		SchemeParams:        N16QP1788H32768H32.SchemeParams,
+		BootstrappingParams: N16QP1788H32768H32.BootstrappingParams,
+	}
+
+	// PresetBalanced is the preset selected by [PresetNameBalanced],
+	// backed by the default parameters N16QP1546H192H32.
+	PresetBalanced = Preset{
+		Name: PresetNameBalanced,
+		Metadata: PresetMetadata{
+			LogN:                    16,
+			LogSlots:                15,
+			MainSecretHammingWeight: 192,
+			Precision:               26.6,
+			LogFailureProbability:   -138.7,
+			LevelsRecovered:         9,
+		},
+		SchemeParams:        N16QP1546H192H32.SchemeParams,
+		BootstrappingParams: N16QP1546H192H32.BootstrappingParams,
+	}
+
+	// PresetFast is the preset selected by [PresetNameFast],
+	// backed by the default parameters N15QP768H192H32.
+	PresetFast = Preset{
+		Name: PresetNameFast,
+		Metadata: PresetMetadata{
+			LogN:                    15,
+			LogSlots:                14,
+			MainSecretHammingWeight: 192,
+			Precision:               15.4,
+			LogFailureProbability:   -139.7,
+			LevelsRecovered:         2,
+		},
+		// Same as N15QP768H192H32.SchemeParams, with the LogNthRoot required
+		// for the switch to the LogN=16 ring of the bootstrapping circuit.
+		SchemeParams: ckks.ParametersLiteral{
+			LogN:            15,
+			LogNthRoot:      DefaultLogN + 1,
+			LogQ:            []int{33, 50, 25},
+			LogP:            []int{51, 51},
+			Xs:              ring.Ternary{H: 192},
+			LogDefaultScale: 25,
+		},
+		BootstrappingParams: N15QP768H192H32.BootstrappingParams,
+	}
+
+	// Presets is the list of the pre-defined bootstrapping presets.
+	Presets = []Preset{PresetConservative, PresetBalanced, PresetFast}
+)
+
+// GetPreset returns the [Preset] identified by the given name.
+func GetPreset(name PresetName) (Preset, error) {
+	for _, preset := range Presets {
+		if preset.Name == name {
+			return preset, nil
+		}
+	}
+	return Preset{}, fmt.Errorf("invalid preset: %q is not one of the pre-defined preset names", name)
+}
+
+// NewParameters instantiates the residual [ckks.Parameters] and the
+// bootstrapping [Parameters] of the preset.
+func (p Preset) NewParameters() (residualParameters ckks.Parameters, btpParameters Parameters, err error) {
+
+	if residualParameters, err = ckks.NewParametersFromLiteral(p.SchemeParams); err != nil {
+		return ckks.Parameters{}, Parameters{}, fmt.Errorf("cannot NewParameters: %w", err)
+	}
+
+	if btpParameters, err = NewParametersFromLiteral(residualParameters, p.BootstrappingParams); err != nil {
+		return ckks.Parameters{}, Parameters{}, fmt.Errorf("cannot NewParameters: %w", err)
+	}
+
+	return
+}
+
+// EvaluationKeysBinarySize returns the total size, in bytes, of the serialized
+// bootstrapping evaluation keys of the preset (see [Parameters.GenEvaluationKeys]).
+// The size is computed from the shapes of the keys, without generating them,
+// and is within a few bytes of framing of [EvaluationKeys.BinarySize].
+func (p Preset) EvaluationKeysBinarySize() (size int, err error) {
+
+	_, btpParams, err := p.NewParameters()
+	if err != nil {
+		return 0, fmt.Errorf("cannot EvaluationKeysBinarySize: %w", err)
+	}
+
+	paramsN2 := btpParams.BootstrappingParameters
+
+	// Ring degree (or ring type) switching keys
+	if btpParams.ResidualParameters.N() != paramsN2.N() {
+		size += 2 * rlwe.NewEvaluationKey(paramsN2).BinarySize()
+	}
+
+	// Encapsulation keys (https://eprint.iacr.org/2022/024)
+	if btpParams.EphemeralSecretWeight != 0 {
+		size += 2 * rlwe.NewEvaluationKey(paramsN2).BinarySize()
+	}
+
+	// Relinearization key and Galois keys of the bootstrapping circuit
+	size += rlwe.NewRelinearizationKey(paramsN2).BinarySize()
+	size += (len(btpParams.GaloisElements(paramsN2)) + 1) * rlwe.NewGaloisKey(paramsN2).BinarySize()
+
+	return
+}
@@ -0,0 +1,64 @@
+package bootstrapping
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/ring"
+)
+
+func TestPresets(t *testing.T) {
+
+	t.Run("GetPreset", func(t *testing.T) {
+
+		for _, name := range []PresetName{PresetNameConservative, PresetNameBalanced, PresetNameFast} {
+			preset, err := GetPreset(name)
+			require.NoError(t, err)
+			require.Equal(t, name, preset.Name)
+		}
+
+		_, err := GetPreset("reckless")
+		require.Error(t, err)
+	})
+
+	t.Run("Metadata", func(t *testing.T) {
+
+		for _, preset := range Presets {
+
+			meta := preset.Metadata
+
+			require.Equal(t, preset.SchemeParams.LogN, meta.LogN)
+			require.Equal(t, preset.SchemeParams.LogN-1, meta.LogSlots)
+			require.Equal(t, ring.Ternary{H: meta.MainSecretHammingWeight}, preset.SchemeParams.Xs)
+			require.Equal(t, len(preset.SchemeParams.LogQ)-1, meta.LevelsRecovered)
+			require.Greater(t, meta.Precision, 0.0)
+			require.Less(t, meta.LogFailureProbability, -128.0)
+		}
+	})
+
+	// Only instantiates the fast preset (LogN=15), as the LogN=16 presets are
+	// covered by the long test suite of TestBootstrapping.
+	t.Run("NewParameters", func(t *testing.T) {
+
+		residualParams, btpParams, err := PresetFast.NewParameters()
+		require.NoError(t, err)
+
+		meta := PresetFast.Metadata
+
+		require.Equal(t, meta.LogN, residualParams.LogN())
+		require.Equal(t, DefaultLogN, btpParams.BootstrappingParameters.LogN())
+		require.Equal(t, meta.LogSlots, residualParams.LogMaxSlots())
+		require.Equal(t, meta.LevelsRecovered, residualParams.MaxLevel())
+	})
+
+	t.Run("EvaluationKeysBinarySize", func(t *testing.T) {
+
+		size, err := PresetFast.EvaluationKeysBinarySize()
+		require.NoError(t, err)
+
+		// Relinearization, encapsulation and Galois keys at LogN=15 amount to
+		// several hundred megabytes.
+		require.Greater(t, size, 1<<28)
+	})
+}
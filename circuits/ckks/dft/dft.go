@@ -99,13 +99,17 @@ func (d MatrixLiteral) Depth(actual bool) (depth int) {
 }
 
 // GaloisElements returns the list of rotations performed during the CoeffsToSlot operation.
-func (d MatrixLiteral) GaloisElements(params ckks.Parameters) (galEls []uint64) {
+// It implements [rlwe.GaloisElementsProvider].
+func (d MatrixLiteral) GaloisElements(params rlwe.ParameterProvider) (galEls []uint64) {
+
+	p := params.GetRLWEParameters()
+
 	rotations := []int{}
 
 	imgRepack := d.Format == RepackImagAsReal
 
 	logSlots := d.LogSlots
-	logN := params.LogN()
+	logN := p.LogN()
 	slots := 1 << logSlots
 	dslots := slots
 	if logSlots < logN-1 && imgRepack {
@@ -123,7 +127,7 @@ func (d MatrixLiteral) GaloisElements(params ckks.Parameters) (galEls []uint64)
 		rotations = addMatrixRotToList(pVec, rotations, N1, slots, d.Type == HomomorphicDecode && logSlots < logN-1 && i == 0 && imgRepack)
 	}
 
-	return params.GaloisElements(rotations)
+	return p.GaloisElements(rotations)
 }
 
 // MarshalBinary returns a JSON representation of the the target [MatrixLiteral] on a slice of bytes.
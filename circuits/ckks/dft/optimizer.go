@@ -0,0 +1,93 @@
+package dft
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// Objective selects the cost minimized by [Optimize] when choosing the
+// factorization of the DFT matrices.
+type Objective int
+
+const (
+	// MinimizeRotations minimizes the number of distinct rotations (i.e. of
+	// Galois keys to generate), using up to the full level budget to keep the
+	// factors sparse.
+	MinimizeRotations = Objective(0)
+	// MinimizeDepth minimizes the number of levels consumed by the transform,
+	// merging as many radix layers per factor as possible; ties are broken by
+	// the number of rotations. Shallow factorizations have dense factors, so
+	// this objective trades rotations (and keys) for levels.
+	MinimizeDepth = Objective(1)
+)
+
+// Optimize returns the [MatrixLiteral] of the given transform whose
+// factorization minimizes the selected [Objective] within the level budget,
+// replacing the fixed LogSlots-based default split: the number of radix
+// layers merged in each factor and the baby-step giant-step ratio are chosen
+// by enumerating the candidate factorizations of at most levelBudget levels
+// and counting, for each, the exact set of rotations it requires (see
+// [MatrixLiteral.GaloisElements]).
+//
+// The transform starts at level levelQ, which must provide levelBudget
+// levels; the returned literal consumes [MatrixLiteral.Depth] of them.
+func Optimize(params ckks.Parameters, ltType Type, format Format, logSlots, levelQ, levelP, levelBudget int, objective Objective) (MatrixLiteral, error) {
+
+	if logSlots < 1 || logSlots > params.LogMaxDimensions().Cols {
+		return MatrixLiteral{}, fmt.Errorf("cannot Optimize: logSlots must be in [1, %d] but is %d", params.LogMaxDimensions().Cols, logSlots)
+	}
+
+	perRescale := params.LevelsConsumedPerRescaling()
+
+	maxDepth := utils.Min(levelBudget, logSlots)
+	maxDepth = utils.Min(maxDepth, levelQ/perRescale)
+
+	if maxDepth < 1 {
+		return MatrixLiteral{}, fmt.Errorf("cannot Optimize: level budget and levelQ=%d do not allow any factorization", levelQ)
+	}
+
+	var best MatrixLiteral
+	bestDepth, bestKeys := -1, -1
+
+	for depth := 1; depth <= maxDepth; depth++ {
+
+		levels := make([]int, depth)
+		for i := range levels {
+			levels[i] = 1
+		}
+
+		for logBSGSRatio := 0; logBSGSRatio <= logSlots; logBSGSRatio++ {
+
+			candidate := MatrixLiteral{
+				Type:         ltType,
+				Format:       format,
+				LogSlots:     logSlots,
+				LevelQ:       levelQ,
+				LevelP:       levelP,
+				Levels:       levels,
+				LogBSGSRatio: logBSGSRatio,
+			}
+
+			keys := len(candidate.GaloisElements(params))
+
+			var better bool
+			switch objective {
+			case MinimizeRotations:
+				better = bestKeys == -1 || keys < bestKeys || (keys == bestKeys && depth < bestDepth)
+			case MinimizeDepth:
+				better = bestDepth == -1 || depth < bestDepth || (depth == bestDepth && keys < bestKeys)
+			default:
+				return MatrixLiteral{}, fmt.Errorf("cannot Optimize: invalid objective %d", objective)
+			}
+
+			if better {
+				best = candidate
+				bestDepth, bestKeys = depth, keys
+			}
+		}
+	}
+
+	return best, nil
+}
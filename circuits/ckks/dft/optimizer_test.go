@@ -0,0 +1,70 @@
+package dft
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+func TestOptimize(t *testing.T) {
+
+	params, err := ckks.NewParametersFromLiteral(testInsecurePrec45)
+	require.NoError(t, err)
+
+	logSlots := params.LogMaxDimensions().Cols
+	levelQ := params.MaxLevelQ()
+	levelP := params.MaxLevelP()
+	budget := 3
+
+	// The fixed default split: one radix group per budgeted level.
+	def := MatrixLiteral{
+		Type:     HomomorphicEncode,
+		Format:   RepackImagAsReal,
+		LogSlots: logSlots,
+		LevelQ:   levelQ,
+		LevelP:   levelP,
+		Levels:   []int{1, 1, 1},
+	}
+
+	t.Run("MinimizeRotations", func(t *testing.T) {
+
+		lit, err := Optimize(params, HomomorphicEncode, RepackImagAsReal, logSlots, levelQ, levelP, budget, MinimizeRotations)
+		require.NoError(t, err)
+		require.LessOrEqual(t, lit.Depth(true), budget)
+
+		// The optimized factorization requires at most as many rotations as
+		// the default split.
+		require.LessOrEqual(t, len(lit.GaloisElements(params)), len(def.GaloisElements(params)))
+
+		// The optimized literal generates valid matrices.
+		ecd := ckks.NewEncoder(params, 90)
+		_, err = NewMatrixFromLiteral(params, lit, ecd)
+		require.NoError(t, err)
+	})
+
+	t.Run("MinimizeDepth", func(t *testing.T) {
+
+		lit, err := Optimize(params, HomomorphicDecode, Standard, logSlots, levelQ, levelP, budget, MinimizeDepth)
+		require.NoError(t, err)
+
+		// A single dense factor always minimizes the depth.
+		require.Equal(t, 1, lit.Depth(true))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+
+		// Invalid logSlots
+		_, err := Optimize(params, HomomorphicEncode, Standard, 0, levelQ, levelP, budget, MinimizeRotations)
+		require.Error(t, err)
+
+		// No level budget
+		_, err = Optimize(params, HomomorphicEncode, Standard, logSlots, levelQ, levelP, 0, MinimizeRotations)
+		require.Error(t, err)
+
+		// Invalid objective
+		_, err = Optimize(params, HomomorphicEncode, Standard, logSlots, levelQ, levelP, budget, Objective(2))
+		require.Error(t, err)
+	})
+}
@@ -110,6 +110,12 @@ func (lt LinearTransformation) GaloisElements(params rlwe.ParameterProvider) []u
 	return lintrans.LinearTransformation(lt).GaloisElements(params)
 }
 
+// AtLevel returns a view of the linear transformation truncated to the given level and is a wrapper of [lintrans.LinearTransformation.AtLevel].
+func (lt LinearTransformation) AtLevel(levelQ int) (LinearTransformation, error) {
+	truncated, err := lintrans.LinearTransformation(lt).AtLevel(levelQ)
+	return LinearTransformation(truncated), err
+}
+
 // NewTransformation instantiates a new LinearTransformation and is a wrapper of [lintrans.LinearTransformation].
 func NewTransformation(params rlwe.ParameterProvider, lt Parameters) LinearTransformation {
 	return LinearTransformation(lintrans.NewLinearTransformation(params, lintrans.Parameters(lt)))
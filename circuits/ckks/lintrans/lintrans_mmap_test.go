@@ -0,0 +1,119 @@
+//go:build linux || darwin
+
+package lintrans
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/common/lintrans"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+func TestLinearTransformationMMap(t *testing.T) {
+
+	paramsLiteral := testInsecurePrec45
+	if testing.Short() {
+		paramsLiteral.LogN = 10
+	}
+
+	tc := ckks.NewTestContext(paramsLiteral)
+	params := tc.Params
+
+	mulCmplx := bignum.NewComplexMultiplier().Mul
+
+	add := func(a, b, c []*bignum.Complex) {
+		for i := range c {
+			if a[i] != nil && b[i] != nil {
+				c[i].Add(a[i], b[i])
+			}
+		}
+	}
+
+	muladd := func(a, b, c []*bignum.Complex) {
+		tmp := &bignum.Complex{new(big.Float), new(big.Float)}
+		for i := range c {
+			if a[i] != nil && b[i] != nil {
+				mulCmplx(a[i], b[i], tmp)
+				c[i].Add(c[i], tmp)
+			}
+		}
+	}
+
+	prec := tc.Ecd.Prec()
+
+	newVec := func(size int) (vec []*bignum.Complex) {
+		vec = make([]*bignum.Complex, size)
+		for i := range vec {
+			vec[i] = &bignum.Complex{new(big.Float).SetPrec(prec), new(big.Float).SetPrec(0)}
+		}
+		return
+	}
+
+	t.Run(name("LinearTransform/MMap", tc), func(t *testing.T) {
+
+		values, _, ciphertext := tc.NewTestVector(-1-1i, 1+1i)
+
+		slots := ciphertext.Slots()
+
+		nonZeroDiags := []int{-15, -4, -1, 0, 1, 2, 3, 4, 15}
+
+		one := new(big.Float).SetInt64(1)
+		zero := new(big.Float)
+
+		diagonals := make(Diagonals[*bignum.Complex])
+		for _, i := range nonZeroDiags {
+			diagonals[i] = make([]*bignum.Complex, slots)
+			for j := 0; j < slots>>1; j++ {
+				diagonals[i][j] = &bignum.Complex{one, zero}
+			}
+		}
+
+		ltparams := lintrans.Parameters{
+			DiagonalsIndexList:        diagonals.DiagonalsIndexList(),
+			LevelQ:                    ciphertext.Level(),
+			LevelP:                    params.MaxLevelP(),
+			Scale:                     params.GetOptimalScalingFactor(ciphertext.Scale, params.DefaultScale(), ciphertext.Level()),
+			LogDimensions:             ciphertext.LogDimensions,
+			LogBabyStepGiantStepRatio: 1,
+		}
+
+		f, err := os.Create(filepath.Join(t.TempDir(), "lt.bin"))
+		require.NoError(t, err)
+		defer f.Close()
+
+		// Allocate the linear transformation on the memory-mapped file
+		linTransf, backing, err := lintrans.NewLinearTransformationMMap(params, ltparams, f)
+		require.NoError(t, err)
+		defer backing.Close()
+
+		// Encode on the linear transformation (persisted through the mapping)
+		require.NoError(t, lintrans.Encode(tc.Ecd, lintrans.Diagonals[*bignum.Complex](diagonals), linTransf))
+
+		// Prefetch hints for the BSGS access pattern
+		index, _, _ := linTransf.BSGSIndex()
+		for j := range index {
+			for _, i := range index[j] {
+				require.NoError(t, backing.Prefetch(i+j))
+			}
+		}
+
+		galEls := linTransf.GaloisElements(params)
+
+		evk := rlwe.NewMemEvaluationKeySet(nil, tc.Kgen.GenGaloisKeysNew(galEls, tc.Sk)...)
+
+		ltEval := NewEvaluator(tc.Evl.WithKey(evk))
+
+		require.NoError(t, ltEval.Evaluate(ciphertext, LinearTransformation(linTransf), ciphertext))
+
+		values = diagonals.Evaluate(values, newVec, add, muladd)
+
+		ckks.VerifyTestVectors(params, tc.Ecd, tc.Dec, values, ciphertext, params.LogDefaultScale(), 0, *printPrecisionStats, t)
+	})
+}
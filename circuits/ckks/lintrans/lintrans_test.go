@@ -188,6 +188,64 @@ func run(tc *ckks.TestContext, t *testing.T) {
 		ckks.VerifyTestVectors(params, tc.Ecd, tc.Dec, values, ciphertext, params.LogDefaultScale(), 0, *printPrecisionStats, t)
 	})
 
+	t.Run(name("LinearTransform/AtLevel", tc), func(t *testing.T) {
+
+		values, _, ciphertext := tc.NewTestVector(-1-1i, 1+1i)
+
+		slots := ciphertext.Slots()
+
+		nonZeroDiags := []int{-15, -4, -1, 0, 1, 2, 3, 4, 15}
+
+		one := new(big.Float).SetInt64(1)
+		zero := new(big.Float)
+
+		diagonals := make(Diagonals[*bignum.Complex])
+		for _, i := range nonZeroDiags {
+			diagonals[i] = make([]*bignum.Complex, slots)
+			for j := 0; j < slots>>1; j++ {
+				diagonals[i][j] = &bignum.Complex{one, zero}
+			}
+		}
+
+		// The transformation is encoded once at the top level.
+		ltparams := Parameters{
+			DiagonalsIndexList:        diagonals.DiagonalsIndexList(),
+			LevelQ:                    ciphertext.Level(),
+			LevelP:                    params.MaxLevelP(),
+			Scale:                     params.GetOptimalScalingFactor(ciphertext.Scale, params.DefaultScale(), ciphertext.Level()),
+			LogDimensions:             ciphertext.LogDimensions,
+			LogBabyStepGiantStepRatio: 1,
+		}
+
+		// Allocate the linear transformation
+		linTransf := NewTransformation(params, ltparams)
+
+		// Encode on the linear transformation
+		require.NoError(t, Encode(tc.Ecd, diagonals, linTransf))
+
+		galEls := linTransf.GaloisElements(params)
+
+		evk := rlwe.NewMemEvaluationKeySet(nil, tc.Kgen.GenGaloisKeysNew(galEls, tc.Sk)...)
+
+		ltEval := NewEvaluator(tc.Evl.WithKey(evk))
+
+		// The ciphertext is dropped below the level of the encoded transformation.
+		tc.Evl.DropLevel(ciphertext, 2)
+
+		truncated, err := linTransf.AtLevel(ciphertext.Level())
+		require.NoError(t, err)
+		require.Equal(t, ciphertext.Level(), truncated.LevelQ)
+
+		require.NoError(t, ltEval.Evaluate(ciphertext, truncated, ciphertext))
+
+		values = diagonals.Evaluate(values, newVec, add, muladd)
+
+		ckks.VerifyTestVectors(params, tc.Ecd, tc.Dec, values, ciphertext, params.LogDefaultScale(), 0, *printPrecisionStats, t)
+
+		_, err = linTransf.AtLevel(linTransf.LevelQ + 1)
+		require.Error(t, err)
+	})
+
 	t.Run(name("LinearTransform/BSGS=False", tc), func(t *testing.T) {
 
 		values, _, ciphertext := tc.NewTestVector(-1-1i, 1+1i)
@@ -236,6 +294,69 @@ func run(tc *ckks.TestContext, t *testing.T) {
 		ckks.VerifyTestVectors(params, tc.Ecd, tc.Dec, values, ciphertext, params.LogDefaultScale(), 0, *printPrecisionStats, t)
 	})
 
+	t.Run(name("LinearTransform/PermutationCircuit", tc), func(t *testing.T) {
+
+		values, _, ciphertext := tc.NewTestVector(-1-1i, 1+1i)
+
+		slots := ciphertext.Slots()
+
+		// A random shuffle is dense in the diagonal representation and is compiled
+		// to the baby-step giant-step algorithm.
+		perm := make([]int, slots)
+		for i := range perm {
+			perm[i] = i
+		}
+		r := rand.New(rand.NewSource(1))
+		r.Shuffle(len(perm), func(i, j int) {
+			perm[i], perm[j] = perm[j], perm[i]
+		})
+
+		pc, err := NewPermutationCircuit(params, tc.Ecd, perm, ciphertext.Level())
+		require.NoError(t, err)
+
+		evk := rlwe.NewMemEvaluationKeySet(nil, tc.Kgen.GenGaloisKeysNew(pc.GaloisElements, tc.Sk)...)
+
+		ltEval := NewEvaluator(tc.Evl.WithKey(evk))
+
+		require.NoError(t, ltEval.Evaluate(ciphertext, pc.LinearTransformation, ciphertext))
+
+		want := make([]*bignum.Complex, slots)
+		for i := range want {
+			want[i] = values[perm[i]]
+		}
+
+		ckks.VerifyTestVectors(params, tc.Ecd, tc.Dec, want, ciphertext, params.LogDefaultScale(), 0, *printPrecisionStats, t)
+
+		// A cyclic shift has a single non-zero diagonal and is compiled to a single
+		// hoisted rotation.
+		rot := make([]int, slots)
+		for i := range rot {
+			rot[i] = (i + 1) % slots
+		}
+
+		pcRot, err := NewPermutationCircuit(params, tc.Ecd, rot, params.MaxLevel())
+		require.NoError(t, err)
+		require.Equal(t, 1, len(pcRot.GaloisElements))
+
+		values, _, ciphertext = tc.NewTestVector(-1-1i, 1+1i)
+
+		evk = rlwe.NewMemEvaluationKeySet(nil, tc.Kgen.GenGaloisKeysNew(pcRot.GaloisElements, tc.Sk)...)
+
+		require.NoError(t, NewEvaluator(tc.Evl.WithKey(evk)).Evaluate(ciphertext, pcRot.LinearTransformation, ciphertext))
+
+		for i := range want {
+			want[i] = values[rot[i]]
+		}
+
+		ckks.VerifyTestVectors(params, tc.Ecd, tc.Dec, want, ciphertext, params.LogDefaultScale(), 0, *printPrecisionStats, t)
+
+		_, err = NewPermutationCircuit(params, tc.Ecd, perm[:slots-1], params.MaxLevel())
+		require.Error(t, err)
+
+		_, err = NewPermutationCircuit(params, tc.Ecd, make([]int, slots), params.MaxLevel())
+		require.Error(t, err)
+	})
+
 	t.Run(name("LinearTransform/Permutation", tc), func(t *testing.T) {
 		idx := make([]int, params.MaxSlots())
 		for i := range idx {
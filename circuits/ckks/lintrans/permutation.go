@@ -0,0 +1,85 @@
+package lintrans
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/schemes"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// PermutationCircuit is a pre-encoded, reusable circuit evaluating an arbitrary
+// permutation of the slots as a single sparse linear transformation
+// (see [NewPermutationCircuit]).
+type PermutationCircuit struct {
+	LinearTransformation
+	// GaloisElements is the list of the Galois elements required to evaluate the circuit.
+	GaloisElements []uint64
+}
+
+// NewPermutationCircuit compiles an arbitrary permutation of the slots into a
+// [PermutationCircuit] encoded at the given level: slot i of the output holds slot
+// perm[i] of the input. perm must be a permutation of the slot indices
+// [0, params.MaxSlots()).
+//
+// The permutation is evaluated as masked rotations over the non-zero diagonals of its
+// matrix representation: a permutation with at most log(slots) non-zero diagonals is
+// evaluated with one hoisted rotation per diagonal, while denser permutations fall back
+// to the baby-step giant-step algorithm, which requires fewer Galois keys.
+//
+// The returned circuit is encoded once and can be evaluated on any number of ciphertexts
+// with [Evaluator.Evaluate]; ciphertexts below the encoding level are supported through
+// the automatic level truncation of the evaluator.
+func NewPermutationCircuit(params ckks.Parameters, ecd schemes.Encoder, perm []int, level int) (PermutationCircuit, error) {
+
+	slots := params.MaxSlots()
+
+	if len(perm) != slots {
+		return PermutationCircuit{}, fmt.Errorf("cannot NewPermutationCircuit: permutation has %d entries but the parameters provide %d slots", len(perm), slots)
+	}
+
+	seen := make([]bool, slots)
+	for _, v := range perm {
+		if v < 0 || v >= slots {
+			return PermutationCircuit{}, fmt.Errorf("cannot NewPermutationCircuit: entry %d is out of the slot range [0, %d)", v, slots)
+		}
+		if seen[v] {
+			return PermutationCircuit{}, fmt.Errorf("cannot NewPermutationCircuit: entry %d appears more than once", v)
+		}
+		seen[v] = true
+	}
+
+	permutation := make(Permutation[float64], slots)
+	for i, v := range perm {
+		permutation[i] = PermutationMapping[float64]{From: v, To: i, Scaling: 1}
+	}
+
+	diagonals := permutation.GetDiagonals(params.LogMaxSlots())
+
+	// A permutation that is sparse in the diagonal representation is evaluated with the
+	// naive algorithm (one hoisted rotation per non-zero diagonal), which is faster but
+	// requires one Galois key per diagonal.
+	logBabyStepGiantStepRatio := 1
+	if len(diagonals) <= params.LogMaxSlots() {
+		logBabyStepGiantStepRatio = -1
+	}
+
+	ltparams := Parameters{
+		DiagonalsIndexList:        diagonals.DiagonalsIndexList(),
+		LevelQ:                    level,
+		LevelP:                    params.MaxLevelP(),
+		Scale:                     params.GetOptimalScalingFactor(params.DefaultScale(), params.DefaultScale(), level),
+		LogDimensions:             params.LogMaxDimensions(),
+		LogBabyStepGiantStepRatio: logBabyStepGiantStepRatio,
+	}
+
+	lt := NewTransformation(params, ltparams)
+
+	if err := Encode(ecd, diagonals, lt); err != nil {
+		return PermutationCircuit{}, fmt.Errorf("cannot NewPermutationCircuit: %w", err)
+	}
+
+	return PermutationCircuit{
+		LinearTransformation: lt,
+		GaloisElements:       lt.GaloisElements(params),
+	}, nil
+}
@@ -0,0 +1,96 @@
+// Package masking implements the mask-aware aggregation circuits for the CKKS scheme,
+// extending the scheme-agnostic sum and count of [masking.Evaluator] with the mean over
+// the present values, which requires an encrypted division by the count.
+package masking
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/inverse"
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/minimax"
+	"github.com/tuneinsight/lattigo/v6/circuits/common/masking"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// MaskFloat64 is a wrapper of [masking.MaskFloat64].
+func MaskFloat64(values []float64) (cleaned, mask []float64) {
+	return masking.MaskFloat64(values)
+}
+
+// GaloisElements is a wrapper of [masking.GaloisElements].
+func GaloisElements(params rlwe.ParameterProvider, n int) (galEls []uint64) {
+	return masking.GaloisElements(params, n)
+}
+
+// Evaluator is an evaluator used to aggregate masked CKKS ciphertexts.
+type Evaluator struct {
+	masking.Evaluator
+	InvEval inverse.Evaluator
+}
+
+// NewEvaluator instantiates a new masking [Evaluator] from a [minimax.Evaluator], whose
+// bootstrapper (if any) is used by the encrypted division of [Evaluator.Mean].
+// This method is allocation free.
+func NewEvaluator(params ckks.Parameters, minimaxEvl *minimax.Evaluator) Evaluator {
+	return Evaluator{
+		Evaluator: masking.NewEvaluator(minimaxEvl.Evaluator),
+		InvEval:   inverse.NewEvaluator(params, minimaxEvl),
+	}
+}
+
+// Mean evaluates the mean of the present values over groups of n slots, as the masked sum
+// divided by the count of present values. The caller must guarantee that each group holds at
+// least one present value, as the division is evaluated on the interval [1/n, 1]. The first
+// slot of each group of the output holds the mean of the present values of the group; the
+// remaining slots hold garbage.
+func (eval Evaluator) Mean(ctValues, ctMask *rlwe.Ciphertext, n int) (opOut *rlwe.Ciphertext, err error) {
+
+	var sum *rlwe.Ciphertext
+	if sum, err = eval.Sum(ctValues, ctMask, n); err != nil {
+		return nil, err
+	}
+
+	if err = eval.Rescale(sum, sum); err != nil {
+		return nil, err
+	}
+
+	var count *rlwe.Ciphertext
+	if count, err = eval.Count(ctMask, n); err != nil {
+		return nil, err
+	}
+
+	// count/n in (0, 1], as required by the Goldschmidt division
+	if err = eval.Mul(count, 1/float64(n), count); err != nil {
+		return nil, err
+	}
+
+	if err = eval.Rescale(count, count); err != nil {
+		return nil, err
+	}
+
+	var inv *rlwe.Ciphertext
+	if inv, err = eval.InvEval.GoldschmidtDivisionNew(count, -math.Log2(float64(n))); err != nil {
+		return nil, fmt.Errorf("division by the count: %w", err)
+	}
+
+	// mean = (sum/n) * (n/count)
+	if err = eval.Mul(sum, 1/float64(n), sum); err != nil {
+		return nil, err
+	}
+
+	if err = eval.Rescale(sum, sum); err != nil {
+		return nil, err
+	}
+
+	if opOut, err = eval.MulRelinNew(sum, inv); err != nil {
+		return nil, err
+	}
+
+	if err = eval.Rescale(opOut, opOut); err != nil {
+		return nil, err
+	}
+
+	return
+}
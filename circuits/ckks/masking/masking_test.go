@@ -0,0 +1,71 @@
+package masking
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/bootstrapping"
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/minimax"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestMaskedMean(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	tc := ckks.NewTestContext(ckks.ParametersLiteral{
+		LogN:            10,
+		LogQ:            []int{55, 45, 45, 45, 45, 45, 45},
+		LogP:            []int{61},
+		LogDefaultScale: 45,
+	})
+
+	params := tc.Params
+
+	n := 8
+
+	evk := rlwe.NewMemEvaluationKeySet(tc.Kgen.GenRelinearizationKeyNew(tc.Sk), tc.Kgen.GenGaloisKeysNew(GaloisElements(params, n), tc.Sk)...)
+
+	btp := bootstrapping.NewSecretKeyBootstrapper(params, tc.Sk)
+
+	eval := NewEvaluator(params, minimax.NewEvaluator(params, tc.Evl.WithKey(evk), btp))
+
+	slots := params.MaxSlots()
+
+	raw := make([]float64, slots)
+	for i := range raw {
+		if i%3 == 0 && i%n != 0 { // keep at least one present value per group
+			raw[i] = math.NaN()
+		} else {
+			raw[i] = sampling.RandFloat64(-1, 1)
+		}
+	}
+
+	values, mask := MaskFloat64(raw)
+
+	encrypt := func(v []float64) *rlwe.Ciphertext {
+		pt := ckks.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, tc.Ecd.Encode(v, pt))
+		ct, err := tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+		return ct
+	}
+
+	ctMean, err := eval.Mean(encrypt(values), encrypt(mask), n)
+	require.NoError(t, err)
+
+	have := make([]float64, slots)
+	require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(ctMean), have))
+
+	for i := 0; i < slots; i += n {
+		var sum, count float64
+		for j := i; j < i+n; j++ {
+			sum += values[j]
+			count += mask[j]
+		}
+		require.InDelta(t, sum/count, have[i], 1e-3)
+	}
+}
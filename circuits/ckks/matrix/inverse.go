@@ -0,0 +1,87 @@
+package matrix
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+// PrescaleFactor returns the Newton-Schulz pre-scaling factor 2/(sigmaMin^2 + sigmaMax^2)
+// for a matrix whose singular values lie in [sigmaMin, sigmaMax]. Starting from
+// X_0 = PrescaleFactor(sigmaMin, sigmaMax) * A^T, the iteration converges to A^{-1}
+// with quadratic rate ((sigmaMax^2 - sigmaMin^2)/(sigmaMax^2 + sigmaMin^2))^{2^k}.
+func PrescaleFactor(sigmaMin, sigmaMax float64) float64 {
+	return 2 / (sigmaMin*sigmaMin + sigmaMax*sigmaMax)
+}
+
+// InverseDepth returns the multiplicative depth (number of levels consumed) of
+// the inversion circuit evaluated by [Evaluator.InverseNew].
+func InverseDepth(iterations int) int {
+	return 1 + 4*iterations
+}
+
+// InverseNew approximates the inverse of the encrypted real-valued d x d matrix A
+// with the Newton-Schulz iteration
+//
+//	X_{k+1} = X_k (2I - A X_k)
+//
+// starting from X_0 = prescale * A^T and returns X_{iterations} in a new ciphertext.
+// The iteration converges quadratically to A^{-1} provided ||I - prescale * A^T A|| < 1,
+// which holds for any well-conditioned matrix pre-scaled with [PrescaleFactor] and
+// bounds on its singular values. The bit-precision of the approximation doubles
+// with each iteration.
+//
+// The circuit consumes [InverseDepth](iterations) levels and the method returns an
+// error if the input ciphertext does not have enough remaining levels.
+func (eval *Evaluator) InverseNew(ct *rlwe.Ciphertext, prescale float64, iterations int) (opOut *rlwe.Ciphertext, err error) {
+
+	if iterations < 1 {
+		return nil, fmt.Errorf("cannot InverseNew: iterations must be at least one but is %d", iterations)
+	}
+
+	if depth := InverseDepth(iterations) * eval.Parameters.LevelsConsumedPerRescaling(); ct.Level() < depth {
+		return nil, fmt.Errorf("cannot InverseNew: ct.Level()=%d < depth=%d", ct.Level(), depth)
+	}
+
+	d := eval.Dimension
+	d2 := d * d
+
+	// 2I, replicated on each d^2 block.
+	twoI := make([]complex128, ct.Slots())
+	for b := 0; b < len(twoI); b += d2 {
+		for i := 0; i < d; i++ {
+			twoI[b+d*i+i] = 2
+		}
+	}
+
+	// X_0 = prescale * A^T, folding the pre-scaling into the transposition masks.
+	var ctX *rlwe.Ciphertext
+	if ctX, err = eval.permuteNew(ct, complex(prescale, 0), transposeSource(d)); err != nil {
+		return nil, fmt.Errorf("cannot InverseNew: %w", err)
+	}
+
+	for k := 0; k < iterations; k++ {
+
+		// T = A X_k
+		var ctT *rlwe.Ciphertext
+		if ctT, err = eval.MulMatrixNew(ct, ctX); err != nil {
+			return nil, fmt.Errorf("cannot InverseNew: %w", err)
+		}
+
+		// W = 2I - T
+		if err = eval.Mul(ctT, -1, ctT); err != nil {
+			return nil, fmt.Errorf("cannot InverseNew: %w", err)
+		}
+
+		if err = eval.Add(ctT, twoI, ctT); err != nil {
+			return nil, fmt.Errorf("cannot InverseNew: %w", err)
+		}
+
+		// X_{k+1} = X_k W
+		if ctX, err = eval.MulMatrixNew(ctX, ctT); err != nil {
+			return nil, fmt.Errorf("cannot InverseNew: %w", err)
+		}
+	}
+
+	return ctX, nil
+}
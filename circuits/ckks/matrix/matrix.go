@@ -0,0 +1,290 @@
+// Package matrix implements homomorphic operations on encrypted square matrices
+// for the CKKS scheme. A d x d matrix is packed row-major in blocks of d^2 slots
+// (replicated to fill the ciphertext) and matrix products are evaluated with the
+// permutation-based algorithm of Jiang, Kim, Lauter and Song
+// (https://eprint.iacr.org/2018/1041), where the row and column permutations are
+// implemented with masked rotations.
+package matrix
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// Evaluator is an evaluator used to evaluate products and inverses of encrypted
+// square matrices. All fields of this struct are public, enabling custom
+// instantiations.
+type Evaluator struct {
+	Parameters ckks.Parameters
+	// Dimension is the dimension d of the d x d matrices on which the evaluator operates.
+	Dimension int
+	*ckks.Evaluator
+}
+
+// NewEvaluator instantiates a new [Evaluator] for d x d matrices.
+// The dimension d must be a power of two (so that matrix blocks align with the
+// slot rotations) with d^2 at most the number of slots.
+// This method is allocation free.
+func NewEvaluator(params ckks.Parameters, dimension int, eval *ckks.Evaluator) (*Evaluator, error) {
+
+	if dimension < 2 || dimension&(dimension-1) != 0 {
+		return nil, fmt.Errorf("cannot NewEvaluator: dimension must be a power of two greater than one but is %d", dimension)
+	}
+
+	if dimension*dimension > params.MaxSlots() {
+		return nil, fmt.Errorf("cannot NewEvaluator: dimension^2=%d exceeds the maximum number of slots %d", dimension*dimension, params.MaxSlots())
+	}
+
+	return &Evaluator{
+		Parameters: params,
+		Dimension:  dimension,
+		Evaluator:  eval,
+	}, nil
+}
+
+// Pack returns the row-major packing of the d x d matrix m, replicated to fill
+// the given number of slots. The replication ensures that all slot rotations
+// used by the [Evaluator] act as cyclic rotations within each d^2 block.
+func Pack(m [][]complex128, slots int) ([]complex128, error) {
+
+	d := len(m)
+	d2 := d * d
+
+	if d2 > slots || slots%d2 != 0 {
+		return nil, fmt.Errorf("cannot Pack: dimension^2=%d does not divide the number of slots %d", d2, slots)
+	}
+
+	values := make([]complex128, slots)
+	for i := range m {
+
+		if len(m[i]) != d {
+			return nil, fmt.Errorf("cannot Pack: matrix row %d has %d columns but the matrix has %d rows", i, len(m[i]), d)
+		}
+
+		for j, c := range m[i] {
+			for b := d*i + j; b < slots; b += d2 {
+				values[b] = c
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// Unpack returns the d x d matrix packed row-major in the first d^2 entries of
+// the vector of slot values. It is the inverse of [Pack].
+func Unpack(values []complex128, d int) [][]complex128 {
+	m := make([][]complex128, d)
+	for i := range m {
+		m[i] = make([]complex128, d)
+		copy(m[i], values[d*i:d*(i+1)])
+	}
+	return m
+}
+
+// GaloisElements returns the list of Galois elements required to evaluate the
+// matrix products, transpositions and inversions of an [Evaluator] instantiated
+// for d x d matrices.
+func GaloisElements(params ckks.Parameters, dimension int) (galEls []uint64) {
+
+	d := dimension
+
+	rotations := map[int]bool{}
+
+	for _, src := range []func(i, j int) (int, int){sigmaSource(d), tauSource(d), transposeSource(d)} {
+		for _, t := range rotationIndices(d, src) {
+			rotations[t] = true
+		}
+	}
+
+	for k := 1; k < d; k++ {
+
+		for _, t := range rotationIndices(d, phiSource(d, k)) {
+			rotations[t] = true
+		}
+
+		rotations[k*d] = true
+	}
+
+	delete(rotations, 0)
+
+	for _, t := range utils.GetSortedKeys(rotations) {
+		galEls = append(galEls, params.GaloisElement(t))
+	}
+
+	return
+}
+
+// MulMatrixNew computes the product of the two encrypted d x d matrices ctA and
+// ctB and returns the result in a new ciphertext. The circuit consumes three
+// levels: one for each of the two permutations and one for the products.
+func (eval *Evaluator) MulMatrixNew(ctA, ctB *rlwe.Ciphertext) (opOut *rlwe.Ciphertext, err error) {
+
+	d := eval.Dimension
+
+	// A0_{i,j} = A_{i,(i+j) mod d}
+	var ctA0 *rlwe.Ciphertext
+	if ctA0, err = eval.permuteNew(ctA, 1, sigmaSource(d)); err != nil {
+		return nil, fmt.Errorf("cannot MulMatrixNew: %w", err)
+	}
+
+	// B0_{i,j} = B_{(i+j) mod d,j}
+	var ctB0 *rlwe.Ciphertext
+	if ctB0, err = eval.permuteNew(ctB, 1, tauSource(d)); err != nil {
+		return nil, fmt.Errorf("cannot MulMatrixNew: %w", err)
+	}
+
+	// (AB)_{i,j} = sum_k A0_{i,(j+k) mod d} * B0_{(i+k) mod d,j}
+	for k := 0; k < d; k++ {
+
+		ctAk := ctA0
+		if k > 0 {
+			if ctAk, err = eval.permuteNew(ctA0, 1, phiSource(d, k)); err != nil {
+				return nil, fmt.Errorf("cannot MulMatrixNew: %w", err)
+			}
+		}
+
+		// Since the packing is replicated, shifting the rows down by k is a plain rotation.
+		ctBk := ctB0
+		if k > 0 {
+			if ctBk, err = eval.RotateNew(ctB0, k*d); err != nil {
+				return nil, fmt.Errorf("cannot MulMatrixNew: %w", err)
+			}
+		}
+
+		var prod *rlwe.Ciphertext
+		if prod, err = eval.MulRelinNew(ctAk, ctBk); err != nil {
+			return nil, fmt.Errorf("cannot MulMatrixNew: %w", err)
+		}
+
+		if opOut == nil {
+			opOut = prod
+		} else if err = eval.Add(opOut, prod, opOut); err != nil {
+			return nil, fmt.Errorf("cannot MulMatrixNew: %w", err)
+		}
+	}
+
+	if err = eval.Rescale(opOut, opOut); err != nil {
+		return nil, fmt.Errorf("cannot MulMatrixNew: %w", err)
+	}
+
+	return
+}
+
+// TransposeNew computes the transpose of the encrypted d x d matrix and returns
+// the result in a new ciphertext. The circuit consumes one level.
+func (eval *Evaluator) TransposeNew(ct *rlwe.Ciphertext) (opOut *rlwe.Ciphertext, err error) {
+	if opOut, err = eval.permuteNew(ct, 1, transposeSource(eval.Dimension)); err != nil {
+		return nil, fmt.Errorf("cannot TransposeNew: %w", err)
+	}
+	return
+}
+
+// sigmaSource maps position (i,j) to its source (i,(i+j) mod d), aligning the
+// i-th row of the left operand on its i-th diagonal.
+func sigmaSource(d int) func(i, j int) (int, int) {
+	return func(i, j int) (int, int) {
+		return i, (i + j) % d
+	}
+}
+
+// tauSource maps position (i,j) to its source ((i+j) mod d,j), aligning the
+// j-th column of the right operand on its j-th diagonal.
+func tauSource(d int) func(i, j int) (int, int) {
+	return func(i, j int) (int, int) {
+		return (i + j) % d, j
+	}
+}
+
+// phiSource maps position (i,j) to its source (i,(j+k) mod d), a cyclic shift
+// of the columns by k.
+func phiSource(d, k int) func(i, j int) (int, int) {
+	return func(i, j int) (int, int) {
+		return i, (j + k) % d
+	}
+}
+
+// transposeSource maps position (i,j) to its source (j,i).
+func transposeSource(d int) func(i, j int) (int, int) {
+	return func(i, j int) (int, int) {
+		return j, i
+	}
+}
+
+// rotationIndices returns the sorted list of rotations required to evaluate the
+// permutation defined by the source mapping with masked rotations.
+func rotationIndices(d int, src func(i, j int) (int, int)) []int {
+
+	d2 := d * d
+
+	rotations := map[int]bool{}
+	for i := 0; i < d; i++ {
+		for j := 0; j < d; j++ {
+			qi, qj := src(i, j)
+			rotations[((d*qi+qj)-(d*i+j)+d2)%d2] = true
+		}
+	}
+
+	return utils.GetSortedKeys(rotations)
+}
+
+// permuteNew evaluates the slot permutation defined by the source mapping
+// (i,j) -> src(i,j) on each d^2 block, scaled by the given factor, as a sum of
+// masked rotations. The circuit consumes one level.
+func (eval *Evaluator) permuteNew(ct *rlwe.Ciphertext, scaling complex128, src func(i, j int) (int, int)) (opOut *rlwe.Ciphertext, err error) {
+
+	d := eval.Dimension
+	d2 := d * d
+	slots := ct.Slots()
+
+	if d2 > slots || slots%d2 != 0 {
+		return nil, fmt.Errorf("dimension^2=%d does not divide the number of slots %d", d2, slots)
+	}
+
+	// Groups the slots by the rotation bringing their source in place.
+	masks := map[int][]complex128{}
+	for i := 0; i < d; i++ {
+		for j := 0; j < d; j++ {
+
+			p := d*i + j
+			qi, qj := src(i, j)
+			t := ((d*qi + qj) - p + d2) % d2
+
+			mask, ok := masks[t]
+			if !ok {
+				mask = make([]complex128, slots)
+				masks[t] = mask
+			}
+
+			for b := p; b < slots; b += d2 {
+				mask[b] = scaling
+			}
+		}
+	}
+
+	for _, t := range utils.GetSortedKeys(masks) {
+
+		rot := ct
+		if t != 0 {
+			if rot, err = eval.RotateNew(ct, t); err != nil {
+				return nil, err
+			}
+		}
+
+		var tmp *rlwe.Ciphertext
+		if tmp, err = eval.MulNew(rot, masks[t]); err != nil {
+			return nil, err
+		}
+
+		if opOut == nil {
+			opOut = tmp
+		} else if err = eval.Add(opOut, tmp, opOut); err != nil {
+			return nil, err
+		}
+	}
+
+	return opOut, eval.Rescale(opOut, opOut)
+}
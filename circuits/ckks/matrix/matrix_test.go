@@ -0,0 +1,143 @@
+package matrix
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+var printPrecisionStats = flag.Bool("print-precision", false, "print precision stats")
+
+// testInsecure are insecure parameters used for the sole purpose of fast testing.
+var testInsecure = ckks.ParametersLiteral{
+	LogN:            10,
+	LogQ:            []int{60, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45},
+	LogP:            []int{61},
+	LogDefaultScale: 45,
+}
+
+func TestMatrix(t *testing.T) {
+
+	tc := ckks.NewTestContext(testInsecure)
+	params := tc.Params
+
+	d := 4
+
+	evk := rlwe.NewMemEvaluationKeySet(tc.Kgen.GenRelinearizationKeyNew(tc.Sk), tc.Kgen.GenGaloisKeysNew(GaloisElements(params, d), tc.Sk)...)
+
+	eval, err := NewEvaluator(params, d, tc.Evl.WithKey(evk))
+	require.NoError(t, err)
+
+	newMatrix := func(f func(i, j int) complex128) [][]complex128 {
+		m := make([][]complex128, d)
+		for i := range m {
+			m[i] = make([]complex128, d)
+			for j := range m[i] {
+				m[i][j] = f(i, j)
+			}
+		}
+		return m
+	}
+
+	encrypt := func(t *testing.T, m [][]complex128) *rlwe.Ciphertext {
+		values, err := Pack(m, params.MaxSlots())
+		require.NoError(t, err)
+		pt := ckks.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, tc.Ecd.Encode(values, pt))
+		ct, err := tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+		return ct
+	}
+
+	mulMat := func(a, b [][]complex128) [][]complex128 {
+		return newMatrix(func(i, j int) (c complex128) {
+			for k := 0; k < d; k++ {
+				c += a[i][k] * b[k][j]
+			}
+			return
+		})
+	}
+
+	verify := func(t *testing.T, want [][]complex128, ct *rlwe.Ciphertext, minPrec int) {
+		values, err := Pack(want, params.MaxSlots())
+		require.NoError(t, err)
+		ckks.VerifyTestVectors(params, tc.Ecd, tc.Dec, values, ct, minPrec, 0, *printPrecisionStats, t)
+	}
+
+	t.Run("Mul", func(t *testing.T) {
+
+		A := newMatrix(func(i, j int) complex128 { return sampling.RandComplex128(-1, 1) })
+		B := newMatrix(func(i, j int) complex128 { return sampling.RandComplex128(-1, 1) })
+
+		ct, err := eval.MulMatrixNew(encrypt(t, A), encrypt(t, B))
+		require.NoError(t, err)
+
+		verify(t, mulMat(A, B), ct, 30)
+	})
+
+	t.Run("Transpose", func(t *testing.T) {
+
+		A := newMatrix(func(i, j int) complex128 { return sampling.RandComplex128(-1, 1) })
+
+		ct, err := eval.TransposeNew(encrypt(t, A))
+		require.NoError(t, err)
+
+		verify(t, newMatrix(func(i, j int) complex128 { return A[j][i] }), ct, 35)
+	})
+
+	t.Run("Inverse", func(t *testing.T) {
+
+		// A = I + E with ||E||_2 <= 0.4: the singular values of A lie in [0.6, 1.4].
+		A := newMatrix(func(i, j int) complex128 {
+			c := complex(0.1*sampling.RandFloat64(-1, 1), 0)
+			if i == j {
+				c++
+			}
+			return c
+		})
+
+		prescale := PrescaleFactor(0.6, 1.4)
+		iterations := 3
+
+		ct, err := eval.InverseNew(encrypt(t, A), prescale, iterations)
+		require.NoError(t, err)
+
+		// Evaluates the same Newton-Schulz iteration on the plaintext matrix.
+		X := newMatrix(func(i, j int) complex128 { return complex(prescale, 0) * A[j][i] })
+		for k := 0; k < iterations; k++ {
+			W := mulMat(A, X)
+			for i := range W {
+				for j := range W[i] {
+					W[i][j] = -W[i][j]
+					if i == j {
+						W[i][j] += 2
+					}
+				}
+			}
+			X = mulMat(X, W)
+		}
+
+		verify(t, X, ct, 20)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+
+		_, err := NewEvaluator(params, 3, tc.Evl)
+		require.Error(t, err)
+
+		A := newMatrix(func(i, j int) complex128 { return sampling.RandComplex128(-1, 1) })
+		ct := encrypt(t, A)
+
+		_, err = eval.InverseNew(ct, 1, 0)
+		require.Error(t, err)
+
+		ct.Resize(ct.Degree(), InverseDepth(1)-1)
+		_, err = eval.InverseNew(ct, 1, 1)
+		require.Error(t, err)
+	})
+}
@@ -70,6 +70,13 @@ func (evm ParametersLiteral) Depth() (depth int) {
 	return depth
 }
 
+// GaloisElements returns the list of Galois elements required to evaluate x mod 1,
+// which is empty since the circuit performs no rotation.
+// It implements [rlwe.GaloisElementsProvider].
+func (evm ParametersLiteral) GaloisElements(params rlwe.ParameterProvider) []uint64 {
+	return nil
+}
+
 // Parameters is a struct storing the parameters and polynomials approximating the function x mod Q[0] (the first prime of the moduli chain).
 type Parameters struct {
 	LevelQ          int                // starting level of the operation
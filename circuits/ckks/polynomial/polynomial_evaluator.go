@@ -56,6 +56,17 @@ func (eval Evaluator) Evaluate(ct *rlwe.Ciphertext, p interface{}, targetScale r
 	return eval.Evaluator.Evaluate(ct, phe, targetScale, levelsConsumedPerRescaling, &simEvaluator{eval.Parameters, levelsConsumedPerRescaling})
 }
 
+// EvaluateSymmetric evaluates a polynomial on the input Ciphertext, exploiting the odd or
+// even symmetry of the polynomial (as produced e.g. by sign or sine approximations) when
+// present: the evaluation is carried out in X^2 on a polynomial of half the degree, halving
+// the number of non-scalar multiplications. The detected symmetry and the saved depth are
+// returned along with the result; without symmetry (or in Chebyshev basis, where the
+// rewriting does not hold), the method falls back to [Evaluator.Evaluate].
+func (eval Evaluator) EvaluateSymmetric(ct *rlwe.Ciphertext, p bignum.Polynomial, targetScale rlwe.Scale) (opOut *rlwe.Ciphertext, report polynomial.SymmetryReport, err error) {
+	levelsConsumedPerRescaling := eval.Parameters.LevelsConsumedPerRescaling()
+	return eval.Evaluator.EvaluateSymmetric(ct, p, targetScale, levelsConsumedPerRescaling, &simEvaluator{eval.Parameters, levelsConsumedPerRescaling})
+}
+
 // EvaluateFromPowerBasis evaluates a polynomial using the provided [polynomial.PowerBasis], holding pre-computed powers of X.
 // This method is the same as [Evaluate] except that the encrypted input is a [polynomial.PowerBasis].
 // See [Evaluate] for additional information.
@@ -0,0 +1,86 @@
+package polynomial
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/common/polynomial"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+func TestEvaluateSymmetric(t *testing.T) {
+
+	tc := ckks.NewTestContext(testInsecurePrec45)
+	params := tc.Params
+
+	polyEval := NewEvaluator(params, tc.Evl)
+
+	t.Run(name("EvaluateSymmetric/Odd", tc), func(t *testing.T) {
+
+		// x - x^3/6 + x^5/120 - x^7/5040 (Taylor approximation of sin)
+		poly := bignum.NewPolynomial(bignum.Monomial, []float64{0, 1, 0, -1.0 / 6, 0, 1.0 / 120, 0, -1.0 / 5040}, nil)
+
+		odd, even := polynomial.DetectSymmetry(poly)
+		require.True(t, odd)
+		require.False(t, even)
+
+		values, _, ciphertext := tc.NewTestVector(-1, 1)
+
+		for i := range values {
+			values[i] = poly.Evaluate(values[i])
+		}
+
+		res, report, err := polyEval.EvaluateSymmetric(ciphertext, poly, ciphertext.Scale)
+		require.NoError(t, err)
+		require.True(t, report.Odd)
+		require.Equal(t, 3, report.InnerDegree)
+		require.Greater(t, report.DepthSaved, 0)
+
+		ckks.VerifyTestVectors(params, tc.Ecd, tc.Dec, values, res, params.LogDefaultScale(), 0, *printPrecisionStats, t)
+	})
+
+	t.Run(name("EvaluateSymmetric/Even", tc), func(t *testing.T) {
+
+		// 1 - x^2/2 + x^4/24 - x^6/720 (Taylor approximation of cos)
+		poly := bignum.NewPolynomial(bignum.Monomial, []float64{1, 0, -1.0 / 2, 0, 1.0 / 24, 0, -1.0 / 720}, nil)
+
+		odd, even := polynomial.DetectSymmetry(poly)
+		require.False(t, odd)
+		require.True(t, even)
+
+		values, _, ciphertext := tc.NewTestVector(-1, 1)
+
+		for i := range values {
+			values[i] = poly.Evaluate(values[i])
+		}
+
+		res, report, err := polyEval.EvaluateSymmetric(ciphertext, poly, ciphertext.Scale)
+		require.NoError(t, err)
+		require.True(t, report.Even)
+		require.Equal(t, 3, report.InnerDegree)
+
+		ckks.VerifyTestVectors(params, tc.Ecd, tc.Dec, values, res, params.LogDefaultScale(), 0, *printPrecisionStats, t)
+	})
+
+	t.Run(name("EvaluateSymmetric/Fallback", tc), func(t *testing.T) {
+
+		// No symmetry: falls back to the direct evaluation
+		poly := bignum.NewPolynomial(bignum.Monomial, []float64{1, 1, 0.5, 1.0 / 6}, nil)
+
+		values, _, ciphertext := tc.NewTestVector(-1, 1)
+
+		for i := range values {
+			values[i] = poly.Evaluate(values[i])
+		}
+
+		res, report, err := polyEval.EvaluateSymmetric(ciphertext, poly, ciphertext.Scale)
+		require.NoError(t, err)
+		require.False(t, report.Odd)
+		require.False(t, report.Even)
+		require.Equal(t, 3, report.InnerDegree)
+
+		ckks.VerifyTestVectors(params, tc.Ecd, tc.Dec, values, res, params.LogDefaultScale(), 0, *printPrecisionStats, t)
+	})
+}
@@ -0,0 +1,135 @@
+// Package rounding implements an approximate homomorphic rounding to the nearest integer
+// for the CKKS scheme, built on the trigonometric x mod 1 machinery of the
+// [github.com/tuneinsight/lattigo/v6/circuits/ckks/mod1] package.
+//
+// The circuit evaluates round(x) = x - frac(x), where frac(x), the centered fractional
+// part of x, is approximated with the scaled sine (or cosine with double-angle formula)
+// polynomials that are otherwise used during bootstrapping. It is therefore subject to the
+// same domain restrictions: the evaluation is accurate for |x| smaller than the interpolation
+// bound K of the [mod1.Parameters] and for inputs whose distance to the nearest integer is
+// at most 2^{-LogMessageRatio}. This makes the circuit suitable for quantization steps in
+// encrypted pipelines (e.g. casting values that already lie close to a discrete grid to low
+// precision), but not for rounding arbitrary real inputs.
+package rounding
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/mod1"
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/polynomial"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+// Evaluator is an evaluator providing an API for homomorphic rounding to the nearest integer.
+// All fields of this struct are public, enabling custom instantiations.
+type Evaluator struct {
+	*ckks.Evaluator
+	Mod1Evaluator *mod1.Evaluator
+	Parameters    mod1.Parameters
+}
+
+// NewEvaluator instantiates a new [Evaluator] from a [ckks.Evaluator] and the [mod1.Parameters]
+// defining the fractional-part approximation. This method is allocation free.
+func NewEvaluator(eval *ckks.Evaluator, evalPoly *polynomial.Evaluator, mod1Parameters mod1.Parameters) *Evaluator {
+	return &Evaluator{
+		Evaluator:     eval,
+		Mod1Evaluator: mod1.NewEvaluator(eval, evalPoly, mod1Parameters),
+		Parameters:    mod1Parameters,
+	}
+}
+
+// Depth returns the number of levels consumed by [Evaluator.RoundNew] and [Evaluator.FloorNew]
+// on top of the depth of the underlying x mod 1 evaluation: one level for the input
+// normalization.
+func (eval Evaluator) Depth() int {
+	return 1
+}
+
+// RoundNew returns round(ct), the closest integer to each slot of ct.
+//
+// The input must be at level at least Mod1Parameters.LevelQ + 1 (one level is consumed to
+// normalize the input to the interpolation interval before the trigonometric evaluation) and
+// its scale must not be larger than 2^{LogScale - LogMessageRatio}. The output is returned
+// at level Mod1Parameters.LevelQ - Depth(x mod 1) and scale 2^{LogScale - LogMessageRatio}.
+//
+// The result is accurate if |x| < K and |x - round(x)| <= 2^{-LogMessageRatio}; outside of
+// this domain the trigonometric approximation of the fractional part degrades and the output
+// is unspecified.
+func (eval Evaluator) RoundNew(ct *rlwe.Ciphertext) (res *rlwe.Ciphertext, err error) {
+
+	evm := eval.Parameters
+
+	if ct.Level() < evm.LevelQ+1 {
+		return nil, fmt.Errorf("cannot RoundNew: ct.Level() must be at least Mod1Parameters.LevelQ+1")
+	}
+
+	y := ct.CopyNew()
+
+	if y.Level() > evm.LevelQ+1 {
+		eval.DropLevel(y, y.Level()-evm.LevelQ-1)
+	}
+
+	// Normalization to the interpolation interval: the x mod 1 circuit expects the input
+	// scaled by MessageRatio/K, mapping a unit period to the native period of the
+	// approximation.
+	if err = eval.Mul(y, evm.MessageRatio()/evm.K, y); err != nil {
+		return nil, fmt.Errorf("cannot RoundNew: %w", err)
+	}
+
+	if err = eval.Rescale(y, y); err != nil {
+		return nil, fmt.Errorf("cannot RoundNew: %w", err)
+	}
+
+	// Scale the ciphertext up to 2^{LogScale}/MessageRatio, the scale expected by the
+	// x mod 1 evaluator.
+	targetScale := evm.ScalingFactor().Div(rlwe.NewScale(evm.MessageRatio()))
+
+	k := math.Round(targetScale.Div(y.Scale).Float64())
+
+	if k < 1 {
+		return nil, fmt.Errorf("cannot RoundNew: input scale is larger than 2^{LogScale-LogMessageRatio}")
+	}
+
+	if err = eval.ScaleUp(y, rlwe.NewScale(k), y); err != nil {
+		return nil, fmt.Errorf("cannot RoundNew: %w", err)
+	}
+
+	// Centered fractional part: the native output of the x mod 1 circuit is scaled by
+	// MessageRatio * QDiff, which is canceled without consuming additional depth.
+	var frac *rlwe.Ciphertext
+	if frac, err = eval.Mod1Evaluator.EvaluateAndScaleNew(y, complex(1/(evm.MessageRatio()*evm.QDiff), 0)); err != nil {
+		return nil, fmt.Errorf("cannot RoundNew: %w", err)
+	}
+
+	// round(x) = x - frac(x); the scales of the two operands differ by an integer factor,
+	// which the evaluator matches internally.
+	res = ct.CopyNew()
+
+	eval.DropLevel(res, res.Level()-frac.Level())
+
+	if err = eval.Sub(res, frac, res); err != nil {
+		return nil, fmt.Errorf("cannot RoundNew: %w", err)
+	}
+
+	return res, nil
+}
+
+// FloorNew returns floor(ct), the largest integer smaller or equal to each slot of ct.
+//
+// The evaluation is performed as round(ct - 1/2) and inherits the level, scale and domain
+// requirements of [Evaluator.RoundNew]; in particular the result is accurate if |x| < K and
+// the fractional part of x is at distance at least 2^{-LogMessageRatio} from 0 and 1 (exact
+// integers sit on the discontinuity of the floor function and cannot be resolved by the
+// approximation).
+func (eval Evaluator) FloorNew(ct *rlwe.Ciphertext) (res *rlwe.Ciphertext, err error) {
+
+	y := ct.CopyNew()
+
+	if err = eval.Add(y, -0.5, y); err != nil {
+		return nil, fmt.Errorf("cannot FloorNew: %w", err)
+	}
+
+	return eval.RoundNew(y)
+}
@@ -0,0 +1,119 @@
+package rounding
+
+import (
+	"math"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/mod1"
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/polynomial"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestRounding(t *testing.T) {
+
+	if runtime.GOARCH == "wasm" {
+		t.Skip("skipping homomorphic rounding tests for GOARCH=wasm")
+	}
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	params, err := ckks.NewParametersFromLiteral(ckks.ParametersLiteral{
+		LogN:            10,
+		LogQ:            []int{55, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 53},
+		LogP:            []int{61, 61, 61, 61, 61},
+		Xs:              ring.Ternary{H: 192},
+		LogDefaultScale: 45,
+	})
+	require.NoError(t, err)
+
+	evm := mod1.ParametersLiteral{
+		LevelQ:          12,
+		Mod1Type:        mod1.SinContinuous,
+		LogMessageRatio: 8,
+		K:               14,
+		Mod1Degree:      127,
+		Mod1InvDegree:   7,
+		LogScale:        60,
+	}
+
+	mod1Parameters, err := mod1.NewParametersFromLiteral(params, evm)
+	require.NoError(t, err)
+
+	kgen := rlwe.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	ecd := ckks.NewEncoder(params)
+	enc := rlwe.NewEncryptor(params, sk)
+	dec := rlwe.NewDecryptor(params, sk)
+	evalCKKS := ckks.NewEvaluator(params, rlwe.NewMemEvaluationKeySet(kgen.GenRelinearizationKeyNew(sk)))
+
+	eval := NewEvaluator(evalCKKS, polynomial.NewEvaluator(params, evalCKKS), mod1Parameters)
+
+	K := mod1Parameters.K - 1
+	maxFrac := math.Exp2(-float64(evm.LogMessageRatio))
+
+	encrypt := func(values []float64) *rlwe.Ciphertext {
+		pt := ckks.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, ecd.Encode(values, pt))
+		ct, err := enc.EncryptNew(pt)
+		require.NoError(t, err)
+		return ct
+	}
+
+	t.Run("Round", func(t *testing.T) {
+
+		// Integers with a fractional perturbation within the admissible distance
+		values := make([]float64, params.MaxSlots())
+		want := make([]float64, params.MaxSlots())
+		for i := range values {
+			want[i] = math.Round(sampling.RandFloat64(-K, K))
+			values[i] = want[i] + sampling.RandFloat64(-maxFrac, maxFrac)
+		}
+
+		res, err := eval.RoundNew(encrypt(values))
+		require.NoError(t, err)
+
+		have := make([]float64, params.MaxSlots())
+		require.NoError(t, ecd.Decode(dec.DecryptNew(res), have))
+
+		for i := range want {
+			require.InDelta(t, want[i], have[i], 1e-6)
+		}
+	})
+
+	t.Run("Floor", func(t *testing.T) {
+
+		// Values whose fractional part stays away from the discontinuities of floor
+		values := make([]float64, params.MaxSlots())
+		want := make([]float64, params.MaxSlots())
+		for i := range values {
+			want[i] = math.Round(sampling.RandFloat64(-K, K))
+			values[i] = want[i] + 0.5 + sampling.RandFloat64(-maxFrac, maxFrac)
+		}
+
+		res, err := eval.FloorNew(encrypt(values))
+		require.NoError(t, err)
+
+		have := make([]float64, params.MaxSlots())
+		require.NoError(t, ecd.Decode(dec.DecryptNew(res), have))
+
+		for i := range want {
+			require.InDelta(t, want[i], have[i], 1e-6)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+
+		values := make([]float64, params.MaxSlots())
+
+		ct := encrypt(values)
+		evalCKKS.DropLevel(ct, ct.Level()-mod1Parameters.LevelQ)
+
+		_, err := eval.RoundNew(ct)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,194 @@
+// Package batch implements a container for batches of ciphertexts sharing the
+// same level, scale and degree, together with vectorized evaluator operations
+// applying a single operation across the whole batch. The container checks the
+// metadata consistency once at construction instead of leaving it to unchecked
+// user loops, and the vectorized operations distribute the batch over several
+// evaluators, failing fast on the first error.
+package batch
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes"
+)
+
+// CiphertextBatch is a batch of ciphertexts enforced to share the same level,
+// scale and degree, so that a single operation can be applied uniformly across
+// the batch.
+type CiphertextBatch struct {
+	cts []*rlwe.Ciphertext
+}
+
+// NewCiphertextBatch instantiates a new [CiphertextBatch] from the given
+// ciphertexts, returning an error identifying the first ciphertext whose
+// level, scale or degree does not match the first element of the batch.
+func NewCiphertextBatch(cts []*rlwe.Ciphertext) (*CiphertextBatch, error) {
+
+	if len(cts) == 0 {
+		return nil, fmt.Errorf("cannot NewCiphertextBatch: batch cannot be empty")
+	}
+
+	b := &CiphertextBatch{cts: make([]*rlwe.Ciphertext, 0, len(cts))}
+	for i, ct := range cts {
+		if err := b.Append(ct); err != nil {
+			return nil, fmt.Errorf("cannot NewCiphertextBatch: ciphertext %d: %w", i, err)
+		}
+	}
+
+	return b, nil
+}
+
+// Append adds ct to the batch, returning an error if its level, scale or
+// degree does not match the batch.
+func (b *CiphertextBatch) Append(ct *rlwe.Ciphertext) error {
+
+	if ct == nil {
+		return fmt.Errorf("ciphertext is nil")
+	}
+
+	if len(b.cts) > 0 {
+		ref := b.cts[0]
+		if ct.Level() != ref.Level() {
+			return fmt.Errorf("level %d does not match the batch level %d", ct.Level(), ref.Level())
+		}
+		if !ct.Scale.Equal(ref.Scale) {
+			return fmt.Errorf("scale does not match the batch scale")
+		}
+		if ct.Degree() != ref.Degree() {
+			return fmt.Errorf("degree %d does not match the batch degree %d", ct.Degree(), ref.Degree())
+		}
+	}
+
+	b.cts = append(b.cts, ct)
+	return nil
+}
+
+// Len returns the number of ciphertexts in the batch.
+func (b *CiphertextBatch) Len() int {
+	return len(b.cts)
+}
+
+// At returns the i-th ciphertext of the batch.
+func (b *CiphertextBatch) At(i int) *rlwe.Ciphertext {
+	return b.cts[i]
+}
+
+// Level returns the common level of the batch.
+func (b *CiphertextBatch) Level() int {
+	return b.cts[0].Level()
+}
+
+// Scale returns the common scale of the batch.
+func (b *CiphertextBatch) Scale() rlwe.Scale {
+	return b.cts[0].Scale
+}
+
+// Degree returns the common degree of the batch.
+func (b *CiphertextBatch) Degree() int {
+	return b.cts[0].Degree()
+}
+
+// SchemeEvaluator defines the scheme-specific operations required to evaluate
+// vectorized batch operations. Both bgv.Evaluator and ckks.Evaluator comply to
+// it.
+type SchemeEvaluator interface {
+	schemes.Evaluator
+	Automorphism(ctIn *rlwe.Ciphertext, galEl uint64, opOut *rlwe.Ciphertext) (err error)
+}
+
+// Evaluator is an evaluator applying one operation across a whole
+// [CiphertextBatch], distributing the batch over its backing scheme
+// evaluators.
+type Evaluator struct {
+	evals []SchemeEvaluator
+}
+
+// NewEvaluator instantiates a new batch [Evaluator] from one or more scheme
+// evaluators. Each evaluator backs one worker: to process a batch over w
+// goroutines, pass w evaluators (e.g. one evaluator and w-1 of its
+// ShallowCopy), as the underlying buffers cannot be shared across workers.
+func NewEvaluator(evals ...SchemeEvaluator) (Evaluator, error) {
+	if len(evals) == 0 {
+		return Evaluator{}, fmt.Errorf("cannot NewEvaluator: at least one scheme evaluator is required")
+	}
+	return Evaluator{evals: evals}, nil
+}
+
+// AddNew returns a new batch holding b[i] + op for each ciphertext of the
+// batch.
+func (eval Evaluator) AddNew(b *CiphertextBatch, op rlwe.Operand) (*CiphertextBatch, error) {
+	return eval.run(b, func(eval SchemeEvaluator, ct *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+		return eval.AddNew(ct, op)
+	})
+}
+
+// MulRelinNew returns a new batch holding b[i] * op, with relinearization, for
+// each ciphertext of the batch.
+func (eval Evaluator) MulRelinNew(b *CiphertextBatch, op rlwe.Operand) (*CiphertextBatch, error) {
+	return eval.run(b, func(eval SchemeEvaluator, ct *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+		return eval.MulRelinNew(ct, op)
+	})
+}
+
+// RotateNew returns a new batch holding b[i] rotated by k slots for each
+// ciphertext of the batch. The Galois key for the rotation must be loaded in
+// every backing evaluator.
+func (eval Evaluator) RotateNew(b *CiphertextBatch, k int) (*CiphertextBatch, error) {
+	return eval.run(b, func(eval SchemeEvaluator, ct *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+		opOut := rlwe.NewCiphertext(*eval.GetRLWEParameters(), ct.Degree(), ct.Level())
+		if err := eval.Automorphism(ct, eval.GetRLWEParameters().GaloisElement(k), opOut); err != nil {
+			return nil, err
+		}
+		return opOut, nil
+	})
+}
+
+// run applies f to every ciphertext of the batch, distributing the work over
+// the backing evaluators and returning the first error encountered.
+func (eval Evaluator) run(b *CiphertextBatch, f func(eval SchemeEvaluator, ct *rlwe.Ciphertext) (*rlwe.Ciphertext, error)) (*CiphertextBatch, error) {
+
+	if b == nil || len(b.cts) == 0 {
+		return nil, fmt.Errorf("batch cannot be empty")
+	}
+
+	cts := make([]*rlwe.Ciphertext, len(b.cts))
+
+	jobs := make(chan int, len(b.cts))
+	for i := range b.cts {
+		jobs <- i
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+
+	for w := 0; w < len(eval.evals) && w < len(b.cts); w++ {
+		wg.Add(1)
+		go func(eval SchemeEvaluator) {
+			defer wg.Done()
+			for i := range jobs {
+				ct, err := f(eval, b.cts[i])
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("ciphertext %d: %w", i, err)
+					}
+					mu.Unlock()
+					return
+				}
+				cts[i] = ct
+			}
+		}(eval.evals[w])
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return NewCiphertextBatch(cts)
+}
@@ -0,0 +1,106 @@
+package batch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+func TestCiphertextBatch(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	tc := bgv.NewTestContext(bgv.ParametersLiteral{
+		LogN:             10,
+		LogQ:             []int{60, 60, 60},
+		LogP:             []int{61},
+		PlaintextModulus: 0x10001,
+	}, true) // scale-invariant multiplication, i.e. BFV
+
+	params := tc.Params
+	slots := params.MaxSlots()
+	rT := params.RingT().SubRings[0]
+
+	evk := rlwe.NewMemEvaluationKeySet(tc.Kgen.GenRelinearizationKeyNew(tc.Sk), tc.Kgen.GenGaloisKeysNew([]uint64{params.GaloisElement(1)}, tc.Sk)...)
+
+	evl := tc.Evl.WithKey(evk)
+	eval, err := NewEvaluator(evl, evl.ShallowCopy())
+	require.NoError(t, err)
+
+	n := 4
+	values := make([][]uint64, n)
+	cts := make([]*rlwe.Ciphertext, n)
+	for i := range cts {
+		values[i] = make([]uint64, slots)
+		for j := range values[i] {
+			values[i][j] = uint64(i*slots+j+1) % params.PlaintextModulus()
+		}
+		pt := bgv.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, tc.Ecd.Encode(values[i], pt))
+		cts[i], err = tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+	}
+
+	b, err := NewCiphertextBatch(cts)
+	require.NoError(t, err)
+	require.Equal(t, n, b.Len())
+	require.Equal(t, params.MaxLevel(), b.Level())
+
+	t.Run("Inconsistent", func(t *testing.T) {
+
+		// A ciphertext at a lower level is rejected
+		lower := rlwe.NewCiphertext(params, 1, params.MaxLevel()-1)
+		lower.Scale = cts[0].Scale
+		_, err := NewCiphertextBatch(append(cts[:n:n], lower))
+		require.Error(t, err)
+
+		// A degree-2 ciphertext is rejected
+		deg2 := rlwe.NewCiphertext(params, 2, params.MaxLevel())
+		deg2.Scale = cts[0].Scale
+		require.Error(t, b.Append(deg2))
+	})
+
+	t.Run("AddNew", func(t *testing.T) {
+
+		offset := make([]uint64, slots)
+		for j := range offset {
+			offset[j] = uint64(j) % params.PlaintextModulus()
+		}
+
+		res, err := eval.AddNew(b, offset)
+		require.NoError(t, err)
+
+		for i := 0; i < n; i++ {
+			want := make([]uint64, slots)
+			rT.Add(values[i], offset, want)
+			bgv.VerifyTestVectors(params, tc.Ecd, tc.Dec, res.At(i), want, t)
+		}
+	})
+
+	t.Run("MulRelinNew", func(t *testing.T) {
+
+		res, err := eval.MulRelinNew(b, cts[0])
+		require.NoError(t, err)
+
+		for i := 0; i < n; i++ {
+			want := make([]uint64, slots)
+			rT.MulCoeffsBarrett(values[i], values[0], want)
+			bgv.VerifyTestVectors(params, tc.Ecd, tc.Dec, res.At(i), want, t)
+		}
+	})
+
+	t.Run("RotateNew", func(t *testing.T) {
+
+		res, err := eval.RotateNew(b, 1)
+		require.NoError(t, err)
+
+		for i := 0; i < n; i++ {
+			// The ciphertext is viewed as a 2 x N/2 matrix whose rows rotate independently
+			want := append(utils.RotateSlice(values[i][:slots>>1], 1), utils.RotateSlice(values[i][slots>>1:], 1)...)
+			bgv.VerifyTestVectors(params, tc.Ecd, tc.Dec, res.At(i), want, t)
+		}
+	})
+}
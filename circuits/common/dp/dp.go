@@ -0,0 +1,177 @@
+// Package dp implements differentially private release of encrypted
+// aggregates: calibrated Laplace or Gaussian noise is sampled as plaintext by
+// the aggregator (or collectively, each party adding an equal share of the
+// noise) and added homomorphically to the encrypted aggregate before the
+// (threshold) decryption, so that the decrypted statistics satisfy an
+// (epsilon, delta) differential privacy budget.
+package dp
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+// Budget is an (epsilon, delta) differential privacy budget.
+type Budget struct {
+	Epsilon float64
+	Delta   float64
+}
+
+// Mechanism is a noise mechanism whose scale is calibrated to a [Budget] and
+// to the L1 (Laplace) or L2 (Gaussian) sensitivity of the released aggregate.
+type Mechanism struct {
+	budget      Budget
+	sensitivity float64
+	scale       float64 // Laplace scale b or Gaussian standard deviation
+	gaussian    bool
+}
+
+// NewLaplaceMechanism returns the Laplace mechanism of scale
+// b = sensitivity/epsilon, satisfying epsilon-differential privacy (delta = 0)
+// for an aggregate of L1 sensitivity sensitivity.
+func NewLaplaceMechanism(sensitivity float64, budget Budget) (Mechanism, error) {
+	if sensitivity <= 0 {
+		return Mechanism{}, fmt.Errorf("cannot NewLaplaceMechanism: sensitivity must be > 0 but is %f", sensitivity)
+	}
+	if budget.Epsilon <= 0 {
+		return Mechanism{}, fmt.Errorf("cannot NewLaplaceMechanism: epsilon must be > 0 but is %f", budget.Epsilon)
+	}
+	if budget.Delta != 0 {
+		return Mechanism{}, fmt.Errorf("cannot NewLaplaceMechanism: the Laplace mechanism is a pure epsilon-DP mechanism, delta must be 0 but is %e", budget.Delta)
+	}
+	return Mechanism{
+		budget:      budget,
+		sensitivity: sensitivity,
+		scale:       sensitivity / budget.Epsilon,
+	}, nil
+}
+
+// NewGaussianMechanism returns the Gaussian mechanism of standard deviation
+// sigma = sensitivity * sqrt(2*ln(1.25/delta)) / epsilon, satisfying
+// (epsilon, delta)-differential privacy for epsilon <= 1 and an aggregate of
+// L2 sensitivity sensitivity.
+func NewGaussianMechanism(sensitivity float64, budget Budget) (Mechanism, error) {
+	if sensitivity <= 0 {
+		return Mechanism{}, fmt.Errorf("cannot NewGaussianMechanism: sensitivity must be > 0 but is %f", sensitivity)
+	}
+	if budget.Epsilon <= 0 || budget.Epsilon > 1 {
+		return Mechanism{}, fmt.Errorf("cannot NewGaussianMechanism: epsilon must lie in (0, 1] but is %f", budget.Epsilon)
+	}
+	if budget.Delta <= 0 || budget.Delta >= 1 {
+		return Mechanism{}, fmt.Errorf("cannot NewGaussianMechanism: delta must lie in (0, 1) but is %e", budget.Delta)
+	}
+	return Mechanism{
+		budget:      budget,
+		sensitivity: sensitivity,
+		scale:       sensitivity * math.Sqrt(2*math.Log(1.25/budget.Delta)) / budget.Epsilon,
+		gaussian:    true,
+	}, nil
+}
+
+// Budget returns the (epsilon, delta) budget the mechanism is calibrated to.
+func (m Mechanism) Budget() Budget {
+	return m.budget
+}
+
+// Scale returns the noise scale of the mechanism: the scale b of the Laplace
+// distribution or the standard deviation sigma of the Gaussian distribution.
+func (m Mechanism) Scale() float64 {
+	return m.scale
+}
+
+// Split returns the per-party mechanism for the collective sampling of the
+// noise by parties parties: each party samples and adds its own noise, and the
+// sum of the parties shares follows the collective mechanism. Only the
+// Gaussian mechanism is stable under summation (each party samples with
+// standard deviation sigma/sqrt(parties)); splitting a Laplace mechanism
+// returns an error.
+func (m Mechanism) Split(parties int) (Mechanism, error) {
+	if !m.gaussian {
+		return Mechanism{}, fmt.Errorf("cannot Split: the Laplace distribution is not stable under summation, use the Gaussian mechanism for collective sampling")
+	}
+	if parties < 1 {
+		return Mechanism{}, fmt.Errorf("cannot Split: parties must be >= 1 but is %d", parties)
+	}
+	split := m
+	split.scale = m.scale / math.Sqrt(float64(parties))
+	return split, nil
+}
+
+// SampleNoise samples n fresh noise values from the mechanism, reading the
+// underlying randomness from prng.
+func (m Mechanism) SampleNoise(prng sampling.PRNG, n int) (noise []float64, err error) {
+
+	noise = make([]float64, n)
+
+	for i := range noise {
+
+		var u float64
+		if u, err = uniformFloat64(prng); err != nil {
+			return nil, fmt.Errorf("cannot SampleNoise: %w", err)
+		}
+
+		if m.gaussian {
+			// Box-Muller transform
+			var v float64
+			if v, err = uniformFloat64(prng); err != nil {
+				return nil, fmt.Errorf("cannot SampleNoise: %w", err)
+			}
+			noise[i] = m.scale * math.Sqrt(-2*math.Log(1-u)) * math.Cos(2*math.Pi*v)
+		} else {
+			// Inverse CDF of the Laplace distribution
+			if u < 0.5 {
+				noise[i] = m.scale * math.Log(2*u)
+			} else {
+				noise[i] = -m.scale * math.Log(2*(1-u))
+			}
+		}
+	}
+
+	return
+}
+
+// uniformFloat64 returns a uniform sample in [2^-53, 1), reading 8 bytes from
+// prng. The sample is never exactly 0, so that its logarithm is finite.
+func uniformFloat64(prng sampling.PRNG) (float64, error) {
+	var b [8]byte
+	if _, err := prng.Read(b[:]); err != nil {
+		return 0, err
+	}
+	return float64(binary.LittleEndian.Uint64(b[:])>>11+1) / (1 << 53), nil
+}
+
+// Evaluator is an evaluator adding calibrated differential privacy noise to
+// encrypted aggregates.
+type Evaluator struct {
+	schemes.Evaluator
+}
+
+// NewEvaluator instantiates a new [Evaluator] from a scheme evaluator. This
+// method is allocation free.
+func NewEvaluator(eval schemes.Evaluator) Evaluator {
+	return Evaluator{Evaluator: eval}
+}
+
+// AddNoiseNew samples one fresh noise value per slot of ct from the mechanism
+// and returns a new ciphertext holding ct + noise. The noise is encoded as a
+// []float64 plaintext operand, hence the method requires an approximate
+// arithmetic scheme (CKKS). The returned noise must be kept secret by the
+// sampling party: releasing it voids the differential privacy guarantee.
+func (eval Evaluator) AddNoiseNew(ct *rlwe.Ciphertext, m Mechanism, prng sampling.PRNG) (opOut *rlwe.Ciphertext, err error) {
+
+	noise, err := m.SampleNoise(prng, ct.Slots())
+	if err != nil {
+		return nil, fmt.Errorf("cannot AddNoiseNew: %w", err)
+	}
+
+	if opOut, err = eval.AddNew(ct, noise); err != nil {
+		return nil, fmt.Errorf("cannot AddNoiseNew: %w", err)
+	}
+
+	return
+}
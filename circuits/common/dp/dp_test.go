@@ -0,0 +1,123 @@
+package dp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestMechanisms(t *testing.T) {
+
+	prng, err := sampling.NewPRNG()
+	require.NoError(t, err)
+
+	n := 1 << 16
+
+	t.Run("Laplace", func(t *testing.T) {
+
+		m, err := NewLaplaceMechanism(2, Budget{Epsilon: 0.5})
+		require.NoError(t, err)
+		require.Equal(t, 4.0, m.Scale())
+
+		noise, err := m.SampleNoise(prng, n)
+		require.NoError(t, err)
+
+		// The standard deviation of Laplace(b) is b*sqrt(2)
+		require.InEpsilon(t, m.Scale()*math.Sqrt2, std(noise), 0.05)
+
+		// The Laplace mechanism is a pure epsilon-DP mechanism
+		_, err = NewLaplaceMechanism(1, Budget{Epsilon: 0.5, Delta: 1e-5})
+		require.Error(t, err)
+
+		// and cannot be sampled collectively
+		_, err = m.Split(4)
+		require.Error(t, err)
+	})
+
+	t.Run("Gaussian", func(t *testing.T) {
+
+		budget := Budget{Epsilon: 0.5, Delta: 1e-5}
+
+		m, err := NewGaussianMechanism(1, budget)
+		require.NoError(t, err)
+		require.Equal(t, math.Sqrt(2*math.Log(1.25/budget.Delta))/budget.Epsilon, m.Scale())
+
+		noise, err := m.SampleNoise(prng, n)
+		require.NoError(t, err)
+		require.InEpsilon(t, m.Scale(), std(noise), 0.05)
+
+		// The sum of the per-party shares follows the collective mechanism
+		share, err := m.Split(4)
+		require.NoError(t, err)
+		require.Equal(t, m.Scale()/2, share.Scale())
+
+		sum := make([]float64, n)
+		for i := 0; i < 4; i++ {
+			noise, err := share.SampleNoise(prng, n)
+			require.NoError(t, err)
+			for j := range sum {
+				sum[j] += noise[j]
+			}
+		}
+		require.InEpsilon(t, m.Scale(), std(sum), 0.05)
+
+		_, err = NewGaussianMechanism(1, Budget{Epsilon: 2, Delta: 1e-5})
+		require.Error(t, err)
+	})
+}
+
+func TestAddNoise(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	tc := ckks.NewTestContext(ckks.ParametersLiteral{
+		LogN:            12,
+		LogQ:            []int{55, 45, 45},
+		LogP:            []int{60},
+		LogDefaultScale: 45,
+	})
+
+	params := tc.Params
+	slots := params.MaxSlots()
+
+	prng, err := sampling.NewPRNG()
+	require.NoError(t, err)
+
+	m, err := NewGaussianMechanism(1, Budget{Epsilon: 0.5, Delta: 1e-5})
+	require.NoError(t, err)
+
+	values, _, ct := tc.NewTestVector(complex(-1, 0), complex(1, 0))
+
+	eval := NewEvaluator(tc.Evl)
+
+	noisy, err := eval.AddNoiseNew(ct, m, prng)
+	require.NoError(t, err)
+
+	have := make([]float64, slots)
+	require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(noisy), have))
+
+	diff := make([]float64, slots)
+	for i := range diff {
+		want, _ := values[i][0].Float64()
+		diff[i] = have[i] - want
+	}
+
+	// The released aggregate carries the calibrated noise
+	require.InEpsilon(t, m.Scale(), std(diff), 0.1)
+}
+
+func std(values []float64) float64 {
+	var mean float64
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(variance / float64(len(values)-1))
+}
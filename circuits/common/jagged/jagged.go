@@ -0,0 +1,223 @@
+// Package jagged implements order-preserving packing of variable-length
+// records (jagged rows) into ciphertext slots. Tabular data rarely comes in
+// neat power-of-two vectors: rows are concatenated in input order into
+// fixed-size slot vectors, together with an explicit plaintext [Layout]
+// recording where each record landed, and mask-based extraction circuits
+// retrieve a single record from the packed ciphertexts without revealing the
+// others.
+package jagged
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/common/masking"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes"
+)
+
+// Record locates one packed record: the slot vector it was packed into, its
+// first slot within the vector and its length in slots.
+type Record struct {
+	Vector int
+	Start  int
+	Length int
+}
+
+// Layout is the plaintext index structure of a packed batch: one [Record] per
+// input row, in input order, over vectors of Slots slots. The layout is not
+// encrypted and must be stored alongside the ciphertexts to retrieve the
+// records.
+type Layout struct {
+	Records []Record
+	Vectors int
+	Slots   int
+}
+
+// Pack concatenates the rows, in order, into slot vectors of size slots: each
+// row occupies consecutive slots, and a new vector is started whenever the
+// current one cannot hold the next row. The returned vectors are zero-padded
+// and can be encoded and encrypted as-is; the returned layout locates each
+// row. Rows of length zero are assigned a [Record] of length zero.
+//
+// Returns an error if slots is not strictly positive or if a row is longer
+// than slots.
+func Pack[T uint64 | int64 | float64](rows [][]T, slots int) (vectors [][]T, layout Layout, err error) {
+
+	if slots < 1 {
+		return nil, Layout{}, fmt.Errorf("cannot Pack: slots must be strictly positive but is %d", slots)
+	}
+
+	layout = Layout{Records: make([]Record, len(rows)), Slots: slots}
+
+	offset := slots // forces the allocation of the first vector
+
+	for i, row := range rows {
+
+		if len(row) > slots {
+			return nil, Layout{}, fmt.Errorf("cannot Pack: row %d has %d entries but vectors have %d slots", i, len(row), slots)
+		}
+
+		if offset+len(row) > slots {
+			vectors = append(vectors, make([]T, slots))
+			offset = 0
+		}
+
+		layout.Records[i] = Record{Vector: len(vectors) - 1, Start: offset, Length: len(row)}
+		copy(vectors[len(vectors)-1][offset:], row)
+		offset += len(row)
+	}
+
+	layout.Vectors = len(vectors)
+
+	return
+}
+
+// Unpack reverses [Pack] on plaintext vectors using the layout, e.g. after
+// decryption and decoding. Returns an error if a record of the layout is out
+// of the bounds of the vectors.
+func Unpack[T uint64 | int64 | float64](vectors [][]T, layout Layout) (rows [][]T, err error) {
+
+	rows = make([][]T, len(layout.Records))
+
+	for i, rec := range layout.Records {
+
+		if rec.Vector < 0 || rec.Vector >= len(vectors) || rec.Start < 0 || rec.Start+rec.Length > len(vectors[rec.Vector]) {
+			return nil, fmt.Errorf("cannot Unpack: record %d is out of the bounds of the vectors", i)
+		}
+
+		rows[i] = make([]T, rec.Length)
+		copy(rows[i], vectors[rec.Vector][rec.Start:rec.Start+rec.Length])
+	}
+
+	return
+}
+
+// Mask returns the 0/1 mask selecting record r over the slots of its vector.
+func (l Layout) Mask(r int) ([]bool, error) {
+
+	if r < 0 || r >= len(l.Records) {
+		return nil, fmt.Errorf("invalid record: must be in [0, %d] but is %d", len(l.Records)-1, r)
+	}
+
+	rec := l.Records[r]
+
+	mask := make([]bool, l.Slots)
+	for i := rec.Start; i < rec.Start+rec.Length; i++ {
+		mask[i] = true
+	}
+
+	return mask, nil
+}
+
+// GaloisElements returns the list of Galois elements required by
+// [Extractor.ExtractAlignedNew] over the given layout, i.e. the elements of
+// the distinct non-zero record starts.
+func GaloisElements(params rlwe.ParameterProvider, layout Layout) (galEls []uint64) {
+
+	starts := map[int]bool{}
+	for _, rec := range layout.Records {
+		if rec.Start != 0 {
+			starts[rec.Start] = true
+		}
+	}
+
+	rotations := make([]int, 0, len(starts))
+	for k := range starts {
+		rotations = append(rotations, k)
+	}
+
+	return params.GetRLWEParameters().GaloisElements(rotations)
+}
+
+// SchemeEvaluator defines the scheme-specific operations required to extract
+// packed records. Both bgv.Evaluator and ckks.Evaluator comply to it.
+type SchemeEvaluator interface {
+	masking.SchemeEvaluator
+	Automorphism(ctIn *rlwe.Ciphertext, galEl uint64, opOut *rlwe.Ciphertext) (err error)
+}
+
+// Extractor evaluates the extraction of single records from packed
+// ciphertexts, with one precomputed plaintext mask per record (see
+// [masking.PlaintextMask]).
+type Extractor struct {
+	eval    SchemeEvaluator
+	masking masking.Evaluator
+	layout  Layout
+	masks   []*masking.PlaintextMask
+}
+
+// NewExtractor instantiates a new [Extractor] over the given layout,
+// precomputing the extraction mask of each record at the given level and
+// scale. For the BGV and BFV schemes the masks should be encoded at scale 1
+// (exact); for CKKS, at a scale large enough for the required precision, in
+// which case the extracted ciphertexts require a rescaling.
+func NewExtractor(params rlwe.ParameterProvider, ecd schemes.Encoder, eval SchemeEvaluator, layout Layout, level int, scale rlwe.Scale) (*Extractor, error) {
+
+	e := &Extractor{
+		eval:    eval,
+		masking: masking.NewEvaluator(eval),
+		layout:  layout,
+		masks:   make([]*masking.PlaintextMask, len(layout.Records)),
+	}
+
+	for r := range layout.Records {
+
+		mask, err := layout.Mask(r)
+		if err != nil {
+			return nil, fmt.Errorf("cannot NewExtractor: %w", err)
+		}
+
+		if e.masks[r], err = masking.NewPlaintextMask(params, ecd, mask, level, scale); err != nil {
+			return nil, fmt.Errorf("cannot NewExtractor: record %d: %w", r, err)
+		}
+	}
+
+	return e, nil
+}
+
+// ExtractNew returns a new ciphertext encrypting record r in its packed
+// slots [Start, Start+Length) and zero everywhere else, where cts are the
+// encryptions of the packed vectors returned by [Pack]. The extraction is a
+// single mask multiplication (see [masking.Evaluator.MulMask]).
+func (e *Extractor) ExtractNew(cts []*rlwe.Ciphertext, r int) (opOut *rlwe.Ciphertext, err error) {
+
+	if r < 0 || r >= len(e.layout.Records) {
+		return nil, fmt.Errorf("cannot ExtractNew: invalid record: must be in [0, %d] but is %d", len(e.layout.Records)-1, r)
+	}
+
+	rec := e.layout.Records[r]
+
+	if rec.Vector >= len(cts) {
+		return nil, fmt.Errorf("cannot ExtractNew: record %d is packed in vector %d but only %d ciphertexts were given", r, rec.Vector, len(cts))
+	}
+
+	if opOut, err = e.masking.MulMaskNew(cts[rec.Vector], e.masks[r]); err != nil {
+		return nil, fmt.Errorf("cannot ExtractNew: %w", err)
+	}
+
+	return
+}
+
+// ExtractAlignedNew extracts record r as [Extractor.ExtractNew] and rotates
+// it to the first slot, so that the result encrypts the record in slots
+// [0, Length) whatever its packed position. The Galois keys for
+// [GaloisElements] over the layout must be loaded in the evaluator.
+//
+// The alignment uses the column rotation of the scheme: for schemes batching
+// the slots in several rows (BGV/BFV), the records must not cross the row
+// boundary for the rotation to map the packed slots to the first ones.
+func (e *Extractor) ExtractAlignedNew(cts []*rlwe.Ciphertext, r int) (opOut *rlwe.Ciphertext, err error) {
+
+	if opOut, err = e.ExtractNew(cts, r); err != nil {
+		return nil, fmt.Errorf("cannot ExtractAlignedNew: %w", err)
+	}
+
+	if start := e.layout.Records[r].Start; start != 0 {
+		params := e.eval.GetRLWEParameters()
+		if err = e.eval.Automorphism(opOut, params.GaloisElement(start), opOut); err != nil {
+			return nil, fmt.Errorf("cannot ExtractAlignedNew: %w", err)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,133 @@
+package jagged
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestPack(t *testing.T) {
+
+	rows := [][]uint64{{1, 2, 3}, {4, 5, 6, 7, 8}, {}, {9}, {10, 11}}
+
+	vectors, layout, err := Pack(rows, 8)
+	require.NoError(t, err)
+
+	// {1, 2, 3} and {4, 5, 6, 7, 8} fill the first vector; the remaining
+	// rows fit in the second one.
+	require.Equal(t, 2, layout.Vectors)
+	require.Equal(t, [][]uint64{{1, 2, 3, 4, 5, 6, 7, 8}, {9, 10, 11, 0, 0, 0, 0, 0}}, vectors)
+	require.Equal(t, Record{Vector: 0, Start: 0, Length: 3}, layout.Records[0])
+	require.Equal(t, Record{Vector: 0, Start: 3, Length: 5}, layout.Records[1])
+	require.Equal(t, Record{Vector: 0, Start: 8, Length: 0}, layout.Records[2])
+	require.Equal(t, Record{Vector: 1, Start: 0, Length: 1}, layout.Records[3])
+	require.Equal(t, Record{Vector: 1, Start: 1, Length: 2}, layout.Records[4])
+
+	unpacked, err := Unpack(vectors, layout)
+	require.NoError(t, err)
+	for i := range rows {
+		require.Equal(t, len(rows[i]), len(unpacked[i]))
+		for j := range rows[i] {
+			require.Equal(t, rows[i][j], unpacked[i][j])
+		}
+	}
+
+	// Row longer than the vectors
+	_, _, err = Pack([][]uint64{make([]uint64, 9)}, 8)
+	require.Error(t, err)
+
+	// Invalid slot count
+	_, _, err = Pack(rows, 0)
+	require.Error(t, err)
+}
+
+func TestExtractor(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	tc := bgv.NewTestContext(bgv.ParametersLiteral{
+		LogN:             10,
+		LogQ:             []int{60, 60, 60},
+		LogP:             []int{61},
+		PlaintextModulus: 0x10001,
+	}, false)
+
+	params := tc.Params
+	T := params.PlaintextModulus()
+	slots := params.MaxSlots()
+
+	// Jagged rows, all fitting in the first batching row of the scheme.
+	rows := make([][]uint64, 8)
+	for i := range rows {
+		rows[i] = make([]uint64, 3*i+1)
+		for j := range rows[i] {
+			rows[i][j] = sampling.RandUint64()%(T-1) + 1
+		}
+	}
+
+	vectors, layout, err := Pack(rows, slots)
+	require.NoError(t, err)
+
+	cts := make([]*rlwe.Ciphertext, len(vectors))
+	for i := range vectors {
+		pt := bgv.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, tc.Ecd.Encode(vectors[i], pt))
+		cts[i], err = tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+	}
+
+	eval := tc.Evl.WithKey(rlwe.NewMemEvaluationKeySet(nil, tc.Kgen.GenGaloisKeysNew(GaloisElements(params, layout), tc.Sk)...))
+
+	ext, err := NewExtractor(params, tc.Ecd, eval, layout, params.MaxLevel(), params.NewScale(1))
+	require.NoError(t, err)
+
+	t.Run("ExtractNew", func(t *testing.T) {
+
+		for r := range rows {
+
+			opOut, err := ext.ExtractNew(cts, r)
+			require.NoError(t, err)
+
+			have := make([]uint64, slots)
+			require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(opOut), have))
+
+			rec := layout.Records[r]
+			for i := range have {
+				want := uint64(0)
+				if i >= rec.Start && i < rec.Start+rec.Length {
+					want = rows[r][i-rec.Start]
+				}
+				require.Equal(t, want, have[i])
+			}
+		}
+
+		// Out-of-range record
+		_, err := ext.ExtractNew(cts, len(rows))
+		require.Error(t, err)
+
+		// Missing ciphertexts
+		_, err = ext.ExtractNew(cts[:1], len(rows)-1)
+		if layout.Records[len(rows)-1].Vector > 0 {
+			require.Error(t, err)
+		}
+	})
+
+	t.Run("ExtractAlignedNew", func(t *testing.T) {
+
+		for r := range rows {
+
+			opOut, err := ext.ExtractAlignedNew(cts, r)
+			require.NoError(t, err)
+
+			have := make([]uint64, slots)
+			require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(opOut), have))
+
+			for i := range rows[r] {
+				require.Equal(t, rows[r][i], have[i])
+			}
+		}
+	})
+}
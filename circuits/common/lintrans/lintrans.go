@@ -143,6 +143,30 @@ func (lt LinearTransformation) BSGSIndex() (index map[int][]int, n1, n2 []int) {
 	return BSGSIndex(utils.GetKeys(lt.Vec), 1<<lt.LogDimensions.Cols, lt.N1)
 }
 
+// AtLevel returns a view of the linear transformation truncated to the given
+// level, obtained by dropping the RNS limbs above levelQ of each encoded
+// diagonal. The view shares its coefficient data with the receiver, so a
+// transformation encoded once at the top level can be evaluated at any lower
+// level without being re-encoded or copied. The method returns an error if
+// levelQ is negative or larger than the level of the encoded transformation.
+func (lt LinearTransformation) AtLevel(levelQ int) (LinearTransformation, error) {
+
+	if levelQ < 0 || levelQ > lt.LevelQ {
+		return LinearTransformation{}, fmt.Errorf("cannot AtLevel: levelQ (%d) must be in the range [0, %d]", levelQ, lt.LevelQ)
+	}
+
+	vec := make(map[int]ringqp.Poly, len(lt.Vec))
+	for i, p := range lt.Vec {
+		p.Q.Coeffs = p.Q.Coeffs[:levelQ+1]
+		vec[i] = p
+	}
+
+	lt.LevelQ = levelQ
+	lt.Vec = vec
+
+	return lt, nil
+}
+
 // NewLinearTransformation allocates a new LinearTransformation with zero values according to the parameters specified
 // by the [Parameters].
 func NewLinearTransformation(params rlwe.ParameterProvider, ltparams Parameters) LinearTransformation {
@@ -14,7 +14,9 @@ type Evaluator struct {
 }
 
 // EvaluateMany takes as input a ciphertext ctIn, a list of linear transformations [M0, M1, M2, ...] and a list of pre-allocated receiver opOut
-// and evaluates opOut: [M0(ctIn), M1(ctIn), M2(ctIn), ...]
+// and evaluates opOut: [M0(ctIn), M1(ctIn), M2(ctIn), ...].
+// Linear transformations encoded at a level higher than the level of ctIn are automatically truncated to the level of ctIn
+// (see [LinearTransformation.AtLevel]), so a transformation only needs to be encoded once at the highest level at which it is evaluated.
 func (eval Evaluator) EvaluateMany(ctIn *rlwe.Ciphertext, linearTransformations []LinearTransformation, opOut []*rlwe.Ciphertext) (err error) {
 
 	if len(opOut) < len(linearTransformations) {
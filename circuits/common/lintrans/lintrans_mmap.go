@@ -0,0 +1,192 @@
+//go:build linux || darwin
+
+package lintrans
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"syscall"
+	"unsafe"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/ring/ringqp"
+)
+
+// MMapBacking holds a memory-mapped file region backing the encoded diagonal
+// polynomials of a [LinearTransformation]. It allows multi-GB plaintext matrices
+// to be used by the evaluator without being resident in RAM: the operating system
+// pages the encoded diagonals in and out on demand.
+//
+// The backing must be kept alive for as long as the associated [LinearTransformation]
+// is in use, and released with [MMapBacking.Close] afterwards.
+type MMapBacking struct {
+	data      []byte
+	polyBytes int
+	offsets   map[int]int
+}
+
+// NewLinearTransformationMMap allocates a new [LinearTransformation] according to ltparams
+// whose encoded diagonal polynomials are backed by the memory-mapped file f instead of heap
+// slices. The file is truncated to the required size and mapped shared, so encoding through
+// [Encode] persists the diagonals to disk and a subsequent mapping of the same file recovers
+// them without re-encoding.
+func NewLinearTransformationMMap(params rlwe.ParameterProvider, ltparams Parameters, f *os.File) (LinearTransformation, *MMapBacking, error) {
+
+	p := params.GetRLWEParameters()
+
+	cols := 1 << ltparams.LogDimensions.Cols
+	logBabyStepGiantStepRatio := ltparams.LogBabyStepGiantStepRatio
+	levelQ := ltparams.LevelQ
+	levelP := ltparams.LevelP
+	N := p.N()
+
+	diagslislt := ltparams.DiagonalsIndexList
+
+	// Resolves the set of allocated diagonal indexes, as in NewLinearTransformation
+	idxMap := map[int]bool{}
+	var N1 int
+	if logBabyStepGiantStepRatio < 0 {
+		N1 = 0
+		for _, i := range diagslislt {
+			idx := i
+			if idx < 0 {
+				idx += cols
+			}
+			idxMap[idx] = true
+		}
+	} else {
+		N1 = FindBestBSGSRatio(diagslislt, cols, logBabyStepGiantStepRatio)
+		index, _, _ := BSGSIndex(diagslislt, cols, N1)
+		for j := range index {
+			for _, i := range index[j] {
+				idxMap[j+i] = true
+			}
+		}
+	}
+
+	idxList := make([]int, 0, len(idxMap))
+	for idx := range idxMap {
+		idxList = append(idxList, idx)
+	}
+	sort.Ints(idxList)
+
+	nbLevels := levelQ + 1
+	if levelP >= 0 {
+		nbLevels += levelP + 1
+	}
+	polyBytes := nbLevels * N * 8
+
+	if len(idxList) == 0 {
+		return LinearTransformation{}, nil, fmt.Errorf("cannot NewLinearTransformationMMap: empty DiagonalsIndexList")
+	}
+
+	size := int64(len(idxList)) * int64(polyBytes)
+	if err := f.Truncate(size); err != nil {
+		return LinearTransformation{}, nil, fmt.Errorf("cannot NewLinearTransformationMMap: %w", err)
+	}
+
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(size), syscall.PROT_READ|syscall.PROT_WRITE, syscall.MAP_SHARED)
+	if err != nil {
+		return LinearTransformation{}, nil, fmt.Errorf("cannot NewLinearTransformationMMap: mmap: %w", err)
+	}
+
+	backing := &MMapBacking{
+		data:      data,
+		polyBytes: polyBytes,
+		offsets:   make(map[int]int, len(idxList)),
+	}
+
+	vec := make(map[int]ringqp.Poly, len(idxList))
+	for i, idx := range idxList {
+		offset := i * polyBytes
+		backing.offsets[idx] = offset
+		vec[idx] = backing.polyAt(offset, N, levelQ, levelP)
+	}
+
+	metadata := &rlwe.MetaData{
+		PlaintextMetaData: rlwe.PlaintextMetaData{
+			LogDimensions: ltparams.LogDimensions,
+			Scale:         ltparams.Scale,
+			IsBatched:     true,
+		},
+		CiphertextMetaData: rlwe.CiphertextMetaData{
+			IsNTT:        true,
+			IsMontgomery: true,
+		},
+	}
+
+	return LinearTransformation{
+		MetaData:                  metadata,
+		LogBabyStepGiantStepRatio: logBabyStepGiantStepRatio,
+		N1:                        N1,
+		LevelQ:                    levelQ,
+		LevelP:                    levelP,
+		Vec:                       vec,
+	}, backing, nil
+}
+
+// polyAt builds a ringqp.Poly whose coefficient slices alias the mapped region
+// starting at the given byte offset.
+func (mb *MMapBacking) polyAt(offset, N, levelQ, levelP int) ringqp.Poly {
+
+	next := func() []uint64 {
+		s := unsafe.Slice((*uint64)(unsafe.Pointer(&mb.data[offset])), N)
+		offset += N * 8
+		return s
+	}
+
+	var Q, P ring.Poly
+	Q.Coeffs = make([][]uint64, levelQ+1)
+	for i := range Q.Coeffs {
+		Q.Coeffs[i] = next()
+	}
+
+	if levelP >= 0 {
+		P.Coeffs = make([][]uint64, levelP+1)
+		for i := range P.Coeffs {
+			P.Coeffs[i] = next()
+		}
+	}
+
+	return ringqp.Poly{Q: Q, P: P}
+}
+
+// Prefetch advises the operating system that the diagonals at the given indexes
+// will be needed soon (MADV_WILLNEED), so that they can be paged in ahead of use.
+// During a BSGS evaluation, calling it with the diagonal indexes of the next
+// giant-step group overlaps the disk reads with the current group's computation.
+// Indexes without a backing diagonal are ignored.
+func (mb *MMapBacking) Prefetch(indexes ...int) error {
+	for _, idx := range indexes {
+		offset, ok := mb.offsets[idx]
+		if !ok {
+			continue
+		}
+		if err := syscall.Madvise(mb.data[offset:offset+mb.polyBytes], syscall.MADV_WILLNEED); err != nil {
+			return fmt.Errorf("cannot Prefetch: madvise: %w", err)
+		}
+	}
+	return nil
+}
+
+// Sync flushes the mapped diagonals to the backing file.
+func (mb *MMapBacking) Sync() error {
+	_, _, errno := syscall.Syscall(syscall.SYS_MSYNC, uintptr(unsafe.Pointer(&mb.data[0])), uintptr(len(mb.data)), uintptr(syscall.MS_SYNC))
+	if errno != 0 {
+		return fmt.Errorf("cannot Sync: msync: %w", errno)
+	}
+	return nil
+}
+
+// Close unmaps the backing region. The associated [LinearTransformation] must not
+// be used afterwards.
+func (mb *MMapBacking) Close() error {
+	if mb.data == nil {
+		return nil
+	}
+	err := syscall.Munmap(mb.data)
+	mb.data = nil
+	return err
+}
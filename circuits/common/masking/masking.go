@@ -0,0 +1,102 @@
+// Package masking implements a packing convention and aggregation circuits for vectors with
+// missing values. A ciphertext of values is accompanied by a companion mask ciphertext that
+// encrypts 1 in the slots holding a present value and 0 in the missing slots, so that
+// aggregations can be restricted to the present values instead of silently including
+// garbage slots.
+package masking
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes"
+)
+
+// SchemeEvaluator defines the scheme-specific operations required to evaluate the
+// mask-aware aggregations. Both bgv.Evaluator and ckks.Evaluator comply to it.
+type SchemeEvaluator interface {
+	schemes.Evaluator
+	InnerSum(ctIn *rlwe.Ciphertext, batchSize, n int, opOut *rlwe.Ciphertext) (err error)
+}
+
+// Evaluator is an evaluator used to aggregate masked ciphertexts.
+type Evaluator struct {
+	SchemeEvaluator
+}
+
+// NewEvaluator instantiates a new masking [Evaluator] from a scheme evaluator.
+// This method is allocation free.
+func NewEvaluator(eval SchemeEvaluator) Evaluator {
+	return Evaluator{SchemeEvaluator: eval}
+}
+
+// GaloisElements returns the list of Galois elements required to aggregate groups of n slots.
+func GaloisElements(params rlwe.ParameterProvider, n int) (galEls []uint64) {
+	return rlwe.GaloisElementsForInnerSum(params, 1, n)
+}
+
+// MaskFloat64 derives the companion mask of values following the NaN convention: a NaN entry
+// marks a missing value. It returns the values with the missing entries replaced by 0, along
+// with the mask (1 = present, 0 = missing). Both returned slices are newly allocated and can
+// be encoded and encrypted as a (values, mask) ciphertext pair.
+func MaskFloat64(values []float64) (cleaned, mask []float64) {
+	cleaned = make([]float64, len(values))
+	mask = make([]float64, len(values))
+	for i, v := range values {
+		if math.IsNaN(v) {
+			continue
+		}
+		cleaned[i] = v
+		mask[i] = 1
+	}
+	return
+}
+
+// MaskUint64 derives the companion mask of values following a sentinel convention: entries
+// equal to the sentinel mark a missing value. It returns the values with the missing entries
+// replaced by 0, along with the mask (1 = present, 0 = missing).
+func MaskUint64(values []uint64, sentinel uint64) (cleaned, mask []uint64) {
+	cleaned = make([]uint64, len(values))
+	mask = make([]uint64, len(values))
+	for i, v := range values {
+		if v == sentinel {
+			continue
+		}
+		cleaned[i] = v
+		mask[i] = 1
+	}
+	return
+}
+
+// Sum evaluates the sum of the present values over groups of n slots: the missing slots are
+// zeroed by a multiplication with the mask before the inner sum. The first slot of each group
+// of the output holds the sum of the present values of the group; the remaining slots hold
+// garbage. The Galois keys for [GaloisElements](params, n) must be loaded in the evaluator and
+// the output is not rescaled, this is left to the caller.
+func (eval Evaluator) Sum(ctValues, ctMask *rlwe.Ciphertext, n int) (opOut *rlwe.Ciphertext, err error) {
+
+	if opOut, err = eval.MulRelinNew(ctValues, ctMask); err != nil {
+		return nil, fmt.Errorf("eval.MulRelinNew: %w", err)
+	}
+
+	if err = eval.InnerSum(opOut, 1, n, opOut); err != nil {
+		return nil, fmt.Errorf("eval.InnerSum: %w", err)
+	}
+
+	return
+}
+
+// Count evaluates the number of present values over groups of n slots, i.e. the inner sum of
+// the mask. The first slot of each group of the output holds the count of the group; the
+// remaining slots hold garbage.
+func (eval Evaluator) Count(ctMask *rlwe.Ciphertext, n int) (opOut *rlwe.Ciphertext, err error) {
+
+	opOut = rlwe.NewCiphertext(*eval.GetRLWEParameters(), 1, ctMask.Level())
+
+	if err = eval.InnerSum(ctMask, 1, n, opOut); err != nil {
+		return nil, fmt.Errorf("eval.InnerSum: %w", err)
+	}
+
+	return
+}
@@ -0,0 +1,89 @@
+package masking
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestMaskedAggregation(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	tc := bgv.NewTestContext(bgv.ParametersLiteral{
+		LogN:             10,
+		LogQ:             []int{60, 60, 60},
+		LogP:             []int{61},
+		PlaintextModulus: 0x10001,
+	}, false)
+
+	params := tc.Params
+	T := params.PlaintextModulus()
+
+	n := 8
+	sentinel := T - 1
+
+	evk := rlwe.NewMemEvaluationKeySet(tc.Kgen.GenRelinearizationKeyNew(tc.Sk), tc.Kgen.GenGaloisKeysNew(GaloisElements(params, n), tc.Sk)...)
+
+	eval := NewEvaluator(tc.Evl.WithKey(evk))
+
+	slots := params.MaxSlots()
+
+	raw := make([]uint64, slots)
+	for i := range raw {
+		if i%3 == 0 {
+			raw[i] = sentinel
+		} else {
+			raw[i] = sampling.RandUint64() % 0xFF
+		}
+	}
+
+	values, mask := MaskUint64(raw, sentinel)
+
+	encrypt := func(v []uint64) *rlwe.Ciphertext {
+		pt := bgv.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, tc.Ecd.Encode(v, pt))
+		ct, err := tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+		return ct
+	}
+
+	ctValues, ctMask := encrypt(values), encrypt(mask)
+
+	t.Run("Sum", func(t *testing.T) {
+
+		ctSum, err := eval.Sum(ctValues, ctMask, n)
+		require.NoError(t, err)
+
+		have := make([]uint64, slots)
+		require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(ctSum), have))
+
+		for i := 0; i < slots; i += n {
+			var want uint64
+			for j := i; j < i+n; j++ {
+				want = (want + values[j]) % T
+			}
+			require.Equal(t, want, have[i])
+		}
+	})
+
+	t.Run("Count", func(t *testing.T) {
+
+		ctCount, err := eval.Count(ctMask, n)
+		require.NoError(t, err)
+
+		have := make([]uint64, slots)
+		require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(ctCount), have))
+
+		for i := 0; i < slots; i += n {
+			var want uint64
+			for j := i; j < i+n; j++ {
+				want += mask[j]
+			}
+			require.Equal(t, want, have[i])
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package masking
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/schemes"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// PlaintextMask is a 0/1 plaintext mask precomputed in the NTT and Montgomery
+// domain. Filtering a ciphertext with a precomputed mask skips the encoding,
+// NTT and scale-matching work of a generic plaintext multiplication, which
+// makes repeated filtering with the same mask substantially cheaper. The
+// all-zero and all-one masks are stored without a polynomial and evaluated
+// without any multiplication at all.
+type PlaintextMask struct {
+	// Value is the encoded mask in the NTT and Montgomery domain. It is left
+	// unallocated for the trivial all-zero and all-one masks.
+	Value *rlwe.Plaintext
+	// Scale is the scale at which the mask was encoded.
+	Scale rlwe.Scale
+	// Ones is the number of 1 entries of the mask.
+	Ones int
+	// Slots is the total number of entries of the mask.
+	Slots int
+}
+
+// NewPlaintextMask precomputes the given 0/1 mask as an NTT- and
+// Montgomery-domain polynomial at the given level and scale, using the
+// scheme's encoder. For the BGV and BFV schemes the mask should be encoded at
+// scale 1 (exact); for CKKS, at a scale large enough for the required
+// precision, in which case the masked ciphertext requires a rescaling.
+func NewPlaintextMask(params rlwe.ParameterProvider, ecd schemes.Encoder, mask []bool, level int, scale rlwe.Scale) (*PlaintextMask, error) {
+
+	p := params.GetRLWEParameters()
+
+	ones := 0
+	for _, b := range mask {
+		if b {
+			ones++
+		}
+	}
+
+	m := &PlaintextMask{Scale: scale, Ones: ones, Slots: len(mask)}
+
+	// The trivial masks are evaluated without a polynomial
+	if ones == 0 || ones == len(mask) {
+		return m, nil
+	}
+
+	pt := rlwe.NewPlaintext(*p, level)
+	pt.Scale = scale
+	pt.IsNTT = true
+
+	// Batched slot layout of the scheme (the generic rlwe metadata defaults to
+	// the coefficient layout)
+	if p, ok := params.(interface{ LogMaxDimensions() ring.Dimensions }); ok {
+		pt.IsBatched = true
+		pt.LogDimensions = p.LogMaxDimensions()
+	}
+
+	// The mask is embedded in the ciphertext operand domain (i.e. without the
+	// T^{-1} lift of the BGV/BFV plaintext encoding), so that the product with
+	// a ciphertext is a single coefficient-wise multiplication.
+	// The encoders accept []uint64 (BGV/BFV) or []float64 (CKKS).
+	valuesU := make([]uint64, len(mask))
+	for i, b := range mask {
+		if b {
+			valuesU[i] = 1
+		}
+	}
+
+	if err := ecd.Embed(valuesU, pt.MetaData, pt.Value); err != nil {
+
+		valuesF := make([]float64, len(mask))
+		for i, b := range mask {
+			if b {
+				valuesF[i] = 1
+			}
+		}
+
+		if err := ecd.Embed(valuesF, pt.MetaData, pt.Value); err != nil {
+			return nil, fmt.Errorf("cannot NewPlaintextMask: %w", err)
+		}
+	}
+
+	ringQ := p.RingQ().AtLevel(level)
+
+	ringQ.MForm(pt.Value, pt.Value)
+	pt.IsMontgomery = true
+
+	m.Value = pt
+
+	return m, nil
+}
+
+// MulMask multiplies ct with the precomputed mask and writes the result in
+// opOut, which can alias ct. The generic case is a single coefficient-wise
+// Montgomery multiplication per level and ciphertext component; the all-zero
+// mask zeroes the output and the all-one mask copies ct, both without touching
+// the ring. The output scale is ct.Scale * mask.Scale, except for the all-one
+// mask which leaves the scale of ct untouched; the automatic scale matching of
+// the scheme evaluators absorbs the difference in subsequent additions.
+func (eval Evaluator) MulMask(ct *rlwe.Ciphertext, mask *PlaintextMask, opOut *rlwe.Ciphertext) (err error) {
+
+	params := eval.GetRLWEParameters()
+
+	level := ct.Level()
+	if mask.Value != nil {
+		level = utils.Min(level, mask.Value.Level())
+	}
+
+	opOut.Resize(ct.Degree(), level)
+
+	switch {
+	case mask.Ones == 0:
+
+		for i := range opOut.Value {
+			opOut.Value[i].Zero()
+		}
+
+		*opOut.MetaData = *ct.MetaData
+		opOut.Scale = ct.Scale.Mul(mask.Scale)
+
+	case mask.Ones == mask.Slots:
+
+		if opOut != ct {
+			for i := range opOut.Value {
+				opOut.Value[i].CopyLvl(level, ct.Value[i])
+			}
+			*opOut.MetaData = *ct.MetaData
+		}
+
+	default:
+
+		if !ct.IsNTT {
+			return fmt.Errorf("cannot MulMask: ct must be in the NTT domain")
+		}
+
+		ringQ := params.RingQ().AtLevel(level)
+
+		for i := range opOut.Value {
+			ringQ.MulCoeffsMontgomery(ct.Value[i], mask.Value.Value, opOut.Value[i])
+		}
+
+		*opOut.MetaData = *ct.MetaData
+		opOut.Scale = ct.Scale.Mul(mask.Scale)
+	}
+
+	return
+}
+
+// MulMaskNew multiplies ct with the precomputed mask and returns the result in
+// a new ciphertext. See [Evaluator.MulMask] for additional information.
+func (eval Evaluator) MulMaskNew(ct *rlwe.Ciphertext, mask *PlaintextMask) (opOut *rlwe.Ciphertext, err error) {
+
+	level := ct.Level()
+	if mask.Value != nil {
+		level = utils.Min(level, mask.Value.Level())
+	}
+
+	opOut = rlwe.NewCiphertext(*eval.GetRLWEParameters(), ct.Degree(), level)
+
+	if err = eval.MulMask(ct, mask, opOut); err != nil {
+		return nil, fmt.Errorf("cannot MulMaskNew: %w", err)
+	}
+
+	return
+}
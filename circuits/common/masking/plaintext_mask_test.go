@@ -0,0 +1,128 @@
+package masking
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestPlaintextMask(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	tc := bgv.NewTestContext(bgv.ParametersLiteral{
+		LogN:             10,
+		LogQ:             []int{60, 60, 60},
+		LogP:             []int{61},
+		PlaintextModulus: 0x10001,
+	}, false)
+
+	params := tc.Params
+	T := params.PlaintextModulus()
+	slots := params.MaxSlots()
+
+	eval := NewEvaluator(tc.Evl)
+
+	values := make([]uint64, slots)
+	for i := range values {
+		values[i] = sampling.RandUint64() % T
+	}
+
+	pt := bgv.NewPlaintext(params, params.MaxLevel())
+	require.NoError(t, tc.Ecd.Encode(values, pt))
+	ct, err := tc.Enc.EncryptNew(pt)
+	require.NoError(t, err)
+
+	for _, tcase := range []struct {
+		name string
+		mask func(i int) bool
+	}{
+		{name: "Generic", mask: func(i int) bool { return i%3 == 0 }},
+		{name: "AllZero", mask: func(i int) bool { return false }},
+		{name: "AllOne", mask: func(i int) bool { return true }},
+	} {
+		t.Run(tcase.name, func(t *testing.T) {
+
+			boolMask := make([]bool, slots)
+			for i := range boolMask {
+				boolMask[i] = tcase.mask(i)
+			}
+
+			mask, err := NewPlaintextMask(params, tc.Ecd, boolMask, params.MaxLevel(), params.NewScale(1))
+			require.NoError(t, err)
+
+			opOut, err := eval.MulMaskNew(ct, mask)
+			require.NoError(t, err)
+
+			have := make([]uint64, slots)
+			require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(opOut), have))
+
+			for i := range have {
+				want := uint64(0)
+				if boolMask[i] {
+					want = values[i]
+				}
+				require.Equal(t, want, have[i])
+			}
+		})
+	}
+
+	t.Run("CKKS", func(t *testing.T) {
+
+		// Insecure parameters used for the sole purpose of fast testing.
+		params, err := ckks.NewParametersFromLiteral(ckks.ParametersLiteral{
+			LogN:            10,
+			LogQ:            []int{55, 45, 45},
+			LogP:            []int{61},
+			LogDefaultScale: 45,
+		})
+		require.NoError(t, err)
+
+		ecd := ckks.NewEncoder(params)
+		kgen := rlwe.NewKeyGenerator(params)
+		sk := kgen.GenSecretKeyNew()
+		enc := rlwe.NewEncryptor(params, sk)
+		dec := rlwe.NewDecryptor(params, sk)
+		eval := NewEvaluator(ckks.NewEvaluator(params, nil))
+
+		slots := params.MaxSlots()
+
+		values := make([]float64, slots)
+		for i := range values {
+			values[i] = sampling.RandFloat64(-1, 1)
+		}
+
+		pt := ckks.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, ecd.Encode(values, pt))
+		ct, err := enc.EncryptNew(pt)
+		require.NoError(t, err)
+
+		boolMask := make([]bool, slots)
+		for i := range boolMask {
+			boolMask[i] = i%2 == 0
+		}
+
+		mask, err := NewPlaintextMask(params, ecd, boolMask, params.MaxLevel(), params.DefaultScale())
+		require.NoError(t, err)
+
+		opOut, err := eval.MulMaskNew(ct, mask)
+		require.NoError(t, err)
+		require.NoError(t, eval.Rescale(opOut, opOut))
+
+		have := make([]float64, slots)
+		require.NoError(t, ecd.Decode(dec.DecryptNew(opOut), have))
+
+		for i := range have {
+			want := 0.0
+			if boolMask[i] {
+				want = values[i]
+			}
+			require.InDelta(t, want, have[i], math.Exp2(-30))
+		}
+	})
+}
@@ -0,0 +1,108 @@
+// Package minmax implements coordinate-wise min/max reductions over a batch of
+// ciphertexts, evaluated as a tournament over the batch: each round halves the
+// number of ciphertexts by taking the pairwise extremum, and the pairs of a
+// round are evaluated in parallel. The pairwise extremum is scheme-specific:
+// approximate for ckks and exact for bgv/bfv (see the comparison packages).
+package minmax
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+// PairwiseEvaluator computes the element-wise minimum and maximum of two
+// ciphertexts. Both the ckks and the bgv comparison evaluators comply to it.
+type PairwiseEvaluator interface {
+	Min(op0, op1 *rlwe.Ciphertext) (*rlwe.Ciphertext, error)
+	Max(op0, op1 *rlwe.Ciphertext) (*rlwe.Ciphertext, error)
+}
+
+// Evaluator is an evaluator used to reduce batches of ciphertexts to their
+// element-wise extremum.
+type Evaluator struct {
+	evals []PairwiseEvaluator
+}
+
+// NewEvaluator instantiates a new reduction [Evaluator]. Each pairwise
+// evaluator backs one parallel worker, so the level of parallelism of the
+// reductions is len(evals); since evaluators are not safe for concurrent use,
+// each must be backed by its own scheme evaluator (e.g. a ShallowCopy).
+func NewEvaluator(evals ...PairwiseEvaluator) (*Evaluator, error) {
+	if len(evals) == 0 {
+		return nil, fmt.Errorf("cannot NewEvaluator: at least one pairwise evaluator is required")
+	}
+	return &Evaluator{evals: evals}, nil
+}
+
+// MinReduce returns the element-wise minimum across all the input ciphertexts.
+func (eval Evaluator) MinReduce(cts ...*rlwe.Ciphertext) (opOut *rlwe.Ciphertext, err error) {
+	return eval.reduce(cts, PairwiseEvaluator.Min)
+}
+
+// MaxReduce returns the element-wise maximum across all the input ciphertexts.
+func (eval Evaluator) MaxReduce(cts ...*rlwe.Ciphertext) (opOut *rlwe.Ciphertext, err error) {
+	return eval.reduce(cts, PairwiseEvaluator.Max)
+}
+
+func (eval Evaluator) reduce(cts []*rlwe.Ciphertext, pairwise func(PairwiseEvaluator, *rlwe.Ciphertext, *rlwe.Ciphertext) (*rlwe.Ciphertext, error)) (opOut *rlwe.Ciphertext, err error) {
+
+	if len(cts) == 0 {
+		return nil, fmt.Errorf("cannot reduce: empty batch")
+	}
+
+	round := cts
+
+	for len(round) > 1 {
+
+		pairs := len(round) >> 1
+		next := make([]*rlwe.Ciphertext, (len(round)+1)>>1)
+
+		// An odd trailing ciphertext is promoted unchanged to the next round.
+		if len(round)&1 == 1 {
+			next[pairs] = round[len(round)-1]
+		}
+
+		jobs := make(chan int, pairs)
+		for i := 0; i < pairs; i++ {
+			jobs <- i
+		}
+		close(jobs)
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		var firstErr error
+
+		for _, pe := range eval.evals {
+
+			wg.Add(1)
+
+			go func(pe PairwiseEvaluator) {
+				defer wg.Done()
+				for i := range jobs {
+					res, err := pairwise(pe, round[2*i], round[2*i+1])
+					if err != nil {
+						mu.Lock()
+						if firstErr == nil {
+							firstErr = err
+						}
+						mu.Unlock()
+						return
+					}
+					next[i] = res
+				}
+			}(pe)
+		}
+
+		wg.Wait()
+
+		if firstErr != nil {
+			return nil, fmt.Errorf("cannot reduce: %w", firstErr)
+		}
+
+		round = next
+	}
+
+	return round[0], nil
+}
@@ -0,0 +1,101 @@
+package minmax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/bgv/comparison"
+	"github.com/tuneinsight/lattigo/v6/circuits/bgv/polynomial"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+func TestMinMaxReduce(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	tc := bgv.NewTestContext(bgv.ParametersLiteral{
+		LogN:             7,
+		LogQ:             []int{60, 60, 60, 60, 60, 60, 60, 60, 60},
+		LogP:             []int{61},
+		PlaintextModulus: 0x101,
+	}, true) // scale-invariant multiplication, i.e. BFV
+
+	params := tc.Params
+	slots := params.MaxSlots()
+	half := (params.PlaintextModulus() - 1) >> 1
+
+	// Two workers, each backed by its own scheme evaluator.
+	var pairwise []PairwiseEvaluator
+	for i := 0; i < 2; i++ {
+		cmp, err := comparison.NewEvaluator(params, polynomial.NewEvaluator(params, tc.Evl.ShallowCopy()))
+		require.NoError(t, err)
+		pairwise = append(pairwise, cmp)
+	}
+
+	eval, err := NewEvaluator(pairwise...)
+	require.NoError(t, err)
+
+	_, err = NewEvaluator()
+	require.Error(t, err)
+
+	// Values in [0, (t-1)/2] so that all the pairwise differences lie in the
+	// centered representation of Z_t.
+	const batch = 5
+	values := make([][]uint64, batch)
+	cts := make([]*rlwe.Ciphertext, batch)
+	for i := range cts {
+		values[i] = make([]uint64, slots)
+		for j := range values[i] {
+			values[i][j] = uint64((i+1)*(2*j+1)) % (half + 1)
+		}
+		pt := bgv.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, tc.Ecd.Encode(values[i], pt))
+		cts[i], err = tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+	}
+
+	decrypt := func(ct *rlwe.Ciphertext) []uint64 {
+		have := make([]uint64, slots)
+		require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(ct), have))
+		return have
+	}
+
+	t.Run("MinReduce", func(t *testing.T) {
+
+		min, err := eval.MinReduce(cts...)
+		require.NoError(t, err)
+
+		want := make([]uint64, slots)
+		for j := range want {
+			want[j] = values[0][j]
+			for i := 1; i < batch; i++ {
+				want[j] = utils.Min(want[j], values[i][j])
+			}
+		}
+
+		require.Equal(t, want, decrypt(min))
+	})
+
+	t.Run("MaxReduce", func(t *testing.T) {
+
+		max, err := eval.MaxReduce(cts...)
+		require.NoError(t, err)
+
+		want := make([]uint64, slots)
+		for j := range want {
+			want[j] = values[0][j]
+			for i := 1; i < batch; i++ {
+				want[j] = utils.Max(want[j], values[i][j])
+			}
+		}
+
+		require.Equal(t, want, decrypt(max))
+	})
+
+	t.Run("EmptyBatch", func(t *testing.T) {
+		_, err := eval.MinReduce()
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,111 @@
+package polynomial
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/utils"
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+// SymmetryReport reports the symmetry detected on an evaluated polynomial and
+// the resulting savings, see [Evaluator.EvaluateSymmetric].
+type SymmetryReport struct {
+	// Odd (resp. Even) is true if the polynomial was detected to be odd
+	// (resp. even) and the evaluation was carried out in X^2.
+	Odd, Even bool
+
+	// InnerDegree is the degree of the polynomial actually evaluated: the
+	// degree of q for p(X) = q(X^2) or p(X) = X * q(X^2), or the degree of p
+	// if no symmetry was detected.
+	InnerDegree int
+
+	// DepthSaved is the reduction in multiplicative depth of the evaluated
+	// polynomial, i.e. the depth of p minus the depth of q.
+	DepthSaved int
+}
+
+// DetectSymmetry inspects the coefficients of the polynomial and returns
+// whether it is odd (all even-degree coefficients are zero) and/or even (all
+// odd-degree coefficients are zero), as produced e.g. by sign or sine
+// approximations.
+func DetectSymmetry(p bignum.Polynomial) (odd, even bool) {
+	odd, even = true, true
+	for i, c := range p.Coeffs {
+		if !isZeroCoefficient(c) {
+			if i&1 == 0 {
+				odd = false
+			} else {
+				even = false
+			}
+		}
+	}
+	return
+}
+
+func isZeroCoefficient(c *bignum.Complex) bool {
+	return c == nil || ((c[0] == nil || c[0].Sign() == 0) && (c[1] == nil || c[1].Sign() == 0))
+}
+
+// EvaluateSymmetric evaluates the polynomial p on the input ciphertext,
+// exploiting the odd or even symmetry of p when present: an even polynomial is
+// rewritten as q(X^2) and an odd one as X * q(X^2), halving the number of
+// non-scalar multiplications by evaluating q of half the degree on X^2. The
+// symmetry is detected automatically from the coefficients of p and reported
+// along with the saved depth; without symmetry, the method falls back to
+// [Evaluator.Evaluate]. The rewriting is only valid in the monomial basis:
+// Chebyshev-basis polynomials fall back to the direct evaluation as well.
+func (eval Evaluator[T]) EvaluateSymmetric(ct *rlwe.Ciphertext, p bignum.Polynomial, targetScale rlwe.Scale, levelsConsumedPerRescaling int, SimEval SimEvaluator) (opOut *rlwe.Ciphertext, report SymmetryReport, err error) {
+
+	odd, even := DetectSymmetry(p)
+
+	// The rewriting p(X) = q(X^2) only holds in the monomial basis, and a
+	// constant polynomial has nothing to save.
+	if p.Basis != bignum.Monomial || p.Degree() < 2 || !(odd != even) {
+		report.InnerDegree = p.Degree()
+		opOut, err = eval.Evaluate(ct, p, targetScale, levelsConsumedPerRescaling, SimEval)
+		return
+	}
+
+	// Gathers the non-zero coefficients of q: p(X) = q(X^2) + X*r(X^2) with
+	// r = 0 if p is even and q = 0 if p is odd.
+	var offset int
+	if odd {
+		offset = 1
+	}
+	qCoeffs := make([]*bignum.Complex, 0, (p.Degree()>>1)+1)
+	for i := offset; i < len(p.Coeffs); i += 2 {
+		qCoeffs = append(qCoeffs, p.Coeffs[i])
+	}
+	q := bignum.NewPolynomial(bignum.Monomial, qCoeffs, nil)
+
+	report.Odd, report.Even = odd, even
+	report.InnerDegree = q.Degree()
+	report.DepthSaved = utils.Max(0, p.Depth()-q.Depth())
+
+	// X^2
+	var ct2 *rlwe.Ciphertext
+	if ct2, err = eval.MulRelinNew(ct, ct); err != nil {
+		return nil, report, fmt.Errorf("cannot EvaluateSymmetric: %w", err)
+	}
+	if err = eval.Rescale(ct2, ct2); err != nil {
+		return nil, report, fmt.Errorf("cannot EvaluateSymmetric: %w", err)
+	}
+
+	// q(X^2)
+	if opOut, err = eval.Evaluate(ct2, q, targetScale, levelsConsumedPerRescaling, SimEval); err != nil {
+		return nil, report, fmt.Errorf("cannot EvaluateSymmetric: %w", err)
+	}
+
+	// X * q(X^2)
+	if odd {
+		if opOut, err = eval.MulRelinNew(opOut, ct); err != nil {
+			return nil, report, fmt.Errorf("cannot EvaluateSymmetric: %w", err)
+		}
+		if err = eval.Rescale(opOut, opOut); err != nil {
+			return nil, report, fmt.Errorf("cannot EvaluateSymmetric: %w", err)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,109 @@
+// Package rotation implements data-dependent (oblivious) rotations, i.e. the rotation
+// of a ciphertext by an offset that is itself encrypted. The offset is provided as the
+// encryption of its one-hot decomposition and the rotation is evaluated as a multiplexer
+// over all the candidate public rotations, so that nothing about the offset is leaked
+// to the evaluating party.
+package rotation
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes"
+)
+
+// SchemeEvaluator defines the scheme-specific operations required to evaluate an
+// oblivious rotation. Both bgv.Evaluator and ckks.Evaluator comply to it.
+type SchemeEvaluator interface {
+	schemes.Evaluator
+	Automorphism(ctIn *rlwe.Ciphertext, galEl uint64, opOut *rlwe.Ciphertext) (err error)
+}
+
+// Evaluator is an evaluator used to rotate ciphertexts by encrypted offsets.
+type Evaluator struct {
+	SchemeEvaluator
+}
+
+// NewEvaluator instantiates a new oblivious rotation [Evaluator] from a scheme evaluator.
+// This method is allocation free.
+func NewEvaluator(eval SchemeEvaluator) Evaluator {
+	return Evaluator{SchemeEvaluator: eval}
+}
+
+// GaloisElements returns the list of Galois elements required to rotate a ciphertext
+// by any encrypted offset in [0, maxOffset).
+func GaloisElements(params rlwe.ParameterProvider, maxOffset int) (galEls []uint64) {
+	p := params.GetRLWEParameters()
+	galEls = make([]uint64, 0, maxOffset-1)
+	for k := 1; k < maxOffset; k++ {
+		galEls = append(galEls, p.GaloisElement(k))
+	}
+	return
+}
+
+// Selector returns the one-hot decomposition of offset over [0, maxOffset) as maxOffset
+// constant vectors of dimension slots: entry k is the all-ones vector if k == offset and
+// the all-zeros vector otherwise. Each vector is meant to be encrypted individually by
+// the party holding the offset and passed to [Evaluator.RotateByEncryptedOffsetNew].
+func Selector[T uint64 | float64](offset, maxOffset, slots int) (selector [][]T) {
+	selector = make([][]T, maxOffset)
+	for k := range selector {
+		selector[k] = make([]T, slots)
+		if k == offset {
+			for j := range selector[k] {
+				selector[k][j] = 1
+			}
+		}
+	}
+	return
+}
+
+// RotateByEncryptedOffsetNew evaluates the rotation of ct by the encrypted offset whose
+// one-hot decomposition is given by selector: selector[k] must encrypt the constant
+// indicator vector of the event offset == k (see [Selector]), and the result is
+//
+//	opOut = sum_{k} selector[k] * (ct <<< k),
+//
+// which equals ct rotated by the offset if the selector is a valid one-hot encoding.
+// The circuit consumes one multiplication (with relinearization) and len(selector)-1
+// rotations; the Galois keys for [GaloisElements](params, len(selector)) must be loaded
+// in the evaluator. The output is not rescaled, this is left to the caller.
+func (eval Evaluator) RotateByEncryptedOffsetNew(ct *rlwe.Ciphertext, selector []*rlwe.Ciphertext) (opOut *rlwe.Ciphertext, err error) {
+
+	if len(selector) == 0 {
+		return nil, fmt.Errorf("selector cannot be empty")
+	}
+
+	for k := range selector {
+		if selector[k] == nil {
+			return nil, fmt.Errorf("selector[%d] is nil", k)
+		}
+	}
+
+	params := eval.GetRLWEParameters()
+
+	// Offset zero: opOut = selector[0] * ct
+	if opOut, err = eval.MulRelinNew(selector[0], ct); err != nil {
+		return nil, fmt.Errorf("eval.MulRelinNew: %w", err)
+	}
+
+	ctRot := ct.CopyNew()
+
+	for k := 1; k < len(selector); k++ {
+
+		if err = eval.Automorphism(ct, params.GaloisElement(k), ctRot); err != nil {
+			return nil, fmt.Errorf("eval.Automorphism: %w", err)
+		}
+
+		var tmp *rlwe.Ciphertext
+		if tmp, err = eval.MulRelinNew(selector[k], ctRot); err != nil {
+			return nil, fmt.Errorf("eval.MulRelinNew: %w", err)
+		}
+
+		if err = eval.Add(opOut, tmp, opOut); err != nil {
+			return nil, fmt.Errorf("eval.Add: %w", err)
+		}
+	}
+
+	return
+}
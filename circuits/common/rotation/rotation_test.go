@@ -0,0 +1,91 @@
+package rotation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+func TestRotationByEncryptedOffsetBFV(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	tc := bgv.NewTestContext(bgv.ParametersLiteral{
+		LogN:             10,
+		LogQ:             []int{60, 60, 60},
+		LogP:             []int{61},
+		PlaintextModulus: 0x10001,
+	}, true) // scale-invariant multiplication, i.e. BFV
+
+	params := tc.Params
+
+	maxOffset := 4
+	offset := 3
+
+	evk := rlwe.NewMemEvaluationKeySet(tc.Kgen.GenRelinearizationKeyNew(tc.Sk), tc.Kgen.GenGaloisKeysNew(GaloisElements(params, maxOffset), tc.Sk)...)
+
+	eval := NewEvaluator(tc.Evl.WithKey(evk))
+
+	values, _, ct := bgv.NewTestVector(params, tc.Ecd, tc.Enc, params.MaxLevel(), params.DefaultScale())
+
+	selector := make([]*rlwe.Ciphertext, maxOffset)
+	for k, vec := range Selector[uint64](offset, maxOffset, params.MaxSlots()) {
+		pt := bgv.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, tc.Ecd.Encode(vec, pt))
+		var err error
+		selector[k], err = tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+	}
+
+	res, err := eval.RotateByEncryptedOffsetNew(ct, selector)
+	require.NoError(t, err)
+
+	// The ciphertext is viewed as a 2 x N/2 matrix whose rows rotate independently
+	slots := params.MaxSlots()
+	want := append(utils.RotateSlice(values[:slots>>1], offset), utils.RotateSlice(values[slots>>1:], offset)...)
+
+	bgv.VerifyTestVectors(params, tc.Ecd, tc.Dec, res, want, t)
+}
+
+func TestRotationByEncryptedOffsetCKKS(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	tc := ckks.NewTestContext(ckks.ParametersLiteral{
+		LogN:            10,
+		LogQ:            []int{55, 45, 45},
+		LogP:            []int{60},
+		LogDefaultScale: 45,
+	})
+
+	params := tc.Params
+
+	maxOffset := 4
+	offset := 2
+
+	evk := rlwe.NewMemEvaluationKeySet(tc.Kgen.GenRelinearizationKeyNew(tc.Sk), tc.Kgen.GenGaloisKeysNew(GaloisElements(params, maxOffset), tc.Sk)...)
+
+	eval := NewEvaluator(tc.Evl.WithKey(evk))
+
+	values, _, ct := tc.NewTestVector(complex(-1, -1), complex(1, 1))
+
+	selector := make([]*rlwe.Ciphertext, maxOffset)
+	for k, vec := range Selector[float64](offset, maxOffset, params.MaxSlots()) {
+		pt := ckks.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, tc.Ecd.Encode(vec, pt))
+		var err error
+		selector[k], err = tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+	}
+
+	res, err := eval.RotateByEncryptedOffsetNew(ct, selector)
+	require.NoError(t, err)
+	require.NoError(t, eval.Rescale(res, res))
+
+	want := utils.RotateSlice(values, offset)
+
+	ckks.VerifyTestVectors(params, tc.Ecd, tc.Dec, want, res, params.LogDefaultScale(), 0, false, t)
+}
@@ -0,0 +1,200 @@
+// Package sparse implements plaintext-known sparsity annotations for encrypted
+// vectors. A ciphertext is paired with the set of slots that are known (in the
+// clear) to hold zero, and the annotation is propagated through the evaluator
+// operations: additions take the union of the possibly non-zero slots,
+// multiplications their intersection, and rotations shift the annotation.
+// Operations whose result provably touches only zero slots are skipped
+// entirely, which saves key-switches and multiplications on workloads with
+// highly sparse encrypted feature vectors.
+package sparse
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes"
+)
+
+// Support marks the slots of an encrypted vector that may hold a non-zero
+// value: entry i is false if slot i is known in the clear to be zero. A nil
+// Support carries no information (all slots are possibly non-zero).
+type Support []bool
+
+// SupportOf returns the support of a plaintext vector.
+func SupportOf[T uint64 | int64 | float64](values []T) Support {
+	s := make(Support, len(values))
+	for i, v := range values {
+		s[i] = v != 0
+	}
+	return s
+}
+
+// Union returns the slot-wise union of the two supports (sum of vectors).
+// A nil operand is absorbing: the result is nil (unknown).
+func (s Support) Union(other Support) Support {
+	if s == nil || other == nil {
+		return nil
+	}
+	out := make(Support, len(s))
+	for i := range out {
+		out[i] = s[i] || other[i]
+	}
+	return out
+}
+
+// Intersect returns the slot-wise intersection of the two supports (product of
+// vectors). A nil operand carries no information and the other support is
+// returned.
+func (s Support) Intersect(other Support) Support {
+	if s == nil {
+		return other
+	}
+	if other == nil {
+		return s
+	}
+	out := make(Support, len(s))
+	for i := range out {
+		out[i] = s[i] && other[i]
+	}
+	return out
+}
+
+// Rotate returns the support rotated by k slots to the left within each of the
+// given number of rows, matching the rotation of the plaintext matrix.
+func (s Support) Rotate(k, rows int) Support {
+	if s == nil {
+		return nil
+	}
+	out := make(Support, len(s))
+	cols := len(s) / rows
+	for r := 0; r < rows; r++ {
+		row := s[r*cols : (r+1)*cols]
+		for i := range row {
+			out[r*cols+i] = row[(i+k%cols+cols)%cols]
+		}
+	}
+	return out
+}
+
+// IsZero returns true if the vector is known to be zero in every slot.
+func (s Support) IsZero() bool {
+	if s == nil {
+		return false
+	}
+	for _, b := range s {
+		if b {
+			return false
+		}
+	}
+	return true
+}
+
+// Ciphertext is a ciphertext annotated with the support of its plaintext.
+type Ciphertext struct {
+	*rlwe.Ciphertext
+	Support Support
+}
+
+// SchemeEvaluator defines the scheme-specific operations required to propagate
+// sparsity annotations. Both bgv.Evaluator and ckks.Evaluator comply to it.
+type SchemeEvaluator interface {
+	schemes.Evaluator
+	Automorphism(ctIn *rlwe.Ciphertext, galEl uint64, opOut *rlwe.Ciphertext) (err error)
+}
+
+// Evaluator is an evaluator propagating sparsity annotations through the
+// scheme operations.
+type Evaluator struct {
+	SchemeEvaluator
+}
+
+// NewEvaluator instantiates a new sparsity-tracking [Evaluator] from a scheme
+// evaluator. This method is allocation free.
+func NewEvaluator(eval SchemeEvaluator) Evaluator {
+	return Evaluator{SchemeEvaluator: eval}
+}
+
+// AddNew returns op0 + op1 with the support of the result set to the union of
+// the operand supports.
+func (eval Evaluator) AddNew(op0, op1 Ciphertext) (opOut Ciphertext, err error) {
+	if opOut.Ciphertext, err = eval.SchemeEvaluator.AddNew(op0.Ciphertext, op1.Ciphertext); err != nil {
+		return Ciphertext{}, fmt.Errorf("cannot AddNew: %w", err)
+	}
+	opOut.Support = op0.Support.Union(op1.Support)
+	return
+}
+
+// SubNew returns op0 - op1 with the support of the result set to the union of
+// the operand supports.
+func (eval Evaluator) SubNew(op0, op1 Ciphertext) (opOut Ciphertext, err error) {
+	if opOut.Ciphertext, err = eval.SchemeEvaluator.SubNew(op0.Ciphertext, op1.Ciphertext); err != nil {
+		return Ciphertext{}, fmt.Errorf("cannot SubNew: %w", err)
+	}
+	opOut.Support = op0.Support.Union(op1.Support)
+	return
+}
+
+// MulRelinNew returns op0 * op1 with the support of the result set to the
+// intersection of the operand supports. If the intersection is empty, the
+// multiplication is skipped entirely and a known-zero ciphertext is returned.
+func (eval Evaluator) MulRelinNew(op0, op1 Ciphertext) (opOut Ciphertext, err error) {
+
+	support := op0.Support.Intersect(op1.Support)
+
+	if support.IsZero() {
+		return eval.zero(op0, support), nil
+	}
+
+	if opOut.Ciphertext, err = eval.SchemeEvaluator.MulRelinNew(op0.Ciphertext, op1.Ciphertext); err != nil {
+		return Ciphertext{}, fmt.Errorf("cannot MulRelinNew: %w", err)
+	}
+	opOut.Support = support
+	return
+}
+
+// MulPlaintextNew returns op0 * values, where values is a plaintext operand of
+// [schemes.Evaluator.Mul] with the given support (see [SupportOf]). If the
+// intersection of the supports is empty, the multiplication is skipped
+// entirely and a known-zero ciphertext is returned.
+func (eval Evaluator) MulPlaintextNew(op0 Ciphertext, values rlwe.Operand, support Support) (opOut Ciphertext, err error) {
+
+	outSupport := op0.Support.Intersect(support)
+
+	if outSupport.IsZero() {
+		return eval.zero(op0, outSupport), nil
+	}
+
+	if opOut.Ciphertext, err = eval.SchemeEvaluator.MulNew(op0.Ciphertext, values); err != nil {
+		return Ciphertext{}, fmt.Errorf("cannot MulPlaintextNew: %w", err)
+	}
+	opOut.Support = outSupport
+	return
+}
+
+// RotateNew returns op0 rotated by k slots to the left, with the support
+// rotated accordingly. If op0 is known to be zero, the key-switch is skipped
+// and a copy of op0 is returned.
+func (eval Evaluator) RotateNew(op0 Ciphertext, k int) (opOut Ciphertext, err error) {
+
+	if op0.Support.IsZero() {
+		return Ciphertext{Ciphertext: op0.Ciphertext.CopyNew(), Support: op0.Support}, nil
+	}
+
+	params := eval.GetRLWEParameters()
+
+	opOut.Ciphertext = rlwe.NewCiphertext(*params, op0.Degree(), op0.Level())
+	if err = eval.Automorphism(op0.Ciphertext, params.GaloisElement(k), opOut.Ciphertext); err != nil {
+		return Ciphertext{}, fmt.Errorf("cannot RotateNew: %w", err)
+	}
+
+	opOut.Support = op0.Support.Rotate(k, 1<<op0.LogDimensions.Rows)
+	return
+}
+
+// zero returns a known-zero ciphertext with the metadata of op and the given
+// (empty) support, without evaluating anything.
+func (eval Evaluator) zero(op Ciphertext, support Support) Ciphertext {
+	ct := rlwe.NewCiphertext(*eval.GetRLWEParameters(), 1, op.Level())
+	*ct.MetaData = *op.MetaData
+	return Ciphertext{Ciphertext: ct, Support: support}
+}
@@ -0,0 +1,141 @@
+package sparse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+func TestSparseEvaluator(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	tc := bgv.NewTestContext(bgv.ParametersLiteral{
+		LogN:             10,
+		LogQ:             []int{60, 60, 60},
+		LogP:             []int{61},
+		PlaintextModulus: 0x10001,
+	}, true) // scale-invariant multiplication, i.e. BFV
+
+	params := tc.Params
+	slots := params.MaxSlots()
+
+	evk := rlwe.NewMemEvaluationKeySet(tc.Kgen.GenRelinearizationKeyNew(tc.Sk), tc.Kgen.GenGaloisKeysNew([]uint64{params.GaloisElement(1)}, tc.Sk)...)
+
+	eval := NewEvaluator(tc.Evl.WithKey(evk))
+
+	encrypt := func(values []uint64) Ciphertext {
+		pt := bgv.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, tc.Ecd.Encode(values, pt))
+		ct, err := tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+		return Ciphertext{Ciphertext: ct, Support: SupportOf(values)}
+	}
+
+	decrypt := func(ct Ciphertext) []uint64 {
+		values := make([]uint64, slots)
+		require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(ct.Ciphertext), values))
+		return values
+	}
+
+	// values0 lives in the first quarter of the slots, values1 in the second:
+	// their supports are disjoint.
+	values0 := make([]uint64, slots)
+	values1 := make([]uint64, slots)
+	for i := 0; i < slots/4; i++ {
+		values0[i] = uint64(i + 1)
+		values1[i+slots/4] = uint64(2*i + 1)
+	}
+
+	ct0 := encrypt(values0)
+	ct1 := encrypt(values1)
+
+	t.Run("AddNew", func(t *testing.T) {
+
+		ctAdd, err := eval.AddNew(ct0, ct1)
+		require.NoError(t, err)
+
+		want := make([]uint64, slots)
+		for i := range want {
+			want[i] = values0[i] + values1[i]
+			require.Equal(t, want[i] != 0, bool(ctAdd.Support[i]))
+		}
+
+		require.Equal(t, want, decrypt(ctAdd))
+	})
+
+	t.Run("MulRelinNewDisjoint", func(t *testing.T) {
+
+		// Disjoint supports: the tensoring is skipped and the result is a
+		// known-zero ciphertext.
+		ctMul, err := eval.MulRelinNew(ct0, ct1)
+		require.NoError(t, err)
+		require.True(t, ctMul.Support.IsZero())
+
+		require.Equal(t, make([]uint64, slots), decrypt(ctMul))
+	})
+
+	t.Run("MulRelinNewOverlapping", func(t *testing.T) {
+
+		ctMul, err := eval.MulRelinNew(ct0, ct0)
+		require.NoError(t, err)
+		require.False(t, ctMul.Support.IsZero())
+
+		want := make([]uint64, slots)
+		params.RingT().SubRings[0].MulCoeffsBarrett(values0, values0, want)
+
+		require.Equal(t, want, decrypt(ctMul))
+	})
+
+	t.Run("MulPlaintextNew", func(t *testing.T) {
+
+		// A mask supported only on the second quarter is disjoint from ct0.
+		mask := make([]uint64, slots)
+		for i := slots / 4; i < slots/2; i++ {
+			mask[i] = 1
+		}
+
+		ctMask, err := eval.MulPlaintextNew(ct0, mask, SupportOf(mask))
+		require.NoError(t, err)
+		require.True(t, ctMask.Support.IsZero())
+		require.Equal(t, make([]uint64, slots), decrypt(ctMask))
+
+		// The same mask overlaps ct1.
+		if ctMask, err = eval.MulPlaintextNew(ct1, mask, SupportOf(mask)); err != nil {
+			t.Fatal(err)
+		}
+		require.False(t, ctMask.Support.IsZero())
+		require.Equal(t, values1, decrypt(ctMask))
+	})
+
+	t.Run("RotateNew", func(t *testing.T) {
+
+		ctRot, err := eval.RotateNew(ct0, 1)
+		require.NoError(t, err)
+
+		// The ciphertext is viewed as a 2 x N/2 matrix whose rows rotate independently
+		want := append(utils.RotateSlice(values0[:slots>>1], 1), utils.RotateSlice(values0[slots>>1:], 1)...)
+
+		for i := range want {
+			require.Equal(t, want[i] != 0, bool(ctRot.Support[i]))
+		}
+
+		require.Equal(t, want, decrypt(ctRot))
+	})
+
+	t.Run("RotateNewZero", func(t *testing.T) {
+
+		ctZero, err := eval.MulRelinNew(ct0, ct1)
+		require.NoError(t, err)
+
+		// Rotating a known-zero ciphertext skips the key-switch: no Galois key
+		// for this rotation is loaded in the evaluator.
+		ctRot, err := eval.RotateNew(ctZero, 5)
+		require.NoError(t, err)
+		require.True(t, ctRot.Support.IsZero())
+		require.Equal(t, make([]uint64, slots), decrypt(ctRot))
+	})
+}
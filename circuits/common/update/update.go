@@ -0,0 +1,100 @@
+// Package update implements sub-ciphertext updates: the replacement of a contiguous
+// slot range of an existing ciphertext with fresh values from another ciphertext,
+// preserving the remaining slots. The update is evaluated as a mask-rotate-add circuit,
+// so that incremental changes do not require re-encrypting the whole ciphertext.
+package update
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes"
+)
+
+// SchemeEvaluator defines the scheme-specific operations required to evaluate a
+// slot-range update. Both bgv.Evaluator and ckks.Evaluator comply to it.
+type SchemeEvaluator interface {
+	schemes.Evaluator
+	Automorphism(ctIn *rlwe.Ciphertext, galEl uint64, opOut *rlwe.Ciphertext) (err error)
+}
+
+// Evaluator is an evaluator used to overwrite slot ranges of ciphertexts. The type
+// parameter T is the plaintext slot type of the scheme: uint64 for bgv and float64
+// for ckks.
+type Evaluator[T uint64 | float64] struct {
+	SchemeEvaluator
+}
+
+// NewEvaluator instantiates a new update [Evaluator] from a scheme evaluator.
+// This method is allocation free.
+func NewEvaluator[T uint64 | float64](eval SchemeEvaluator) Evaluator[T] {
+	return Evaluator[T]{SchemeEvaluator: eval}
+}
+
+// GaloisElements returns the list of Galois elements required to update a slot range
+// starting at the given offset.
+func GaloisElements(params rlwe.ParameterProvider, offset int) (galEls []uint64) {
+	if offset == 0 {
+		return nil
+	}
+	return []uint64{params.GetRLWEParameters().GaloisElement(-offset)}
+}
+
+// UpdateSlots overwrites the slots [offset, offset+length) of dst with the slots
+// [0, length) of src, preserving the remaining slots of dst, and returns the result
+// in a new ciphertext. Both inputs must be at the same level and scale. The circuit
+// rotates src by -offset and recombines the two ciphertexts under complementary
+// binary masks, consuming one multiplicative level; the output is not rescaled, this
+// is left to the caller. The Galois keys for [GaloisElements](params, offset) must be
+// loaded in the evaluator. For bgv, the rotation of src is row-wise (the same
+// semantics as RotateColumns), so the range must not cross the row boundary.
+func (eval Evaluator[T]) UpdateSlots(dst, src *rlwe.Ciphertext, offset, length int) (opOut *rlwe.Ciphertext, err error) {
+
+	slots := dst.Slots()
+
+	if offset < 0 || length < 1 || offset+length > slots {
+		return nil, fmt.Errorf("cannot UpdateSlots: range [%d, %d) out of the %d slots", offset, offset+length, slots)
+	}
+
+	if dst.Level() != src.Level() {
+		return nil, fmt.Errorf("cannot UpdateSlots: dst level %d differs from src level %d", dst.Level(), src.Level())
+	}
+
+	if !dst.Scale.InDelta(src.Scale, 12) {
+		return nil, fmt.Errorf("cannot UpdateSlots: dst and src scales do not match")
+	}
+
+	// Complementary binary masks: sel selects the updated range, keep the rest.
+	sel := make([]T, slots)
+	keep := make([]T, slots)
+	for i := range keep {
+		keep[i] = 1
+	}
+	for i := offset; i < offset+length; i++ {
+		sel[i], keep[i] = 1, 0
+	}
+
+	// Aligns the source values on the updated range.
+	aligned := src
+	if offset != 0 {
+		aligned = rlwe.NewCiphertext(*eval.GetRLWEParameters(), src.Degree(), src.Level())
+		if err = eval.Automorphism(src, eval.GetRLWEParameters().GaloisElement(-offset), aligned); err != nil {
+			return nil, fmt.Errorf("cannot UpdateSlots: %w", err)
+		}
+	}
+
+	if opOut, err = eval.MulNew(aligned, sel); err != nil {
+		return nil, fmt.Errorf("cannot UpdateSlots: %w", err)
+	}
+
+	var preserved *rlwe.Ciphertext
+	if preserved, err = eval.MulNew(dst, keep); err != nil {
+		return nil, fmt.Errorf("cannot UpdateSlots: %w", err)
+	}
+
+	if err = eval.Add(opOut, preserved, opOut); err != nil {
+		return nil, fmt.Errorf("cannot UpdateSlots: %w", err)
+	}
+
+	return
+}
@@ -0,0 +1,107 @@
+package update
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+)
+
+func TestUpdateSlotsBGV(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	tc := bgv.NewTestContext(bgv.ParametersLiteral{
+		LogN:             10,
+		LogQ:             []int{60, 60, 60},
+		LogP:             []int{61},
+		PlaintextModulus: 0x10001,
+	}, false)
+
+	params := tc.Params
+	slots := params.MaxSlots()
+
+	const offset, length = 13, 32
+
+	evk := rlwe.NewMemEvaluationKeySet(nil, tc.Kgen.GenGaloisKeysNew(GaloisElements(params, offset), tc.Sk)...)
+	eval := NewEvaluator[uint64](tc.Evl.WithKey(evk))
+
+	encrypt := func(values []uint64) *rlwe.Ciphertext {
+		pt := bgv.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, tc.Ecd.Encode(values, pt))
+		ct, err := tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+		return ct
+	}
+
+	dstValues := make([]uint64, slots)
+	srcValues := make([]uint64, slots)
+	for i := range dstValues {
+		dstValues[i] = uint64(i + 1)
+		srcValues[i] = uint64(1000 + i)
+	}
+
+	res, err := eval.UpdateSlots(encrypt(dstValues), encrypt(srcValues), offset, length)
+	require.NoError(t, err)
+	require.NoError(t, eval.Rescale(res, res))
+
+	want := append([]uint64{}, dstValues...)
+	copy(want[offset:offset+length], srcValues[:length])
+
+	have := make([]uint64, slots)
+	require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(res), have))
+	require.Equal(t, want, have)
+}
+
+func TestUpdateSlotsCKKS(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	tc := ckks.NewTestContext(ckks.ParametersLiteral{
+		LogN:            10,
+		LogQ:            []int{55, 45, 45},
+		LogP:            []int{60},
+		LogDefaultScale: 45,
+	})
+
+	params := tc.Params
+	slots := params.MaxSlots()
+
+	const offset, length = 7, 16
+
+	evk := rlwe.NewMemEvaluationKeySet(nil, tc.Kgen.GenGaloisKeysNew(GaloisElements(params, offset), tc.Sk)...)
+	eval := NewEvaluator[float64](tc.Evl.WithKey(evk))
+
+	encrypt := func(values []float64) *rlwe.Ciphertext {
+		pt := ckks.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, tc.Ecd.Encode(values, pt))
+		ct, err := tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+		return ct
+	}
+
+	dstValues := make([]float64, slots)
+	srcValues := make([]float64, slots)
+	for i := range dstValues {
+		dstValues[i] = float64(i) / float64(slots)
+		srcValues[i] = -float64(i) / float64(slots)
+	}
+
+	res, err := eval.UpdateSlots(encrypt(dstValues), encrypt(srcValues), offset, length)
+	require.NoError(t, err)
+	require.NoError(t, eval.Rescale(res, res))
+
+	want := append([]float64{}, dstValues...)
+	copy(want[offset:offset+length], srcValues[:length])
+
+	have := make([]float64, slots)
+	require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(res), have))
+	for i := range want {
+		require.InDelta(t, want[i], have[i], 1e-6)
+	}
+
+	// Out-of-range updates are rejected.
+	_, err = eval.UpdateSlots(encrypt(dstValues), encrypt(srcValues), slots-1, 2)
+	require.Error(t, err)
+}
@@ -0,0 +1,438 @@
+// Package layers implements packed fixed-point neural network layers for
+// encrypted CKKS inference: dense layers evaluated with the diagonal method of
+// the linear transformation circuit, batched one-dimensional convolutions,
+// average pooling and polynomial activations. Each layer exposes the Galois
+// elements it requires and the number of levels it consumes, and layers
+// compose into a [Sequential] model runner.
+//
+// All layers operate on block-packed slot vectors: the slots hold a batch of
+// contiguous feature vectors (blocks) and each layer is evaluated on every
+// block in parallel.
+package layers
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/lintrans"
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/polynomial"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils"
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+// Layer is the interface implemented by all neural network layers.
+type Layer interface {
+	// GaloisElements returns the Galois elements required to evaluate the
+	// layer on a fully packed ciphertext.
+	GaloisElements(params ckks.Parameters) []uint64
+
+	// Depth returns the number of levels consumed by the layer, assuming
+	// parameters consuming a single level per rescaling.
+	Depth() int
+
+	// Evaluate applies the layer on ctIn and returns the result.
+	Evaluate(eval *Evaluator, ctIn *rlwe.Ciphertext) (*rlwe.Ciphertext, error)
+}
+
+// Evaluator bundles the evaluators required to run the layers. All fields of
+// this struct are public, enabling custom instantiations.
+type Evaluator struct {
+	Parameters ckks.Parameters
+	*ckks.Evaluator
+	LinTrans   *lintrans.Evaluator
+	Polynomial *polynomial.Evaluator
+	Encoder    *ckks.Encoder
+}
+
+// NewEvaluator instantiates a new [Evaluator] from a [ckks.Evaluator]. The
+// [ckks.Evaluator] must hold the Galois keys for the Galois elements reported
+// by the layers to be evaluated.
+func NewEvaluator(params ckks.Parameters, eval *ckks.Evaluator) *Evaluator {
+	return &Evaluator{
+		Parameters: params,
+		Evaluator:  eval,
+		LinTrans:   lintrans.NewEvaluator(eval),
+		Polynomial: polynomial.NewEvaluator(params, eval),
+		Encoder:    ckks.NewEncoder(params),
+	}
+}
+
+// Sequential is a model runner chaining layers: the output of each layer is
+// fed to the next one. A [Sequential] is itself a [Layer] and can be nested.
+type Sequential []Layer
+
+// GaloisElements returns the union of the Galois elements required by the
+// layers of the model.
+func (m Sequential) GaloisElements(params ckks.Parameters) []uint64 {
+	galEls := map[uint64]bool{}
+	for _, layer := range m {
+		for _, galEl := range layer.GaloisElements(params) {
+			galEls[galEl] = true
+		}
+	}
+	return utils.GetSortedKeys(galEls)
+}
+
+// Depth returns the total number of levels consumed by the model.
+func (m Sequential) Depth() (depth int) {
+	for _, layer := range m {
+		depth += layer.Depth()
+	}
+	return
+}
+
+// Evaluate runs the model on ctIn and returns the result. The method returns
+// an error if the level of ctIn is smaller than the depth of the model.
+func (m Sequential) Evaluate(eval *Evaluator, ctIn *rlwe.Ciphertext) (opOut *rlwe.Ciphertext, err error) {
+
+	if depth := m.Depth(); ctIn.Level() < depth {
+		return nil, fmt.Errorf("cannot Evaluate: the model consumes %d levels but the input ciphertext is at level %d", depth, ctIn.Level())
+	}
+
+	opOut = ctIn
+	for i, layer := range m {
+		if opOut, err = layer.Evaluate(eval, opOut); err != nil {
+			return nil, fmt.Errorf("cannot Evaluate: layer %d: %w", i, err)
+		}
+	}
+
+	return
+}
+
+// Dense is a fully connected layer y = Wx + b, evaluated with the diagonal
+// method of the linear transformation circuit. The weight matrix is padded to
+// a square power-of-two dimension d and replicated on every block of d slots,
+// so that a batch of slots/d feature vectors is processed in parallel. The
+// layer consumes one level.
+type Dense struct {
+	// Weights is the weight matrix, with one row per output and one column
+	// per input.
+	Weights [][]float64
+
+	// Bias is the optional bias vector, with one entry per output.
+	Bias []float64
+
+	// LogBabyStepGiantStepRatio is the log2 of the baby-step giant-step ratio
+	// of the underlying linear transformation (see [lintrans.Parameters]).
+	LogBabyStepGiantStepRatio int
+}
+
+// dimension returns the power-of-two dimension of the padded square weight
+// matrix.
+func (l Dense) dimension() int {
+	d := 1
+	for d < len(l.Weights) || (len(l.Weights) > 0 && d < len(l.Weights[0])) {
+		d <<= 1
+	}
+	return d
+}
+
+// weightAt returns the entry of the zero-padded weight matrix.
+func (l Dense) weightAt(row, col int) float64 {
+	if row < len(l.Weights) && col < len(l.Weights[row]) {
+		return l.Weights[row][col]
+	}
+	return 0
+}
+
+// diagonals returns the non-zero diagonals of the block-diagonal slots x slots
+// matrix replicating the padded weight matrix on every block: the output slot
+// b+r of block b is sum_c W[r][c] * x[b+c], so the entry of slot b+r on
+// diagonal (c-r) mod slots is W[r][c].
+func (l Dense) diagonals(slots int) (lintrans.Diagonals[float64], error) {
+
+	d := l.dimension()
+
+	if d > slots || slots%d != 0 {
+		return nil, fmt.Errorf("the padded weight matrix dimension %d does not divide the number of slots %d", d, slots)
+	}
+
+	diagonals := lintrans.Diagonals[float64]{}
+
+	for k := 0; k < d; k++ {
+
+		upper := make([]float64, slots)
+		lower := make([]float64, slots)
+		var upperNonZero, lowerNonZero bool
+
+		for b := 0; b < slots; b += d {
+			for r := 0; r < d; r++ {
+				w := l.weightAt(r, (r+k)%d)
+				if w == 0 {
+					continue
+				}
+				if r+k < d {
+					upper[b+r] = w
+					upperNonZero = true
+				} else {
+					lower[b+r] = w
+					lowerNonZero = true
+				}
+			}
+		}
+
+		if upperNonZero {
+			diagonals[k] = upper
+		}
+		if lowerNonZero {
+			diagonals[k-d] = lower
+		}
+	}
+
+	return diagonals, nil
+}
+
+// GaloisElements returns the Galois elements required to evaluate the layer
+// on a fully packed ciphertext.
+func (l Dense) GaloisElements(params ckks.Parameters) []uint64 {
+	diagonals, err := l.diagonals(params.MaxSlots())
+	if err != nil {
+		return nil
+	}
+	return lintrans.GaloisElements(params, lintrans.Parameters{
+		DiagonalsIndexList:        diagonals.DiagonalsIndexList(),
+		LevelQ:                    params.MaxLevel(),
+		LevelP:                    params.MaxLevelP(),
+		LogDimensions:             params.LogMaxDimensions(),
+		LogBabyStepGiantStepRatio: l.LogBabyStepGiantStepRatio,
+	})
+}
+
+// Depth returns the number of levels consumed by the layer.
+func (l Dense) Depth() int {
+	return 1
+}
+
+// Evaluate applies the layer on ctIn and returns the result.
+func (l Dense) Evaluate(eval *Evaluator, ctIn *rlwe.Ciphertext) (opOut *rlwe.Ciphertext, err error) {
+
+	params := eval.Parameters
+	slots := ctIn.Slots()
+
+	diagonals, err := l.diagonals(slots)
+	if err != nil {
+		return nil, fmt.Errorf("cannot Evaluate: %w", err)
+	}
+
+	lt := lintrans.NewTransformation(params, lintrans.Parameters{
+		DiagonalsIndexList:        diagonals.DiagonalsIndexList(),
+		LevelQ:                    ctIn.Level(),
+		LevelP:                    params.MaxLevelP(),
+		Scale:                     params.GetOptimalScalingFactor(ctIn.Scale, params.DefaultScale(), ctIn.Level()),
+		LogDimensions:             ctIn.LogDimensions,
+		LogBabyStepGiantStepRatio: l.LogBabyStepGiantStepRatio,
+	})
+
+	if err = lintrans.Encode(eval.Encoder, diagonals, lt); err != nil {
+		return nil, fmt.Errorf("cannot Evaluate: %w", err)
+	}
+
+	if opOut, err = eval.LinTrans.EvaluateNew(ctIn, lt); err != nil {
+		return nil, fmt.Errorf("cannot Evaluate: %w", err)
+	}
+
+	if err = eval.Rescale(opOut, opOut); err != nil {
+		return nil, fmt.Errorf("cannot Evaluate: %w", err)
+	}
+
+	if l.Bias != nil {
+
+		d := l.dimension()
+
+		bias := make([]float64, slots)
+		for b := 0; b < slots; b += d {
+			copy(bias[b:b+d], l.Bias)
+		}
+
+		if err = eval.Add(opOut, bias, opOut); err != nil {
+			return nil, fmt.Errorf("cannot Evaluate: %w", err)
+		}
+	}
+
+	return
+}
+
+// Conv is a batched one-dimensional convolution: each block of Period slots
+// holds a signal and the slot i of each block receives
+// sum_k Kernel[k] * x[i+k]. The last len(Kernel)-1 slots of each block, whose
+// windows would overlap the next block, are zeroed. The layer consumes one
+// level.
+type Conv struct {
+	// Kernel holds the convolution weights.
+	Kernel []float64
+
+	// Period is the length of each packed signal; 0 means the full slot
+	// vector.
+	Period int
+}
+
+// period returns the signal length for the given number of slots.
+func (l Conv) period(slots int) int {
+	if l.Period == 0 {
+		return slots
+	}
+	return l.Period
+}
+
+// GaloisElements returns the Galois elements required to evaluate the layer
+// on a fully packed ciphertext.
+func (l Conv) GaloisElements(params ckks.Parameters) []uint64 {
+	rotations := make([]int, 0, len(l.Kernel)-1)
+	for k := 1; k < len(l.Kernel); k++ {
+		rotations = append(rotations, k)
+	}
+	return params.GaloisElements(rotations)
+}
+
+// Depth returns the number of levels consumed by the layer.
+func (l Conv) Depth() int {
+	return 1
+}
+
+// Evaluate applies the layer on ctIn and returns the result.
+func (l Conv) Evaluate(eval *Evaluator, ctIn *rlwe.Ciphertext) (opOut *rlwe.Ciphertext, err error) {
+
+	if len(l.Kernel) == 0 {
+		return nil, fmt.Errorf("cannot Evaluate: the kernel is empty")
+	}
+
+	slots := ctIn.Slots()
+	period := l.period(slots)
+
+	if len(l.Kernel) > period {
+		return nil, fmt.Errorf("cannot Evaluate: the kernel has %d taps but the signals have %d slots", len(l.Kernel), period)
+	}
+
+	if period > slots || slots%period != 0 {
+		return nil, fmt.Errorf("cannot Evaluate: the signal length %d does not divide the number of slots %d", period, slots)
+	}
+
+	rotations := make([]int, 0, len(l.Kernel)-1)
+	for k := 1; k < len(l.Kernel); k++ {
+		rotations = append(rotations, k)
+	}
+
+	// All the rotations share the decomposition of ctIn.
+	rotated, err := eval.RotateHoistedNew(ctIn, rotations)
+	if err != nil {
+		return nil, fmt.Errorf("cannot Evaluate: %w", err)
+	}
+	rotated[0] = ctIn
+
+	valid := period - len(l.Kernel) + 1
+
+	for k, w := range l.Kernel {
+
+		// The kernel weight is masked on the slots whose window does not
+		// overlap the next block.
+		mask := make([]float64, slots)
+		for b := 0; b < slots; b += period {
+			for i := 0; i < valid; i++ {
+				mask[b+i] = w
+			}
+		}
+
+		tmp, err := eval.MulNew(rotated[k], mask)
+		if err != nil {
+			return nil, fmt.Errorf("cannot Evaluate: %w", err)
+		}
+
+		if opOut == nil {
+			opOut = tmp
+		} else if err = eval.Add(opOut, tmp, opOut); err != nil {
+			return nil, fmt.Errorf("cannot Evaluate: %w", err)
+		}
+	}
+
+	if err = eval.Rescale(opOut, opOut); err != nil {
+		return nil, fmt.Errorf("cannot Evaluate: %w", err)
+	}
+
+	return
+}
+
+// AveragePool is a batched average pooling layer: the slots are averaged over
+// contiguous windows of Size and the result of each window is written on its
+// first slot, the remaining slots being zeroed. The layer consumes one level.
+type AveragePool struct {
+	// Size is the pooling window, which must divide the number of slots.
+	Size int
+}
+
+// GaloisElements returns the Galois elements required to evaluate the layer
+// on a fully packed ciphertext.
+func (l AveragePool) GaloisElements(params ckks.Parameters) []uint64 {
+	return rlwe.GaloisElementsForInnerSum(params, 1, l.Size)
+}
+
+// Depth returns the number of levels consumed by the layer.
+func (l AveragePool) Depth() int {
+	return 1
+}
+
+// Evaluate applies the layer on ctIn and returns the result.
+func (l AveragePool) Evaluate(eval *Evaluator, ctIn *rlwe.Ciphertext) (opOut *rlwe.Ciphertext, err error) {
+
+	slots := ctIn.Slots()
+
+	if l.Size < 1 || l.Size > slots || slots%l.Size != 0 {
+		return nil, fmt.Errorf("cannot Evaluate: the pooling window %d does not divide the number of slots %d", l.Size, slots)
+	}
+
+	opOut = ctIn.CopyNew()
+
+	// The sliding inner sum writes on each slot the sum of its window of Size
+	// slots; the mask keeps the disjoint windows and divides by the window.
+	if err = eval.InnerSum(opOut, 1, l.Size, opOut); err != nil {
+		return nil, fmt.Errorf("cannot Evaluate: %w", err)
+	}
+
+	mask := make([]float64, slots)
+	for i := 0; i < slots; i += l.Size {
+		mask[i] = 1 / float64(l.Size)
+	}
+
+	if err = eval.Mul(opOut, mask, opOut); err != nil {
+		return nil, fmt.Errorf("cannot Evaluate: %w", err)
+	}
+
+	if err = eval.Rescale(opOut, opOut); err != nil {
+		return nil, fmt.Errorf("cannot Evaluate: %w", err)
+	}
+
+	return
+}
+
+// Activation is a polynomial activation layer, evaluating on each slot a
+// polynomial approximation of the activation function, e.g. the degree-2
+// polynomial 0.25x^2 + 0.5x + 0.25 approximating ReLU on [-1, 1]. The layer
+// consumes Depth levels.
+type Activation struct {
+	// Coefficients holds the coefficients of the polynomial in ascending
+	// degree order.
+	Coefficients []float64
+}
+
+// GaloisElements returns the Galois elements required to evaluate the layer,
+// which is the empty set since the layer performs no rotation.
+func (l Activation) GaloisElements(params ckks.Parameters) []uint64 {
+	return nil
+}
+
+// Depth returns the number of levels consumed by the layer.
+func (l Activation) Depth() int {
+	return bignum.NewPolynomial(bignum.Monomial, l.Coefficients, nil).Depth()
+}
+
+// Evaluate applies the layer on ctIn and returns the result.
+func (l Activation) Evaluate(eval *Evaluator, ctIn *rlwe.Ciphertext) (opOut *rlwe.Ciphertext, err error) {
+
+	poly := bignum.NewPolynomial(bignum.Monomial, l.Coefficients, nil)
+
+	if opOut, err = eval.Polynomial.Evaluate(ctIn, poly, eval.Parameters.DefaultScale()); err != nil {
+		return nil, fmt.Errorf("cannot Evaluate: %w", err)
+	}
+
+	return
+}
@@ -0,0 +1,177 @@
+package layers
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+var printPrecisionStats = flag.Bool("print-precision", false, "print precision stats")
+
+// testInsecure are insecure parameters used for the sole purpose of fast testing.
+var testInsecure = ckks.ParametersLiteral{
+	LogN:            10,
+	LogQ:            []int{60, 45, 45, 45, 45, 45, 45, 45},
+	LogP:            []int{61},
+	LogDefaultScale: 45,
+}
+
+func TestLayers(t *testing.T) {
+
+	tc := ckks.NewTestContext(testInsecure)
+	params := tc.Params
+	slots := params.MaxSlots()
+
+	period := 16
+	kernel := []float64{0.25, 0.5, 0.25}
+	activation := Activation{Coefficients: []float64{0.25, 0.5, 0.25}}
+	poolSize := 4
+
+	// The dense layer reads the pooled slots {0, 4, 8, 12} of each block of 16
+	// slots and writes 4 outputs on its first slots.
+	weights := make([][]float64, 4)
+	bias := make([]float64, 4)
+	for r := range weights {
+		weights[r] = make([]float64, period)
+		for c := 0; c < period; c += poolSize {
+			weights[r][c] = sampling.RandFloat64(-1, 1)
+		}
+		bias[r] = sampling.RandFloat64(-1, 1)
+	}
+
+	model := Sequential{
+		Conv{Kernel: kernel, Period: period},
+		activation,
+		AveragePool{Size: poolSize},
+		Dense{Weights: weights, Bias: bias},
+	}
+
+	evk := rlwe.NewMemEvaluationKeySet(tc.Kgen.GenRelinearizationKeyNew(tc.Sk), tc.Kgen.GenGaloisKeysNew(model.GaloisElements(params), tc.Sk)...)
+
+	eval := NewEvaluator(params, tc.Evl.WithKey(evk))
+
+	values := make([]float64, slots)
+	for i := range values {
+		values[i] = sampling.RandFloat64(-1, 1)
+	}
+
+	pt := ckks.NewPlaintext(params, params.MaxLevel())
+	require.NoError(t, tc.Ecd.Encode(values, pt))
+	ct, err := tc.Enc.EncryptNew(pt)
+	require.NoError(t, err)
+
+	// Cleartext references of the layers.
+	convRef := func(in []float64) []float64 {
+		out := make([]float64, slots)
+		for b := 0; b < slots; b += period {
+			for i := 0; i <= period-len(kernel); i++ {
+				for k, w := range kernel {
+					out[b+i] += w * in[b+i+k]
+				}
+			}
+		}
+		return out
+	}
+
+	activationRef := func(in []float64) []float64 {
+		out := make([]float64, slots)
+		for i, x := range in {
+			for d := len(activation.Coefficients) - 1; d >= 0; d-- {
+				out[i] = out[i]*x + activation.Coefficients[d]
+			}
+		}
+		return out
+	}
+
+	poolRef := func(in []float64) []float64 {
+		out := make([]float64, slots)
+		for i := 0; i < slots; i += poolSize {
+			for k := 0; k < poolSize; k++ {
+				out[i] += in[i+k] / float64(poolSize)
+			}
+		}
+		return out
+	}
+
+	denseRef := func(in []float64) []float64 {
+		out := make([]float64, slots)
+		for b := 0; b < slots; b += period {
+			for r := range weights {
+				out[b+r] = bias[r]
+				for c, w := range weights[r] {
+					out[b+r] += w * in[b+c]
+				}
+			}
+		}
+		return out
+	}
+
+	verify := func(t *testing.T, want []float64, ct *rlwe.Ciphertext) {
+		wantCmplx := make([]complex128, slots)
+		for i, v := range want {
+			wantCmplx[i] = complex(v, 0)
+		}
+		ckks.VerifyTestVectors(params, tc.Ecd, tc.Dec, wantCmplx, ct, 25, 0, *printPrecisionStats, t)
+	}
+
+	t.Run("Conv", func(t *testing.T) {
+		out, err := model[0].Evaluate(eval, ct)
+		require.NoError(t, err)
+		require.Equal(t, ct.Level()-1, out.Level())
+		verify(t, convRef(values), out)
+	})
+
+	t.Run("Activation", func(t *testing.T) {
+		out, err := activation.Evaluate(eval, ct)
+		require.NoError(t, err)
+		verify(t, activationRef(values), out)
+	})
+
+	t.Run("AveragePool", func(t *testing.T) {
+		out, err := model[2].Evaluate(eval, ct)
+		require.NoError(t, err)
+		require.Equal(t, ct.Level()-1, out.Level())
+		verify(t, poolRef(values), out)
+	})
+
+	t.Run("Dense", func(t *testing.T) {
+		out, err := model[3].Evaluate(eval, ct)
+		require.NoError(t, err)
+		require.Equal(t, ct.Level()-1, out.Level())
+		verify(t, denseRef(values), out)
+	})
+
+	t.Run("Sequential", func(t *testing.T) {
+
+		require.Equal(t, 3+activation.Depth(), model.Depth())
+
+		out, err := model.Evaluate(eval, ct)
+		require.NoError(t, err)
+		verify(t, denseRef(poolRef(activationRef(convRef(values)))), out)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+
+		// Input ciphertext below the depth of the model.
+		ctLow := ckks.NewCiphertext(params, 1, model.Depth()-1)
+		_, err := model.Evaluate(eval, ctLow)
+		require.Error(t, err)
+
+		// Kernel longer than the packed signals.
+		_, err = Conv{Kernel: make([]float64, period+1), Period: period}.Evaluate(eval, ct)
+		require.Error(t, err)
+
+		// Pooling window not dividing the number of slots.
+		_, err = AveragePool{Size: 3}.Evaluate(eval, ct)
+		require.Error(t, err)
+
+		// Weight matrix larger than the number of slots.
+		_, err = Dense{Weights: make([][]float64, 2*slots)}.Evaluate(eval, ct)
+		require.Error(t, err)
+	})
+}
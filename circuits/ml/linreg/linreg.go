@@ -0,0 +1,276 @@
+// Package linreg implements an encrypted linear and ridge regression solver for
+// the CKKS scheme. The evaluator aggregates the normal equations X^T X and X^T y
+// homomorphically over column-packed data and either solves the ridge system
+// under encryption with the Newton-Schulz matrix inversion of
+// [github.com/tuneinsight/lattigo/v6/circuits/ckks/matrix], or leaves the
+// aggregated normal equations to be (threshold-)decrypted and solved in the
+// clear with [SolveNormalEquations].
+package linreg
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/matrix"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// Evaluator is an evaluator used to aggregate and solve encrypted regression
+// systems. All fields of this struct are public, enabling custom instantiations.
+type Evaluator struct {
+	*matrix.Evaluator
+}
+
+// NewEvaluator instantiates a new [Evaluator] for regressions over the given
+// number of features, which must be a power of two with features^2 at most the
+// number of slots. This method is allocation free.
+func NewEvaluator(params ckks.Parameters, features int, eval *ckks.Evaluator) (*Evaluator, error) {
+
+	matEval, err := matrix.NewEvaluator(params, features, eval)
+	if err != nil {
+		return nil, fmt.Errorf("cannot NewEvaluator: %w", err)
+	}
+
+	return &Evaluator{Evaluator: matEval}, nil
+}
+
+// GaloisElements returns the list of Galois elements required to aggregate and
+// solve a regression system over the given number of features and samples.
+func GaloisElements(params ckks.Parameters, features, samples int) []uint64 {
+
+	galEls := map[uint64]bool{}
+
+	for _, galEl := range matrix.GaloisElements(params, features) {
+		galEls[galEl] = true
+	}
+
+	for _, galEl := range rlwe.GaloisElementsForInnerSum(params, 1, samples) {
+		galEls[galEl] = true
+	}
+
+	return utils.GetSortedKeys(galEls)
+}
+
+// PackColumn returns the encoding of a data column, replicated to fill the
+// given number of slots. Columns shorter than samples are zero-padded. The
+// replication ensures that the sliding inner sum over the sample window yields
+// the column sum in every slot.
+func PackColumn(column []float64, samples, slots int) ([]complex128, error) {
+
+	if len(column) > samples {
+		return nil, fmt.Errorf("cannot PackColumn: column has %d entries but the sample window is %d", len(column), samples)
+	}
+
+	if samples > slots || slots%samples != 0 {
+		return nil, fmt.Errorf("cannot PackColumn: the sample window %d does not divide the number of slots %d", samples, slots)
+	}
+
+	values := make([]complex128, slots)
+	for r := 0; r < slots; r += samples {
+		for i, v := range column {
+			values[r+i] = complex(v, 0)
+		}
+	}
+
+	return values, nil
+}
+
+// NormalEquationsNew computes the normal equations of the ridge system over the
+// encrypted column-packed data (see [PackColumn]):
+//   - ctGram: X^T X + lambda*I, packed as a features x features matrix (see the
+//     packing of [github.com/tuneinsight/lattigo/v6/circuits/ckks/matrix]),
+//   - ctXTy: X^T y, replicated on the columns of a features x features matrix so
+//     that it can be multiplied with the inverted Gram matrix.
+//
+// The circuit consumes two levels. Both outputs can either be passed to
+// [Evaluator.SolveNew] or be (threshold-)decrypted and solved in the clear with
+// [SolveNormalEquations].
+func (eval *Evaluator) NormalEquationsNew(cols []*rlwe.Ciphertext, cty *rlwe.Ciphertext, samples int, lambda float64) (ctGram, ctXTy *rlwe.Ciphertext, err error) {
+
+	d := eval.Dimension
+
+	if len(cols) != d {
+		return nil, nil, fmt.Errorf("cannot NormalEquationsNew: %d columns provided but the evaluator operates on %d features", len(cols), d)
+	}
+
+	d2 := d * d
+	slots := cty.Slots()
+
+	if samples > slots || slots%samples != 0 {
+		return nil, nil, fmt.Errorf("cannot NormalEquationsNew: the sample window %d does not divide the number of slots %d", samples, slots)
+	}
+
+	// (X^T X)_{jk} = sum_i X_{ij} X_{ik}; the Gram matrix is symmetric so each
+	// product is summed once and masked on both of its positions.
+	for j := 0; j < d; j++ {
+		for k := j; k < d; k++ {
+
+			mask := make([]complex128, slots)
+			for b := 0; b < slots; b += d2 {
+				mask[b+d*j+k] = 1
+				mask[b+d*k+j] = 1
+			}
+
+			if ctGram, err = eval.accumulateInnerSum(cols[j], cols[k], samples, mask, ctGram); err != nil {
+				return nil, nil, fmt.Errorf("cannot NormalEquationsNew: %w", err)
+			}
+		}
+	}
+
+	if err = eval.Rescale(ctGram, ctGram); err != nil {
+		return nil, nil, fmt.Errorf("cannot NormalEquationsNew: %w", err)
+	}
+
+	if lambda != 0 {
+
+		ridge := make([]complex128, slots)
+		for b := 0; b < slots; b += d2 {
+			for i := 0; i < d; i++ {
+				ridge[b+d*i+i] = complex(lambda, 0)
+			}
+		}
+
+		if err = eval.Add(ctGram, ridge, ctGram); err != nil {
+			return nil, nil, fmt.Errorf("cannot NormalEquationsNew: %w", err)
+		}
+	}
+
+	// (X^T y)_j = sum_i X_{ij} y_i, replicated on the j-th row so that the
+	// packed matrix holds X^T y in each of its columns.
+	for j := 0; j < d; j++ {
+
+		mask := make([]complex128, slots)
+		for b := 0; b < slots; b += d2 {
+			for k := 0; k < d; k++ {
+				mask[b+d*j+k] = 1
+			}
+		}
+
+		if ctXTy, err = eval.accumulateInnerSum(cols[j], cty, samples, mask, ctXTy); err != nil {
+			return nil, nil, fmt.Errorf("cannot NormalEquationsNew: %w", err)
+		}
+	}
+
+	if err = eval.Rescale(ctXTy, ctXTy); err != nil {
+		return nil, nil, fmt.Errorf("cannot NormalEquationsNew: %w", err)
+	}
+
+	return
+}
+
+// accumulateInnerSum multiplies ct0 with ct1, sums the product over the sample
+// window, masks the replicated sum on the given positions and adds the result
+// on acc.
+func (eval *Evaluator) accumulateInnerSum(ct0, ct1 *rlwe.Ciphertext, samples int, mask []complex128, acc *rlwe.Ciphertext) (*rlwe.Ciphertext, error) {
+
+	prod, err := eval.MulRelinNew(ct0, ct1)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = eval.Rescale(prod, prod); err != nil {
+		return nil, err
+	}
+
+	// The data is replicated with the sample window as period, so the sliding
+	// inner sum yields the full window sum in every slot.
+	if err = eval.InnerSum(prod, 1, samples, prod); err != nil {
+		return nil, err
+	}
+
+	if err = eval.Mul(prod, mask, prod); err != nil {
+		return nil, err
+	}
+
+	if acc == nil {
+		return prod, nil
+	}
+
+	return acc, eval.Add(acc, prod, acc)
+}
+
+// SolveNew solves the ridge system under encryption by inverting the Gram
+// matrix with iterations of the Newton-Schulz method and multiplying the
+// inverse with X^T y. The returned ciphertext holds the regression weights
+// replicated on the columns of a features x features matrix (see
+// [UnpackWeights]). See [matrix.Evaluator.InverseNew] for the choice of the
+// pre-scaling factor and the level requirements.
+func (eval *Evaluator) SolveNew(ctGram, ctXTy *rlwe.Ciphertext, prescale float64, iterations int) (opOut *rlwe.Ciphertext, err error) {
+
+	var ctInv *rlwe.Ciphertext
+	if ctInv, err = eval.InverseNew(ctGram, prescale, iterations); err != nil {
+		return nil, fmt.Errorf("cannot SolveNew: %w", err)
+	}
+
+	if opOut, err = eval.MulMatrixNew(ctInv, ctXTy); err != nil {
+		return nil, fmt.Errorf("cannot SolveNew: %w", err)
+	}
+
+	return
+}
+
+// UnpackWeights returns the regression weights from the decoded slot values of
+// a ciphertext returned by [Evaluator.SolveNew].
+func UnpackWeights(values []complex128, features int) []complex128 {
+	weights := make([]complex128, features)
+	for i := range weights {
+		weights[i] = values[features*i]
+	}
+	return weights
+}
+
+// SolveNormalEquations solves the normal equations (X^T X + lambda*I) w = X^T y
+// in the clear, for the flow where the aggregated Gram matrix and X^T y are
+// released through (threshold-)decryption instead of being inverted under
+// encryption. The system is solved with Gaussian elimination with partial
+// pivoting and the method returns an error if the Gram matrix is singular.
+func SolveNormalEquations(gram [][]float64, xty []float64) ([]float64, error) {
+
+	d := len(xty)
+
+	a := make([][]float64, d)
+	for i := range a {
+		if len(gram[i]) != d {
+			return nil, fmt.Errorf("cannot SolveNormalEquations: gram matrix row %d has %d columns but the system has %d unknowns", i, len(gram[i]), d)
+		}
+		a[i] = make([]float64, d+1)
+		copy(a[i], gram[i])
+		a[i][d] = xty[i]
+	}
+
+	for col := 0; col < d; col++ {
+
+		pivot := col
+		for row := col + 1; row < d; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+
+		if a[pivot][col] == 0 {
+			return nil, fmt.Errorf("cannot SolveNormalEquations: the gram matrix is singular")
+		}
+
+		a[col], a[pivot] = a[pivot], a[col]
+
+		for row := col + 1; row < d; row++ {
+			f := a[row][col] / a[col][col]
+			for k := col; k <= d; k++ {
+				a[row][k] -= f * a[col][k]
+			}
+		}
+	}
+
+	weights := make([]float64, d)
+	for i := d - 1; i >= 0; i-- {
+		w := a[i][d]
+		for k := i + 1; k < d; k++ {
+			w -= a[i][k] * weights[k]
+		}
+		weights[i] = w / a[i][i]
+	}
+
+	return weights, nil
+}
@@ -0,0 +1,226 @@
+package linreg
+
+import (
+	"flag"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/circuits/ckks/matrix"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/ckks"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+var printPrecisionStats = flag.Bool("print-precision", false, "print precision stats")
+
+// testInsecure are insecure parameters used for the sole purpose of fast testing.
+var testInsecure = ckks.ParametersLiteral{
+	LogN:            10,
+	LogQ:            []int{60, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45},
+	LogP:            []int{61},
+	LogDefaultScale: 45,
+}
+
+func TestLinReg(t *testing.T) {
+
+	tc := ckks.NewTestContext(testInsecure)
+	params := tc.Params
+
+	features := 4
+	samples := 64
+	lambda := 0.05
+	slots := params.MaxSlots()
+
+	evk := rlwe.NewMemEvaluationKeySet(tc.Kgen.GenRelinearizationKeyNew(tc.Sk), tc.Kgen.GenGaloisKeysNew(GaloisElements(params, features, samples), tc.Sk)...)
+
+	eval, err := NewEvaluator(params, features, tc.Evl.WithKey(evk))
+	require.NoError(t, err)
+
+	// y = X w, with the columns of X and y normalized by sqrt(samples) so that
+	// the aggregated Gram matrix is the sample covariance.
+	wTrue := make([]float64, features)
+	for j := range wTrue {
+		wTrue[j] = sampling.RandFloat64(-1, 1)
+	}
+
+	X := make([][]float64, samples)
+	y := make([]float64, samples)
+	for i := range X {
+		X[i] = make([]float64, features)
+		for j := range X[i] {
+			X[i][j] = sampling.RandFloat64(-1, 1)
+			y[i] += X[i][j] * wTrue[j]
+		}
+	}
+
+	norm := 1 / math.Sqrt(float64(samples))
+
+	encryptColumn := func(t *testing.T, column []float64) *rlwe.Ciphertext {
+		values, err := PackColumn(column, samples, slots)
+		require.NoError(t, err)
+		pt := ckks.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, tc.Ecd.Encode(values, pt))
+		ct, err := tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+		return ct
+	}
+
+	cols := make([]*rlwe.Ciphertext, features)
+	for j := range cols {
+		column := make([]float64, samples)
+		for i := range column {
+			column[i] = X[i][j] * norm
+		}
+		cols[j] = encryptColumn(t, column)
+	}
+
+	yNorm := make([]float64, samples)
+	for i := range yNorm {
+		yNorm[i] = y[i] * norm
+	}
+	cty := encryptColumn(t, yNorm)
+
+	// Cleartext normal equations of the normalized system.
+	gram := make([][]float64, features)
+	xty := make([]float64, features)
+	for j := range gram {
+		gram[j] = make([]float64, features)
+		for k := range gram[j] {
+			for i := 0; i < samples; i++ {
+				gram[j][k] += X[i][j] * X[i][k]
+			}
+			gram[j][k] /= float64(samples)
+		}
+		gram[j][j] += lambda
+		for i := 0; i < samples; i++ {
+			xty[j] += X[i][j] * y[i]
+		}
+		xty[j] /= float64(samples)
+	}
+
+	ctGram, ctXTy, err := eval.NormalEquationsNew(cols, cty, samples, lambda)
+	require.NoError(t, err)
+
+	t.Run("NormalEquations", func(t *testing.T) {
+
+		gramWant := make([][]complex128, features)
+		xtyWant := make([][]complex128, features)
+		for j := range gramWant {
+			gramWant[j] = make([]complex128, features)
+			xtyWant[j] = make([]complex128, features)
+			for k := range gramWant[j] {
+				gramWant[j][k] = complex(gram[j][k], 0)
+				xtyWant[j][k] = complex(xty[j], 0)
+			}
+		}
+
+		values, err := matrix.Pack(gramWant, slots)
+		require.NoError(t, err)
+		ckks.VerifyTestVectors(params, tc.Ecd, tc.Dec, values, ctGram, 30, 0, *printPrecisionStats, t)
+
+		values, err = matrix.Pack(xtyWant, slots)
+		require.NoError(t, err)
+		ckks.VerifyTestVectors(params, tc.Ecd, tc.Dec, values, ctXTy, 30, 0, *printPrecisionStats, t)
+	})
+
+	t.Run("Solve", func(t *testing.T) {
+
+		prescale := matrix.PrescaleFactor(0.2, 0.9)
+		iterations := 2
+
+		ctW, err := eval.SolveNew(ctGram, ctXTy, prescale, iterations)
+		require.NoError(t, err)
+
+		// Evaluates the same Newton-Schulz solve on the cleartext system.
+		mulMat := func(a, b [][]float64) [][]float64 {
+			c := make([][]float64, features)
+			for i := range c {
+				c[i] = make([]float64, features)
+				for j := range c[i] {
+					for k := 0; k < features; k++ {
+						c[i][j] += a[i][k] * b[k][j]
+					}
+				}
+			}
+			return c
+		}
+
+		inv := make([][]float64, features)
+		for i := range inv {
+			inv[i] = make([]float64, features)
+			for j := range inv[i] {
+				inv[i][j] = prescale * gram[j][i]
+			}
+		}
+
+		for k := 0; k < iterations; k++ {
+			W := mulMat(gram, inv)
+			for i := range W {
+				for j := range W[i] {
+					W[i][j] = -W[i][j]
+					if i == j {
+						W[i][j] += 2
+					}
+				}
+			}
+			inv = mulMat(inv, W)
+		}
+
+		want := make([][]complex128, features)
+		for i := range want {
+			want[i] = make([]complex128, features)
+			for j := range want[i] {
+				var w float64
+				for k := 0; k < features; k++ {
+					w += inv[i][k] * xty[k]
+				}
+				want[i][j] = complex(w, 0)
+			}
+		}
+
+		values, err := matrix.Pack(want, slots)
+		require.NoError(t, err)
+		ckks.VerifyTestVectors(params, tc.Ecd, tc.Dec, values, ctW, 15, 0, *printPrecisionStats, t)
+
+		weights := UnpackWeights(values, features)
+		require.Equal(t, features, len(weights))
+	})
+
+	t.Run("SolveCleartext", func(t *testing.T) {
+
+		weights, err := SolveNormalEquations(gram, xty)
+		require.NoError(t, err)
+
+		// With a small ridge penalty the exact solution is close to the model.
+		for j := range weights {
+			require.InDelta(t, wTrue[j], weights[j], 0.5)
+		}
+
+		// Without the penalty the system solves back to the model exactly.
+		for j := range gram {
+			gram[j][j] -= lambda
+		}
+		weights, err = SolveNormalEquations(gram, xty)
+		require.NoError(t, err)
+		for j := range weights {
+			require.InDelta(t, wTrue[j], weights[j], 1e-8)
+		}
+		for j := range gram {
+			gram[j][j] += lambda
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+
+		_, _, err := eval.NormalEquationsNew(cols[:features-1], cty, samples, lambda)
+		require.Error(t, err)
+
+		_, _, err = eval.NormalEquationsNew(cols, cty, samples-1, lambda)
+		require.Error(t, err)
+
+		_, err = PackColumn(make([]float64, samples+1), samples, slots)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,202 @@
+package text
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+)
+
+// Evaluator is an evaluator used to match encrypted byte strings against
+// plaintext patterns. All fields of this struct are public, enabling custom
+// instantiations.
+type Evaluator struct {
+	bgv.Parameters
+	*bgv.Evaluator
+}
+
+// NewEvaluator instantiates a new matching [Evaluator] from a [bgv.Evaluator],
+// which should be instantiated with scale-invariant (BFV-style) tensoring to
+// accommodate the multiplicative depth log2(T-1) of the equality circuit.
+// Returns an error if the plaintext modulus is too small to embed bytes.
+// This method is allocation free.
+func NewEvaluator(params bgv.Parameters, eval *bgv.Evaluator) (*Evaluator, error) {
+
+	if params.PlaintextModulus() < 256 {
+		return nil, fmt.Errorf("cannot NewEvaluator: plaintext modulus must be a prime greater than 255, but is %d", params.PlaintextModulus())
+	}
+
+	return &Evaluator{Parameters: params, Evaluator: eval}, nil
+}
+
+// EqualZeroNew returns a new ciphertext holding 1 in each slot where op0 is
+// zero and 0 elsewhere, evaluated as 1 - op0^(T-1) in ceil(log2(T-1)) +
+// HW(T-1) - 1 multiplications. The plaintext modulus T must be prime.
+func (eval Evaluator) EqualZeroNew(op0 *rlwe.Ciphertext) (opOut *rlwe.Ciphertext, err error) {
+
+	exp := eval.PlaintextModulus() - 1
+
+	// op0^(T-1) by left-to-right binary exponentiation
+	opOut = op0
+	for i := bits.Len64(exp) - 2; i >= 0; i-- {
+
+		if opOut, err = eval.MulRelinNew(opOut, opOut); err != nil {
+			return nil, fmt.Errorf("cannot EqualZeroNew: %w", err)
+		}
+
+		if exp>>i&1 == 1 {
+			if opOut, err = eval.MulRelinNew(opOut, op0); err != nil {
+				return nil, fmt.Errorf("cannot EqualZeroNew: %w", err)
+			}
+		}
+	}
+
+	// 1 - op0^(T-1)
+	if err = eval.Mul(opOut, -1, opOut); err != nil {
+		return nil, fmt.Errorf("cannot EqualZeroNew: %w", err)
+	}
+
+	if err = eval.Add(opOut, 1, opOut); err != nil {
+		return nil, fmt.Errorf("cannot EqualZeroNew: %w", err)
+	}
+
+	return
+}
+
+// EqualNew returns a new ciphertext holding 1 in each slot where op0 and op1
+// are equal and 0 elsewhere. op1 can be a ciphertext, a plaintext, a scalar or
+// a vector of scalars, following the operands of [bgv.Evaluator.Sub].
+func (eval Evaluator) EqualNew(op0 *rlwe.Ciphertext, op1 rlwe.Operand) (opOut *rlwe.Ciphertext, err error) {
+
+	var diff *rlwe.Ciphertext
+	if diff, err = eval.SubNew(op0, op1); err != nil {
+		return nil, fmt.Errorf("cannot EqualNew: %w", err)
+	}
+
+	return eval.EqualZeroNew(diff)
+}
+
+// EqualPackedNew returns a new ciphertext comparing op0 and op1 as batches of
+// byte strings packed over groups of n adjacent slots, with n a power of two
+// dividing the number of slots per row of the plaintext matrix. The first slot
+// of each group holds 1 if the two strings are equal over the whole group and
+// 0 otherwise; the remaining slots of the group hold garbage.
+func (eval Evaluator) EqualPackedNew(op0 *rlwe.Ciphertext, op1 rlwe.Operand, n int) (opOut *rlwe.Ciphertext, err error) {
+
+	if n < 1 || n&(n-1) != 0 || n > eval.MaxSlots()>>1 {
+		return nil, fmt.Errorf("cannot EqualPackedNew: n must be a power of two at most half the slot count, but is %d", n)
+	}
+
+	if opOut, err = eval.EqualNew(op0, op1); err != nil {
+		return nil, fmt.Errorf("cannot EqualPackedNew: %w", err)
+	}
+
+	// Folds the group of n slot-wise equality bits into their product
+	for k := n >> 1; k > 0; k >>= 1 {
+
+		var rot *rlwe.Ciphertext
+		if rot, err = eval.RotateColumnsNew(opOut, k); err != nil {
+			return nil, fmt.Errorf("cannot EqualPackedNew: %w", err)
+		}
+
+		if opOut, err = eval.MulRelinNew(opOut, rot); err != nil {
+			return nil, fmt.Errorf("cannot EqualPackedNew: %w", err)
+		}
+	}
+
+	return
+}
+
+// MatchSubstringNew returns a new ciphertext holding in slot i the equality
+// bit between the polynomial hash of the length-len(pattern) window starting
+// at slot i of op0 and the hash of the pattern at the public point r, which
+// must be non-zero mod T. A 1 marks a candidate occurrence of the pattern:
+// distinct windows collide with probability at most (len(pattern)-1)/T, so
+// matches are a probabilistic filter unless r is refreshed between queries.
+func (eval Evaluator) MatchSubstringNew(op0 *rlwe.Ciphertext, pattern []byte, r uint64) (opOut *rlwe.Ciphertext, err error) {
+
+	t := eval.PlaintextModulus()
+
+	if len(pattern) == 0 || r%t == 0 {
+		return nil, fmt.Errorf("cannot MatchSubstringNew: pattern must be non-empty and r non-zero mod T")
+	}
+
+	// sum_j r^j * rot_j(op0), holding in slot i the hash of the window starting at slot i
+	acc := op0.CopyNew()
+
+	rj := uint64(1)
+	for j := 1; j < len(pattern); j++ {
+
+		rj = rj * r % t
+
+		var rot *rlwe.Ciphertext
+		if rot, err = eval.RotateColumnsNew(op0, j); err != nil {
+			return nil, fmt.Errorf("cannot MatchSubstringNew: %w", err)
+		}
+
+		if err = eval.MulThenAdd(rot, rj, acc); err != nil {
+			return nil, fmt.Errorf("cannot MatchSubstringNew: %w", err)
+		}
+	}
+
+	return eval.EqualNew(acc, Hash(pattern, r, t))
+}
+
+// MatchPatternNew returns a new ciphertext holding in slot i a 1 if the
+// length-len(pattern) window starting at slot i of op0 matches the pattern
+// and a 0 otherwise. Pattern bytes equal to the wildcard match any slot value
+// and are skipped. Returns an error if the pattern holds no literal byte.
+func (eval Evaluator) MatchPatternNew(op0 *rlwe.Ciphertext, pattern []byte, wildcard byte) (opOut *rlwe.Ciphertext, err error) {
+
+	// one equality bit per literal byte of the pattern
+	var eqs []*rlwe.Ciphertext
+	for j, p := range pattern {
+
+		if p == wildcard {
+			continue
+		}
+
+		rot := op0
+		if j != 0 {
+			if rot, err = eval.RotateColumnsNew(op0, j); err != nil {
+				return nil, fmt.Errorf("cannot MatchPatternNew: %w", err)
+			}
+		}
+
+		var eq *rlwe.Ciphertext
+		if eq, err = eval.EqualNew(rot, uint64(p)); err != nil {
+			return nil, fmt.Errorf("cannot MatchPatternNew: %w", err)
+		}
+
+		eqs = append(eqs, eq)
+	}
+
+	if len(eqs) == 0 {
+		return nil, fmt.Errorf("cannot MatchPatternNew: pattern holds no literal byte")
+	}
+
+	// balanced product tree over the equality bits
+	for len(eqs) > 1 {
+
+		next := eqs[:0]
+		for i := 0; i < len(eqs); i += 2 {
+
+			if i+1 == len(eqs) {
+				next = append(next, eqs[i])
+				break
+			}
+
+			var prod *rlwe.Ciphertext
+			if prod, err = eval.MulRelinNew(eqs[i], eqs[i+1]); err != nil {
+				return nil, fmt.Errorf("cannot MatchPatternNew: %w", err)
+			}
+
+			next = append(next, prod)
+		}
+
+		eqs = next
+	}
+
+	return eqs[0], nil
+}
@@ -0,0 +1,51 @@
+// Package text implements encrypted string and pattern matching circuits over
+// the BFV scheme. Byte strings are packed one byte per slot, slot-wise equality
+// is evaluated with Fermat's little theorem x^(T-1) = 1 for x != 0 mod the
+// prime plaintext modulus T, and substring search uses a public polynomial
+// hash over the pattern windows as a probabilistic filter.
+package text
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+// PackBytes returns the encoding of a byte string as one byte per slot,
+// zero-padded to the given number of slots. Returns an error if the string
+// does not fit in the given number of slots.
+func PackBytes(str []byte, slots int) ([]uint64, error) {
+
+	if len(str) > slots {
+		return nil, fmt.Errorf("cannot PackBytes: string of length %d exceeds %d slots", len(str), slots)
+	}
+
+	values := make([]uint64, slots)
+	for i, c := range str {
+		values[i] = uint64(c)
+	}
+
+	return values, nil
+}
+
+// Hash returns the polynomial hash sum_j str[j] * r^j mod t of a byte string.
+// The same public point r must be used to hash the pattern and to evaluate
+// [Evaluator.MatchSubstringNew] on the ciphertext.
+func Hash(str []byte, r, t uint64) (h uint64) {
+	for j := len(str) - 1; j >= 0; j-- {
+		h = (h*r + uint64(str[j])) % t
+	}
+	return
+}
+
+// GaloisElements returns the list of Galois elements required to evaluate the
+// matching circuits of the [Evaluator] for patterns or packed strings of at
+// most n bytes.
+func GaloisElements(params rlwe.ParameterProvider, n int) (galEls []uint64) {
+	p := params.GetRLWEParameters()
+	galEls = make([]uint64, 0, n-1)
+	for j := 1; j < n; j++ {
+		galEls = append(galEls, p.GaloisElement(j))
+	}
+	return
+}
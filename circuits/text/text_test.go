@@ -0,0 +1,130 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+)
+
+// testInsecure are insecure parameters used for the sole purpose of fast testing,
+// with a modulus chain deep enough for the log2(T-1) = 8 squarings of the
+// equality circuit under scale-invariant tensoring.
+var testInsecure = bgv.ParametersLiteral{
+	LogN:             11,
+	LogQ:             []int{50, 40, 40, 40, 40, 40, 40, 40, 40, 40},
+	LogP:             []int{60},
+	PlaintextModulus: 0x101,
+}
+
+func testContext(t *testing.T, n int) (*bgv.TestContext, *Evaluator) {
+
+	tc := bgv.NewTestContext(testInsecure, true)
+
+	evk := rlwe.NewMemEvaluationKeySet(
+		tc.Kgen.GenRelinearizationKeyNew(tc.Sk),
+		tc.Kgen.GenGaloisKeysNew(GaloisElements(tc.Params, n), tc.Sk)...)
+
+	eval, err := NewEvaluator(tc.Params, tc.Evl.WithKey(evk))
+	require.NoError(t, err)
+
+	return tc, eval
+}
+
+func encryptBytes(t *testing.T, tc *bgv.TestContext, str []byte) *rlwe.Ciphertext {
+
+	values, err := PackBytes(str, tc.Params.MaxSlots())
+	require.NoError(t, err)
+
+	pt := bgv.NewPlaintext(tc.Params, tc.Params.MaxLevel())
+	require.NoError(t, tc.Ecd.Encode(values, pt))
+
+	ct, err := tc.Enc.EncryptNew(pt)
+	require.NoError(t, err)
+
+	return ct
+}
+
+func TestEqualPacked(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("skipping homomorphic equality test in short mode")
+	}
+
+	n := 8
+	tc, eval := testContext(t, n)
+
+	str0 := []byte("aardvark/sardine.")
+	str1 := []byte("aardvark/sardines")
+
+	ct0 := encryptBytes(t, tc, str0)
+	ct1 := encryptBytes(t, tc, str1)
+
+	res, err := eval.EqualPackedNew(ct0, ct1, n)
+	require.NoError(t, err)
+
+	values := make([]uint64, tc.Params.MaxSlots())
+	require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(res), values))
+
+	// group 0 ("aardvark" vs "aardvark") matches, group 1 ("/sardine" vs "/sardine") matches,
+	// group 2 (".\x00..." vs "s\x00...") does not
+	require.Equal(t, uint64(1), values[0])
+	require.Equal(t, uint64(1), values[n])
+	require.Equal(t, uint64(0), values[2*n])
+}
+
+func TestMatchSubstring(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("skipping homomorphic equality test in short mode")
+	}
+
+	pattern := []byte("ban")
+	tc, eval := testContext(t, len(pattern))
+
+	str := []byte("urban banana band")
+	ct := encryptBytes(t, tc, str)
+
+	res, err := eval.MatchSubstringNew(ct, pattern, 0x2a)
+	require.NoError(t, err)
+
+	values := make([]uint64, tc.Params.MaxSlots())
+	require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(res), values))
+
+	for i := 0; i+len(pattern) <= len(str); i++ {
+		want := uint64(0)
+		if string(str[i:i+len(pattern)]) == string(pattern) {
+			want = 1
+		}
+		require.Equal(t, want, values[i], "window %d", i)
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+
+	if testing.Short() {
+		t.Skip("skipping homomorphic equality test in short mode")
+	}
+
+	pattern := []byte("b?nd")
+	tc, eval := testContext(t, len(pattern))
+
+	str := []byte("bind band bonds")
+	ct := encryptBytes(t, tc, str)
+
+	res, err := eval.MatchPatternNew(ct, pattern, '?')
+	require.NoError(t, err)
+
+	values := make([]uint64, tc.Params.MaxSlots())
+	require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(res), values))
+
+	require.Equal(t, uint64(1), values[0])  // "bind"
+	require.Equal(t, uint64(1), values[5])  // "band"
+	require.Equal(t, uint64(1), values[10]) // "bond"
+	require.Equal(t, uint64(0), values[1])  // "ind "
+
+	_, err = eval.MatchPatternNew(ct, []byte("???"), '?')
+	require.Error(t, err)
+}
@@ -0,0 +1,222 @@
+// Package lwe implements basic homomorphic operations on LWE ciphertexts:
+// addition, scalar multiplication, modulus switching and LWE-to-LWE key
+// switching. LWE ciphertexts are extracted from RLWE ciphertexts with
+// [rlwe.ExtractLWE] and serialized like the other objects of the library,
+// so post-extraction processing does not require ad-hoc user code.
+package lwe
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/ring"
+)
+
+// Evaluator is a type for evaluating homomorphic operations on LWE
+// ciphertexts. It embeds an [rlwe.Evaluator], which provides the gadget
+// products of the LWE-to-LWE key switching.
+type Evaluator struct {
+	params rlwe.Parameters
+	*rlwe.Evaluator
+}
+
+// NewEvaluator creates a new [Evaluator]. The evaluation key set is only
+// accessed by [Evaluator.KeySwitch] when the evaluation key is not provided
+// explicitly, and can be nil otherwise.
+func NewEvaluator(params rlwe.ParameterProvider, evk rlwe.EvaluationKeySet) *Evaluator {
+	p := *params.GetRLWEParameters()
+	return &Evaluator{params: p, Evaluator: rlwe.NewEvaluator(p, evk)}
+}
+
+// ShallowCopy creates a shallow copy of this [Evaluator] in which all the
+// read-only data-structures are shared with the receiver and the temporary
+// buffers are reallocated. The receiver and the returned Evaluators can be
+// used concurrently.
+func (eval Evaluator) ShallowCopy() *Evaluator {
+	return &Evaluator{params: eval.params, Evaluator: eval.Evaluator.ShallowCopy()}
+}
+
+// checkBinary checks that the operands share the same dimension and level.
+func checkBinary(op0, op1 *rlwe.LWECiphertext) error {
+	if op0.N() != op1.N() {
+		return fmt.Errorf("operands dimension do not match: %d != %d", op0.N(), op1.N())
+	}
+	if op0.Level() != op1.Level() {
+		return fmt.Errorf("operands level do not match: %d != %d", op0.Level(), op1.Level())
+	}
+	return nil
+}
+
+// Add evaluates opOut = op0 + op1. The operands and the receiver must share
+// the same dimension and level.
+func (eval Evaluator) Add(op0, op1, opOut *rlwe.LWECiphertext) (err error) {
+
+	if err = checkBinary(op0, op1); err != nil {
+		return fmt.Errorf("cannot Add: %w", err)
+	}
+
+	if err = checkBinary(op0, opOut); err != nil {
+		return fmt.Errorf("cannot Add: %w", err)
+	}
+
+	for i := 0; i < op0.Level()+1; i++ {
+		qi := eval.params.RingQ().SubRings[i].Modulus
+		v0, v1, vOut := op0.Value[i], op1.Value[i], opOut.Value[i]
+		for j := range vOut {
+			vOut[j] = ring.CRed(v0[j]+v1[j], qi)
+		}
+	}
+
+	*opOut.MetaData = *op0.MetaData
+
+	return
+}
+
+// AddNew evaluates opOut = op0 + op1 and returns the result on a new
+// [rlwe.LWECiphertext].
+func (eval Evaluator) AddNew(op0, op1 *rlwe.LWECiphertext) (opOut *rlwe.LWECiphertext, err error) {
+	opOut = rlwe.NewLWECiphertext(op0.N(), op0.Level())
+	return opOut, eval.Add(op0, op1, opOut)
+}
+
+// MulScalar evaluates opOut = op0 * scalar. The operand and the receiver must
+// share the same dimension and level.
+func (eval Evaluator) MulScalar(op0 *rlwe.LWECiphertext, scalar uint64, opOut *rlwe.LWECiphertext) (err error) {
+
+	if err = checkBinary(op0, opOut); err != nil {
+		return fmt.Errorf("cannot MulScalar: %w", err)
+	}
+
+	for i := 0; i < op0.Level()+1; i++ {
+		s := eval.params.RingQ().SubRings[i]
+		scalarMont := ring.MForm(ring.BRedAdd(scalar, s.Modulus, s.BRedConstant), s.Modulus, s.BRedConstant)
+		v0, vOut := op0.Value[i], opOut.Value[i]
+		for j := range vOut {
+			vOut[j] = ring.MRed(v0[j], scalarMont, s.Modulus, s.MRedConstant)
+		}
+	}
+
+	*opOut.MetaData = *op0.MetaData
+
+	return
+}
+
+// MulScalarNew evaluates opOut = op0 * scalar and returns the result on a new
+// [rlwe.LWECiphertext].
+func (eval Evaluator) MulScalarNew(op0 *rlwe.LWECiphertext, scalar uint64) (opOut *rlwe.LWECiphertext, err error) {
+	opOut = rlwe.NewLWECiphertext(op0.N(), op0.Level())
+	return opOut, eval.MulScalar(op0, scalar, opOut)
+}
+
+// Rescale evaluates the LWE modulus switching opOut = round(op0 / qL), where
+// qL is the last modulus of op0, bringing the ciphertext from level L to
+// level L-1. The receiver must be at the level of op0 minus one.
+func (eval Evaluator) Rescale(op0, opOut *rlwe.LWECiphertext) (err error) {
+
+	level := op0.Level()
+
+	if level == 0 {
+		return fmt.Errorf("cannot Rescale: op0 is already at level 0")
+	}
+
+	if opOut.N() != op0.N() || opOut.Level() != level-1 {
+		return fmt.Errorf("cannot Rescale: opOut should have dimension %d and level %d", op0.N(), level-1)
+	}
+
+	ringQ := eval.params.RingQ()
+
+	sL := ringQ.SubRings[level]
+
+	// Center by (qL-1)/2 to round instead of floor.
+	qLHalf := (sL.Modulus - 1) >> 1
+
+	for i := 0; i < level; i++ {
+
+		s := ringQ.SubRings[i]
+
+		// MForm(-qL^{-1} mod qi)
+		c := ringQ.RescaleConstants[level-1][i]
+
+		qLHalfModQi := ring.BRedAdd(qLHalf, s.Modulus, s.BRedConstant)
+
+		vL, vi, vOut := op0.Value[level], op0.Value[i], opOut.Value[i]
+
+		for j := range vOut {
+			// (vL - vi) * -qL^{-1} = (vi - vL) * qL^{-1} mod qi
+			x := ring.BRedAdd(ring.CRed(vL[j]+qLHalf, sL.Modulus), s.Modulus, s.BRedConstant)
+			y := ring.CRed(vi[j]+qLHalfModQi, s.Modulus)
+			vOut[j] = ring.MRed(x+s.Modulus-y, c, s.Modulus, s.MRedConstant)
+		}
+	}
+
+	*opOut.MetaData = *op0.MetaData
+
+	return
+}
+
+// RescaleNew evaluates the LWE modulus switching opOut = round(op0 / qL) and
+// returns the result on a new [rlwe.LWECiphertext] at the level of op0 minus
+// one. See [Evaluator.Rescale].
+func (eval Evaluator) RescaleNew(op0 *rlwe.LWECiphertext) (opOut *rlwe.LWECiphertext, err error) {
+	opOut = rlwe.NewLWECiphertext(op0.N(), op0.Level()-1)
+	return opOut, eval.Rescale(op0, opOut)
+}
+
+// KeySwitch re-encrypts op0 under the output secret of the provided
+// [rlwe.EvaluationKey], generated with
+// [rlwe.KeyGenerator.GenEvaluationKeyNew](skIn, skOut). The dimension of op0
+// must be the degree of the ring of the evaluator parameters.
+func (eval Evaluator) KeySwitch(op0 *rlwe.LWECiphertext, evk *rlwe.EvaluationKey, opOut *rlwe.LWECiphertext) (err error) {
+
+	N := eval.params.N()
+
+	if op0.N() != N {
+		return fmt.Errorf("cannot KeySwitch: op0 dimension should be %d but is %d", N, op0.N())
+	}
+
+	level := op0.Level()
+
+	// Embeds op0 into a degree-one RLWE ciphertext whose constant coefficient
+	// decrypts to b + <a, s>, with garbage in the other coefficients.
+	ct := rlwe.NewCiphertext(eval.params, 1, level)
+	*ct.MetaData = *op0.MetaData
+	ct.IsNTT = false
+
+	ringQ := eval.params.RingQ().AtLevel(level)
+
+	for i := 0; i < level+1; i++ {
+
+		qi := ringQ.SubRings[i].Modulus
+		v := op0.Value[i]
+		c0, c1 := ct.Value[0].Coeffs[i], ct.Value[1].Coeffs[i]
+
+		c0[0] = v[0]
+		c1[0] = v[1]
+		for j := 1; j < N; j++ {
+			if c := v[1+N-j]; c != 0 {
+				c1[j] = qi - c
+			}
+		}
+	}
+
+	if err = eval.ApplyEvaluationKey(ct, evk, ct); err != nil {
+		return fmt.Errorf("cannot KeySwitch: %w", err)
+	}
+
+	cts, err := rlwe.ExtractLWE(eval.params, ct, []int{0})
+	if err != nil {
+		return fmt.Errorf("cannot KeySwitch: %w", err)
+	}
+
+	*opOut = *cts[0]
+
+	return
+}
+
+// KeySwitchNew re-encrypts op0 under the output secret of the provided
+// [rlwe.EvaluationKey] and returns the result on a new [rlwe.LWECiphertext].
+// See [Evaluator.KeySwitch].
+func (eval Evaluator) KeySwitchNew(op0 *rlwe.LWECiphertext, evk *rlwe.EvaluationKey) (opOut *rlwe.LWECiphertext, err error) {
+	opOut = rlwe.NewLWECiphertext(op0.N(), op0.Level())
+	return opOut, eval.KeySwitch(op0, evk, opOut)
+}
@@ -0,0 +1,157 @@
+package lwe
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+// decryptLWE returns the full-precision CRT reconstruction of b + <a, s>,
+// centered in (-Q/2, Q/2].
+func decryptLWE(t *testing.T, params rlwe.Parameters, sk *rlwe.SecretKey, ct *rlwe.LWECiphertext) *big.Int {
+
+	level := ct.Level()
+	ringQ := params.RingQ().AtLevel(level)
+
+	// Secret in the coefficient domain.
+	s := ringQ.NewPoly()
+	ringQ.IMForm(sk.Value.Q, s)
+	ringQ.INTT(s, s)
+
+	Q := new(big.Int).SetUint64(1)
+	rems := make([]*big.Int, level+1)
+	mods := make([]*big.Int, level+1)
+
+	for i := 0; i < level+1; i++ {
+
+		qi := new(big.Int).SetUint64(ringQ.SubRings[i].Modulus)
+
+		acc := new(big.Int).SetUint64(ct.Value[i][0])
+		tmp := new(big.Int)
+		for j := 0; j < ct.N(); j++ {
+			acc.Add(acc, tmp.Mul(tmp.SetUint64(ct.Value[i][1+j]), new(big.Int).SetUint64(s.Coeffs[i][j])))
+		}
+
+		rems[i] = acc.Mod(acc, qi)
+		mods[i] = qi
+		Q.Mul(Q, qi)
+	}
+
+	// CRT reconstruction.
+	v := new(big.Int)
+	for i := 0; i < level+1; i++ {
+		qiStar := new(big.Int).Quo(Q, mods[i])
+		inv := new(big.Int).ModInverse(qiStar, mods[i])
+		v.Add(v, inv.Mul(inv.Mul(inv, qiStar), rems[i]))
+	}
+	v.Mod(v, Q)
+
+	// Centers in (-Q/2, Q/2].
+	if v.Cmp(new(big.Int).Rsh(Q, 1)) > 0 {
+		v.Sub(v, Q)
+	}
+
+	return v
+}
+
+func TestLWE(t *testing.T) {
+
+	params, err := rlwe.NewParametersFromLiteral(rlwe.ParametersLiteral{
+		LogN:    10,
+		LogQ:    []int{50, 40},
+		LogP:    []int{60},
+		NTTFlag: true,
+	})
+	require.NoError(t, err)
+
+	kgen := rlwe.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	enc := rlwe.NewEncryptor(params, sk)
+
+	level := params.MaxLevel()
+	ringQ := params.RingQ().AtLevel(level)
+
+	// Encrypts a plaintext with large, distinguishable coefficients.
+	want := make([]*big.Int, params.N())
+	pt := rlwe.NewPlaintext(params, level)
+	for i := 0; i < level+1; i++ {
+		qi := ringQ.SubRings[i].Modulus
+		for j := 0; j < params.N(); j++ {
+			pt.Value.Coeffs[i][j] = (uint64(j) << 45) % qi
+		}
+	}
+	for j := 0; j < params.N(); j++ {
+		want[j] = new(big.Int).Lsh(new(big.Int).SetUint64(uint64(j)), 45)
+	}
+	ringQ.NTT(pt.Value, pt.Value)
+	pt.IsNTT = true
+
+	ct, err := enc.EncryptNew(pt)
+	require.NoError(t, err)
+
+	cts, err := rlwe.ExtractLWE(params, ct, []int{3, 5})
+	require.NoError(t, err)
+
+	eval := NewEvaluator(params, nil)
+
+	// requireClose asserts that the decryption of ct is within noise bits of
+	// the expected value.
+	requireClose := func(t *testing.T, want *big.Int, ct *rlwe.LWECiphertext, noise uint) {
+		diff := new(big.Int).Sub(decryptLWE(t, params, sk, ct), want)
+		require.LessOrEqual(t, diff.Abs(diff).BitLen(), int(noise))
+	}
+
+	t.Run("Add", func(t *testing.T) {
+
+		opOut, err := eval.AddNew(cts[3], cts[5])
+		require.NoError(t, err)
+
+		requireClose(t, new(big.Int).Add(want[3], want[5]), opOut, 20)
+
+		// Mismatched levels are rejected.
+		lower := rlwe.NewLWECiphertext(params.N(), level-1)
+		require.Error(t, eval.Add(cts[3], lower, opOut))
+	})
+
+	t.Run("MulScalar", func(t *testing.T) {
+
+		opOut, err := eval.MulScalarNew(cts[3], 257)
+		require.NoError(t, err)
+
+		requireClose(t, new(big.Int).Mul(want[3], new(big.Int).SetUint64(257)), opOut, 30)
+	})
+
+	t.Run("Rescale", func(t *testing.T) {
+
+		opOut, err := eval.RescaleNew(cts[3])
+		require.NoError(t, err)
+		require.Equal(t, level-1, opOut.Level())
+
+		qL := new(big.Int).SetUint64(ringQ.SubRings[level].Modulus)
+		wantRescaled := new(big.Int).Quo(want[3], qL)
+
+		// The modulus switching divides the message and the noise by qL and
+		// adds a small rounding noise bounded by the secret Hamming weight.
+		requireClose(t, wantRescaled, opOut, 12)
+
+		require.Error(t, eval.Rescale(opOut, opOut))
+	})
+
+	t.Run("KeySwitch", func(t *testing.T) {
+
+		skOut := kgen.GenSecretKeyNew()
+		evk := kgen.GenEvaluationKeyNew(sk, skOut)
+
+		opOut, err := eval.KeySwitchNew(cts[3], evk)
+		require.NoError(t, err)
+
+		diff := new(big.Int).Sub(decryptLWE(t, params, skOut, opOut), want[3])
+		require.LessOrEqual(t, diff.Abs(diff).BitLen(), 30)
+
+		// Mismatched dimension is rejected.
+		require.Error(t, eval.KeySwitch(rlwe.NewLWECiphertext(params.N()>>1, level), evk, opOut))
+	})
+}
@@ -0,0 +1,170 @@
+package rgsw
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// CMUX evaluates the homomorphic multiplexer opOut = op0 + (op1 - op0) x selector,
+// where selector is an RGSW encryption of a bit b: the result decrypts to the
+// plaintext of op0 if b = 0 and to the plaintext of op1 if b = 1.
+// op0, op1 and opOut must be degree-1 ciphertexts in the NTT domain; opOut can
+// alias op0. The evaluation reuses the internal buffers of the [Evaluator],
+// which therefore cannot be used concurrently (see [Evaluator.ShallowCopy]).
+func (eval Evaluator) CMUX(selector *Ciphertext, op0, op1, opOut *rlwe.Ciphertext) (err error) {
+
+	if op0.Degree() != 1 || op1.Degree() != 1 || opOut.Degree() != 1 {
+		return fmt.Errorf("cannot CMUX: op0, op1 and opOut must be degree-1 ciphertexts")
+	}
+
+	if !op0.IsNTT || !op1.IsNTT {
+		return fmt.Errorf("cannot CMUX: op0 and op1 must be in the NTT domain")
+	}
+
+	levelQ := utils.Min(utils.Min(op0.Level(), op1.Level()), selector.LevelQ())
+
+	ringQ := eval.GetRLWEParameters().RingQ().AtLevel(levelQ)
+
+	// buff = op1 - op0
+	buff, err := rlwe.NewCiphertextAtLevelFromPoly(levelQ, eval.BuffCt.Value[:2])
+
+	// Sanity check, this error should not happen unless the evaluator's buffer
+	// has been improperly tempered with.
+	if err != nil {
+		panic(err)
+	}
+
+	*buff.MetaData = *op0.MetaData
+
+	ringQ.Sub(op1.Value[0], op0.Value[0], buff.Value[0])
+	ringQ.Sub(op1.Value[1], op0.Value[1], buff.Value[1])
+
+	// buff = (op1 - op0) x RGSW(b)
+	eval.ExternalProduct(buff, selector, buff)
+
+	opOut.Resize(1, levelQ)
+
+	ringQ.Add(op0.Value[0], buff.Value[0], opOut.Value[0])
+	ringQ.Add(op0.Value[1], buff.Value[1], opOut.Value[1])
+
+	*opOut.MetaData = *op0.MetaData
+
+	return
+}
+
+// ExternalProductBatch computes the external product of each ciphertext of ops
+// with op1, writing the i-th result in opOut[i] (which can alias ops[i]), with
+// at most workers concurrent evaluations. Each worker uses its own shallow copy
+// of the [Evaluator], so the receiver's buffers are left untouched.
+func (eval Evaluator) ExternalProductBatch(ops []*rlwe.Ciphertext, op1 *Ciphertext, opOut []*rlwe.Ciphertext, workers int) (err error) {
+
+	if len(ops) != len(opOut) {
+		return fmt.Errorf("cannot ExternalProductBatch: len(ops) = %d does not match len(opOut) = %d", len(ops), len(opOut))
+	}
+
+	return runBatch(utils.Min(workers, len(ops)), len(ops), eval, func(eval *Evaluator, i int) error {
+		eval.ExternalProduct(ops[i], op1, opOut[i])
+		return nil
+	})
+}
+
+// CMUXTree evaluates a tree of CMUX gates over the leaves, selecting the leaf
+// of index sum_j b_j 2^j where b_j is the bit encrypted by selectors[j] (least
+// significant bit first). The number of leaves must be 2^len(selectors) and all
+// leaves must be degree-1 ciphertexts in the NTT domain. The reduction is
+// evaluated in place over the leaves slice, which is consumed as scratch space,
+// and the levels of the tree are parallelized over at most workers goroutines,
+// each using its own shallow copy of the [Evaluator].
+func (eval Evaluator) CMUXTree(selectors []*Ciphertext, leaves []*rlwe.Ciphertext, workers int) (opOut *rlwe.Ciphertext, err error) {
+
+	if len(selectors) == 0 {
+		return nil, fmt.Errorf("cannot CMUXTree: at least one selector is required")
+	}
+
+	if len(leaves) != 1<<len(selectors) {
+		return nil, fmt.Errorf("cannot CMUXTree: len(leaves) = %d does not match 2^len(selectors) = %d", len(leaves), 1<<len(selectors))
+	}
+
+	cur := leaves
+	for _, selector := range selectors {
+
+		selector := selector
+		pairs := len(cur) >> 1
+
+		if err = runBatch(utils.Min(workers, pairs), pairs, eval, func(eval *Evaluator, i int) error {
+			return eval.CMUX(selector, cur[2*i], cur[2*i+1], cur[2*i])
+		}); err != nil {
+			return nil, fmt.Errorf("cannot CMUXTree: %w", err)
+		}
+
+		for i := 0; i < pairs; i++ {
+			cur[i] = cur[2*i]
+		}
+
+		cur = cur[:pairs]
+	}
+
+	return cur[0], nil
+}
+
+// runBatch evaluates f on the job indices [0, jobs) with at most workers
+// concurrent goroutines, each holding its own shallow copy of the evaluator,
+// and returns the first error encountered.
+func runBatch(workers, jobs int, eval Evaluator, f func(eval *Evaluator, i int) error) (err error) {
+
+	if workers < 2 {
+		evalCpy := &eval
+		for i := 0; i < jobs; i++ {
+			if err = f(evalCpy, i); err != nil {
+				return
+			}
+		}
+		return
+	}
+
+	queue := make(chan int, jobs)
+	for i := 0; i < jobs; i++ {
+		queue <- i
+	}
+	close(queue)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Each worker uses its own shallow copy of the Evaluator, as the
+			// temporary buffers cannot be shared across goroutines.
+			evalCpy := eval.ShallowCopy()
+
+			for i := range queue {
+
+				mu.Lock()
+				stop := err != nil
+				mu.Unlock()
+
+				if stop {
+					return
+				}
+
+				if errF := f(evalCpy, i); errF != nil {
+					mu.Lock()
+					if err == nil {
+						err = errF
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return
+}
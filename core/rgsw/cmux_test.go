@@ -0,0 +1,116 @@
+package rgsw
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+func TestCMUXTree(t *testing.T) {
+
+	// <<<<!Insecure parameters!>>>>
+	params, err := rlwe.NewParametersFromLiteral(rlwe.ParametersLiteral{
+		LogN:    10,
+		LogQ:    []int{35, 20},
+		LogP:    []int{61, 61},
+		NTTFlag: true,
+	})
+
+	require.NoError(t, err)
+
+	kgen := rlwe.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+
+	encRGSW := NewEncryptor(params, sk)
+	encRLWE := rlwe.NewEncryptor(params, sk)
+	eval := NewEvaluator(params, nil)
+
+	// RGSW encryption of the bit b
+	encryptSelector := func(b int) (ct *Ciphertext) {
+		ct = NewCiphertext(params, params.MaxLevelQ(), params.MaxLevelP(), 0)
+		if b == 0 {
+			require.NoError(t, encRGSW.EncryptZero(ct))
+		} else {
+			pt := rlwe.NewPlaintext(params, params.MaxLevel())
+			setPlaintext(params, pt, 0) // X^{0} = 1
+			require.NoError(t, encRGSW.Encrypt(pt, ct))
+		}
+		return
+	}
+
+	scale := new(big.Int).SetUint64(params.Q()[0])
+
+	// RLWE encryption of Scale * X^{k}
+	encryptLeaf := func(k int) (ct *rlwe.Ciphertext) {
+		pt := rlwe.NewPlaintext(params, params.MaxLevel())
+		setPlaintext(params, pt, k)
+		params.RingQ().MulScalarBigint(pt.Value, scale, pt.Value)
+		ct = rlwe.NewCiphertext(params, 1, params.MaxLevelQ())
+		require.NoError(t, encRLWE.Encrypt(pt, ct))
+		return
+	}
+
+	// decrypts and returns the monomial degree of the leaf
+	decryptLeaf := func(ct *rlwe.Ciphertext) (k int) {
+
+		ptHave := rlwe.NewDecryptor(params, sk).DecryptNew(ct)
+
+		params.RingQ().INTT(ptHave.Value, ptHave.Value)
+
+		coeffs := make([]*big.Int, params.N())
+		for i := range coeffs {
+			coeffs[i] = new(big.Int)
+		}
+
+		params.RingQ().PolyToBigintCentered(ptHave.Value, 1, coeffs)
+
+		k = -1
+		for i := range coeffs {
+			bignum.DivRound(coeffs[i], scale, coeffs[i])
+			if coeffs[i].Uint64() == 1 {
+				require.Equal(t, -1, k) // single monomial expected
+				k = i
+			}
+		}
+
+		return
+	}
+
+	for _, workers := range []int{1, 4} {
+
+		// selector bits (1, 0, 1) -> leaf of index 5
+		selectors := []*Ciphertext{encryptSelector(1), encryptSelector(0), encryptSelector(1)}
+
+		leaves := make([]*rlwe.Ciphertext, 1<<len(selectors))
+		for k := range leaves {
+			leaves[k] = encryptLeaf(k)
+		}
+
+		res, err := eval.CMUXTree(selectors, leaves, workers)
+		require.NoError(t, err)
+		require.Equal(t, 5, decryptLeaf(res))
+	}
+
+	t.Run("ExternalProductBatch", func(t *testing.T) {
+
+		// RGSW(X^{1})
+		ptRGSW := rlwe.NewPlaintext(params, params.MaxLevel())
+		setPlaintext(params, ptRGSW, 1)
+		ctRGSW := NewCiphertext(params, params.MaxLevelQ(), params.MaxLevelP(), 0)
+		require.NoError(t, encRGSW.Encrypt(ptRGSW, ctRGSW))
+
+		cts := make([]*rlwe.Ciphertext, 8)
+		for k := range cts {
+			cts[k] = encryptLeaf(k)
+		}
+
+		require.NoError(t, eval.ExternalProductBatch(cts, ctRGSW, cts, 4))
+
+		for k := range cts {
+			require.Equal(t, k+1, decryptLeaf(cts[k]))
+		}
+	})
+}
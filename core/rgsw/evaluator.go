@@ -4,6 +4,7 @@ import (
 	"github.com/tuneinsight/lattigo/v6/core/rlwe"
 	"github.com/tuneinsight/lattigo/v6/ring"
 	"github.com/tuneinsight/lattigo/v6/ring/ringqp"
+	"github.com/tuneinsight/lattigo/v6/utils"
 )
 
 // Evaluator is a type for evaluating homomorphic operations involving RGSW ciphertexts.
@@ -72,13 +73,23 @@ func (eval Evaluator) ExternalProduct(op0 *rlwe.Ciphertext, op1 *Ciphertext, opO
 			}
 		}
 	} else {
-		eval.externalProductInPlaceMultipleP(levelQ, levelP, op0, op1, eval.BuffQP[1].Q, eval.BuffQP[1].P, eval.BuffQP[2].Q, eval.BuffQP[2].P)
+		eval.externalProductInPlaceMultipleP(levelQ, levelP, op0, op1, c0QP.Q, c0QP.P, c1QP.Q, c1QP.P)
 		eval.BasisExtender.ModDownQPtoQNTT(levelQ, levelP, c0QP.Q, c0QP.P, opOut.Value[0])
 		eval.BasisExtender.ModDownQPtoQNTT(levelQ, levelP, c1QP.Q, c1QP.P, opOut.Value[1])
 
 	}
 }
 
+// ExternalProductNew computes RLWE x RGSW -> RLWE and returns the result on a
+// new [rlwe.Ciphertext], at the level of op1 (or of op0, if smaller).
+// See [Evaluator.ExternalProduct].
+func (eval Evaluator) ExternalProductNew(op0 *rlwe.Ciphertext, op1 *Ciphertext) (opOut *rlwe.Ciphertext) {
+	opOut = rlwe.NewCiphertext(eval.GetRLWEParameters(), 1, utils.Min(op0.Level(), op1.LevelQ()))
+	*opOut.MetaData = *op0.MetaData
+	eval.ExternalProduct(op0, op1, opOut)
+	return
+}
+
 func (eval Evaluator) externalProduct32Bit(ct0 *rlwe.Ciphertext, rgsw *Ciphertext, c0, c1 ring.Poly) {
 
 	// rgsw = [(-as + P*w*m1 + e, a), (-bs + e, b + P*w*m1)]
@@ -80,8 +80,13 @@ func TestRGSW(t *testing.T) {
 		NewEncryptor(params, sk).Encrypt(ptRGSW, ctRGSW)
 		rlwe.NewEncryptor(params, sk).Encrypt(ptRLWE, ctRLWE)
 
+		eval := NewEvaluator(params, nil)
+
 		// X^{k0} * Scale * X^{k1}
-		NewEvaluator(params, nil).ExternalProduct(ctRLWE, ctRGSW, ctRLWE)
+		ctNew := eval.ExternalProductNew(ctRLWE, ctRGSW)
+		eval.ExternalProduct(ctRLWE, ctRGSW, ctRLWE)
+
+		require.True(t, ctRLWE.Equal(ctNew))
 
 		ptHave := rlwe.NewDecryptor(params, sk).DecryptNew(ctRLWE)
 
@@ -0,0 +1,190 @@
+package rlwe
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/ring/ringqp"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// AutomorphismSum evaluates opOut = sum(phi_g(ctIn)) over the provided Galois elements g,
+// where phi_g denotes the automorphism X -> X^{g}. The identity element (g = 1) contributes
+// ctIn itself. The input ciphertext is decomposed only once and each automorphism is
+// evaluated with hoisting, so the cost is one decomposition plus one lazy key-switch per
+// non-identity element.
+//
+// This method is the generic building block of the automorphism-sum family: [Evaluator.Trace],
+// [Evaluator.PartialTrace] and [Evaluator.TraceSum] evaluate structured sums with fewer keys
+// thanks to the doubling trick, while AutomorphismSum sums an arbitrary, explicit set of
+// automorphisms, e.g. a set of coset representatives.
+//
+// The evaluator must hold the Galois keys for the provided non-identity elements.
+// The method will return an error if the input and output ciphertexts degree is not one or
+// if the list of Galois elements is empty.
+func (eval Evaluator) AutomorphismSum(ctIn *Ciphertext, galEls []uint64, opOut *Ciphertext) (err error) {
+
+	if ctIn.Degree() != 1 || opOut.Degree() != 1 {
+		return fmt.Errorf("ctIn.Degree() != 1 or opOut.Degree() != 1")
+	}
+
+	if len(galEls) == 0 {
+		return fmt.Errorf("cannot AutomorphismSum: galEls is empty")
+	}
+
+	params := eval.GetRLWEParameters()
+
+	levelQ := utils.Min(ctIn.Level(), opOut.Level())
+	levelP := params.PCount() - 1
+
+	ringQP := params.RingQP().AtLevel(levelQ, levelP)
+
+	ringQ := ringQP.RingQ
+
+	opOut.Resize(opOut.Degree(), levelQ)
+	*opOut.MetaData = *ctIn.MetaData
+
+	ctInNTT, err := NewCiphertextAtLevelFromPoly(levelQ, eval.BuffCt.Value[:2])
+
+	// Sanity check, this error should not happen unless the
+	// evaluator's buffer has been improperly tempered with.
+	if err != nil {
+		panic(err)
+	}
+
+	ctInNTT.MetaData = &MetaData{}
+	ctInNTT.IsNTT = true
+
+	if !ctIn.IsNTT {
+		ringQ.NTT(ctIn.Value[0], ctInNTT.Value[0])
+		ringQ.NTT(ctIn.Value[1], ctInNTT.Value[1])
+	} else {
+		ctInNTT.Value[0].CopyLvl(levelQ, ctIn.Value[0])
+		ctInNTT.Value[1].CopyLvl(levelQ, ctIn.Value[1])
+	}
+
+	// Accumulator mod QP (i.e. opOut Mod QP)
+	accQP := &Element[ringqp.Poly]{Value: []ringqp.Poly{eval.BuffQP[2], eval.BuffQP[3]}}
+	accQP.MetaData = ctInNTT.MetaData
+
+	// Buffer mod QP (i.e. to store the result of lazy gadget products)
+	cQP := &Element[ringqp.Poly]{Value: []ringqp.Poly{eval.BuffQP[4], eval.BuffQP[5]}}
+	cQP.MetaData = ctInNTT.MetaData
+
+	// Decomposes the input ciphertext once for all the automorphisms
+	eval.DecomposeNTT(levelQ, levelP, levelP+1, ctInNTT.Value[1], true, eval.BuffDecompQP)
+
+	identity := false
+	first := true
+	for _, galEl := range galEls {
+
+		// The identity automorphism contributes the input itself,
+		// added after the division by P.
+		if galEl == 1 {
+			identity = true
+			continue
+		}
+
+		if first {
+			if err = eval.AutomorphismHoistedLazy(levelQ, ctInNTT, eval.BuffDecompQP, galEl, accQP); err != nil {
+				return fmt.Errorf("cannot AutomorphismSum: %w", err)
+			}
+			first = false
+		} else {
+			if err = eval.AutomorphismHoistedLazy(levelQ, ctInNTT, eval.BuffDecompQP, galEl, cQP); err != nil {
+				return fmt.Errorf("cannot AutomorphismSum: %w", err)
+			}
+			ringQP.Add(accQP.Value[0], cQP.Value[0], accQP.Value[0])
+			ringQP.Add(accQP.Value[1], cQP.Value[1], accQP.Value[1])
+		}
+	}
+
+	if first {
+		// Only the identity element was provided
+		opOut.Value[0].CopyLvl(levelQ, ctInNTT.Value[0])
+		opOut.Value[1].CopyLvl(levelQ, ctInNTT.Value[1])
+	} else {
+
+		// opOut = accQP/P
+		eval.BasisExtender.ModDownQPtoQNTT(levelQ, levelP, accQP.Value[0].Q, accQP.Value[0].P, opOut.Value[0])
+		eval.BasisExtender.ModDownQPtoQNTT(levelQ, levelP, accQP.Value[1].Q, accQP.Value[1].P, opOut.Value[1])
+
+		if identity {
+			ringQ.Add(opOut.Value[0], ctInNTT.Value[0], opOut.Value[0])
+			ringQ.Add(opOut.Value[1], ctInNTT.Value[1], opOut.Value[1])
+		}
+	}
+
+	if !ctIn.IsNTT {
+		ringQ.INTT(opOut.Value[0], opOut.Value[0])
+		ringQ.INTT(opOut.Value[1], opOut.Value[1])
+	}
+
+	return
+}
+
+// PartialTrace evaluates the partial trace sum(phi_{g^j}(ctIn)) for 0 <= j < 2^{end-start},
+// where g = 5^{2^{start}}, i.e. the sum of ctIn over the automorphism subgroup generated by g.
+// The evaluation uses the doubling trick, i.e. end-start automorphisms and as many Galois keys,
+// which are listed by [GaloisElementsForPartialTrace] and must be loaded in the evaluator.
+//
+// For start=logN and end=LogN-1, the partial trace zeroes every coefficient whose degree is
+// not a multiple of N/2^{logN+1} and multiplies the remaining ones by 2^{end-start}: it is the
+// projection onto the sub-ring evaluated by [Evaluator.Trace], without the normalization by
+// the inverse of the subgroup order.
+//
+// The method will return an error if the input and output ciphertexts degree is not one or
+// if the range [start, end] is not contained in [0, LogN-1].
+func (eval Evaluator) PartialTrace(ctIn *Ciphertext, start, end int, opOut *Ciphertext) (err error) {
+
+	if ctIn.Degree() != 1 || opOut.Degree() != 1 {
+		return fmt.Errorf("ctIn.Degree() != 1 or opOut.Degree() != 1")
+	}
+
+	params := eval.GetRLWEParameters()
+
+	if start < 0 || end > params.LogN()-1 || start > end {
+		return fmt.Errorf("invalid range: [start, end] = [%d, %d] is not contained in [0, %d]", start, end, params.LogN()-1)
+	}
+
+	levelQ := utils.Min(ctIn.Level(), opOut.Level())
+
+	opOut.Resize(opOut.Degree(), levelQ)
+
+	if opOut != ctIn {
+		*opOut.MetaData = *ctIn.MetaData
+		opOut.Value[0].CopyLvl(levelQ, ctIn.Value[0])
+		opOut.Value[1].CopyLvl(levelQ, ctIn.Value[1])
+	}
+
+	ringQ := params.RingQ().AtLevel(levelQ)
+
+	ctTmp := NewCiphertext(params, 1, levelQ)
+
+	// Doubling trick: after the i-th step opOut holds the sum over the subgroup generated by 5^{2^{start}}
+	// of order 2^{i+1-start}, as phi_{5^{2^{i+1}}} = phi_{5^{2^{i}}} o phi_{5^{2^{i}}}
+	for i := start; i < end; i++ {
+
+		if err = eval.Automorphism(opOut, params.GaloisElement(1<<i), ctTmp); err != nil {
+			return fmt.Errorf("cannot PartialTrace: %w", err)
+		}
+
+		ringQ.Add(opOut.Value[0], ctTmp.Value[0], opOut.Value[0])
+		ringQ.Add(opOut.Value[1], ctTmp.Value[1], opOut.Value[1])
+	}
+
+	return
+}
+
+// GaloisElementsForPartialTrace returns the list of Galois elements necessary to apply the
+// method [Evaluator.PartialTrace] with the range [start, end], i.e. 5^{2^{i}} for start <= i < end.
+func GaloisElementsForPartialTrace(params ParameterProvider, start, end int) (galEls []uint64) {
+
+	p := params.GetRLWEParameters()
+
+	galEls = make([]uint64, 0, end-start)
+	for i := start; i < end; i++ {
+		galEls = append(galEls, p.GaloisElement(1<<i))
+	}
+
+	return
+}
@@ -0,0 +1,93 @@
+package rlwe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomorphismSum(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN:    10,
+		LogQ:    []int{60},
+		LogP:    []int{60},
+		NTTFlag: true,
+	})
+	require.NoError(t, err)
+
+	tc, err := NewTestContext(params)
+	require.NoError(t, err)
+
+	sk := tc.sk
+	enc := tc.enc
+	dec := tc.dec
+
+	level := params.MaxLevel()
+	ringQ := params.RingQ().AtLevel(level)
+
+	noise := float64(params.LogN() + 2)
+
+	pt := genPlaintextNTT(params, level, 1<<40)
+
+	ct, err := enc.EncryptNew(pt)
+	require.NoError(t, err)
+
+	t.Run(testString(params, level, params.MaxLevelP(), 0, "AutomorphismSum"), func(t *testing.T) {
+
+		// Identity element and a few rotations
+		galEls := append([]uint64{1}, params.GaloisElements([]int{1, 2, 3})...)
+
+		eval := NewEvaluator(params, NewMemEvaluationKeySet(nil, tc.kgen.GenGaloisKeysNew(galEls, sk)...))
+
+		opOut := NewCiphertext(params, 1, level)
+		require.NoError(t, eval.AutomorphismSum(ct, galEls, opOut))
+
+		// Reference: sum of the automorphisms of the plaintext
+		ref := NewPlaintext(params, level)
+		tmp := NewPlaintext(params, level)
+		for _, galEl := range galEls {
+			ringQ.AutomorphismNTT(pt.Value, galEl, tmp.Value)
+			ringQ.Add(ref.Value, tmp.Value, ref.Value)
+		}
+
+		ptDec := dec.DecryptNew(opOut)
+		ringQ.Sub(ptDec.Value, ref.Value, ptDec.Value)
+		ringQ.INTT(ptDec.Value, ptDec.Value)
+		require.GreaterOrEqual(t, noise, ringQ.Log2OfStandardDeviation(ptDec.Value))
+
+		// Empty list of Galois elements is rejected
+		require.Error(t, eval.AutomorphismSum(ct, nil, opOut))
+	})
+
+	t.Run(testString(params, level, params.MaxLevelP(), 0, "PartialTrace"), func(t *testing.T) {
+
+		start, end := 2, 5
+
+		galEls := GaloisElementsForPartialTrace(params, start, end)
+		require.Equal(t, end-start, len(galEls))
+
+		eval := NewEvaluator(params, NewMemEvaluationKeySet(nil, tc.kgen.GenGaloisKeysNew(galEls, sk)...))
+
+		opOut := NewCiphertext(params, 1, level)
+		require.NoError(t, eval.PartialTrace(ct, start, end, opOut))
+
+		// Reference: doubling trick on the plaintext
+		ref := NewPlaintext(params, level)
+		tmp := NewPlaintext(params, level)
+		ref.Value.CopyLvl(level, pt.Value)
+		for i := start; i < end; i++ {
+			ringQ.AutomorphismNTT(ref.Value, params.GaloisElement(1<<i), tmp.Value)
+			ringQ.Add(ref.Value, tmp.Value, ref.Value)
+		}
+
+		ptDec := dec.DecryptNew(opOut)
+		ringQ.Sub(ptDec.Value, ref.Value, ptDec.Value)
+		ringQ.INTT(ptDec.Value, ptDec.Value)
+		require.GreaterOrEqual(t, noise, ringQ.Log2OfStandardDeviation(ptDec.Value))
+
+		// Invalid range is rejected
+		require.Error(t, eval.PartialTrace(ct, -1, end, opOut))
+		require.Error(t, eval.PartialTrace(ct, start, params.LogN(), opOut))
+	})
+}
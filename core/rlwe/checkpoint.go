@@ -0,0 +1,237 @@
+package rlwe
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/tuneinsight/lattigo/v6/utils"
+	"github.com/tuneinsight/lattigo/v6/utils/buffer"
+)
+
+// Checkpoint is a serializable snapshot of the state of a long-running encrypted
+// computation: a set of ciphertexts indexed by name, together with a user-defined
+// progress marker and the fingerprint of the parameters under which the snapshot
+// was taken. A job that must survive restarts can periodically write a Checkpoint
+// (see [Checkpoint.WriteTo]) and, after a restart, restore it (see
+// [Checkpoint.ReadFrom]) and resume from the recorded step instead of recomputing
+// the work done so far.
+//
+// The serialization is a single self-delimiting blob and the restoration is
+// all-or-nothing: a receiver on which ReadFrom returns an error is left unchanged.
+type Checkpoint struct {
+
+	// Step is a user-defined progress marker (e.g. an iteration counter) stored
+	// and restored along with the ciphertexts.
+	Step uint64
+
+	// ParametersFingerprint is the fingerprint (see [Parameters.Fingerprint]) of
+	// the parameters under which the checkpoint was taken. It is checked by
+	// [Checkpoint.CheckParameters] after restoration. A zero fingerprint
+	// (manually assembled checkpoint) is never checked.
+	ParametersFingerprint uint64
+
+	// Ciphertexts is the set of checkpointed ciphertexts, indexed by name.
+	Ciphertexts map[string]*Ciphertext
+}
+
+// NewCheckpoint instantiates an empty [Checkpoint] at the given step, tagged with
+// the fingerprint of the provided parameters.
+func NewCheckpoint(params ParameterProvider, step uint64) *Checkpoint {
+	return &Checkpoint{
+		Step:                  step,
+		ParametersFingerprint: params.GetRLWEParameters().Fingerprint(),
+		Ciphertexts:           map[string]*Ciphertext{},
+	}
+}
+
+// Set stores the ciphertext under the given name, replacing any previously
+// stored ciphertext of the same name. The ciphertext is stored by reference and
+// must not be modified between Set and the serialization of the checkpoint.
+func (c *Checkpoint) Set(name string, ct *Ciphertext) {
+	c.Ciphertexts[name] = ct
+}
+
+// Get retrieves the ciphertext stored under the given name.
+func (c *Checkpoint) Get(name string) (*Ciphertext, error) {
+	ct, ok := c.Ciphertexts[name]
+	if !ok {
+		return nil, fmt.Errorf("cannot Get: checkpoint holds no ciphertext named %q", name)
+	}
+	return ct, nil
+}
+
+// Names returns the sorted list of the names of the checkpointed ciphertexts.
+func (c *Checkpoint) Names() []string {
+	return utils.GetSortedKeys(c.Ciphertexts)
+}
+
+// CheckParameters returns a [KeyParametersMismatchError] if the checkpoint
+// carries a non-zero parameters fingerprint different from the fingerprint of
+// the provided parameters, i.e., if the checkpoint was taken under different
+// parameters than the ones it is restored under.
+func (c *Checkpoint) CheckParameters(params ParameterProvider) error {
+	return checkKeyFingerprint(params.GetRLWEParameters().Fingerprint(), "checkpoint", c.ParametersFingerprint)
+}
+
+// BinarySize returns the serialized size of the object in bytes.
+func (c *Checkpoint) BinarySize() (size int) {
+
+	size = 8 + 8 + 4 // Step + ParametersFingerprint + #Ciphertexts
+
+	for name, ct := range c.Ciphertexts {
+		size += 4 + len(name)
+		size += ct.BinarySize()
+	}
+
+	return
+}
+
+// WriteTo writes the object on an io.Writer. It implements the io.WriterTo
+// interface, and will write exactly object.BinarySize() bytes on w.
+//
+// The ciphertexts are written in the sorted order of their names, so that two
+// checkpoints holding the same content serialize to the same bytes.
+//
+// Unless w implements the buffer.Writer interface (see lattigo/utils/buffer/writer.go),
+// it will be wrapped into a bufio.Writer. Since this requires allocations, it
+// is preferable to pass a buffer.Writer directly:
+//
+//   - When writing multiple times to a io.Writer, it is preferable to first wrap the
+//     io.Writer in a pre-allocated bufio.Writer.
+//   - When writing to a pre-allocated var b []byte, it is preferable to pass
+//     buffer.NewBuffer(b) as w (see lattigo/utils/buffer/buffer.go).
+func (c *Checkpoint) WriteTo(w io.Writer) (n int64, err error) {
+	switch w := w.(type) {
+	case buffer.Writer:
+
+		var inc int64
+
+		if inc, err = buffer.WriteUint64(w, c.Step); err != nil {
+			return n + inc, err
+		}
+		n += inc
+
+		if inc, err = buffer.WriteUint64(w, c.ParametersFingerprint); err != nil {
+			return n + inc, err
+		}
+		n += inc
+
+		if inc, err = buffer.WriteUint32(w, uint32(len(c.Ciphertexts))); err != nil {
+			return n + inc, err
+		}
+		n += inc
+
+		for _, name := range c.Names() {
+
+			if inc, err = buffer.WriteUint32(w, uint32(len(name))); err != nil {
+				return n + inc, err
+			}
+			n += inc
+
+			if inc, err = buffer.Write(w, []byte(name)); err != nil {
+				return n + inc, err
+			}
+			n += inc
+
+			if inc, err = c.Ciphertexts[name].WriteTo(w); err != nil {
+				return n + inc, err
+			}
+			n += inc
+		}
+
+		return
+
+	default:
+		return c.WriteTo(bufio.NewWriter(w))
+	}
+}
+
+// ReadFrom reads on the object from an io.Writer. It implements the
+// io.ReaderFrom interface.
+//
+// The restoration is all-or-nothing: the receiver is only updated once the
+// whole checkpoint has been read successfully, and is left unchanged if an
+// error occurs.
+//
+// Unless r implements the buffer.Reader interface (see lattigo/utils/buffer/reader.go),
+// it will be wrapped into a bufio.Reader. Since this requires allocation, it
+// is preferable to pass a buffer.Reader directly:
+//
+//   - When reading multiple values from a io.Reader, it is preferable to first
+//     first wrap io.Reader in a pre-allocated bufio.Reader.
+//   - When reading from a var b []byte, it is preferable to pass a buffer.NewBuffer(b)
+//     as w (see lattigo/utils/buffer/buffer.go).
+func (c *Checkpoint) ReadFrom(r io.Reader) (n int64, err error) {
+	switch r := r.(type) {
+	case buffer.Reader:
+
+		var inc int64
+
+		var step, fingerprint uint64
+
+		if inc, err = buffer.ReadUint64(r, &step); err != nil {
+			return n + inc, err
+		}
+		n += inc
+
+		if inc, err = buffer.ReadUint64(r, &fingerprint); err != nil {
+			return n + inc, err
+		}
+		n += inc
+
+		var size uint32
+		if inc, err = buffer.ReadUint32(r, &size); err != nil {
+			return n + inc, err
+		}
+		n += inc
+
+		cts := make(map[string]*Ciphertext, size)
+
+		for i := 0; i < int(size); i++ {
+
+			var nameLen uint32
+			if inc, err = buffer.ReadUint32(r, &nameLen); err != nil {
+				return n + inc, err
+			}
+			n += inc
+
+			name := make([]byte, nameLen)
+			if inc, err = buffer.Read(r, name); err != nil {
+				return n + inc, err
+			}
+			n += inc
+
+			ct := new(Ciphertext)
+			if inc, err = ct.ReadFrom(r); err != nil {
+				return n + inc, err
+			}
+			n += inc
+
+			cts[string(name)] = ct
+		}
+
+		c.Step = step
+		c.ParametersFingerprint = fingerprint
+		c.Ciphertexts = cts
+
+		return
+
+	default:
+		return c.ReadFrom(bufio.NewReader(r))
+	}
+}
+
+// MarshalBinary encodes the object into a binary form on a newly allocated slice of bytes.
+func (c *Checkpoint) MarshalBinary() (p []byte, err error) {
+	buf := buffer.NewBufferSize(c.BinarySize())
+	_, err = c.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary decodes a slice of bytes generated by
+// MarshalBinary or WriteTo on the object.
+func (c *Checkpoint) UnmarshalBinary(p []byte) (err error) {
+	_, err = c.ReadFrom(buffer.NewBuffer(p))
+	return
+}
@@ -0,0 +1,73 @@
+package rlwe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/utils/buffer"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestCheckpoint(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	prng, err := sampling.NewPRNG()
+	require.NoError(t, err)
+
+	checkpoint := NewCheckpoint(params, 17)
+	checkpoint.Set("acc", NewCiphertextRandom(prng, params, 1, params.MaxLevel()))
+	checkpoint.Set("state", NewCiphertextRandom(prng, params, 1, 0))
+
+	require.Equal(t, []string{"acc", "state"}, checkpoint.Names())
+
+	_, err = checkpoint.Get("missing")
+	require.Error(t, err)
+
+	t.Run("Checkpoint/Serialization", func(t *testing.T) {
+		buffer.RequireSerializerCorrect(t, checkpoint)
+	})
+
+	t.Run("Checkpoint/Restore", func(t *testing.T) {
+
+		data, err := checkpoint.MarshalBinary()
+		require.NoError(t, err)
+
+		restored := new(Checkpoint)
+		require.NoError(t, restored.UnmarshalBinary(data))
+		require.NoError(t, restored.CheckParameters(params))
+
+		require.Equal(t, uint64(17), restored.Step)
+
+		ct, err := restored.Get("acc")
+		require.NoError(t, err)
+		require.True(t, checkpoint.Ciphertexts["acc"].Equal(ct))
+
+		// A truncated blob leaves the receiver unchanged.
+		require.Error(t, restored.UnmarshalBinary(data[:len(data)>>1]))
+		require.Equal(t, uint64(17), restored.Step)
+		require.Equal(t, []string{"acc", "state"}, restored.Names())
+	})
+
+	t.Run("Checkpoint/CheckParameters", func(t *testing.T) {
+
+		paramsOther, err := NewParametersFromLiteral(ParametersLiteral{
+			LogN: 10,
+			LogQ: []int{45, 45, 45},
+			LogP: []int{60},
+		})
+		require.NoError(t, err)
+
+		require.Error(t, checkpoint.CheckParameters(paramsOther))
+
+		// A zero fingerprint is never checked.
+		checkpoint.ParametersFingerprint = 0
+		require.NoError(t, checkpoint.CheckParameters(paramsOther))
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/utils"
 	"github.com/tuneinsight/lattigo/v6/utils/sampling"
 )
 
@@ -36,6 +37,50 @@ func NewCiphertextAtLevelFromPoly(level int, poly []ring.Poly) (*Ciphertext, err
 	return &Ciphertext{*operand}, nil
 }
 
+// CiphertextBufferSize returns the number of bytes required by
+// [NewCiphertextFromBuffer] to back a [Ciphertext] of the given degree and level.
+func CiphertextBufferSize(params ParameterProvider, degree, level int) int {
+	return (degree + 1) * ring.PolyBufferSize(params.GetRLWEParameters().N(), level)
+}
+
+// NewCiphertextFromBuffer constructs a new [Ciphertext] of the given degree and
+// level whose polynomials alias the caller-provided buffer, without copies (see
+// [ring.NewPolyFromBuffer]). The buffer can live outside of the Go heap, e.g.
+// in an mmap'd shared-memory segment, so that a multi-process pipeline can
+// exchange ciphertexts without serialization. Only the coefficients are backed
+// by the buffer: the MetaData is allocated on the heap and set to the
+// Parameters default value, and must be carried across processes separately.
+//
+// The buffer must be 8-byte aligned and at least
+// [CiphertextBufferSize](params, degree, level) bytes long.
+func NewCiphertextFromBuffer(params ParameterProvider, degree, level int, buff []byte) (ct *Ciphertext, err error) {
+
+	p := params.GetRLWEParameters()
+
+	if size := CiphertextBufferSize(params, degree, level); len(buff) < size {
+		return nil, fmt.Errorf("cannot NewCiphertextFromBuffer: buffer must be at least %d bytes but is %d", size, len(buff))
+	}
+
+	N := p.N()
+	polySize := ring.PolyBufferSize(N, level)
+
+	Value := make([]ring.Poly, degree+1)
+	for i := range Value {
+		if Value[i], err = ring.NewPolyFromBuffer(N, level, buff[i*polySize:(i+1)*polySize]); err != nil {
+			return nil, fmt.Errorf("cannot NewCiphertextFromBuffer: %w", err)
+		}
+	}
+
+	return &Ciphertext{Element[ring.Poly]{
+		Value: Value,
+		MetaData: &MetaData{
+			CiphertextMetaData: CiphertextMetaData{
+				IsNTT: p.NTTFlag(),
+			},
+		},
+	}}, nil
+}
+
 // NewCiphertextRandom generates a new uniformly distributed [Ciphertext] of degree, level.
 func NewCiphertextRandom(prng sampling.PRNG, params ParameterProvider, degree, level int) (ciphertext *Ciphertext) {
 	ciphertext = NewCiphertext(params, degree, level)
@@ -69,3 +114,22 @@ func (ct Ciphertext) Copy(ctxCopy *Ciphertext) {
 func (ct Ciphertext) Equal(other *Ciphertext) bool {
 	return ct.Element.Equal(&other.Element)
 }
+
+// Aliases returns true if the target ciphertext and other share at least one
+// backing coefficient array. Two distinct elements can alias, e.g. after a
+// shallow struct copy; such sharing is invisible to methods that only compare
+// element pointers.
+func (ct Ciphertext) Aliases(other *Element[ring.Poly]) bool {
+	for i := range ct.Value {
+		for j := range other.Value {
+			for _, c0 := range ct.Value[i].Coeffs {
+				for _, c1 := range other.Value[j].Coeffs {
+					if utils.Alias1D(c0, c1) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package rlwe
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestCiphertextFromBuffer(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	degree, level := 1, params.MaxLevel()
+
+	// 8-byte aligned backing memory, standing in for e.g. an mmap'd segment.
+	backing := make([]uint64, CiphertextBufferSize(params, degree, level)/8)
+	/* #nosec G103 -- test-only reinterpretation of an aligned uint64 slice as bytes */
+	buff := unsafe.Slice((*byte)(unsafe.Pointer(&backing[0])), 8*len(backing))
+
+	ct, err := NewCiphertextFromBuffer(params, degree, level, buff)
+	require.NoError(t, err)
+	require.Equal(t, degree, ct.Degree())
+	require.Equal(t, level, ct.Level())
+
+	prng, err := sampling.NewPRNG()
+	require.NoError(t, err)
+
+	PopulateElementRandom(prng, params, ct.El())
+
+	// A second view over the same buffer sees the same coefficients without
+	// any copy, as a second process mapping the same segment would.
+	ctShared, err := NewCiphertextFromBuffer(params, degree, level, buff)
+	require.NoError(t, err)
+	require.True(t, ct.Equal(ctShared))
+
+	// The view is usable as a regular ciphertext.
+	kgen := NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	enc := NewEncryptor(params, sk)
+	dec := NewDecryptor(params, sk)
+
+	pt := NewPlaintext(params, level)
+	require.NoError(t, enc.Encrypt(pt, ct))
+	*ctShared.MetaData = *ct.MetaData
+	require.NotPanics(t, func() { dec.Decrypt(ctShared, pt) })
+
+	t.Run("Errors", func(t *testing.T) {
+		_, err := NewCiphertextFromBuffer(params, degree, level, buff[:len(buff)-1])
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,176 @@
+package rlwe
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/ring/ringqp"
+	"github.com/tuneinsight/lattigo/v6/utils/buffer"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+// CompressedCiphertext is a seeded (half-size) symmetric-key ciphertext:
+// only the polynomial -a*s + m + e is stored, along with the 32-byte seed
+// of the PRNG from which the uniform polynomial a was sampled. The full
+// ciphertext (-a*s + m + e, a) is regenerated with
+// [CompressedCiphertext.Expand] before decryption or evaluation, halving
+// the upload size of client-to-server workloads.
+//
+// Compressed ciphertexts can only be produced with a secret key, see
+// [Encryptor.EncryptCompressed].
+type CompressedCiphertext struct {
+	Element[ring.Poly]
+	Seed []byte
+}
+
+// NewCompressedCiphertext returns a new [CompressedCiphertext] with zero
+// values and an associated MetaData set to the Parameters default value.
+func NewCompressedCiphertext(params ParameterProvider, level ...int) *CompressedCiphertext {
+	return &CompressedCiphertext{Element: *NewElement(params, 0, level...)}
+}
+
+// Expand regenerates the uniform polynomial a from the stored seed and
+// returns the full degree-1 [Ciphertext] (-a*s + m + e, a). The returned
+// [Ciphertext] does not share its backing arrays with the receiver.
+func (ct CompressedCiphertext) Expand(params ParameterProvider) (*Ciphertext, error) {
+
+	prng, err := sampling.NewKeyedPRNG(ct.Seed)
+	if err != nil {
+		return nil, fmt.Errorf("sampling.NewKeyedPRNG: %w", err)
+	}
+
+	p := params.GetRLWEParameters()
+
+	// The seeded sampler is invoked exactly as during the encryption (see
+	// Encryptor.EncryptZero with a degree-0 ciphertext). The raw uniform
+	// sample is a valid representation in both the NTT and the coefficient
+	// domain, so no domain switch is required.
+	a := p.RingQ().AtLevel(ct.Level()).NewPoly()
+	ringqp.NewUniformSampler(prng, *p.RingQP()).AtLevel(ct.Level(), -1).Read(ringqp.Poly{Q: a})
+
+	meta := *ct.MetaData
+
+	return &Ciphertext{Element[ring.Poly]{
+		Value:    []ring.Poly{*ct.Value[0].CopyNew(), a},
+		MetaData: &meta,
+	}}, nil
+}
+
+// CopyNew creates a deep copy of the target [CompressedCiphertext] and returns it.
+func (ct CompressedCiphertext) CopyNew() *CompressedCiphertext {
+	return &CompressedCiphertext{Element: *ct.Element.CopyNew(), Seed: slices.Clone(ct.Seed)}
+}
+
+// Equal performs a deep equal.
+func (ct CompressedCiphertext) Equal(other *CompressedCiphertext) bool {
+	return ct.Element.Equal(&other.Element) && slices.Equal(ct.Seed, other.Seed)
+}
+
+// BinarySize returns the serialized size of the object in bytes.
+func (ct CompressedCiphertext) BinarySize() int {
+	return 4 + len(ct.Seed) + ct.Element.BinarySize()
+}
+
+// WriteTo writes the object on an [io.Writer]. It implements the
+// [io.WriterTo] interface, and will write exactly object.BinarySize() bytes on w.
+//
+// Unless w implements the [buffer.Writer] interface (see lattigo/utils/buffer/writer.go),
+// it will be wrapped into a [bufio.Writer]. Since this requires allocations, it
+// is preferable to pass a [buffer.Writer] directly:
+//
+//   - When writing multiple times to a [io.Writer], it is preferable to first wrap the
+//     [io.Writer] in a pre-allocated [bufio.Writer].
+//   - When writing to a pre-allocated var b []byte, it is preferable to pass
+//     buffer.NewBuffer(b) as w (see lattigo/utils/buffer/buffer.go).
+func (ct CompressedCiphertext) WriteTo(w io.Writer) (n int64, err error) {
+	switch w := w.(type) {
+	case buffer.Writer:
+
+		var inc int64
+
+		if inc, err = buffer.WriteUint32(w, uint32(len(ct.Seed))); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		if inc, err = buffer.Write(w, ct.Seed); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		if inc, err = ct.Element.WriteTo(w); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		return n, w.Flush()
+	default:
+		return ct.WriteTo(bufio.NewWriter(w))
+	}
+}
+
+// ReadFrom reads on the object from an [io.Writer]. It implements the
+// [io.ReaderFrom] interface.
+//
+// Unless r implements the [buffer.Reader] interface (see see lattigo/utils/buffer/reader.go),
+// it will be wrapped into a [bufio.Reader]. Since this requires allocation, it
+// is preferable to pass a [buffer.Reader] directly:
+//
+//   - When reading multiple values from a [io.Reader], it is preferable to first
+//     first wrap [io.Reader] in a pre-allocated [bufio.Reader].
+//   - When reading from a var b []byte, it is preferable to pass a buffer.NewBuffer(b)
+//     as w (see lattigo/utils/buffer/buffer.go).
+func (ct *CompressedCiphertext) ReadFrom(r io.Reader) (n int64, err error) {
+	switch r := r.(type) {
+	case buffer.Reader:
+
+		var inc int64
+
+		var seedLen uint32
+		if inc, err = buffer.ReadUint32(r, &seedLen); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		ct.Seed = nil
+		if seedLen > 0 {
+			ct.Seed = make([]byte, seedLen)
+			if inc, err = buffer.Read(r, ct.Seed); err != nil {
+				return n + inc, err
+			}
+
+			n += inc
+		}
+
+		if inc, err = ct.Element.ReadFrom(r); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		return n, nil
+	default:
+		return ct.ReadFrom(bufio.NewReader(r))
+	}
+}
+
+// MarshalBinary encodes the object into a binary form on a newly allocated slice of bytes.
+func (ct CompressedCiphertext) MarshalBinary() (p []byte, err error) {
+	buf := buffer.NewBufferSize(ct.BinarySize())
+	_, err = ct.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary decodes a slice of bytes generated by
+// [CompressedCiphertext.MarshalBinary] or [CompressedCiphertext.WriteTo] on the object.
+func (ct *CompressedCiphertext) UnmarshalBinary(p []byte) (err error) {
+	_, err = ct.ReadFrom(buffer.NewBuffer(p))
+	return
+}
@@ -24,6 +24,10 @@ func NewDecryptor(params ParameterProvider, sk *SecretKey) *Decryptor {
 		panic(fmt.Errorf("cannot NewDecryptor: secret_key ring degree does not match parameters ring degree"))
 	}
 
+	if err := checkKeyFingerprint(p.Fingerprint(), "sk", sk.ParametersFingerprint); err != nil {
+		panic(fmt.Errorf("cannot NewDecryptor: %w", err))
+	}
+
 	return &Decryptor{
 		params: *p,
 		ringQ:  p.RingQ(),
@@ -0,0 +1,117 @@
+package rlwe
+
+import (
+	"fmt"
+	"time"
+)
+
+// DecryptionRequest carries the metadata of a decryption request, passed to the
+// [DecryptionPolicy] before the secret key is used.
+type DecryptionRequest struct {
+	// Tags are the caller-provided attributes of the request (e.g. purpose,
+	// requester identity), interpreted by the policy.
+	Tags map[string]string
+	// Degree and Level are the degree and level of the ciphertext.
+	Degree, Level int
+	// MetaData is the metadata of the ciphertext.
+	MetaData *MetaData
+}
+
+// DecryptionPolicy authorizes decryption requests: Authorize is invoked with the
+// request metadata before each decryption and a non-nil error denies the request,
+// in which case the secret key is never used.
+type DecryptionPolicy interface {
+	Authorize(request DecryptionRequest) error
+}
+
+// DecryptionPolicyFunc adapts a function to the [DecryptionPolicy] interface.
+type DecryptionPolicyFunc func(request DecryptionRequest) error
+
+// Authorize implements the [DecryptionPolicy] interface.
+func (f DecryptionPolicyFunc) Authorize(request DecryptionRequest) error {
+	return f(request)
+}
+
+// DecryptionAuditEntry is the record passed to the audit hook of a
+// [GatedDecryptor] after each decryption request, allowed or denied.
+type DecryptionAuditEntry struct {
+	// Request is the audited decryption request.
+	Request DecryptionRequest
+	// Time is the time at which the request was evaluated.
+	Time time.Time
+	// Allowed is true if the policy authorized the request.
+	Allowed bool
+	// Err is the error returned by the policy when the request was denied.
+	Err error
+}
+
+// GatedDecryptor wraps a [Decryptor] behind a [DecryptionPolicy]: every
+// decryption request is passed to the policy along with the caller-provided
+// tags and the ciphertext metadata, and is only forwarded to the underlying
+// decryptor if the policy authorizes it. An optional audit hook is invoked
+// with the outcome of every request, supporting deployments where decryption
+// is a controlled, logged event.
+type GatedDecryptor struct {
+	dec    *Decryptor
+	policy DecryptionPolicy
+	audit  func(DecryptionAuditEntry)
+}
+
+// NewGatedDecryptor instantiates a new [GatedDecryptor] wrapping the given
+// decryptor. The policy must be non-nil; audit can be nil, in which case no
+// audit hook is invoked.
+func NewGatedDecryptor(dec *Decryptor, policy DecryptionPolicy, audit func(DecryptionAuditEntry)) (*GatedDecryptor, error) {
+
+	if policy == nil {
+		return nil, fmt.Errorf("cannot NewGatedDecryptor: policy cannot be nil")
+	}
+
+	return &GatedDecryptor{dec: dec, policy: policy, audit: audit}, nil
+}
+
+// GetRLWEParameters returns the underlying [Parameters].
+func (d GatedDecryptor) GetRLWEParameters() *Parameters {
+	return d.dec.GetRLWEParameters()
+}
+
+// Decrypt decrypts the ciphertext on the provided plaintext if the policy
+// authorizes the request, and returns the policy error otherwise.
+func (d GatedDecryptor) Decrypt(ct *Ciphertext, pt *Plaintext, tags map[string]string) (err error) {
+
+	request := DecryptionRequest{
+		Tags:     tags,
+		Degree:   ct.Degree(),
+		Level:    ct.Level(),
+		MetaData: ct.MetaData,
+	}
+
+	err = d.policy.Authorize(request)
+
+	if d.audit != nil {
+		d.audit(DecryptionAuditEntry{Request: request, Time: time.Now(), Allowed: err == nil, Err: err})
+	}
+
+	if err != nil {
+		return fmt.Errorf("cannot Decrypt: request denied by policy: %w", err)
+	}
+
+	d.dec.Decrypt(ct, pt)
+
+	return nil
+}
+
+// DecryptNew decrypts the ciphertext on a new plaintext if the policy
+// authorizes the request, and returns the policy error otherwise.
+func (d GatedDecryptor) DecryptNew(ct *Ciphertext, tags map[string]string) (pt *Plaintext, err error) {
+	pt = NewPlaintext(*d.GetRLWEParameters(), ct.Level())
+	if err = d.Decrypt(ct, pt, tags); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// WithKey returns a shallow copy of the receiver with a new decryption key and
+// the same policy and audit hook.
+func (d GatedDecryptor) WithKey(sk *SecretKey) *GatedDecryptor {
+	return &GatedDecryptor{dec: d.dec.WithKey(sk), policy: d.policy, audit: d.audit}
+}
@@ -0,0 +1,62 @@
+package rlwe
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGatedDecryptor(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	tc, err := NewTestContext(params)
+	require.NoError(t, err)
+
+	ct := NewCiphertext(params, 1, params.MaxLevel())
+	require.NoError(t, tc.enc.Encrypt(NewPlaintext(params, params.MaxLevel()), ct))
+
+	// Policy only authorizing requests tagged with an approved purpose.
+	policy := DecryptionPolicyFunc(func(request DecryptionRequest) error {
+		if request.Tags["purpose"] != "test" {
+			return fmt.Errorf("unapproved purpose %q", request.Tags["purpose"])
+		}
+		return nil
+	})
+
+	var log []DecryptionAuditEntry
+	audit := func(entry DecryptionAuditEntry) {
+		log = append(log, entry)
+	}
+
+	dec, err := NewGatedDecryptor(tc.dec, policy, audit)
+	require.NoError(t, err)
+
+	t.Run("GatedDecryptor/Allowed", func(t *testing.T) {
+		pt, err := dec.DecryptNew(ct, map[string]string{"purpose": "test"})
+		require.NoError(t, err)
+		require.NotNil(t, pt)
+		require.Len(t, log, 1)
+		require.True(t, log[0].Allowed)
+		require.Equal(t, ct.Level(), log[0].Request.Level)
+	})
+
+	t.Run("GatedDecryptor/Denied", func(t *testing.T) {
+		_, err := dec.DecryptNew(ct, map[string]string{"purpose": "exfiltration"})
+		require.ErrorContains(t, err, "denied by policy")
+		require.Len(t, log, 2)
+		require.False(t, log[1].Allowed)
+		require.Error(t, log[1].Err)
+	})
+
+	t.Run("GatedDecryptor/NilPolicy", func(t *testing.T) {
+		_, err := NewGatedDecryptor(tc.dec, nil, nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,62 @@
+package rlwe
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// ErrNoiseBoundExceeded is the sentinel error wrapped by the errors returned
+// by the verified decryption methods of the [Decryptor] when the norm of the
+// decrypted polynomial exceeds the expected bound.
+var ErrNoiseBoundExceeded = errors.New("decrypted norm exceeds the expected bound")
+
+// DecryptVerified decrypts the [Ciphertext] into pt like [Decryptor.Decrypt]
+// and additionally returns the log2 of the maximum absolute norm of the
+// decrypted polynomial (message plus error, centered mod Q). If this norm
+// exceeds maxLog2Norm, an error wrapping [ErrNoiseBoundExceeded] is returned
+// along with the plaintext, flagging a corrupted or wrongly evaluated
+// ciphertext before its content is propagated into downstream systems.
+//
+// For the BGV and BFV schemes the decrypted polynomial is m + T*e, so the
+// bound is log2(T) plus the log2 of the expected error norm of the evaluated
+// circuit. For CKKS the message itself dominates the norm and the bound must
+// account for it in addition to the error.
+func (d Decryptor) DecryptVerified(ct *Ciphertext, pt *Plaintext, maxLog2Norm float64) (log2Max float64, err error) {
+
+	d.Decrypt(ct, pt)
+
+	level := pt.Level()
+	ringQ := d.ringQ.AtLevel(level)
+
+	if pt.IsNTT {
+		ringQ.INTT(pt.Value, d.buff)
+	} else {
+		d.buff.CopyLvl(level, pt.Value)
+	}
+
+	coeffs := make([]*big.Int, d.params.N())
+	for i := range coeffs {
+		coeffs[i] = new(big.Int)
+	}
+
+	ringQ.PolyToBigintCentered(d.buff, 1, coeffs)
+
+	_, _, log2Max = NormStats(coeffs)
+
+	if log2Max > maxLog2Norm {
+		return log2Max, fmt.Errorf("cannot DecryptVerified: log2 norm %f greater than bound %f: %w", log2Max, maxLog2Norm, ErrNoiseBoundExceeded)
+	}
+
+	return
+}
+
+// DecryptVerifiedNew decrypts the [Ciphertext] and returns the result in a new
+// [Plaintext], along with the measured log2 norm and an error wrapping
+// [ErrNoiseBoundExceeded] if the norm exceeds maxLog2Norm.
+// See [Decryptor.DecryptVerified] for additional information.
+func (d Decryptor) DecryptVerifiedNew(ct *Ciphertext, maxLog2Norm float64) (pt *Plaintext, log2Max float64, err error) {
+	pt = NewPlaintext(d.params, ct.Level())
+	log2Max, err = d.DecryptVerified(ct, pt, maxLog2Norm)
+	return
+}
@@ -0,0 +1,46 @@
+package rlwe
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestDecryptVerified(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	kgen := NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	enc := NewEncryptor(params, sk)
+	dec := NewDecryptor(params, sk)
+
+	pt := NewPlaintext(params, params.MaxLevel())
+	ct, err := enc.EncryptNew(pt)
+	require.NoError(t, err)
+
+	// A fresh encryption of zero holds only the fresh error, whose standard
+	// deviation is params.NoiseFreshSK(); a 40-bit bound is well above it.
+	ptHave, log2Max, err := dec.DecryptVerifiedNew(ct, 40)
+	require.NoError(t, err)
+	require.NotNil(t, ptHave)
+	require.Less(t, log2Max, 40.0)
+
+	// Corrupting a coefficient of the ciphertext pushes the decrypted norm
+	// above the bound and must be flagged.
+	prng, err := sampling.NewPRNG()
+	require.NoError(t, err)
+	ring.NewUniformSampler(prng, params.RingQ()).Read(ct.Value[0])
+
+	_, _, err = dec.DecryptVerifiedNew(ct, 40)
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrNoiseBoundExceeded))
+}
@@ -0,0 +1,197 @@
+package rlwe
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/ring/ringqp"
+)
+
+// Diagnostics runs cheap sanity checks over produced key material and
+// ciphertexts, to catch catastrophic randomness failures (a broken or
+// misconfigured PRNG, a zeroed entropy source) before the affected objects
+// leave the key-generation or encryption pipeline: an all-zero or constant
+// secret, all-zero encryption noise and uniform polynomials repeated across
+// independent ciphertexts all make small-norm secret recovery trivial.
+//
+// The checks are heuristics: a passing check is not a proof of correct
+// randomness, but a failing check always indicates an anomaly that would be
+// exploitable in production.
+//
+// The detection of repeated uniform polynomials is stateful: the [Diagnostics]
+// remembers a digest of every mask it has seen, so the same instance should be
+// fed the sample of ciphertexts to cross-check. Use [Diagnostics.Reset] to
+// start a new sample. A [Diagnostics] instance is not safe for concurrent use.
+type Diagnostics struct {
+	params Parameters
+
+	// Digests of the uniform polynomials observed so far, mapped to a label
+	// identifying the object they were observed in.
+	seen map[[32]byte]string
+
+	buffQP ringqp.Poly
+}
+
+// NewDiagnostics instantiates a new [Diagnostics] for the given parameters.
+func NewDiagnostics(params Parameters) *Diagnostics {
+	return &Diagnostics{
+		params: params,
+		seen:   map[[32]byte]string{},
+		buffQP: params.RingQP().NewPoly(),
+	}
+}
+
+// Reset discards the set of uniform polynomials observed so far, starting a
+// new sample for the repeated-mask detection.
+func (d *Diagnostics) Reset() {
+	d.seen = map[[32]byte]string{}
+}
+
+// CheckSecretKey reports an anomaly if the secret is degenerate: the zero
+// polynomial, a constant polynomial or a single monomial, all of which reduce
+// secret recovery to at most a linear scan.
+func (d *Diagnostics) CheckSecretKey(sk *SecretKey) error {
+
+	ringQ := d.params.RingQ().AtLevel(sk.LevelQ())
+
+	buff := d.buffQP.Q
+	ringQ.INTT(sk.Value.Q, buff)
+	ringQ.IMForm(buff, buff)
+
+	coeffs := buff.Coeffs[0]
+
+	var nonZero int
+	constant := true
+	for _, c := range coeffs {
+		if c != 0 {
+			nonZero++
+		}
+		constant = constant && c == coeffs[0]
+	}
+
+	switch {
+	case nonZero == 0:
+		return fmt.Errorf("anomalous secret key: secret is the zero polynomial")
+	case constant:
+		return fmt.Errorf("anomalous secret key: secret is a constant polynomial")
+	case nonZero == 1:
+		return fmt.Errorf("anomalous secret key: secret is a single monomial")
+	}
+
+	return nil
+}
+
+// CheckPublicKey reports an anomaly if the encryption noise of the public key
+// is the zero polynomial (in which case the secret can be recovered by linear
+// algebra from the public key alone), or if its uniform polynomial is
+// degenerate or has already been observed by this [Diagnostics].
+func (d *Diagnostics) CheckPublicKey(pk *PublicKey, sk *SecretKey) error {
+
+	levelQ, levelP := pk.LevelQ(), pk.LevelP()
+	ringQP := d.params.RingQP().AtLevel(levelQ, levelP)
+
+	if err := d.checkUniformPoly(pk.Value[1].Q, levelQ, "pk"); err != nil {
+		return err
+	}
+
+	// [-as + e] + [as] = [e]
+	buff := d.buffQP
+	ringQP.MulCoeffsMontgomery(sk.Value, pk.Value[1], buff)
+	ringQP.Add(buff, pk.Value[0], buff)
+	ringQP.INTT(buff, buff)
+	ringQP.IMForm(buff, buff)
+
+	for _, c := range buff.Q.Coeffs[0] {
+		if c != 0 {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("anomalous public key: encryption noise is the zero polynomial")
+}
+
+// CheckCiphertext reports an anomaly if the uniform polynomial of the
+// ciphertext is degenerate (all-zero or constant) or has already been observed
+// by this [Diagnostics] in another object: two independent ciphertexts sharing
+// the same mask leak the difference of their plaintexts.
+// The label identifies the ciphertext in the reported anomaly.
+func (d *Diagnostics) CheckCiphertext(ct *Ciphertext, label string) error {
+
+	if ct.Degree() < 1 {
+		return fmt.Errorf("cannot check %s: ciphertext degree must be at least 1", label)
+	}
+
+	return d.checkUniformPoly(ct.Value[ct.Degree()], ct.Level(), label)
+}
+
+// CheckCiphertexts runs [Diagnostics.CheckCiphertext] over a sample of
+// ciphertexts, reporting the first anomaly found.
+func (d *Diagnostics) CheckCiphertexts(cts ...*Ciphertext) error {
+	for i, ct := range cts {
+		if err := d.CheckCiphertext(ct, fmt.Sprintf("ct[%d]", i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkUniformPoly reports an anomaly if the polynomial, which is expected to
+// be uniform, is all-zero or constant, or if it was already observed.
+func (d *Diagnostics) checkUniformPoly(pol ring.Poly, level int, label string) error {
+
+	coeffs := pol.Coeffs[0]
+
+	var nonZero int
+	constant := true
+	for _, c := range coeffs {
+		if c != 0 {
+			nonZero++
+		}
+		constant = constant && c == coeffs[0]
+	}
+
+	switch {
+	case nonZero == 0:
+		return fmt.Errorf("anomalous %s: uniform polynomial is the zero polynomial", label)
+	case constant:
+		return fmt.Errorf("anomalous %s: uniform polynomial is a constant polynomial", label)
+	}
+
+	digest := digestPoly(pol, level)
+
+	if prev, ok := d.seen[digest]; ok {
+		return fmt.Errorf("anomalous %s: uniform polynomial already observed in %s", label, prev)
+	}
+
+	d.seen[digest] = label
+
+	return nil
+}
+
+// digestPoly returns a 256-bit digest of the coefficients of the polynomial
+// up to the given level.
+func digestPoly(pol ring.Poly, level int) [32]byte {
+
+	h, err := blake2b.New256(nil)
+
+	// Sanity check, this error should not happen.
+	if err != nil {
+		panic(err)
+	}
+
+	var buff [8]byte
+	for _, coeffs := range pol.Coeffs[:level+1] {
+		for _, c := range coeffs {
+			binary.LittleEndian.PutUint64(buff[:], c)
+			h.Write(buff[:])
+		}
+	}
+
+	var digest [32]byte
+	copy(digest[:], h.Sum(nil))
+
+	return digest
+}
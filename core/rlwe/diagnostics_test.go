@@ -0,0 +1,85 @@
+package rlwe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiagnostics(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	kgen := NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	pk := kgen.GenPublicKeyNew(sk)
+	enc := NewEncryptor(params, pk)
+
+	diagnostics := NewDiagnostics(params)
+
+	t.Run("Diagnostics/SecretKey", func(t *testing.T) {
+
+		require.NoError(t, diagnostics.CheckSecretKey(sk))
+
+		skZero := NewSecretKey(params)
+		require.Error(t, diagnostics.CheckSecretKey(skZero))
+
+		// A constant secret: s = 1 in the coefficient domain.
+		skConstant := NewSecretKey(params)
+		ringQP := params.RingQP()
+		for i := range skConstant.Value.Q.Coeffs {
+			skConstant.Value.Q.Coeffs[i][0] = 1
+		}
+		for i := range skConstant.Value.P.Coeffs {
+			skConstant.Value.P.Coeffs[i][0] = 1
+		}
+		ringQP.NTT(skConstant.Value, skConstant.Value)
+		ringQP.MForm(skConstant.Value, skConstant.Value)
+		require.Error(t, diagnostics.CheckSecretKey(skConstant))
+	})
+
+	t.Run("Diagnostics/PublicKey", func(t *testing.T) {
+
+		require.NoError(t, diagnostics.CheckPublicKey(pk, sk))
+
+		// A public key with zero noise: pk = (-as, a).
+		pkNoiseless := pk.CopyNew()
+		ringQP := params.RingQP()
+		ringQP.MulCoeffsMontgomery(sk.Value, pkNoiseless.Value[1], pkNoiseless.Value[0])
+		ringQP.Neg(pkNoiseless.Value[0], pkNoiseless.Value[0])
+		require.Error(t, diagnostics.CheckPublicKey(pkNoiseless, sk))
+	})
+
+	t.Run("Diagnostics/Ciphertexts", func(t *testing.T) {
+
+		diagnostics.Reset()
+
+		pt := NewPlaintext(params, params.MaxLevel())
+
+		ct0, err := enc.EncryptNew(pt)
+		require.NoError(t, err)
+		ct1, err := enc.EncryptNew(pt)
+		require.NoError(t, err)
+
+		require.NoError(t, diagnostics.CheckCiphertexts(ct0, ct1))
+
+		// A repeated mask across two "independent" ciphertexts.
+		diagnostics.Reset()
+		ctRepeated := ct1.CopyNew()
+		ctRepeated.Value[1].Copy(ct0.Value[1])
+		require.Error(t, diagnostics.CheckCiphertexts(ct0, ctRepeated))
+
+		// A zero mask.
+		diagnostics.Reset()
+		ctZero := NewCiphertext(params, 1, params.MaxLevel())
+		require.Error(t, diagnostics.CheckCiphertext(ctZero, "ctZero"))
+
+		// A degree-0 operand has no mask to check.
+		require.Error(t, diagnostics.CheckCiphertext(&Ciphertext{Element: pt.Element}, "pt"))
+	})
+}
@@ -0,0 +1,65 @@
+package rlwe
+
+import (
+	"fmt"
+)
+
+// Downscale derives from the receiver a set of functionally-equivalent but INSECURE
+// parameters of ring degree 2^{targetLogN}, intended for fast testing: the ring degree
+// is lowered to targetLogN and the moduli chains are truncated proportionally to the
+// ratio targetLogN/LogN (keeping at least one modulus in every non-empty chain), while
+// the distributions, the ring type, the default scale and the NTT flag are left unchanged.
+//
+// Explicit moduli are preserved as-is: a modulus that is NTT-friendly for the original
+// ring degree is also NTT-friendly for any smaller power-of-two ring degree.
+//
+// The returned parameters provide no security whatsoever and must only be used to
+// exercise code paths in tests.
+func (p ParametersLiteral) Downscale(targetLogN int) (ParametersLiteral, error) {
+
+	if err := checkSizeParams(targetLogN); err != nil {
+		return ParametersLiteral{}, err
+	}
+
+	if targetLogN > p.LogN {
+		return ParametersLiteral{}, fmt.Errorf("targetLogN=%d is larger than LogN=%d", targetLogN, p.LogN)
+	}
+
+	truncate := func(n int) int {
+		if n == 0 {
+			return 0
+		}
+		if m := n * targetLogN / p.LogN; m > 1 {
+			return m
+		}
+		return 1
+	}
+
+	pCpy := p
+
+	pCpy.LogN = targetLogN
+
+	if p.LogNthRoot != 0 {
+		if pCpy.LogNthRoot = p.LogNthRoot - (p.LogN - targetLogN); pCpy.LogNthRoot < targetLogN+1 {
+			pCpy.LogNthRoot = targetLogN + 1
+		}
+	}
+
+	if p.Q != nil {
+		pCpy.Q = append([]uint64{}, p.Q[:truncate(len(p.Q))]...)
+	}
+
+	if p.P != nil {
+		pCpy.P = append([]uint64{}, p.P[:truncate(len(p.P))]...)
+	}
+
+	if p.LogQ != nil {
+		pCpy.LogQ = append([]int{}, p.LogQ[:truncate(len(p.LogQ))]...)
+	}
+
+	if p.LogP != nil {
+		pCpy.LogP = append([]int{}, p.LogP[:truncate(len(p.LogP))]...)
+	}
+
+	return pCpy, nil
+}
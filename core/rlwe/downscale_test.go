@@ -0,0 +1,55 @@
+package rlwe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownscale(t *testing.T) {
+
+	prod := ParametersLiteral{
+		LogN: 14,
+		LogQ: []int{55, 45, 45, 45, 45, 45, 45},
+		LogP: []int{60, 60},
+	}
+
+	t.Run("Downscale/LogModuli", func(t *testing.T) {
+
+		lit, err := prod.Downscale(10)
+		require.NoError(t, err)
+		require.Equal(t, 10, lit.LogN)
+		require.Equal(t, []int{55, 45, 45, 45, 45}, lit.LogQ) // 7*10/14 = 5
+		require.Equal(t, []int{60}, lit.LogP)                 // 2*10/14 = 1
+
+		_, err = NewParametersFromLiteral(lit)
+		require.NoError(t, err)
+	})
+
+	t.Run("Downscale/ExplicitModuli", func(t *testing.T) {
+
+		lit, err := ParametersLiteral{
+			LogN:    14,
+			Q:       []uint64{0x200000440001, 0x7fff80001, 0x800280001, 0x7ffd80001, 0x7ffc80001},
+			P:       []uint64{0x3ffffffb80001, 0x4000000800001},
+			NTTFlag: true,
+		}.Downscale(10)
+		require.NoError(t, err)
+
+		// Explicit moduli remain NTT-friendly at the smaller ring degree
+		params, err := NewParametersFromLiteral(lit)
+		require.NoError(t, err)
+		require.Equal(t, 10, params.LogN())
+		require.Equal(t, 3, params.QCount()) // 5*10/14 = 3
+		require.Equal(t, 1, params.PCount()) // 2*10/14 = 1
+	})
+
+	t.Run("Downscale/Errors", func(t *testing.T) {
+
+		_, err := prod.Downscale(15)
+		require.Error(t, err) // cannot upscale
+
+		_, err = prod.Downscale(MinLogN - 1)
+		require.Error(t, err)
+	})
+}
@@ -192,6 +192,46 @@ func (enc Encryptor) EncryptZeroNew(level int) (ct *Ciphertext) {
 	return
 }
 
+// EncryptCompressed encrypts the input plaintext using the stored secret key and
+// writes the result on the (half-size) [CompressedCiphertext] ct: the uniform
+// polynomial a is sampled from a fresh 32-byte seed which is stored in ct in
+// place of the polynomial itself (see [CompressedCiphertext]).
+// The method returns an error if the stored encryption key is not an
+// [*rlwe.SecretKey], as the seed of a public-key encryption would leak the
+// encryption randomness.
+func (enc Encryptor) EncryptCompressed(pt *Plaintext, ct *CompressedCiphertext) (err error) {
+
+	if _, isSk := enc.encKey.(*SecretKey); !isSk {
+		return fmt.Errorf("cannot EncryptCompressed: compressed encryption requires an *rlwe.SecretKey but the Encryptor stores a %T", enc.encKey)
+	}
+
+	ct.Seed = make([]byte, 32)
+	if n, err := enc.prng.Read(ct.Seed); n != 32 || err != nil {
+		return fmt.Errorf("cannot EncryptCompressed: unable to sample seed")
+	}
+
+	sampler, err := sampling.NewKeyedPRNG(ct.Seed)
+	if err != nil {
+		return fmt.Errorf("cannot EncryptCompressed: %w", err)
+	}
+
+	c := &Ciphertext{ct.Element}
+	if err = enc.WithPRNG(sampler).Encrypt(pt, c); err != nil {
+		return fmt.Errorf("cannot EncryptCompressed: %w", err)
+	}
+	ct.Element = c.Element
+
+	return
+}
+
+// EncryptCompressedNew encrypts the input plaintext using the stored secret key
+// and returns a newly allocated [CompressedCiphertext] containing the result.
+// See [Encryptor.EncryptCompressed].
+func (enc Encryptor) EncryptCompressedNew(pt *Plaintext) (ct *CompressedCiphertext, err error) {
+	ct = NewCompressedCiphertext(enc.params, pt.Level())
+	return ct, enc.EncryptCompressed(pt, ct)
+}
+
 func (enc Encryptor) encryptZeroPk(pk *PublicKey, ct interface{}) (err error) {
 
 	var ct0QP, ct1QP ringqp.Poly
@@ -475,7 +515,7 @@ func (enc Encryptor) checkPk(pk *PublicKey) (err error) {
 	if pk.Value[0].Q.N() != enc.params.N() || pk.Value[1].Q.N() != enc.params.N() {
 		return fmt.Errorf("pk ring degree does not match params ring degree")
 	}
-	return
+	return checkKeyFingerprint(enc.params.Fingerprint(), "pk", pk.ParametersFingerprint)
 }
 
 // checkPk checks that a given pk is correct for the parameters.
@@ -483,7 +523,7 @@ func (enc Encryptor) checkSk(sk *SecretKey) (err error) {
 	if sk.Value.Q.N() != enc.params.N() {
 		return fmt.Errorf("sk ring degree does not match params ring degree")
 	}
-	return
+	return checkKeyFingerprint(enc.params.Fingerprint(), "sk", sk.ParametersFingerprint)
 }
 
 func (enc Encryptor) addPtToCt(level int, pt *Plaintext, ct *Ciphertext) {
@@ -16,9 +16,16 @@ type Evaluator struct {
 	*EvaluatorBuffers
 
 	automorphismIndex map[uint64][]uint64
+	paramsFingerprint uint64
 
 	BasisExtender *ring.BasisExtender
 	Decomposer    *ring.Decomposer
+
+	// PolyPool recycles the scratch polynomials of the operations whose
+	// temporaries depend on the operand level and thus cannot live in the
+	// [EvaluatorBuffers]. It is shared across shallow copies of the
+	// evaluator and can be pre-warmed with [ring.PolyPool.Warm].
+	PolyPool *ring.PolyPool
 }
 
 type EvaluatorBuffers struct {
@@ -60,11 +67,13 @@ func NewEvaluatorBuffers(params Parameters) *EvaluatorBuffers {
 	return buff
 }
 
-// NewEvaluator creates a new [Evaluator].
+// NewEvaluator creates a new [Evaluator]. A compute backend offloading the
+// heavy ring operations can be attached with [Evaluator.WithBackend].
 func NewEvaluator(params ParameterProvider, evk EvaluationKeySet) (eval *Evaluator) {
 	eval = new(Evaluator)
 	p := params.GetRLWEParameters()
 	eval.params = *p
+	eval.paramsFingerprint = p.Fingerprint()
 	eval.EvaluatorBuffers = NewEvaluatorBuffers(eval.params)
 
 	if p.RingP() != nil {
@@ -73,11 +82,15 @@ func NewEvaluator(params ParameterProvider, evk EvaluationKeySet) (eval *Evaluat
 
 	eval.Decomposer = ring.NewDecomposer(p.RingQ(), p.RingP())
 
+	eval.PolyPool = p.RingQ().NewPolyPool()
+
 	eval.EvaluationKeySet = evk
 
 	var AutomorphismIndex map[uint64][]uint64
 
-	if !utils.IsNil(evk) {
+	// Some EvaluationKeySet implementations maintain their own permutation tables,
+	// kept in sync with the keys (e.g. AtomicEvaluationKeySet, GaloisKeyRecorder).
+	if _, ok := evk.(automorphismIndexProvider); !ok && !utils.IsNil(evk) {
 		if galEls := evk.GetGaloisKeysList(); len(galEls) != 0 {
 			AutomorphismIndex = make(map[uint64][]uint64)
 
@@ -113,6 +126,16 @@ func (eval Evaluator) CheckAndGetGaloisKey(galEl uint64) (evk *GaloisKey, err er
 		return nil, fmt.Errorf("evaluation key interface is nil")
 	}
 
+	if err = checkKeyFingerprint(eval.paramsFingerprint, fmt.Sprintf("GaloisKey[%d]", galEl), evk.ParametersFingerprint); err != nil {
+		return nil, err
+	}
+
+	// An AtomicEvaluationKeySet precomputes the permutation tables of its keys and can be
+	// read concurrently, so no lazy population of the evaluator-local table is needed.
+	if _, isAtomic := eval.EvaluationKeySet.(*AtomicEvaluationKeySet); isAtomic {
+		return
+	}
+
 	if eval.automorphismIndex == nil {
 		eval.automorphismIndex = map[uint64][]uint64{}
 	}
@@ -137,6 +160,10 @@ func (eval Evaluator) CheckAndGetRelinearizationKey() (evk *RelinearizationKey,
 		return nil, fmt.Errorf("evaluation key interface is nil")
 	}
 
+	if err = checkKeyFingerprint(eval.paramsFingerprint, "RelinearizationKey", evk.ParametersFingerprint); err != nil {
+		return nil, err
+	}
+
 	return
 }
 
@@ -245,26 +272,53 @@ func (eval Evaluator) ShallowCopy() *Evaluator {
 		EvaluatorBuffers:  NewEvaluatorBuffers(eval.params),
 		EvaluationKeySet:  eval.EvaluationKeySet,
 		automorphismIndex: eval.automorphismIndex,
+		paramsFingerprint: eval.paramsFingerprint,
+		PolyPool:          eval.PolyPool,
+	}
+}
+
+// WithBackend creates a shallow copy of the receiver [Evaluator] whose ring
+// operations (NTT, coefficient-wise multiplication and base extension) are
+// dispatched to the provided [ring.Backend], so that the heavy key-switching
+// arithmetic can be offloaded, e.g. to a GPU. The evaluation keys and the
+// temporary buffers are shared with the receiver, so the receiver and the
+// returned evaluator cannot be used concurrently.
+func (eval Evaluator) WithBackend(b ring.Backend) *Evaluator {
+
+	eval.params.ringQ = eval.params.ringQ.WithBackend(b)
+	if eval.params.ringP != nil {
+		eval.params.ringP = eval.params.ringP.WithBackend(b)
+		eval.BasisExtender = ring.NewBasisExtender(eval.params.ringQ, eval.params.ringP)
 	}
+
+	eval.Decomposer = ring.NewDecomposer(eval.params.ringQ, eval.params.ringP)
+
+	return &eval
 }
 
 // WithKey creates a shallow copy of the receiver [Evaluator] for which the new [EvaluationKey] is evaluationKey
 // and where the temporary buffers are shared. The receiver and the returned evaluators cannot be used concurrently.
+//
+// If evk is an [AtomicEvaluationKeySet], no internal state is precomputed: the wrapper
+// maintains the permutation tables itself and its keys can later be rotated in place with
+// [AtomicEvaluationKeySet.Swap].
 func (eval Evaluator) WithKey(evk EvaluationKeySet) *Evaluator {
 
 	var AutomorphismIndex map[uint64][]uint64
 
-	if galEls := evk.GetGaloisKeysList(); len(galEls) != 0 {
-		AutomorphismIndex = make(map[uint64][]uint64)
+	if _, ok := evk.(automorphismIndexProvider); !ok {
+		if galEls := evk.GetGaloisKeysList(); len(galEls) != 0 {
+			AutomorphismIndex = make(map[uint64][]uint64)
 
-		N := eval.params.N()
-		NthRoot := eval.params.RingQ().NthRoot()
+			N := eval.params.N()
+			NthRoot := eval.params.RingQ().NthRoot()
 
-		var err error
-		for _, galEl := range galEls {
-			if AutomorphismIndex[galEl], err = ring.AutomorphismNTTIndex(N, NthRoot, galEl); err != nil {
-				// Sanity check, this error should not happen.
-				panic(err)
+			var err error
+			for _, galEl := range galEls {
+				if AutomorphismIndex[galEl], err = ring.AutomorphismNTTIndex(N, NthRoot, galEl); err != nil {
+					// Sanity check, this error should not happen.
+					panic(err)
+				}
 			}
 		}
 	}
@@ -276,10 +330,21 @@ func (eval Evaluator) WithKey(evk EvaluationKeySet) *Evaluator {
 		BasisExtender:     eval.BasisExtender,
 		EvaluationKeySet:  evk,
 		automorphismIndex: AutomorphismIndex,
+		paramsFingerprint: eval.paramsFingerprint,
 	}
 }
 
+// automorphismIndexProvider is implemented by EvaluationKeySet implementations
+// that maintain their own automorphism permutation tables, kept in sync with
+// the keys they hold, in which case the [Evaluator] does not precompute any.
+type automorphismIndexProvider interface {
+	automorphismNTTIndex(galEl uint64) []uint64
+}
+
 func (eval Evaluator) AutomorphismIndex(galEl uint64) []uint64 {
+	if evk, ok := eval.EvaluationKeySet.(automorphismIndexProvider); ok {
+		return evk.automorphismNTTIndex(galEl)
+	}
 	return eval.automorphismIndex[galEl]
 }
 
@@ -153,6 +153,10 @@ func (eval Evaluator) gadgetProductMultiplePLazy(levelQ int, cx ring.Poly, gadge
 	var reduce int
 	for i := 0; i < BaseRNSDecompositionVectorSize; i++ {
 
+		if i < BaseRNSDecompositionVectorSize-1 {
+			prefetchVectorQP(el[i+1][0], levelQ, levelP)
+		}
+
 		eval.DecomposeSingleNTT(levelQ, levelP, levelP+1, i, cxNTT, cxInvNTT, c2QP.Q, c2QP.P)
 
 		if i == 0 {
@@ -392,6 +396,10 @@ func (eval Evaluator) gadgetProductMultiplePLazyHoisted(levelQ int, BuffQPDecomp
 
 		gct := gadgetCt.Value[i][0]
 
+		if i < BaseRNSDecompositionVectorSize-1 {
+			prefetchVectorQP(gadgetCt.Value[i+1][0], levelQ, levelP)
+		}
+
 		if i == 0 {
 			ringQP.MulCoeffsMontgomeryLazy(gct[0], BuffQPDecompQP[i], c0QP)
 			ringQP.MulCoeffsMontgomeryLazy(gct[1], BuffQPDecompQP[i], c1QP)
@@ -424,6 +432,21 @@ func (eval Evaluator) gadgetProductMultiplePLazyHoisted(levelQ int, BuffQPDecomp
 	}
 }
 
+// prefetchVectorQP stages the coefficients of the next decomposition limb of
+// the evaluation key into the cache while the current one is being consumed,
+// hiding the latency of a fetch from a remote NUMA node. It is a no-op unless
+// the library is built with the `numa` tag.
+func prefetchVectorQP(v VectorQP, levelQ, levelP int) {
+	for i := range v {
+		for j := 0; j < levelQ+1 && j < len(v[i].Q.Coeffs); j++ {
+			ring.Prefetch(v[i].Q.Coeffs[j])
+		}
+		for j := 0; j < levelP+1 && j < len(v[i].P.Coeffs); j++ {
+			ring.Prefetch(v[i].P.Coeffs[j])
+		}
+	}
+}
+
 // DecomposeNTT applies the full RNS basis decomposition on c2.
 // Expects the IsNTT flag of c2 to correctly reflect the domain of c2.
 // BuffQPDecompQ and BuffQPDecompQ are vectors of polynomials (mod Q and mod P) that store the
@@ -0,0 +1,139 @@
+package rlwe
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/tuneinsight/lattigo/v6/ring"
+)
+
+// Expand obliviously expands a ciphertext encrypting P(X) = ci * X^i into a list of
+// ciphertexts, each encrypting an individual coefficient: the i-th returned ciphertext
+// encrypts c[i*2^{logGap}] * X^{0}, for 0 <= i < 2^{logN-logGap}. This operation is
+// notably used for the query expansion of single-server PIR protocols, where the input
+// encrypts a selection vector (https://eprint.iacr.org/2017/1142).
+//
+// The coefficients of P(X) of degree 2^{logN} or larger must be zero, as they would
+// otherwise be folded onto the non-constant coefficients of the returned ciphertexts.
+//
+// The evaluator must have been instantiated with an [EvaluationKeySet] holding the
+// Galois keys for the elements returned by [GaloisElementsForExpand](params, logN).
+//
+// The method will return an error if:
+//   - The input ciphertext degree is not one
+//   - logN is negative or larger than the ring log-degree, or logGap is not in [0, logN]
+//   - The ring type is not [ring.Standard]
+//   - A required Galois key is missing
+func (eval Evaluator) Expand(ct *Ciphertext, logN, logGap int) (cts []*Ciphertext, err error) {
+
+	if ct.Degree() != 1 {
+		return nil, fmt.Errorf("ct.Degree() != 1")
+	}
+
+	params := eval.GetRLWEParameters()
+
+	if logN < 0 || logN > params.LogN() {
+		return nil, fmt.Errorf("invalid logN: must be in [0, %d] but is %d", params.LogN(), logN)
+	}
+
+	if logGap < 0 || logGap > logN {
+		return nil, fmt.Errorf("invalid logGap: must be in [0, %d] but is %d", logN, logGap)
+	}
+
+	if params.RingType() != ring.Standard {
+		return nil, fmt.Errorf("method is only supported for ring.Type = ring.Standard (X^{-2^{i}} does not exist in the sub-ring Z[X + X^{-1}])")
+	}
+
+	level := ct.Level()
+
+	ringQ := params.RingQ().AtLevel(level)
+
+	xPow2Inv := GenXPow2NTT(ringQ, logN, true)
+
+	gap := 1 << logGap
+
+	tmpCts := map[int]*Ciphertext{}
+	tmpCts[0] = ct.CopyNew()
+	tmpCts[0].LogDimensions = ring.Dimensions{Rows: 0, Cols: 0}
+
+	if ct := tmpCts[0]; !ct.IsNTT {
+		ringQ.NTT(ct.Value[0], ct.Value[0])
+		ringQ.NTT(ct.Value[1], ct.Value[1])
+		ct.IsNTT = true
+	}
+
+	// Multiplies by 2^{-logN} mod Q
+	NInv := new(big.Int).SetUint64(1 << logN)
+	NInv.ModInverse(NInv, ringQ.ModulusAtLevel[level])
+
+	ringQ.MulScalarBigint(tmpCts[0].Value[0], NInv, tmpCts[0].Value[0])
+	ringQ.MulScalarBigint(tmpCts[0].Value[1], NInv, tmpCts[0].Value[1])
+
+	tmp, err := NewCiphertextAtLevelFromPoly(level, []ring.Poly{eval.BuffCt.Value[0], eval.BuffCt.Value[1]})
+
+	// Sanity check, this error should not happen unless the
+	// evaluator's buffer have been improperly tempered with.
+	if err != nil {
+		panic(err)
+	}
+
+	*tmp.MetaData = *tmpCts[0].MetaData
+
+	for i := 0; i < logN; i++ {
+
+		n := 1 << i
+
+		galEl := uint64(ringQ.N()/n + 1)
+
+		for j := 0; j < n; j += gap {
+
+			c0 := tmpCts[j]
+
+			// X -> X^{N/n + 1}
+			//[a, b, c, d] -> [a, -b, c, -d]
+			if err = eval.Automorphism(c0, galEl, tmp); err != nil {
+				return nil, fmt.Errorf("cannot Expand: %w", err)
+			}
+
+			if j+n/gap > 0 {
+
+				c1 := c0.CopyNew()
+
+				// Zeroes odd coeffs: [a, b, c, d] + [a, -b, c, -d] -> [2a, 0, 2b, 0]
+				ringQ.Add(c0.Value[0], tmp.Value[0], c0.Value[0])
+				ringQ.Add(c0.Value[1], tmp.Value[1], c0.Value[1])
+
+				// Zeroes even coeffs: [a, b, c, d] - [a, -b, c, -d] -> [0, 2b, 0, 2d]
+				ringQ.Sub(c1.Value[0], tmp.Value[0], c1.Value[0])
+				ringQ.Sub(c1.Value[1], tmp.Value[1], c1.Value[1])
+
+				// c1 * X^{-2^{i}}: [0, 2b, 0, 2d] * X^{-n} -> [2b, 0, 2d, 0]
+				ringQ.MulCoeffsMontgomery(c1.Value[0], xPow2Inv[i], c1.Value[0])
+				ringQ.MulCoeffsMontgomery(c1.Value[1], xPow2Inv[i], c1.Value[1])
+
+				tmpCts[j+n] = c1
+
+			} else {
+
+				// Zeroes odd coeffs: [a, b, c, d] + [a, -b, c, -d] -> [2a, 0, 2b, 0]
+				ringQ.Add(c0.Value[0], tmp.Value[0], c0.Value[0])
+				ringQ.Add(c0.Value[1], tmp.Value[1], c0.Value[1])
+			}
+		}
+	}
+
+	// Returns the ciphertexts in the domain of the input
+	cts = make([]*Ciphertext, 1<<(logN-logGap))
+	for i := range cts {
+
+		cts[i] = tmpCts[i*gap]
+
+		if !ct.IsNTT {
+			ringQ.INTT(cts[i].Value[0], cts[i].Value[0])
+			ringQ.INTT(cts[i].Value[1], cts[i].Value[1])
+			cts[i].IsNTT = false
+		}
+	}
+
+	return
+}
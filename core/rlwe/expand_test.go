@@ -0,0 +1,88 @@
+package rlwe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/ring"
+)
+
+func TestExpand(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN:    10,
+		LogQ:    []int{60},
+		LogP:    []int{60},
+		NTTFlag: true,
+	})
+	require.NoError(t, err)
+
+	tc, err := NewTestContext(params)
+	require.NoError(t, err)
+
+	sk := tc.sk
+	enc := tc.enc
+	dec := tc.dec
+
+	level := params.MaxLevel()
+	ringQ := params.RingQ().AtLevel(level)
+
+	logN := 6
+	logGap := 1
+	gap := 1 << logGap
+
+	eval := NewEvaluator(params, NewMemEvaluationKeySet(nil, tc.kgen.GenGaloisKeysNew(GaloisElementsForExpand(params, logN), sk)...))
+
+	t.Run(testString(params, level, params.MaxLevelP(), 0, "Expand"), func(t *testing.T) {
+
+		// Plaintext with non-zero coefficients only below 2^{logN}
+		pt := NewPlaintext(params, level)
+		for i := 0; i < level+1; i++ {
+			qi := ringQ.SubRings[i].Modulus
+			for j := 0; j < 1<<logN; j++ {
+				pt.Value.Coeffs[i][j] = (uint64(j) << 40) % qi
+			}
+		}
+		ringQ.NTT(pt.Value, pt.Value)
+		pt.IsNTT = true
+
+		ct, err := enc.EncryptNew(pt)
+		require.NoError(t, err)
+
+		cts, err := eval.Expand(ct, logN, logGap)
+		require.NoError(t, err)
+		require.Equal(t, 1<<(logN-logGap), len(cts))
+
+		// Each returned ciphertext encrypts c[i*gap] * X^{0}.
+		ptDec := NewPlaintext(params, level)
+		for i := range cts {
+
+			dec.Decrypt(cts[i], ptDec)
+
+			if ptDec.IsNTT {
+				ringQ.INTT(ptDec.Value, ptDec.Value)
+			}
+
+			for j := 0; j < level+1; j++ {
+				Q := ringQ.SubRings[j].Modulus
+				ptDec.Value.Coeffs[j][0] = ring.CRed(ptDec.Value.Coeffs[j][0]+Q-(uint64(i*gap)<<40)%Q, Q)
+			}
+
+			// Logs the noise
+			require.GreaterOrEqual(t, float64(params.LogN()+1), ringQ.Log2OfStandardDeviation(ptDec.Value))
+		}
+
+		// Invalid inputs are rejected.
+		_, err = eval.Expand(NewCiphertext(params, 2, level), logN, logGap)
+		require.Error(t, err)
+		_, err = eval.Expand(ct, params.LogN()+1, 0)
+		require.Error(t, err)
+		_, err = eval.Expand(ct, logN, logN+1)
+		require.Error(t, err)
+
+		// Missing Galois keys are reported.
+		_, err = NewEvaluator(params, nil).Expand(ct, logN, logGap)
+		require.Error(t, err)
+	})
+}
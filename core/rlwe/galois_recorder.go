@@ -0,0 +1,112 @@
+package rlwe
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// GaloisKeyRecorder is an [EvaluationKeySet] that generates Galois keys on
+// demand from the secret key and records the Galois elements actually
+// requested during an evaluation. Running a circuit once against an evaluator
+// holding a recorder yields the exact, minimal set of Galois elements the
+// circuit uses, merged across all its sub-circuits, where deriving the set
+// from the declared circuit components alone can over-approximate it and blow
+// up the generated key material.
+//
+// GaloisKeyRecorder implements [GaloisElementsProvider], so the recorded set
+// can be merged with the requirements of declared circuits through
+// [AggregateGaloisElements] before being fed to GenGaloisKeysNew of the
+// [KeyGenerator]. The recorder is safe for concurrent use.
+type GaloisKeyRecorder struct {
+	params Parameters
+	kgen   *KeyGenerator
+	sk     *SecretKey
+	rlk    *RelinearizationKey
+
+	mu    *sync.Mutex
+	keys  map[uint64]*GaloisKey
+	index map[uint64][]uint64
+}
+
+// NewGaloisKeyRecorder instantiates a new [GaloisKeyRecorder] generating its
+// keys (including the relinearization key) from the given secret key.
+func NewGaloisKeyRecorder(params ParameterProvider, sk *SecretKey) *GaloisKeyRecorder {
+
+	p := *params.GetRLWEParameters()
+
+	kgen := NewKeyGenerator(p)
+
+	return &GaloisKeyRecorder{
+		params: p,
+		kgen:   kgen,
+		sk:     sk,
+		rlk:    kgen.GenRelinearizationKeyNew(sk),
+		mu:     &sync.Mutex{},
+		keys:   map[uint64]*GaloisKey{},
+		index:  map[uint64][]uint64{},
+	}
+}
+
+// GetGaloisKey returns the Galois key for the automorphism X^{i} -> X^{i*galEl},
+// generating and recording it on the first request.
+func (r *GaloisKeyRecorder) GetGaloisKey(galEl uint64) (evk *GaloisKey, err error) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	evk, ok := r.keys[galEl]
+	if !ok {
+		evk = r.kgen.GenGaloisKeyNew(galEl, r.sk)
+		r.keys[galEl] = evk
+		if r.index[galEl], err = ring.AutomorphismNTTIndex(r.params.N(), r.params.RingQ().NthRoot(), galEl); err != nil {
+			return nil, fmt.Errorf("cannot GetGaloisKey: %w", err)
+		}
+	}
+
+	return evk, nil
+}
+
+// GetGaloisKeysList returns the sorted list of the Galois elements recorded
+// so far.
+func (r *GaloisKeyRecorder) GetGaloisKeysList() (galEls []uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return utils.GetSortedKeys(r.keys)
+}
+
+// GetRelinearizationKey returns the relinearization key.
+func (r *GaloisKeyRecorder) GetRelinearizationKey() (evk *RelinearizationKey, err error) {
+	return r.rlk, nil
+}
+
+// ShallowCopy returns a thread-safe copy of the recorder: the recorded set,
+// the generated keys and the secret key are shared, the key generator buffers
+// are reallocated.
+func (r *GaloisKeyRecorder) ShallowCopy() EvaluationKeySet {
+	return &GaloisKeyRecorder{
+		params: r.params,
+		kgen:   NewKeyGenerator(r.params),
+		sk:     r.sk,
+		rlk:    r.rlk,
+		mu:     r.mu,
+		keys:   r.keys,
+		index:  r.index,
+	}
+}
+
+// automorphismNTTIndex implements the permutation-table lookup of the
+// [Evaluator], since the tables can only be computed as the keys are recorded.
+func (r *GaloisKeyRecorder) automorphismNTTIndex(galEl uint64) []uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.index[galEl]
+}
+
+// GaloisElements implements [GaloisElementsProvider] with the recorded set,
+// so that a recorded circuit can be aggregated with declared ones.
+func (r *GaloisKeyRecorder) GaloisElements(_ ParameterProvider) []uint64 {
+	return r.GetGaloisKeysList()
+}
@@ -0,0 +1,74 @@
+package rlwe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGaloisKeyRecorder(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN:    10,
+		LogQ:    []int{45, 45},
+		LogP:    []int{60},
+		NTTFlag: true,
+	})
+	require.NoError(t, err)
+
+	tc, err := NewTestContext(params)
+	require.NoError(t, err)
+
+	rec := NewGaloisKeyRecorder(params, tc.sk)
+	require.Empty(t, rec.GetGaloisKeysList())
+
+	eval := NewEvaluator(params, rec)
+
+	pt := NewPlaintext(params, params.MaxLevel())
+	ct, err := tc.enc.EncryptNew(pt)
+	require.NoError(t, err)
+
+	// Dry run of the circuit against the recorder.
+	require.NoError(t, eval.InnerSum(ct, 1, 8, NewCiphertext(params, 1, ct.Level())))
+	require.NoError(t, eval.Automorphism(ct, params.GaloisElement(3), NewCiphertext(params, 1, ct.Level())))
+
+	// The recorded set is contained in the declared requirements of the
+	// circuit and contains every element it requested.
+	declared := map[uint64]bool{params.GaloisElement(3): true}
+	for _, galEl := range GaloisElementsForInnerSum(params, 1, 8) {
+		declared[galEl] = true
+	}
+
+	galEls := rec.GetGaloisKeysList()
+	for _, galEl := range galEls {
+		require.True(t, declared[galEl])
+	}
+	require.Contains(t, galEls, params.GaloisElement(3))
+
+	// For this inner sum the declared requirements over-approximate the
+	// elements the hoisted evaluation requests: the recorded set is strictly
+	// smaller.
+	require.Less(t, len(galEls), len(declared))
+
+	// Requesting an already recorded key must not generate it twice.
+	evk0, err := rec.GetGaloisKey(params.GaloisElement(3))
+	require.NoError(t, err)
+	evk1, err := rec.GetGaloisKey(params.GaloisElement(3))
+	require.NoError(t, err)
+	require.Same(t, evk0, evk1)
+
+	// The copy shares the recorded set.
+	cpy := rec.ShallowCopy()
+	_, err = cpy.GetGaloisKey(params.GaloisElement(5))
+	require.NoError(t, err)
+	require.Contains(t, rec.GetGaloisKeysList(), params.GaloisElement(5))
+
+	// The recorder aggregates with declared circuits.
+	galEls = AggregateGaloisElements(params, rec, TraceParameters{LogN: params.LogN() - 1})
+	for _, galEl := range rec.GetGaloisKeysList() {
+		require.Contains(t, galEls, galEl)
+	}
+	for _, galEl := range GaloisElementsForTrace(params, params.LogN()-1) {
+		require.Contains(t, galEls, galEl)
+	}
+}
@@ -0,0 +1,67 @@
+package rlwe
+
+import (
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// GaloisElementsProvider is implemented by circuit-parameter objects that can
+// report the Galois elements required to evaluate the circuit they describe,
+// e.g. encoded linear transformations, DFT matrices or the configuration
+// types below. It allows the rotation-key requirements of a whole application
+// to be collected uniformly with [AggregateGaloisElements].
+type GaloisElementsProvider interface {
+	GaloisElements(params ParameterProvider) []uint64
+}
+
+// AggregateGaloisElements returns the sorted union of the Galois elements
+// required by the given circuit-parameter objects, with duplicates removed,
+// so that a single call yields the complete list of Galois keys to generate
+// for an application.
+func AggregateGaloisElements(params ParameterProvider, providers ...GaloisElementsProvider) (galEls []uint64) {
+
+	keys := make(map[uint64]bool)
+
+	for _, provider := range providers {
+		for _, galEl := range provider.GaloisElements(params) {
+			keys[galEl] = true
+		}
+	}
+
+	return utils.GetSortedKeys(keys)
+}
+
+// InnerSumParameters describes an [Evaluator.InnerSum] operation over n
+// elements separated by gaps of batch. It implements [GaloisElementsProvider].
+type InnerSumParameters struct {
+	Batch, N int
+}
+
+// GaloisElements returns the list of Galois elements required to evaluate the
+// described inner sum (see [GaloisElementsForInnerSum]).
+func (p InnerSumParameters) GaloisElements(params ParameterProvider) []uint64 {
+	return GaloisElementsForInnerSum(params, p.Batch, p.N)
+}
+
+// ReplicateParameters describes an [Evaluator.Replicate] operation over n
+// elements separated by gaps of batch. It implements [GaloisElementsProvider].
+type ReplicateParameters struct {
+	Batch, N int
+}
+
+// GaloisElements returns the list of Galois elements required to evaluate the
+// described replication (see [GaloisElementsForReplicate]).
+func (p ReplicateParameters) GaloisElements(params ParameterProvider) []uint64 {
+	return GaloisElementsForReplicate(params, p.Batch, p.N)
+}
+
+// TraceParameters describes an [Evaluator.Trace] operation with the given
+// LogN parameter. It implements [GaloisElementsProvider].
+type TraceParameters struct {
+	LogN int
+}
+
+// GaloisElements returns the list of Galois elements required to evaluate the
+// described trace (see [GaloisElementsForTrace]).
+func (p TraceParameters) GaloisElements(params ParameterProvider) []uint64 {
+	return GaloisElementsForTrace(params, p.LogN)
+}
@@ -0,0 +1,53 @@
+package rlwe
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+func TestAggregateGaloisElements(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	providers := []GaloisElementsProvider{
+		InnerSumParameters{Batch: 1, N: 8},
+		InnerSumParameters{Batch: 1, N: 8}, // duplicate, must not be listed twice
+		ReplicateParameters{Batch: 2, N: 4},
+		TraceParameters{LogN: params.LogN() - 2},
+	}
+
+	galEls := AggregateGaloisElements(params, providers...)
+
+	// The aggregation is the sorted union of the individual requirements.
+	keys := make(map[uint64]bool)
+	for _, provider := range providers {
+		for _, galEl := range provider.GaloisElements(params) {
+			keys[galEl] = true
+		}
+	}
+
+	require.Equal(t, utils.GetSortedKeys(keys), galEls)
+	require.True(t, sort.SliceIsSorted(galEls, func(i, j int) bool { return galEls[i] < galEls[j] }))
+
+	// The aggregated elements match the per-circuit helpers.
+	for _, galEl := range GaloisElementsForInnerSum(params, 1, 8) {
+		require.Contains(t, galEls, galEl)
+	}
+	for _, galEl := range GaloisElementsForReplicate(params, 2, 4) {
+		require.Contains(t, galEls, galEl)
+	}
+	for _, galEl := range GaloisElementsForTrace(params, params.LogN()-2) {
+		require.Contains(t, galEls, galEl)
+	}
+
+	require.Empty(t, AggregateGaloisElements(params))
+}
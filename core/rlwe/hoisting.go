@@ -0,0 +1,93 @@
+package rlwe
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/ring/ringqp"
+)
+
+// AutomorphismHoistedMany applies the automorphism X^{i} -> X^{i*galEl} for each element of
+// galEls on ctIn and writes the result on the corresponding entry of opOut. The RNS
+// decomposition of the degree-1 element of ctIn is computed once and reused across all the
+// automorphisms (rotation hoisting), so for k automorphisms a single call to
+// [Evaluator.DecomposeNTT] is amortized over the k key-switches, which is much faster than k
+// calls to [Evaluator.Automorphism].
+//
+// The method requires the [GaloisKey] of each element of galEls different from 1 to be loaded
+// in the [Evaluator], and ctIn to be of degree 1.
+func (eval Evaluator) AutomorphismHoistedMany(ctIn *Ciphertext, galEls []uint64, opOut map[uint64]*Ciphertext) (err error) {
+
+	if ctIn.Degree() != 1 {
+		return fmt.Errorf("cannot AutomorphismHoistedMany: ctIn degree must be 1 but is %d", ctIn.Degree())
+	}
+
+	levelQ := ctIn.Level()
+	levelP := eval.params.MaxLevelP()
+
+	eval.DecomposeNTT(levelQ, levelP, levelP+1, ctIn.Value[1], ctIn.IsNTT, eval.BuffDecompQP)
+
+	for _, galEl := range galEls {
+
+		ct, ok := opOut[galEl]
+		if !ok {
+			return fmt.Errorf("cannot AutomorphismHoistedMany: opOut has no receiver for galEl %d", galEl)
+		}
+
+		if err = eval.AutomorphismHoisted(levelQ, ctIn, eval.BuffDecompQP, galEl, ct); err != nil {
+			return fmt.Errorf("cannot AutomorphismHoistedMany: %w", err)
+		}
+	}
+
+	return
+}
+
+// AutomorphismHoistedManyNew is the allocating variant of [Evaluator.AutomorphismHoistedMany]:
+// it returns the map from each element of galEls to the corresponding automorphism of ctIn.
+func (eval Evaluator) AutomorphismHoistedManyNew(ctIn *Ciphertext, galEls []uint64) (opOut map[uint64]*Ciphertext, err error) {
+
+	opOut = make(map[uint64]*Ciphertext, len(galEls))
+	for _, galEl := range galEls {
+		opOut[galEl] = NewCiphertext(eval.params, 1, ctIn.Level())
+	}
+
+	return opOut, eval.AutomorphismHoistedMany(ctIn, galEls, opOut)
+}
+
+// AutomorphismHoistedLazyMany applies the automorphism X^{i} -> X^{i*galEl} for each element
+// of galEls on ctIn with a single shared RNS decomposition, and returns each result as an
+// [Element] modulo QP scaled by P (double-hoisting): the outputs can be multiplied by
+// plaintexts and accumulated modulo QP (e.g. as the inner loop of a baby-step giant-step
+// linear transformation, see PreRotatedCiphertextForDiagonalMatrixMultiplication of the
+// lintrans package), with the division by P deferred to a single final call to
+// [Evaluator.ModDown], instead of one per automorphism.
+//
+// The identity element (galEl = 1) is skipped, as ctIn itself is already available to the
+// caller. The method requires the [GaloisKey] of each other element of galEls to be loaded in
+// the [Evaluator], and ctIn to be of degree 1.
+func (eval Evaluator) AutomorphismHoistedLazyMany(levelQ int, ctIn *Ciphertext, galEls []uint64) (opOut map[uint64]*Element[ringqp.Poly], err error) {
+
+	if ctIn.Degree() != 1 {
+		return nil, fmt.Errorf("cannot AutomorphismHoistedLazyMany: ctIn degree must be 1 but is %d", ctIn.Degree())
+	}
+
+	levelP := eval.params.MaxLevelP()
+
+	eval.DecomposeNTT(levelQ, levelP, levelP+1, ctIn.Value[1], ctIn.IsNTT, eval.BuffDecompQP)
+
+	opOut = make(map[uint64]*Element[ringqp.Poly], len(galEls))
+
+	for _, galEl := range galEls {
+
+		if galEl == 1 {
+			continue
+		}
+
+		opOut[galEl] = NewElementExtended(eval.params, 1, levelQ, levelP)
+
+		if err = eval.AutomorphismHoistedLazy(levelQ, ctIn, eval.BuffDecompQP, galEl, opOut[galEl]); err != nil {
+			return nil, fmt.Errorf("cannot AutomorphismHoistedLazyMany: %w", err)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,78 @@
+package rlwe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomorphismHoistedMany(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN:    10,
+		LogQ:    []int{60},
+		LogP:    []int{60},
+		NTTFlag: true,
+	})
+	require.NoError(t, err)
+
+	tc, err := NewTestContext(params)
+	require.NoError(t, err)
+
+	level := params.MaxLevel()
+	ringQ := params.RingQ().AtLevel(level)
+
+	noise := float64(params.LogN() + 1)
+
+	pt := genPlaintextNTT(params, level, 1<<40)
+
+	ct, err := tc.enc.EncryptNew(pt)
+	require.NoError(t, err)
+
+	galEls := append([]uint64{1}, params.GaloisElements([]int{1, 2, 5})...)
+
+	eval := NewEvaluator(params, NewMemEvaluationKeySet(nil, tc.kgen.GenGaloisKeysNew(galEls, tc.sk)...))
+
+	verify := func(t *testing.T, galEl uint64, opOut *Ciphertext) {
+		ref := NewPlaintext(params, level)
+		ringQ.AutomorphismNTT(pt.Value, galEl, ref.Value)
+		ptDec := tc.dec.DecryptNew(opOut)
+		ringQ.Sub(ptDec.Value, ref.Value, ptDec.Value)
+		ringQ.INTT(ptDec.Value, ptDec.Value)
+		require.GreaterOrEqual(t, noise, ringQ.Log2OfStandardDeviation(ptDec.Value))
+	}
+
+	t.Run(testString(params, level, params.MaxLevelP(), 0, "AutomorphismHoistedMany"), func(t *testing.T) {
+
+		cts, err := eval.AutomorphismHoistedManyNew(ct, galEls)
+		require.NoError(t, err)
+		require.Len(t, cts, len(galEls))
+
+		for _, galEl := range galEls {
+			verify(t, galEl, cts[galEl])
+		}
+
+		// Missing receiver
+		require.Error(t, eval.AutomorphismHoistedMany(ct, galEls, map[uint64]*Ciphertext{}))
+
+		// Input of invalid degree
+		ct2 := NewCiphertext(params, 2, level)
+		require.Error(t, eval.AutomorphismHoistedMany(ct2, galEls, cts))
+	})
+
+	t.Run(testString(params, level, params.MaxLevelP(), 0, "AutomorphismHoistedLazyMany"), func(t *testing.T) {
+
+		ctsQP, err := eval.AutomorphismHoistedLazyMany(level, ct, galEls)
+		require.NoError(t, err)
+
+		// The identity element is skipped
+		require.Len(t, ctsQP, len(galEls)-1)
+
+		for galEl, ctQP := range ctsQP {
+			opOut := NewCiphertext(params, 1, level)
+			eval.ModDown(level, params.MaxLevelP(), ctQP, opOut)
+			*opOut.MetaData = *ct.MetaData
+			verify(t, galEl, opOut)
+		}
+	})
+}
@@ -335,10 +335,16 @@ func (eval Evaluator) InnerFunction(ctIn *Ciphertext, batchSize, n int, f func(a
 	opOut.Resize(opOut.Degree(), levelQ)
 	*opOut.MetaData = *ctIn.MetaData
 
-	P0 := params.RingQ().NewPoly()
-	P1 := params.RingQ().NewPoly()
-	P2 := params.RingQ().NewPoly()
-	P3 := params.RingQ().NewPoly()
+	P0 := eval.PolyPool.Get(levelQ)
+	P1 := eval.PolyPool.Get(levelQ)
+	P2 := eval.PolyPool.Get(levelQ)
+	P3 := eval.PolyPool.Get(levelQ)
+	defer func() {
+		eval.PolyPool.Put(P0)
+		eval.PolyPool.Put(P1)
+		eval.PolyPool.Put(P2)
+		eval.PolyPool.Put(P3)
+	}()
 
 	ctInNTT := NewCiphertext(params, 1, levelQ)
 
@@ -1,11 +1,15 @@
 package rlwe
 
 import (
+	"bufio"
 	"fmt"
+	"io"
+	"sync"
 
 	"github.com/tuneinsight/lattigo/v6/ring"
 	"github.com/tuneinsight/lattigo/v6/ring/ringqp"
 	"github.com/tuneinsight/lattigo/v6/utils"
+	"github.com/tuneinsight/lattigo/v6/utils/buffer"
 	"github.com/tuneinsight/lattigo/v6/utils/sampling"
 )
 
@@ -80,7 +84,26 @@ func (kgen KeyGenerator) GenPublicKeyNew(sk *SecretKey) (pk *PublicKey) {
 
 // GenPublicKey generates a [PublicKey] from the provided [SecretKey].
 func (kgen KeyGenerator) GenPublicKey(sk *SecretKey, pk *PublicKey) {
-	if err := kgen.WithKey(sk).EncryptZero(Element[ringqp.Poly]{
+
+	enc := kgen.WithKey(sk)
+
+	// For a compressed public key, a seed is created and stored in the PublicKey
+	// struct while an uncompressed key uses an ephemeral seed.
+	if pk.IsCompressed() {
+		pk.Seed = make([]byte, 32)
+		if n, err := kgen.prng.Read(pk.Seed); n != 32 || err != nil {
+			panic(fmt.Errorf("unable to sample public key seed"))
+		}
+
+		sampler, err := sampling.NewKeyedPRNG(pk.Seed)
+		if err != nil {
+			panic(fmt.Errorf("sampling.NewKeyedPRNG: %w", err))
+		}
+
+		enc = enc.WithPRNG(sampler)
+	}
+
+	if err := enc.EncryptZero(Element[ringqp.Poly]{
 		MetaData: &MetaData{CiphertextMetaData: CiphertextMetaData{IsNTT: true, IsMontgomery: true}},
 		Value:    []ringqp.Poly(pk.Value),
 	}); err != nil {
@@ -106,7 +129,10 @@ func (kgen KeyGenerator) GenRelinearizationKeyNew(sk *SecretKey, evkParams ...Ev
 		degree = 0
 	}
 
-	rlk = &RelinearizationKey{EvaluationKey: EvaluationKey{GadgetCiphertext: *NewGadgetCiphertext(kgen.params, degree, levelQ, levelP, BaseTwoDecomposition)}}
+	rlk = &RelinearizationKey{EvaluationKey: EvaluationKey{
+		GadgetCiphertext:      *NewGadgetCiphertext(kgen.params, degree, levelQ, levelP, BaseTwoDecomposition),
+		ParametersFingerprint: kgen.params.Fingerprint(),
+	}}
 	kgen.GenRelinearizationKey(sk, rlk)
 	return
 }
@@ -129,8 +155,11 @@ func (kgen KeyGenerator) GenGaloisKeyNew(galEl uint64, sk *SecretKey, evkParams
 	}
 
 	gk = &GaloisKey{
-		EvaluationKey: EvaluationKey{GadgetCiphertext: *NewGadgetCiphertext(kgen.params, degree, levelQ, levelP, BaseTwoDecomposition)},
-		NthRoot:       kgen.params.GetRLWEParameters().RingQ().NthRoot(),
+		EvaluationKey: EvaluationKey{
+			GadgetCiphertext:      *NewGadgetCiphertext(kgen.params, degree, levelQ, levelP, BaseTwoDecomposition),
+			ParametersFingerprint: kgen.params.Fingerprint(),
+		},
+		NthRoot: kgen.params.GetRLWEParameters().RingQ().NthRoot(),
 	}
 	kgen.GenGaloisKey(galEl, sk, gk)
 	return
@@ -204,6 +233,115 @@ func (kgen KeyGenerator) GenGaloisKeysNew(galEls []uint64, sk *SecretKey, evkPar
 	return
 }
 
+// GenGaloisKeysWriteTo generates the [GaloisKey] for each galois element in galEls and writes
+// each key on w as soon as it is produced, in the order of galEls. Unlike
+// [KeyGenerator.GenGaloisKeysNew], a single key is materialized in memory regardless of
+// len(galEls), enabling the generation of key sets larger than the available memory (e.g.
+// bootstrapping key sets) directly to disk or to a network connection. Each key is written
+// as by [GaloisKey.WriteTo] and can be read back one by one with [GaloisKey.ReadFrom].
+// Returns the total number of bytes written.
+//
+// Unless w implements the [buffer.Writer] interface (see lattigo/utils/buffer/writer.go),
+// it will be wrapped into a [bufio.Writer], which is flushed before returning.
+func (kgen KeyGenerator) GenGaloisKeysWriteTo(galEls []uint64, sk *SecretKey, w io.Writer, evkParams ...EvaluationKeyParameters) (n int64, err error) {
+	switch w := w.(type) {
+	case buffer.Writer:
+
+		levelQ, levelP, BaseTwoDecomposition, compressed := ResolveEvaluationKeyParameters(kgen.params, evkParams)
+		gk := newGaloisKey(kgen.params, levelQ, levelP, BaseTwoDecomposition, compressed)
+
+		var inc int64
+		for _, galEl := range galEls {
+
+			kgen.GenGaloisKey(galEl, sk, gk)
+
+			if inc, err = gk.WriteTo(w); err != nil {
+				return n + inc, fmt.Errorf("cannot GenGaloisKeysWriteTo: %w", err)
+			}
+
+			n += inc
+		}
+
+		return n, w.Flush()
+
+	default:
+		return kgen.GenGaloisKeysWriteTo(galEls, sk, bufio.NewWriter(w), evkParams...)
+	}
+}
+
+// GenGaloisKeysConcurrent generates the [GaloisKey] objects for all galois elements in galEls
+// concurrently, with at most workers concurrent generations, and calls emit for each key as
+// soon as it is ready. Unlike [KeyGenerator.GenGaloisKeysNew], at most workers keys are
+// materialized at any given time, so each key can for example be directly serialized and
+// discarded by emit, keeping the memory usage bounded.
+//
+// emit is called serially (never concurrently), but the order in which the keys are emitted
+// is not guaranteed to follow the order of galEls. If emit returns an error, no further key
+// generation is scheduled and the error is returned.
+func (kgen KeyGenerator) GenGaloisKeysConcurrent(galEls []uint64, sk *SecretKey, workers int, emit func(gk *GaloisKey) error, evkParams ...EvaluationKeyParameters) error {
+
+	if workers < 1 {
+		workers = 1
+	}
+
+	workers = utils.Min(workers, len(galEls))
+
+	jobs := make(chan uint64, len(galEls))
+	for _, galEl := range galEls {
+		jobs <- galEl
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var emitErr error
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// Each worker uses its own shallow copy of the KeyGenerator,
+			// as the temporary buffers cannot be shared across goroutines.
+			kgenCpy := kgen.ShallowCopy()
+
+			for galEl := range jobs {
+
+				mu.Lock()
+				stop := emitErr != nil
+				mu.Unlock()
+
+				if stop {
+					return
+				}
+
+				gk := kgenCpy.GenGaloisKeyNew(galEl, sk, evkParams...)
+
+				mu.Lock()
+				if emitErr == nil {
+					emitErr = emit(gk)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return emitErr
+}
+
+// ShallowCopy creates a shallow copy of the receiver in which all the read-only data-structures
+// are shared with the receiver and the temporary buffers are reallocated. The receiver and the
+// returned [KeyGenerator] can be used concurrently.
+func (kgen KeyGenerator) ShallowCopy() *KeyGenerator {
+	return &KeyGenerator{
+		Encryptor: kgen.Encryptor.ShallowCopy(),
+		bufSkIn:   kgen.params.RingQ().NewPoly(),
+		bufSkOut:  kgen.params.RingQP().NewPoly(),
+	}
+}
+
 // GenEvaluationKeysForRingSwapNew generates the necessary evaluation keys to switch from a standard ring to to a conjugate invariant ring and vice-versa.
 func (kgen KeyGenerator) GenEvaluationKeysForRingSwapNew(skStd, skConjugateInvariant *SecretKey, evkParams ...EvaluationKeyParameters) (stdToci, ciToStd *EvaluationKey) {
 
@@ -0,0 +1,79 @@
+package rlwe
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/utils/buffer"
+)
+
+func TestGenGaloisKeysConcurrent(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	tc, err := NewTestContext(params)
+	require.NoError(t, err)
+
+	galEls := params.GaloisElements([]int{1, 2, 3, 4, 5, 6, 7, 8})
+
+	t.Run("GenGaloisKeysConcurrent", func(t *testing.T) {
+
+		gks := map[uint64]*GaloisKey{}
+		require.NoError(t, tc.kgen.GenGaloisKeysConcurrent(galEls, tc.sk, 4, func(gk *GaloisKey) error {
+			gks[gk.GaloisElement] = gk
+			return nil
+		}))
+
+		require.Len(t, gks, len(galEls))
+
+		BaseRNSDecompositionVectorSize := params.BaseRNSDecompositionVectorSize(params.MaxLevelQ(), params.MaxLevelP())
+		noiseBound := math.Log2(math.Sqrt(float64(BaseRNSDecompositionVectorSize))*params.NoiseFreshSK()) + 1
+
+		for _, galEl := range galEls {
+			gk, ok := gks[galEl]
+			require.True(t, ok)
+			require.GreaterOrEqual(t, noiseBound, NoiseGaloisKey(gk, tc.sk, params))
+		}
+	})
+
+	t.Run("GenGaloisKeysConcurrent/EmitError", func(t *testing.T) {
+
+		errEmit := fmt.Errorf("emit failed")
+		var emitted int
+		err := tc.kgen.GenGaloisKeysConcurrent(galEls, tc.sk, 2, func(gk *GaloisKey) error {
+			emitted++
+			return errEmit
+		})
+		require.ErrorIs(t, err, errEmit)
+		require.Less(t, emitted, len(galEls))
+	})
+
+	t.Run("GenGaloisKeysWriteTo", func(t *testing.T) {
+
+		buf := buffer.NewBufferSize(len(galEls) * NewGaloisKey(params).BinarySize())
+
+		n, err := tc.kgen.GenGaloisKeysWriteTo(galEls, tc.sk, buf)
+		require.NoError(t, err)
+		require.Equal(t, int64(buf.Size()), n)
+
+		BaseRNSDecompositionVectorSize := params.BaseRNSDecompositionVectorSize(params.MaxLevelQ(), params.MaxLevelP())
+		noiseBound := math.Log2(math.Sqrt(float64(BaseRNSDecompositionVectorSize))*params.NoiseFreshSK()) + 1
+
+		// The keys are read back one by one, in the order of galEls.
+		for _, galEl := range galEls {
+			gk := new(GaloisKey)
+			_, err = gk.ReadFrom(buf)
+			require.NoError(t, err)
+			require.Equal(t, galEl, gk.GaloisElement)
+			require.GreaterOrEqual(t, noiseBound, NoiseGaloisKey(gk, tc.sk, params))
+		}
+	})
+}
@@ -15,13 +15,20 @@ import (
 
 // SecretKey is a type for generic RLWE secret keys.
 // The Value field stores the polynomial in NTT and Montgomery form.
+// The ParametersFingerprint field stores the fingerprint (see [Parameters.Fingerprint])
+// of the parameters the key was allocated under; a zero value disables the
+// parameters-mismatch check at use.
 type SecretKey struct {
-	Value ringqp.Poly
+	Value                 ringqp.Poly
+	ParametersFingerprint uint64
 }
 
 // NewSecretKey generates a new [SecretKey] with zero values.
 func NewSecretKey(params ParameterProvider) *SecretKey {
-	return &SecretKey{Value: params.GetRLWEParameters().RingQP().NewPoly()}
+	return &SecretKey{
+		Value:                 params.GetRLWEParameters().RingQP().NewPoly(),
+		ParametersFingerprint: params.GetRLWEParameters().Fingerprint(),
+	}
 }
 
 func (sk SecretKey) Equal(other *SecretKey) bool {
@@ -41,12 +48,12 @@ func (sk SecretKey) LevelP() int {
 
 // CopyNew creates a deep copy of the receiver [SecretKey] and returns it.
 func (sk SecretKey) CopyNew() *SecretKey {
-	return &SecretKey{*sk.Value.CopyNew()}
+	return &SecretKey{Value: *sk.Value.CopyNew(), ParametersFingerprint: sk.ParametersFingerprint}
 }
 
 // BinarySize returns the serialized size of the object in bytes.
 func (sk SecretKey) BinarySize() (dataLen int) {
-	return sk.Value.BinarySize()
+	return 8 + sk.Value.BinarySize()
 }
 
 // WriteTo writes the object on an [io.Writer]. It implements the [io.WriterTo]
@@ -61,7 +68,28 @@ func (sk SecretKey) BinarySize() (dataLen int) {
 //   - When writing to a pre-allocated var b []byte, it is preferable to pass
 //     buffer.NewBuffer(b) as w (see lattigo/utils/buffer/buffer.go).
 func (sk SecretKey) WriteTo(w io.Writer) (n int64, err error) {
-	return sk.Value.WriteTo(w)
+	switch w := w.(type) {
+	case buffer.Writer:
+
+		var inc int64
+
+		if inc, err = buffer.WriteUint64(w, sk.ParametersFingerprint); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		if inc, err = sk.Value.WriteTo(w); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		return
+
+	default:
+		return sk.WriteTo(bufio.NewWriter(w))
+	}
 }
 
 // ReadFrom reads on the object from an [io.Writer]. It implements the
@@ -76,18 +104,42 @@ func (sk SecretKey) WriteTo(w io.Writer) (n int64, err error) {
 //   - When reading from a var b []byte, it is preferable to pass a buffer.NewBuffer(b)
 //     as w (see lattigo/utils/buffer/buffer.go).
 func (sk *SecretKey) ReadFrom(r io.Reader) (n int64, err error) {
-	return sk.Value.ReadFrom(r)
+	switch r := r.(type) {
+	case buffer.Reader:
+
+		var inc int64
+
+		if inc, err = buffer.ReadUint64(r, &sk.ParametersFingerprint); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		if inc, err = sk.Value.ReadFrom(r); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		return
+
+	default:
+		return sk.ReadFrom(bufio.NewReader(r))
+	}
 }
 
 // MarshalBinary encodes the object into a binary form on a newly allocated slice of bytes.
 func (sk SecretKey) MarshalBinary() (p []byte, err error) {
-	return sk.Value.MarshalBinary()
+	buf := buffer.NewBufferSize(sk.BinarySize())
+	_, err = sk.WriteTo(buf)
+	return buf.Bytes(), err
 }
 
 // UnmarshalBinary decodes a slice of bytes generated by
 // [SecretKey.MarshalBinary] or [SecretKey.WriteTo] on the object.
 func (sk *SecretKey) UnmarshalBinary(p []byte) (err error) {
-	return sk.Value.UnmarshalBinary(p)
+	_, err = sk.ReadFrom(buffer.NewBuffer(p))
+	return
 }
 
 func (sk *SecretKey) isEncryptionKey() {}
@@ -204,14 +256,69 @@ func (p *VectorQP) UnmarshalBinary(b []byte) error {
 
 // PublicKey is a type for generic RLWE public keys.
 // The Value field stores the polynomials in NTT and Montgomery form.
+// The ParametersFingerprint field stores the fingerprint (see [Parameters.Fingerprint])
+// of the parameters the key was allocated under; a zero value disables the
+// parameters-mismatch check at use.
 type PublicKey struct {
 	Value VectorQP
+
+	// Seed stores the 32-byte seed of the uniform element of a compressed
+	// [PublicKey], for which only the first element is stored explicitly.
+	// See [NewCompressedPublicKey] and [PublicKey.Expand].
+	Seed []byte
+
+	ParametersFingerprint uint64
 }
 
 // NewPublicKey returns a new [PublicKey] with zero values.
 func NewPublicKey(params ParameterProvider) (pk *PublicKey) {
 	p := params.GetRLWEParameters()
-	return &PublicKey{Value: NewVectorQP(params, 2, p.MaxLevelQ(), p.MaxLevelP())}
+	return &PublicKey{
+		Value:                 NewVectorQP(params, 2, p.MaxLevelQ(), p.MaxLevelP()),
+		ParametersFingerprint: p.Fingerprint(),
+	}
+}
+
+// NewCompressedPublicKey returns a new [PublicKey] in its seeded (compressed)
+// representation, in which the uniform element is replaced by a 32-byte PRNG
+// seed, halving the serialized size. A compressed key is expanded back to its
+// full form with [PublicKey.Expand] before use.
+func NewCompressedPublicKey(params ParameterProvider) (pk *PublicKey) {
+	p := params.GetRLWEParameters()
+	return &PublicKey{
+		Value:                 NewVectorQP(params, 1, p.MaxLevelQ(), p.MaxLevelP()),
+		ParametersFingerprint: p.Fingerprint(),
+	}
+}
+
+// IsCompressed indicates whether the [PublicKey] is in its seeded
+// representation, i.e. whether its uniform element is stored as a seed.
+func (p PublicKey) IsCompressed() bool {
+	return len(p.Value) == 1
+}
+
+// Expand expands a compressed [PublicKey] of the form (-a*sk + e) to
+// (-a*sk + e, a), regenerating the uniform element a from the stored seed.
+// The method returns an error if the [PublicKey] is not compressed.
+func (p *PublicKey) Expand(params ParameterProvider) error {
+
+	if !p.IsCompressed() {
+		return fmt.Errorf("public key is not compressed")
+	}
+
+	prng, err := sampling.NewKeyedPRNG(p.Seed)
+	if err != nil {
+		return fmt.Errorf("sampling.NewKeyedPRNG: %w", err)
+	}
+
+	rqp := params.GetRLWEParameters().RingQP().AtLevel(p.LevelQ(), p.LevelP())
+
+	a := rqp.NewPoly()
+	ringqp.NewUniformSampler(prng, rqp).Read(a)
+
+	p.Value = VectorQP{p.Value[0], a}
+
+	return nil
 }
 
 func (p PublicKey) LevelQ() int {
@@ -224,16 +331,16 @@ func (p PublicKey) LevelP() int {
 
 // CopyNew creates a deep copy of the target [PublicKey] and returns it.
 func (p PublicKey) CopyNew() *PublicKey {
-	return &PublicKey{Value: *p.Value.CopyNew()}
+	return &PublicKey{Value: *p.Value.CopyNew(), Seed: slices.Clone(p.Seed), ParametersFingerprint: p.ParametersFingerprint}
 }
 
 // Equal performs a deep equal.
 func (p PublicKey) Equal(other *PublicKey) bool {
-	return p.Value.Equal(&other.Value)
+	return slices.Equal(p.Seed, other.Seed) && p.Value.Equal(&other.Value)
 }
 
 func (p PublicKey) BinarySize() int {
-	return p.Value.BinarySize()
+	return 8 + 4 + len(p.Seed) + p.Value.BinarySize()
 }
 
 // WriteTo writes the object on an [io.Writer]. It implements the [io.WriterTo]
@@ -248,7 +355,40 @@ func (p PublicKey) BinarySize() int {
 //   - When writing to a pre-allocated var b []byte, it is preferable to pass
 //     buffer.NewBuffer(b) as w (see lattigo/utils/buffer/buffer.go).
 func (p PublicKey) WriteTo(w io.Writer) (n int64, err error) {
-	return p.Value.WriteTo(w)
+	switch w := w.(type) {
+	case buffer.Writer:
+
+		var inc int64
+
+		if inc, err = buffer.WriteUint64(w, p.ParametersFingerprint); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		if inc, err = buffer.WriteUint32(w, uint32(len(p.Seed))); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		if inc, err = buffer.Write(w, p.Seed); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		if inc, err = p.Value.WriteTo(w); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		return
+
+	default:
+		return p.WriteTo(bufio.NewWriter(w))
+	}
 }
 
 // ReadFrom reads on the object from an [io.Writer]. It implements the
@@ -263,18 +403,59 @@ func (p PublicKey) WriteTo(w io.Writer) (n int64, err error) {
 //   - When reading from a var b []byte, it is preferable to pass a buffer.NewBuffer(b)
 //     as w (see lattigo/utils/buffer/buffer.go).
 func (p *PublicKey) ReadFrom(r io.Reader) (n int64, err error) {
-	return p.Value.ReadFrom(r)
+	switch r := r.(type) {
+	case buffer.Reader:
+
+		var inc int64
+
+		if inc, err = buffer.ReadUint64(r, &p.ParametersFingerprint); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		var seedLen uint32
+		if inc, err = buffer.ReadUint32(r, &seedLen); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		p.Seed = nil
+		if seedLen > 0 {
+			p.Seed = make([]byte, seedLen)
+			if inc, err = buffer.Read(r, p.Seed); err != nil {
+				return n + inc, err
+			}
+
+			n += inc
+		}
+
+		if inc, err = p.Value.ReadFrom(r); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		return
+
+	default:
+		return p.ReadFrom(bufio.NewReader(r))
+	}
 }
 
 // MarshalBinary encodes the object into a binary form on a newly allocated slice of bytes.
 func (p PublicKey) MarshalBinary() ([]byte, error) {
-	return p.Value.MarshalBinary()
+	buf := buffer.NewBufferSize(p.BinarySize())
+	_, err := p.WriteTo(buf)
+	return buf.Bytes(), err
 }
 
 // UnmarshalBinary decodes a slice of bytes generated by
 // [PublicKey.MarshalBinary] or [PublicKey.WriteTo] on the object.
 func (p *PublicKey) UnmarshalBinary(b []byte) error {
-	return p.Value.UnmarshalBinary(b)
+	_, err := p.ReadFrom(buffer.NewBuffer(b))
+	return err
 }
 
 func (p *PublicKey) isEncryptionKey() {}
@@ -293,6 +474,11 @@ func (p *PublicKey) isEncryptionKey() {}
 type EvaluationKey struct {
 	GadgetCiphertext
 	Seed []byte
+
+	// ParametersFingerprint stores the fingerprint (see [Parameters.Fingerprint]) of the
+	// parameters the key was allocated under; a zero value disables the
+	// parameters-mismatch check at use.
+	ParametersFingerprint uint64
 }
 
 type EvaluationKeyParameters struct {
@@ -341,7 +527,8 @@ func newEvaluationKey(params Parameters, levelQ, levelP, BaseTwoDecomposition in
 		degree = 0
 	}
 	return &EvaluationKey{
-		GadgetCiphertext: *NewGadgetCiphertext(params, degree, levelQ, levelP, BaseTwoDecomposition),
+		GadgetCiphertext:      *NewGadgetCiphertext(params, degree, levelQ, levelP, BaseTwoDecomposition),
+		ParametersFingerprint: params.Fingerprint(),
 	}
 }
 
@@ -419,7 +606,132 @@ func (evk EvaluationKey) Expand(params ParameterProvider, buffer *GadgetCipherte
 
 // CopyNew creates a deep copy of the target [EvaluationKey] and returns it.
 func (evk EvaluationKey) CopyNew() *EvaluationKey {
-	return &EvaluationKey{GadgetCiphertext: *evk.GadgetCiphertext.CopyNew()}
+	return &EvaluationKey{GadgetCiphertext: *evk.GadgetCiphertext.CopyNew(), Seed: slices.Clone(evk.Seed), ParametersFingerprint: evk.ParametersFingerprint}
+}
+
+// Equal performs a deep equal.
+func (evk EvaluationKey) Equal(other *EvaluationKey) bool {
+	return evk.ParametersFingerprint == other.ParametersFingerprint && slices.Equal(evk.Seed, other.Seed) && evk.GadgetCiphertext.Equal(&other.GadgetCiphertext)
+}
+
+// BinarySize returns the serialized size of the object in bytes.
+func (evk EvaluationKey) BinarySize() int {
+	return 8 + 4 + len(evk.Seed) + evk.GadgetCiphertext.BinarySize()
+}
+
+// WriteTo writes the object on an [io.Writer]. It implements the [io.WriterTo]
+// interface, and will write exactly object.BinarySize() bytes on w.
+//
+// Unless w implements the [buffer.Writer] interface (see lattigo/utils/buffer/writer.go),
+// it will be wrapped into a [bufio.Writer]. Since this requires allocations, it
+// is preferable to pass a [buffer.Writer] directly:
+//
+//   - When writing multiple times to a [io.Writer], it is preferable to first wrap the
+//     io.Writer in a pre-allocated [bufio.Writer].
+//   - When writing to a pre-allocated var b []byte, it is preferable to pass
+//     buffer.NewBuffer(b) as w (see lattigo/utils/buffer/buffer.go).
+func (evk EvaluationKey) WriteTo(w io.Writer) (n int64, err error) {
+	switch w := w.(type) {
+	case buffer.Writer:
+
+		var inc int64
+
+		if inc, err = buffer.WriteUint64(w, evk.ParametersFingerprint); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		if inc, err = buffer.WriteUint32(w, uint32(len(evk.Seed))); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		if inc, err = buffer.Write(w, evk.Seed); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		if inc, err = evk.GadgetCiphertext.WriteTo(w); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		return
+
+	default:
+		return evk.WriteTo(bufio.NewWriter(w))
+	}
+}
+
+// ReadFrom reads on the object from an [io.Writer]. It implements the
+// [io.ReaderFrom] interface.
+//
+// Unless r implements the [buffer.Reader] interface (see see lattigo/utils/buffer/reader.go),
+// it will be wrapped into a [bufio.Reader]. Since this requires allocation, it
+// is preferable to pass a [buffer.Reader] directly:
+//
+//   - When reading multiple values from a [io.Reader], it is preferable to first
+//     first wrap io.Reader in a pre-allocated [bufio.Reader].
+//   - When reading from a var b []byte, it is preferable to pass a buffer.NewBuffer(b)
+//     as w (see lattigo/utils/buffer/buffer.go).
+func (evk *EvaluationKey) ReadFrom(r io.Reader) (n int64, err error) {
+	switch r := r.(type) {
+	case buffer.Reader:
+
+		var inc int64
+
+		if inc, err = buffer.ReadUint64(r, &evk.ParametersFingerprint); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		var seedLen uint32
+		if inc, err = buffer.ReadUint32(r, &seedLen); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		evk.Seed = nil
+		if seedLen > 0 {
+			evk.Seed = make([]byte, seedLen)
+			if inc, err = buffer.Read(r, evk.Seed); err != nil {
+				return n + inc, err
+			}
+
+			n += inc
+		}
+
+		if inc, err = evk.GadgetCiphertext.ReadFrom(r); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		return
+
+	default:
+		return evk.ReadFrom(bufio.NewReader(r))
+	}
+}
+
+// MarshalBinary encodes the object into a binary form on a newly allocated slice of bytes.
+func (evk EvaluationKey) MarshalBinary() (p []byte, err error) {
+	buf := buffer.NewBufferSize(evk.BinarySize())
+	_, err = evk.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary decodes a slice of bytes generated by
+// [EvaluationKey.MarshalBinary] or [EvaluationKey.WriteTo] on the object.
+func (evk *EvaluationKey) UnmarshalBinary(p []byte) (err error) {
+	_, err = evk.ReadFrom(buffer.NewBuffer(p))
+	return
 }
 
 // RelinearizationKey is type of [EvaluationKey] used for ciphertext multiplication compactness.
@@ -442,7 +754,10 @@ func newRelinearizationKey(params Parameters, levelQ, levelP, BaseTwoDecompositi
 	if compressed {
 		degree = 0
 	}
-	return &RelinearizationKey{EvaluationKey: EvaluationKey{GadgetCiphertext: *NewGadgetCiphertext(params, degree, levelQ, levelP, BaseTwoDecomposition)}}
+	return &RelinearizationKey{EvaluationKey: EvaluationKey{
+		GadgetCiphertext:      *NewGadgetCiphertext(params, degree, levelQ, levelP, BaseTwoDecomposition),
+		ParametersFingerprint: params.Fingerprint(),
+	}}
 }
 
 // CopyNew creates a deep copy of the object and returns it.
@@ -481,7 +796,8 @@ func newGaloisKey(params Parameters, levelQ, levelP, BaseTwoDecomposition int, c
 	}
 	return &GaloisKey{
 		EvaluationKey: EvaluationKey{
-			GadgetCiphertext: *NewGadgetCiphertext(params, degree, levelQ, levelP, BaseTwoDecomposition),
+			GadgetCiphertext:      *NewGadgetCiphertext(params, degree, levelQ, levelP, BaseTwoDecomposition),
+			ParametersFingerprint: params.Fingerprint(),
 		},
 		NthRoot: params.GetRLWEParameters().RingQ().NthRoot(),
 	}
@@ -496,6 +812,11 @@ func (gk GaloisKey) CopyNew() *GaloisKey {
 	}
 }
 
+// Equal performs a deep equal.
+func (gk GaloisKey) Equal(other *GaloisKey) bool {
+	return gk.GaloisElement == other.GaloisElement && gk.NthRoot == other.NthRoot && gk.EvaluationKey.Equal(&other.EvaluationKey)
+}
+
 // BinarySize returns the serialized size of the object in bytes.
 func (gk GaloisKey) BinarySize() (size int) {
 	return gk.EvaluationKey.BinarySize() + 16
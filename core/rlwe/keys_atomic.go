@@ -0,0 +1,116 @@
+package rlwe
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// AtomicEvaluationKeySet is a thread-safe wrapper implementing the [EvaluationKeySet]
+// interface whose underlying set of keys can be replaced atomically with [AtomicEvaluationKeySet.Swap]
+// while [Evaluator] instances referencing the wrapper are in use.
+//
+// An [Evaluator] instantiated with an AtomicEvaluationKeySet (or pointed to one with
+// [Evaluator.WithKey]) observes key swaps immediately and without reallocating any of its
+// internal buffers, and so do all of its shallow copies: a single call to Swap rotates the
+// keys of every evaluator sharing the wrapper, which enables key rotation in a long-running
+// serving process without rebuilding the evaluator state.
+//
+// The automorphism permutation tables associated with the Galois keys are precomputed when
+// the keys are stored in the wrapper (at construction and at each Swap), and are retained
+// across swaps, so that an operation racing with a Swap can always complete with the key
+// set it started with.
+type AtomicEvaluationKeySet struct {
+	n       int
+	nthRoot uint64
+	state   atomic.Pointer[atomicEvaluationKeySetState]
+}
+
+type atomicEvaluationKeySetState struct {
+	evk               EvaluationKeySet
+	automorphismIndex map[uint64][]uint64
+}
+
+// NewAtomicEvaluationKeySet instantiates a new [AtomicEvaluationKeySet] holding the provided
+// [EvaluationKeySet], which cannot be nil. Ownership of evk is transferred to the wrapper:
+// it must not be modified afterward (replace it with [AtomicEvaluationKeySet.Swap] instead).
+func NewAtomicEvaluationKeySet(params ParameterProvider, evk EvaluationKeySet) (aevk *AtomicEvaluationKeySet, err error) {
+
+	p := params.GetRLWEParameters()
+
+	aevk = &AtomicEvaluationKeySet{
+		n:       p.N(),
+		nthRoot: p.RingQ().NthRoot(),
+	}
+
+	if err = aevk.Swap(evk); err != nil {
+		return nil, fmt.Errorf("cannot NewAtomicEvaluationKeySet: %w", err)
+	}
+
+	return
+}
+
+// Swap atomically replaces the wrapped [EvaluationKeySet] with evk, which cannot be nil.
+// Operations in flight on evaluators referencing the wrapper complete with the key set they
+// started with; subsequent operations use evk. Ownership of evk is transferred to the
+// wrapper: it must not be modified afterward.
+func (aevk *AtomicEvaluationKeySet) Swap(evk EvaluationKeySet) (err error) {
+
+	if utils.IsNil(evk) {
+		return fmt.Errorf("cannot Swap: evk cannot be nil")
+	}
+
+	// Copy-on-write merge of the permutation tables: tables of previously held Galois
+	// elements are retained so that lookups racing with the swap remain valid.
+	automorphismIndex := map[uint64][]uint64{}
+	if old := aevk.state.Load(); old != nil {
+		for galEl, index := range old.automorphismIndex {
+			automorphismIndex[galEl] = index
+		}
+	}
+
+	for _, galEl := range evk.GetGaloisKeysList() {
+		if _, ok := automorphismIndex[galEl]; !ok {
+			if automorphismIndex[galEl], err = ring.AutomorphismNTTIndex(aevk.n, aevk.nthRoot, galEl); err != nil {
+				return fmt.Errorf("cannot Swap: %w", err)
+			}
+		}
+	}
+
+	aevk.state.Store(&atomicEvaluationKeySetState{
+		evk:               evk,
+		automorphismIndex: automorphismIndex,
+	})
+
+	return
+}
+
+// GetGaloisKey retrieves the Galois key for the automorphism X^{i} -> X^{i*galEl} from the
+// currently wrapped [EvaluationKeySet].
+func (aevk *AtomicEvaluationKeySet) GetGaloisKey(galEl uint64) (evk *GaloisKey, err error) {
+	return aevk.state.Load().evk.GetGaloisKey(galEl)
+}
+
+// GetGaloisKeysList returns the list of all the Galois elements for which a Galois key
+// exists in the currently wrapped [EvaluationKeySet].
+func (aevk *AtomicEvaluationKeySet) GetGaloisKeysList() (galEls []uint64) {
+	return aevk.state.Load().evk.GetGaloisKeysList()
+}
+
+// GetRelinearizationKey retrieves the [RelinearizationKey] from the currently wrapped
+// [EvaluationKeySet].
+func (aevk *AtomicEvaluationKeySet) GetRelinearizationKey() (evk *RelinearizationKey, err error) {
+	return aevk.state.Load().evk.GetRelinearizationKey()
+}
+
+// ShallowCopy returns the receiver: an [AtomicEvaluationKeySet] is already thread-safe and
+// its copies must keep observing subsequent calls to [AtomicEvaluationKeySet.Swap].
+func (aevk *AtomicEvaluationKeySet) ShallowCopy() EvaluationKeySet {
+	return aevk
+}
+
+func (aevk *AtomicEvaluationKeySet) automorphismNTTIndex(galEl uint64) []uint64 {
+	return aevk.state.Load().automorphismIndex[galEl]
+}
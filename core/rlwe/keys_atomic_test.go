@@ -0,0 +1,89 @@
+package rlwe
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtomicEvaluationKeySet(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	tc, err := NewTestContext(params)
+	require.NoError(t, err)
+
+	galEl1 := params.GaloisElement(1)
+	galEl2 := params.GaloisElement(2)
+
+	newKeySet := func(galEls ...uint64) EvaluationKeySet {
+		return NewMemEvaluationKeySet(nil, tc.kgen.GenGaloisKeysNew(galEls, tc.sk)...)
+	}
+
+	ct := tc.enc.EncryptZeroNew(params.MaxLevel())
+	opOut := NewCiphertext(params, 1, params.MaxLevel())
+
+	t.Run("AtomicEvaluationKeySet/Swap", func(t *testing.T) {
+
+		aevk, err := NewAtomicEvaluationKeySet(params, newKeySet(galEl1))
+		require.NoError(t, err)
+
+		eval := NewEvaluator(params, aevk)
+		evalSC := eval.ShallowCopy()
+
+		require.NoError(t, eval.Automorphism(ct, galEl1, opOut))
+		require.Error(t, eval.Automorphism(ct, galEl2, opOut))
+
+		// A swap is observed by the evaluator and its shallow copies alike
+		require.NoError(t, aevk.Swap(newKeySet(galEl2)))
+
+		require.Error(t, eval.Automorphism(ct, galEl1, opOut))
+		require.NoError(t, eval.Automorphism(ct, galEl2, opOut))
+		require.NoError(t, evalSC.Automorphism(ct, galEl2, opOut))
+
+		require.Error(t, aevk.Swap(nil))
+	})
+
+	t.Run("AtomicEvaluationKeySet/Concurrent", func(t *testing.T) {
+
+		// Both key sets hold the key for galEl1, so evaluation must succeed
+		// regardless of how it interleaves with the swaps.
+		evkA := newKeySet(galEl1)
+		evkB := newKeySet(galEl1, galEl2)
+
+		aevk, err := NewAtomicEvaluationKeySet(params, evkA)
+		require.NoError(t, err)
+
+		eval := NewEvaluator(params, aevk)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				evalSC := eval.ShallowCopy()
+				ctSC := tc.enc.EncryptZeroNew(params.MaxLevel())
+				opOutSC := NewCiphertext(params, 1, params.MaxLevel())
+				for j := 0; j < 32; j++ {
+					require.NoError(t, evalSC.Automorphism(ctSC, galEl1, opOutSC))
+				}
+			}()
+		}
+
+		for j := 0; j < 32; j++ {
+			if j&1 == 0 {
+				require.NoError(t, aevk.Swap(evkB))
+			} else {
+				require.NoError(t, aevk.Swap(evkA))
+			}
+		}
+
+		wg.Wait()
+	})
+}
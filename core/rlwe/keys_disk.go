@@ -0,0 +1,205 @@
+package rlwe
+
+import (
+	"bufio"
+	"container/list"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+const (
+	diskRelinearizationKeyFile = "rlk.key"
+	diskGaloisKeyFileFormat    = "gk_%d.key"
+)
+
+// DiskEvaluationKeySet is an implementation of the [EvaluationKeySet] interface
+// backed by a directory of serialized keys: the keys are loaded from disk on
+// demand and at most a fixed number of [GaloisKey] is kept in memory, with a
+// least-recently-used eviction policy. It enables the [Evaluator] to run with a
+// bounded memory budget on key sets that exceed the available RAM, e.g.
+// bootstrapping key sets.
+//
+// The backing directory is populated with [WriteEvaluationKeySetToDir] and the
+// [RelinearizationKey], being a single key, is kept in memory once loaded.
+// The implementation is safe for concurrent use.
+type DiskEvaluationKeySet struct {
+	path     string
+	capacity int
+
+	mu     sync.Mutex
+	galEls []uint64
+	rlk    *RelinearizationKey
+	cache  map[uint64]*list.Element
+	lru    *list.List
+}
+
+type diskCacheEntry struct {
+	galEl uint64
+	key   *GaloisKey
+}
+
+// WriteEvaluationKeySetToDir writes the keys of the provided [EvaluationKeySet]
+// as individual files in the given directory, creating it if necessary. The
+// resulting directory can be opened with [NewDiskEvaluationKeySet].
+func WriteEvaluationKeySetToDir(path string, evk EvaluationKeySet) error {
+
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return fmt.Errorf("cannot WriteEvaluationKeySetToDir: %w", err)
+	}
+
+	writeKey := func(name string, key io.WriterTo) error {
+		f, err := os.Create(filepath.Join(path, name))
+		if err != nil {
+			return err
+		}
+		if _, err = key.WriteTo(f); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}
+
+	if rlk, err := evk.GetRelinearizationKey(); err == nil {
+		if err := writeKey(diskRelinearizationKeyFile, rlk); err != nil {
+			return fmt.Errorf("cannot WriteEvaluationKeySetToDir: %w", err)
+		}
+	}
+
+	for _, galEl := range evk.GetGaloisKeysList() {
+
+		gk, err := evk.GetGaloisKey(galEl)
+		if err != nil {
+			return fmt.Errorf("cannot WriteEvaluationKeySetToDir: %w", err)
+		}
+
+		if err := writeKey(fmt.Sprintf(diskGaloisKeyFileFormat, galEl), gk); err != nil {
+			return fmt.Errorf("cannot WriteEvaluationKeySetToDir: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// NewDiskEvaluationKeySet opens a directory written by
+// [WriteEvaluationKeySetToDir] and returns a [DiskEvaluationKeySet] keeping at
+// most capacity [GaloisKey] in memory. The directory is scanned for the
+// available keys at opening; files added afterwards are not visible.
+func NewDiskEvaluationKeySet(path string, capacity int) (*DiskEvaluationKeySet, error) {
+
+	if capacity < 1 {
+		return nil, fmt.Errorf("invalid capacity: must be at least 1 but is %d", capacity)
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot NewDiskEvaluationKeySet: %w", err)
+	}
+
+	galEls := []uint64{}
+	for _, entry := range entries {
+		var galEl uint64
+		if n, err := fmt.Sscanf(entry.Name(), diskGaloisKeyFileFormat, &galEl); n == 1 && err == nil {
+			galEls = append(galEls, galEl)
+		}
+	}
+
+	slices.Sort(galEls)
+
+	return &DiskEvaluationKeySet{
+		path:     path,
+		capacity: capacity,
+		galEls:   galEls,
+		cache:    map[uint64]*list.Element{},
+		lru:      list.New(),
+	}, nil
+}
+
+// GetGaloisKey retrieves the Galois key for the automorphism X^{i} -> X^{i*galEl},
+// loading it from disk if it is not cached and evicting the least recently
+// used key if the cache is full.
+func (evk *DiskEvaluationKeySet) GetGaloisKey(galEl uint64) (gk *GaloisKey, err error) {
+
+	evk.mu.Lock()
+	defer evk.mu.Unlock()
+
+	if elem, ok := evk.cache[galEl]; ok {
+		evk.lru.MoveToFront(elem)
+		return elem.Value.(*diskCacheEntry).key, nil
+	}
+
+	if !slices.Contains(evk.galEls, galEl) {
+		return nil, fmt.Errorf("GaloisKey[%d] is not part of the %T", galEl, evk)
+	}
+
+	gk = new(GaloisKey)
+	if err = evk.readKey(fmt.Sprintf(diskGaloisKeyFileFormat, galEl), gk); err != nil {
+		return nil, fmt.Errorf("cannot GetGaloisKey: %w", err)
+	}
+
+	evk.cache[galEl] = evk.lru.PushFront(&diskCacheEntry{galEl: galEl, key: gk})
+
+	for evk.lru.Len() > evk.capacity {
+		oldest := evk.lru.Back()
+		evk.lru.Remove(oldest)
+		delete(evk.cache, oldest.Value.(*diskCacheEntry).galEl)
+	}
+
+	return gk, nil
+}
+
+// GetGaloisKeysList returns the list of all the Galois elements for which a
+// Galois key exists in the backing directory.
+func (evk *DiskEvaluationKeySet) GetGaloisKeysList() (galEls []uint64) {
+	evk.mu.Lock()
+	defer evk.mu.Unlock()
+	return slices.Clone(evk.galEls)
+}
+
+// GetRelinearizationKey retrieves the [RelinearizationKey], loading it from
+// disk on the first call.
+func (evk *DiskEvaluationKeySet) GetRelinearizationKey() (*RelinearizationKey, error) {
+
+	evk.mu.Lock()
+	defer evk.mu.Unlock()
+
+	if evk.rlk != nil {
+		return evk.rlk, nil
+	}
+
+	rlk := new(RelinearizationKey)
+	if err := evk.readKey(diskRelinearizationKeyFile, rlk); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("RelinearizationKey is not part of the %T", evk)
+		}
+		return nil, fmt.Errorf("cannot GetRelinearizationKey: %w", err)
+	}
+
+	evk.rlk = rlk
+
+	return rlk, nil
+}
+
+// ShallowCopy returns a thread-safe copy of the DiskEvaluationKeySet object.
+func (evk *DiskEvaluationKeySet) ShallowCopy() EvaluationKeySet {
+	return evk
+}
+
+// readKey reads the given file of the backing directory on key.
+func (evk *DiskEvaluationKeySet) readKey(name string, key io.ReaderFrom) error {
+
+	f, err := os.Open(filepath.Join(evk.path, name))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err = key.ReadFrom(bufio.NewReader(f)); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package rlwe
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskEvaluationKeySet(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	tc, err := NewTestContext(params)
+	require.NoError(t, err)
+
+	galEls := params.GaloisElements([]int{1, 2, 3, 4})
+
+	memEvk := NewMemEvaluationKeySet(tc.kgen.GenRelinearizationKeyNew(tc.sk), tc.kgen.GenGaloisKeysNew(galEls, tc.sk)...)
+
+	path := t.TempDir()
+	require.NoError(t, WriteEvaluationKeySetToDir(path, memEvk))
+
+	t.Run("DiskEvaluationKeySet/Load", func(t *testing.T) {
+
+		evk, err := NewDiskEvaluationKeySet(path, 2)
+		require.NoError(t, err)
+
+		// The disk-backed list is sorted, the in-memory one follows the map order.
+		memList := memEvk.GetGaloisKeysList()
+		slices.Sort(memList)
+		require.Equal(t, memList, evk.GetGaloisKeysList())
+
+		// More keys than the cache capacity are read back correctly and the
+		// cache never exceeds its capacity.
+		for _, galEl := range galEls {
+			gk, err := evk.GetGaloisKey(galEl)
+			require.NoError(t, err)
+			want, err := memEvk.GetGaloisKey(galEl)
+			require.NoError(t, err)
+			require.True(t, want.Equal(gk))
+			require.LessOrEqual(t, evk.lru.Len(), 2)
+		}
+
+		// A cached key is returned without a disk access.
+		gk0, err := evk.GetGaloisKey(galEls[len(galEls)-1])
+		require.NoError(t, err)
+		gk1, err := evk.GetGaloisKey(galEls[len(galEls)-1])
+		require.NoError(t, err)
+		require.True(t, gk0 == gk1)
+
+		rlk, err := evk.GetRelinearizationKey()
+		require.NoError(t, err)
+		wantRlk, err := memEvk.GetRelinearizationKey()
+		require.NoError(t, err)
+		require.True(t, wantRlk.Equal(&rlk.EvaluationKey))
+
+		_, err = evk.GetGaloisKey(params.GaloisElement(5))
+		require.Error(t, err)
+	})
+
+	t.Run("DiskEvaluationKeySet/Evaluator", func(t *testing.T) {
+
+		evk, err := NewDiskEvaluationKeySet(path, 1)
+		require.NoError(t, err)
+
+		eval := NewEvaluator(params, evk)
+
+		ct := tc.enc.EncryptZeroNew(params.MaxLevel())
+		opOut := NewCiphertext(params, 1, params.MaxLevel())
+
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				evalSC := eval.ShallowCopy()
+				for _, galEl := range galEls {
+					require.NoError(t, evalSC.Automorphism(ct, galEl, opOut.CopyNew()))
+				}
+			}()
+		}
+		wg.Wait()
+
+		require.NoError(t, eval.Automorphism(ct, galEls[0], opOut))
+		require.Error(t, eval.Automorphism(ct, params.GaloisElement(5), opOut))
+	})
+
+	t.Run("DiskEvaluationKeySet/Errors", func(t *testing.T) {
+
+		_, err := NewDiskEvaluationKeySet(path, 0)
+		require.Error(t, err)
+
+		_, err = NewDiskEvaluationKeySet(path+"/missing", 1)
+		require.Error(t, err)
+
+		// A directory without relinearization key reports it as missing.
+		emptyPath := t.TempDir()
+		require.NoError(t, WriteEvaluationKeySetToDir(emptyPath, NewMemEvaluationKeySet(nil, tc.kgen.GenGaloisKeysNew(galEls[:1], tc.sk)...)))
+		evk, err := NewDiskEvaluationKeySet(emptyPath, 1)
+		require.NoError(t, err)
+		_, err = evk.GetRelinearizationKey()
+		require.Error(t, err)
+	})
+}
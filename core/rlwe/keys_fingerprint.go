@@ -0,0 +1,52 @@
+package rlwe
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Fingerprint returns a 64-bit digest of the parameters, computed over their marshalled
+// representation. Key material generated by the [KeyGenerator] is tagged with this
+// fingerprint, which is embedded in the key serialization and checked when the key is
+// used with an [Encryptor], [Decryptor] or [Evaluator], so that key material generated
+// under different (even slightly different) parameters is rejected with a
+// [KeyParametersMismatchError] instead of producing silent garbage.
+func (p Parameters) Fingerprint() uint64 {
+
+	data, err := p.MarshalBinary()
+
+	// Sanity check, this error should not happen.
+	if err != nil {
+		panic(err)
+	}
+
+	digest := blake2b.Sum256(data)
+
+	return binary.LittleEndian.Uint64(digest[:8])
+}
+
+// KeyParametersMismatchError is the error returned when key material carrying a parameters
+// fingerprint (see [Parameters.Fingerprint]) is used with a parameter set whose fingerprint
+// differs. Key material with a zero fingerprint (manually assembled or produced by an
+// earlier version of the library) is never checked.
+type KeyParametersMismatchError struct {
+	KeyType  string // description of the offending key, e.g. "sk" or "GaloisKey[5]"
+	Expected uint64 // fingerprint of the parameters the key is used with
+	Have     uint64 // fingerprint carried by the key
+}
+
+// Error implements the error interface.
+func (e KeyParametersMismatchError) Error() string {
+	return fmt.Sprintf("%s was generated under parameters with fingerprint %016x but is used under parameters with fingerprint %016x", e.KeyType, e.Have, e.Expected)
+}
+
+// checkKeyFingerprint returns a [KeyParametersMismatchError] if have is a non-zero
+// fingerprint different from expected.
+func checkKeyFingerprint(expected uint64, keyType string, have uint64) error {
+	if have != 0 && have != expected {
+		return KeyParametersMismatchError{KeyType: keyType, Expected: expected, Have: have}
+	}
+	return nil
+}
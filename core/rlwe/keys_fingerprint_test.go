@@ -0,0 +1,101 @@
+package rlwe
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyParametersFingerprint(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	// Same ring degree, different moduli chain: the ring-degree check cannot
+	// tell the two parameter sets apart.
+	paramsOther, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	require.NotEqual(t, params.Fingerprint(), paramsOther.Fingerprint())
+
+	kgen := NewKeyGenerator(params)
+	sk, pk := kgen.GenKeyPairNew()
+
+	requireMismatchPanic := func(t *testing.T, f func()) {
+		defer func() {
+			r := recover()
+			require.NotNil(t, r)
+			err, ok := r.(error)
+			require.True(t, ok)
+			var target KeyParametersMismatchError
+			require.True(t, errors.As(err, &target))
+		}()
+		f()
+	}
+
+	t.Run("Fingerprint/Serialization", func(t *testing.T) {
+
+		require.Equal(t, params.Fingerprint(), sk.ParametersFingerprint)
+		require.Equal(t, params.Fingerprint(), pk.ParametersFingerprint)
+
+		data, err := sk.MarshalBinary()
+		require.NoError(t, err)
+
+		skNew := new(SecretKey)
+		require.NoError(t, skNew.UnmarshalBinary(data))
+		require.Equal(t, sk.ParametersFingerprint, skNew.ParametersFingerprint)
+		require.True(t, sk.Equal(skNew))
+	})
+
+	t.Run("Fingerprint/Encryptor", func(t *testing.T) {
+		requireMismatchPanic(t, func() { NewEncryptor(paramsOther, sk) })
+		requireMismatchPanic(t, func() { NewEncryptor(paramsOther, pk) })
+	})
+
+	t.Run("Fingerprint/Decryptor", func(t *testing.T) {
+		requireMismatchPanic(t, func() { NewDecryptor(paramsOther, sk) })
+	})
+
+	t.Run("Fingerprint/Evaluator", func(t *testing.T) {
+
+		galEl := params.GaloisElement(1)
+		evk := NewMemEvaluationKeySet(kgen.GenRelinearizationKeyNew(sk), kgen.GenGaloisKeysNew([]uint64{galEl}, sk)...)
+
+		eval := NewEvaluator(paramsOther, evk)
+
+		var target KeyParametersMismatchError
+
+		_, err := eval.CheckAndGetRelinearizationKey()
+		require.True(t, errors.As(err, &target))
+
+		_, err = eval.CheckAndGetGaloisKey(galEl)
+		require.True(t, errors.As(err, &target))
+
+		// Under the parameters the keys were generated with, the checks pass
+		eval = NewEvaluator(params, evk)
+
+		_, err = eval.CheckAndGetRelinearizationKey()
+		require.NoError(t, err)
+
+		_, err = eval.CheckAndGetGaloisKey(galEl)
+		require.NoError(t, err)
+	})
+
+	t.Run("Fingerprint/Legacy", func(t *testing.T) {
+
+		// A zero fingerprint (manually assembled key material) is not checked
+		skLegacy := &SecretKey{Value: params.RingQP().NewPoly()}
+		kgen.GenSecretKey(skLegacy)
+
+		require.NotPanics(t, func() { NewDecryptor(paramsOther, skLegacy) })
+	})
+}
@@ -0,0 +1,111 @@
+package rlwe
+
+import (
+	"fmt"
+	"sort"
+)
+
+// EvaluationKeyGraph stores key-switching keys indexed by the names of their
+// input and output secret keys, and resolves a switch between any connected
+// pair of keys as a composition of the stored keys. Storing a spanning set of
+// keys (e.g. every tenant key to and from a single hub key) instead of all
+// pairwise keys reduces the key storage from quadratic to linear in the number
+// of keys, at the cost of one extra key-switch of noise and time per
+// intermediate hop.
+type EvaluationKeyGraph struct {
+	edges map[string]map[string]*EvaluationKey
+}
+
+// NewEvaluationKeyGraph instantiates a new empty [EvaluationKeyGraph].
+func NewEvaluationKeyGraph() *EvaluationKeyGraph {
+	return &EvaluationKeyGraph{edges: map[string]map[string]*EvaluationKey{}}
+}
+
+// Add stores the key-switching key skIn -> skOut under the given key names,
+// replacing any previously stored key for the same pair.
+func (g *EvaluationKeyGraph) Add(skIn, skOut string, evk *EvaluationKey) {
+	if g.edges[skIn] == nil {
+		g.edges[skIn] = map[string]*EvaluationKey{}
+	}
+	g.edges[skIn][skOut] = evk
+}
+
+// Path returns the shortest sequence of stored key-switching keys composing
+// the switch skIn -> skOut, found by breadth-first search over the key graph.
+// Returns an error if the two keys are not connected.
+func (g *EvaluationKeyGraph) Path(skIn, skOut string) ([]*EvaluationKey, error) {
+
+	if skIn == skOut {
+		return nil, fmt.Errorf("cannot Path: input and output keys are both %q", skIn)
+	}
+
+	prev := map[string]string{skIn: skIn}
+	queue := []string{skIn}
+
+	for len(queue) > 0 {
+
+		if _, reached := prev[skOut]; reached {
+			break
+		}
+
+		node := queue[0]
+		queue = queue[1:]
+
+		// Deterministic traversal order
+		nexts := make([]string, 0, len(g.edges[node]))
+		for next := range g.edges[node] {
+			nexts = append(nexts, next)
+		}
+		sort.Strings(nexts)
+
+		for _, next := range nexts {
+			if _, visited := prev[next]; !visited {
+				prev[next] = node
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	if _, connected := prev[skOut]; !connected {
+		return nil, fmt.Errorf("cannot Path: no chain of key-switching keys from %q to %q", skIn, skOut)
+	}
+
+	var path []*EvaluationKey
+	for node := skOut; node != skIn; node = prev[node] {
+		path = append(path, g.edges[prev[node]][node])
+	}
+
+	// Reverses into skIn -> skOut order
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path, nil
+}
+
+// ApplyEvaluationKeyGraph re-encrypts ctIn from the key named skIn to the key
+// named skOut by composing the key-switching keys along the shortest path of
+// the graph, transparently switching through intermediate keys when no direct
+// key is stored. Each intermediate hop adds the noise of one key-switch.
+// See [Evaluator.ApplyEvaluationKey] for the constraints on the operands.
+func (eval Evaluator) ApplyEvaluationKeyGraph(ctIn *Ciphertext, g *EvaluationKeyGraph, skIn, skOut string, opOut *Ciphertext) (err error) {
+
+	path, err := g.Path(skIn, skOut)
+	if err != nil {
+		return fmt.Errorf("cannot ApplyEvaluationKeyGraph: %w", err)
+	}
+
+	for i, evk := range path {
+
+		in := ctIn
+		if i > 0 {
+			in = opOut
+		}
+
+		if err = eval.ApplyEvaluationKey(in, evk, opOut); err != nil {
+			return fmt.Errorf("cannot ApplyEvaluationKeyGraph: hop %d: %w", i, err)
+		}
+	}
+
+	return
+}
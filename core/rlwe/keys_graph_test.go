@@ -0,0 +1,61 @@
+package rlwe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluationKeyGraph(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	kgen := NewKeyGenerator(params)
+
+	skA := kgen.GenSecretKeyNew()
+	skB := kgen.GenSecretKeyNew()
+	skC := kgen.GenSecretKeyNew()
+
+	// Star topology around the hub key B: no direct key A -> C is stored.
+	g := NewEvaluationKeyGraph()
+	g.Add("A", "B", kgen.GenEvaluationKeyNew(skA, skB))
+	g.Add("B", "C", kgen.GenEvaluationKeyNew(skB, skC))
+
+	t.Run("Path", func(t *testing.T) {
+
+		path, err := g.Path("A", "C")
+		require.NoError(t, err)
+		require.Len(t, path, 2)
+
+		path, err = g.Path("A", "B")
+		require.NoError(t, err)
+		require.Len(t, path, 1)
+
+		_, err = g.Path("C", "A")
+		require.Error(t, err)
+
+		_, err = g.Path("A", "A")
+		require.Error(t, err)
+	})
+
+	t.Run("ApplyEvaluationKeyGraph", func(t *testing.T) {
+
+		eval := NewEvaluator(params, nil)
+
+		pt := NewPlaintext(params, params.MaxLevel())
+		ct, err := NewEncryptor(params, skA).EncryptNew(pt)
+		require.NoError(t, err)
+
+		opOut := NewCiphertext(params, 1, ct.Level())
+		require.NoError(t, eval.ApplyEvaluationKeyGraph(ct, g, "A", "C", opOut))
+
+		// The result must decrypt under skC with the noise of two key-switches
+		_, _, max := Norm(opOut, NewDecryptor(params, skC))
+		require.Less(t, max, 30.0)
+	})
+}
@@ -0,0 +1,78 @@
+package rlwe
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// EvaluationKeySetInfo is a manifest cross-referencing the Galois elements available
+// in an [EvaluationKeySet] with the ones required by a circuit (e.g., as reported by
+// [GaloisElementsForInnerSum], [GaloisElementsForTrace] or the GaloisElements methods
+// of the circuit packages). It allows operators to detect missing keys before an
+// evaluation fails and to prune unused keys from a key set confidently.
+type EvaluationKeySetInfo struct {
+	// Available is the sorted list of the Galois elements present in the key set.
+	Available []uint64
+	// Required is the sorted list of the distinct Galois elements required by the circuit.
+	Required []uint64
+	// Missing is the sorted list of the required Galois elements absent from the key set.
+	Missing []uint64
+	// Unused is the sorted list of the available Galois elements not required by the circuit.
+	Unused []uint64
+	// HasRelinearizationKey indicates whether the key set holds a relinearization key.
+	HasRelinearizationKey bool
+}
+
+// GetEvaluationKeySetInfo returns the [EvaluationKeySetInfo] of the given key set with
+// respect to the Galois elements required by a circuit. Multiple requirement lists
+// (e.g., from different circuit helpers) can be passed and will be deduplicated.
+func GetEvaluationKeySetInfo(evk EvaluationKeySet, required ...[]uint64) (info EvaluationKeySetInfo) {
+
+	info.Available = append([]uint64{}, evk.GetGaloisKeysList()...)
+	sort.Slice(info.Available, func(i, j int) bool { return info.Available[i] < info.Available[j] })
+
+	var all []uint64
+	for _, galEls := range required {
+		all = append(all, galEls...)
+	}
+	info.Required = utils.GetDistincts(all)
+	sort.Slice(info.Required, func(i, j int) bool { return info.Required[i] < info.Required[j] })
+
+	available := map[uint64]bool{}
+	for _, galEl := range info.Available {
+		available[galEl] = true
+	}
+
+	requiredMap := map[uint64]bool{}
+	for _, galEl := range info.Required {
+		requiredMap[galEl] = true
+		if !available[galEl] {
+			info.Missing = append(info.Missing, galEl)
+		}
+	}
+
+	for _, galEl := range info.Available {
+		if !requiredMap[galEl] {
+			info.Unused = append(info.Unused, galEl)
+		}
+	}
+
+	if rlk, err := evk.GetRelinearizationKey(); err == nil && rlk != nil {
+		info.HasRelinearizationKey = true
+	}
+
+	return
+}
+
+// Complete returns true if no required Galois element is missing from the key set.
+func (info EvaluationKeySetInfo) Complete() bool {
+	return len(info.Missing) == 0
+}
+
+// String returns a human-readable summary of the manifest.
+func (info EvaluationKeySetInfo) String() string {
+	return fmt.Sprintf("EvaluationKeySetInfo{Available: %d, Required: %d, Missing: %v, Unused: %v, HasRelinearizationKey: %t}",
+		len(info.Available), len(info.Required), info.Missing, info.Unused, info.HasRelinearizationKey)
+}
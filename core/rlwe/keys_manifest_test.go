@@ -0,0 +1,49 @@
+package rlwe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluationKeySetInfo(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	tc, err := NewTestContext(params)
+	require.NoError(t, err)
+
+	required := GaloisElementsForInnerSum(params, 2, 4)
+
+	t.Run("EvaluationKeySetInfo/Missing", func(t *testing.T) {
+
+		// Key set holding only the first required key and one extra key
+		extra := params.GaloisElement(7)
+		evk := NewMemEvaluationKeySet(nil, tc.kgen.GenGaloisKeysNew([]uint64{required[0], extra}, tc.sk)...)
+
+		info := GetEvaluationKeySetInfo(evk, required)
+		require.False(t, info.Complete())
+		require.False(t, info.HasRelinearizationKey)
+		require.ElementsMatch(t, required[1:], info.Missing)
+		require.ElementsMatch(t, []uint64{extra}, info.Unused)
+		require.Len(t, info.Available, 2)
+	})
+
+	t.Run("EvaluationKeySetInfo/Complete", func(t *testing.T) {
+
+		evk := NewMemEvaluationKeySet(tc.kgen.GenRelinearizationKeyNew(tc.sk), tc.kgen.GenGaloisKeysNew(required, tc.sk)...)
+
+		// Requirement lists are merged and deduplicated
+		info := GetEvaluationKeySetInfo(evk, required[:1], required)
+		require.True(t, info.Complete())
+		require.True(t, info.HasRelinearizationKey)
+		require.Empty(t, info.Missing)
+		require.Empty(t, info.Unused)
+		require.ElementsMatch(t, required, info.Required)
+	})
+}
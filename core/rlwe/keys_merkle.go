@@ -0,0 +1,188 @@
+package rlwe
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Domain-separation prefixes for the Merkle tree hashes, preventing a leaf
+// from being reinterpreted as an internal node (second-preimage protection).
+const (
+	merkleLeafPrefix = 0x00
+	merkleNodePrefix = 0x01
+)
+
+// KeySetCommitment is a Merkle-tree commitment over the chunks of a serialized
+// evaluation key set. The root can be distributed out-of-band (e.g., alongside
+// the parameters) while the key material itself is fetched from an untrusted
+// source such as a CDN: each chunk is then verified individually against the
+// root with its [ChunkProof], so that multi-GB key sets can be checked
+// incrementally as they are downloaded instead of after the full transfer.
+type KeySetCommitment struct {
+	chunkSize int
+	size      int64
+	levels    [][][32]byte // levels[0] are the leaf hashes, levels[len-1][0] is the root
+}
+
+// NewKeySetCommitment builds a [KeySetCommitment] over the serialized key set
+// read from r, split in chunks of chunkSize bytes (the last chunk can be
+// shorter). The reader is consumed until EOF; only the hashes are retained,
+// so the memory footprint is independent of the key set size.
+func NewKeySetCommitment(r io.Reader, chunkSize int) (c *KeySetCommitment, err error) {
+
+	if chunkSize < 1 {
+		return nil, fmt.Errorf("cannot NewKeySetCommitment: chunkSize must be at least 1 but is %d", chunkSize)
+	}
+
+	c = &KeySetCommitment{chunkSize: chunkSize}
+
+	var leaves [][32]byte
+	buff := make([]byte, chunkSize)
+	for {
+		var n int
+		if n, err = io.ReadFull(r, buff); n > 0 {
+			leaves = append(leaves, merkleLeafHash(buff[:n]))
+			c.size += int64(n)
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot NewKeySetCommitment: %w", err)
+		}
+	}
+
+	if len(leaves) == 0 {
+		return nil, fmt.Errorf("cannot NewKeySetCommitment: empty key set")
+	}
+
+	c.levels = append(c.levels, leaves)
+	for level := leaves; len(level) > 1; {
+		next := make([][32]byte, 0, (len(level)+1)>>1)
+		for i := 0; i < len(level); i += 2 {
+			if i+1 < len(level) {
+				next = append(next, merkleNodeHash(level[i], level[i+1]))
+			} else {
+				// Odd node: promoted unchanged to the next level.
+				next = append(next, level[i])
+			}
+		}
+		c.levels = append(c.levels, next)
+		level = next
+	}
+
+	return c, nil
+}
+
+// Root returns the Merkle root of the commitment, to be distributed
+// out-of-band for verification.
+func (c KeySetCommitment) Root() [32]byte {
+	return c.levels[len(c.levels)-1][0]
+}
+
+// Chunks returns the number of chunks committed to.
+func (c KeySetCommitment) Chunks() int {
+	return len(c.levels[0])
+}
+
+// ChunkSize returns the chunk size the commitment was built with.
+func (c KeySetCommitment) ChunkSize() int {
+	return c.chunkSize
+}
+
+// Size returns the total size in bytes of the committed key set.
+func (c KeySetCommitment) Size() int64 {
+	return c.size
+}
+
+// Proof returns the [ChunkProof] for the index-th chunk.
+func (c KeySetCommitment) Proof(index int) (proof ChunkProof, err error) {
+
+	if index < 0 || index >= c.Chunks() {
+		return proof, fmt.Errorf("cannot Proof: index %d out of range [0, %d[", index, c.Chunks())
+	}
+
+	proof.Index = index
+	proof.Chunks = c.Chunks()
+
+	i := index
+	for _, level := range c.levels[:len(c.levels)-1] {
+		if sibling := i ^ 1; sibling < len(level) {
+			proof.Path = append(proof.Path, level[sibling])
+		}
+		i >>= 1
+	}
+
+	return proof, nil
+}
+
+// ChunkProof is the Merkle proof binding one chunk of a serialized key set to
+// the root of a [KeySetCommitment]. See [KeySetCommitment.Proof].
+type ChunkProof struct {
+	// Index is the index of the chunk the proof is for.
+	Index int
+	// Chunks is the total number of chunks of the commitment.
+	Chunks int
+	// Path holds the sibling hashes from the leaf to the root.
+	Path [][32]byte
+}
+
+// Verify checks the chunk against the root and returns an error if the proof
+// does not bind the chunk to the Index-th leaf of the tree with that root.
+func (p ChunkProof) Verify(root [32]byte, chunk []byte) (err error) {
+
+	if p.Index < 0 || p.Index >= p.Chunks {
+		return fmt.Errorf("cannot Verify: index %d out of range [0, %d[", p.Index, p.Chunks)
+	}
+
+	hash := merkleLeafHash(chunk)
+
+	i, n, path := p.Index, p.Chunks, p.Path
+	for n > 1 {
+		if sibling := i ^ 1; sibling < n {
+			if len(path) == 0 {
+				return fmt.Errorf("cannot Verify: proof path is too short")
+			}
+			if i&1 == 0 {
+				hash = merkleNodeHash(hash, path[0])
+			} else {
+				hash = merkleNodeHash(path[0], hash)
+			}
+			path = path[1:]
+		}
+		i >>= 1
+		n = (n + 1) >> 1
+	}
+
+	if len(path) != 0 {
+		return fmt.Errorf("cannot Verify: proof path is too long")
+	}
+
+	if !bytes.Equal(hash[:], root[:]) {
+		return fmt.Errorf("cannot Verify: chunk %d does not match the commitment root", p.Index)
+	}
+
+	return nil
+}
+
+func merkleLeafHash(chunk []byte) [32]byte {
+	h, _ := blake2b.New256(nil)
+	h.Write([]byte{merkleLeafPrefix})
+	h.Write(chunk)
+	var digest [32]byte
+	copy(digest[:], h.Sum(nil))
+	return digest
+}
+
+func merkleNodeHash(left, right [32]byte) [32]byte {
+	h, _ := blake2b.New256(nil)
+	h.Write([]byte{merkleNodePrefix})
+	h.Write(left[:])
+	h.Write(right[:])
+	var digest [32]byte
+	copy(digest[:], h.Sum(nil))
+	return digest
+}
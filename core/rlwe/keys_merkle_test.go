@@ -0,0 +1,75 @@
+package rlwe
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySetCommitment(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	tc, err := NewTestContext(params)
+	require.NoError(t, err)
+
+	evk := NewMemEvaluationKeySet(tc.kgen.GenRelinearizationKeyNew(tc.sk), tc.kgen.GenGaloisKeysNew(params.GaloisElements([]int{1, 2}), tc.sk)...)
+
+	data, err := evk.MarshalBinary()
+	require.NoError(t, err)
+
+	const chunkSize = 4096
+
+	c, err := NewKeySetCommitment(bytes.NewReader(data), chunkSize)
+	require.NoError(t, err)
+	require.Equal(t, (len(data)+chunkSize-1)/chunkSize, c.Chunks())
+	require.Equal(t, int64(len(data)), c.Size())
+
+	root := c.Root()
+
+	t.Run("KeySetCommitment/VerifyChunks", func(t *testing.T) {
+		// Every chunk verifies against the root, including the shorter last one
+		for i := 0; i < c.Chunks(); i++ {
+			proof, err := c.Proof(i)
+			require.NoError(t, err)
+			chunk := data[i*chunkSize : min(len(data), (i+1)*chunkSize)]
+			require.NoError(t, proof.Verify(root, chunk))
+		}
+	})
+
+	t.Run("KeySetCommitment/CorruptedChunk", func(t *testing.T) {
+		proof, err := c.Proof(1)
+		require.NoError(t, err)
+		chunk := append([]byte{}, data[chunkSize:2*chunkSize]...)
+		chunk[0] ^= 1
+		require.Error(t, proof.Verify(root, chunk))
+	})
+
+	t.Run("KeySetCommitment/SwappedChunk", func(t *testing.T) {
+		// A valid chunk presented under the wrong index is rejected
+		proof, err := c.Proof(0)
+		require.NoError(t, err)
+		require.Error(t, proof.Verify(root, data[chunkSize:2*chunkSize]))
+	})
+
+	t.Run("KeySetCommitment/Deterministic", func(t *testing.T) {
+		other, err := NewKeySetCommitment(bytes.NewReader(data), chunkSize)
+		require.NoError(t, err)
+		require.Equal(t, root, other.Root())
+	})
+
+	t.Run("KeySetCommitment/InvalidInputs", func(t *testing.T) {
+		_, err := NewKeySetCommitment(bytes.NewReader(data), 0)
+		require.Error(t, err)
+		_, err = NewKeySetCommitment(bytes.NewReader(nil), chunkSize)
+		require.Error(t, err)
+		_, err = c.Proof(c.Chunks())
+		require.Error(t, err)
+	})
+}
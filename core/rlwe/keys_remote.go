@@ -0,0 +1,254 @@
+package rlwe
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EvaluationKeyTransport abstracts the wire protocol of a remote key service
+// for the [RemoteEvaluationKeySet]. Implementations fetch serialized keys
+// from the service; the reference [HTTPEvaluationKeyTransport] uses plain
+// HTTP and other protocols (e.g. gRPC) can be plugged by implementing this
+// interface.
+type EvaluationKeyTransport interface {
+
+	// GaloisKeysList fetches the list of the Galois elements for which the
+	// service holds a Galois key.
+	GaloisKeysList() ([]uint64, error)
+
+	// GaloisKey fetches the Galois key for the given Galois element.
+	GaloisKey(galEl uint64) (*GaloisKey, error)
+
+	// RelinearizationKey fetches the relinearization key.
+	RelinearizationKey() (*RelinearizationKey, error)
+}
+
+// RemoteEvaluationKeySet is an implementation of the [EvaluationKeySet]
+// interface fetching the keys from a remote key service through an
+// [EvaluationKeyTransport]: each key is fetched at most once and cached in
+// memory, so multi-tenant evaluation servers can evaluate on behalf of many
+// clients without holding a local copy of every key set.
+//
+// The implementation is safe for concurrent use.
+type RemoteEvaluationKeySet struct {
+	transport EvaluationKeyTransport
+
+	mu     sync.Mutex
+	galEls []uint64
+	rlk    *RelinearizationKey
+	cache  map[uint64]*GaloisKey
+}
+
+// NewRemoteEvaluationKeySet instantiates a new [RemoteEvaluationKeySet]
+// backed by the provided transport.
+func NewRemoteEvaluationKeySet(transport EvaluationKeyTransport) *RemoteEvaluationKeySet {
+	return &RemoteEvaluationKeySet{
+		transport: transport,
+		cache:     map[uint64]*GaloisKey{},
+	}
+}
+
+// GetGaloisKey retrieves the Galois key for the automorphism X^{i} -> X^{i*galEl},
+// fetching it from the remote service if it is not cached.
+func (evk *RemoteEvaluationKeySet) GetGaloisKey(galEl uint64) (gk *GaloisKey, err error) {
+
+	evk.mu.Lock()
+	defer evk.mu.Unlock()
+
+	if gk, ok := evk.cache[galEl]; ok {
+		return gk, nil
+	}
+
+	if gk, err = evk.transport.GaloisKey(galEl); err != nil {
+		return nil, fmt.Errorf("cannot GetGaloisKey: %w", err)
+	}
+
+	evk.cache[galEl] = gk
+
+	return gk, nil
+}
+
+// GetGaloisKeysList returns the list of all the Galois elements for which a
+// Galois key exists in the remote service. The list is fetched on the first
+// call and cached; the method returns nil if the fetch fails.
+func (evk *RemoteEvaluationKeySet) GetGaloisKeysList() (galEls []uint64) {
+
+	evk.mu.Lock()
+	defer evk.mu.Unlock()
+
+	if evk.galEls == nil {
+		var err error
+		if evk.galEls, err = evk.transport.GaloisKeysList(); err != nil {
+			return nil
+		}
+	}
+
+	return slices.Clone(evk.galEls)
+}
+
+// GetRelinearizationKey retrieves the [RelinearizationKey], fetching it from
+// the remote service on the first call.
+func (evk *RemoteEvaluationKeySet) GetRelinearizationKey() (*RelinearizationKey, error) {
+
+	evk.mu.Lock()
+	defer evk.mu.Unlock()
+
+	if evk.rlk != nil {
+		return evk.rlk, nil
+	}
+
+	rlk, err := evk.transport.RelinearizationKey()
+	if err != nil {
+		return nil, fmt.Errorf("cannot GetRelinearizationKey: %w", err)
+	}
+
+	evk.rlk = rlk
+
+	return rlk, nil
+}
+
+// ShallowCopy returns a thread-safe copy of the RemoteEvaluationKeySet object.
+func (evk *RemoteEvaluationKeySet) ShallowCopy() EvaluationKeySet {
+	return evk
+}
+
+// HTTPEvaluationKeyTransport is the reference [EvaluationKeyTransport],
+// fetching the keys over HTTP from a service exposing the endpoints of
+// [NewEvaluationKeyServiceHandler].
+type HTTPEvaluationKeyTransport struct {
+	// BaseURL is the base URL of the key service, without trailing slash.
+	BaseURL string
+
+	// Client is the HTTP client to use; if nil, [http.DefaultClient] is used.
+	Client *http.Client
+}
+
+func (t HTTPEvaluationKeyTransport) get(path string) ([]byte, error) {
+
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Get(t.BaseURL + path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("key service returned status %s for %s", resp.Status, path)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// GaloisKeysList fetches the list of the Galois elements for which the
+// service holds a Galois key.
+func (t HTTPEvaluationKeyTransport) GaloisKeysList() (galEls []uint64, err error) {
+
+	data, err := t.get("/galoiskeys")
+	if err != nil {
+		return nil, err
+	}
+
+	if err = json.Unmarshal(data, &galEls); err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// GaloisKey fetches the Galois key for the given Galois element.
+func (t HTTPEvaluationKeyTransport) GaloisKey(galEl uint64) (*GaloisKey, error) {
+
+	data, err := t.get("/galoiskey/" + strconv.FormatUint(galEl, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	gk := new(GaloisKey)
+	if err = gk.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+
+	return gk, nil
+}
+
+// RelinearizationKey fetches the relinearization key.
+func (t HTTPEvaluationKeyTransport) RelinearizationKey() (*RelinearizationKey, error) {
+
+	data, err := t.get("/relinearizationkey")
+	if err != nil {
+		return nil, err
+	}
+
+	rlk := new(RelinearizationKey)
+	if err = rlk.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+
+	return rlk, nil
+}
+
+// NewEvaluationKeyServiceHandler returns an [http.Handler] serving the keys
+// of the provided [EvaluationKeySet] on the endpoints consumed by
+// [HTTPEvaluationKeyTransport]:
+//
+//   - GET /galoiskeys: the list of the available Galois elements, as JSON,
+//   - GET /galoiskey/<galEl>: the serialized Galois key,
+//   - GET /relinearizationkey: the serialized relinearization key.
+func NewEvaluationKeyServiceHandler(evk EvaluationKeySet) http.Handler {
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/galoiskeys", func(w http.ResponseWriter, r *http.Request) {
+		data, err := json.Marshal(evk.GetGaloisKeysList())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(data)
+	})
+
+	mux.HandleFunc("/galoiskey/", func(w http.ResponseWriter, r *http.Request) {
+
+		galEl, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/galoiskey/"), 10, 64)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		gk, err := evk.GetGaloisKey(galEl)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		if _, err := gk.WriteTo(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	mux.HandleFunc("/relinearizationkey", func(w http.ResponseWriter, r *http.Request) {
+
+		rlk, err := evk.GetRelinearizationKey()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		if _, err := rlk.WriteTo(w); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+
+	return mux
+}
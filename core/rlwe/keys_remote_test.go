@@ -0,0 +1,103 @@
+package rlwe
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteEvaluationKeySet(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	tc, err := NewTestContext(params)
+	require.NoError(t, err)
+
+	galEls := params.GaloisElements([]int{1, 2, 3})
+
+	memEvk := NewMemEvaluationKeySet(tc.kgen.GenRelinearizationKeyNew(tc.sk), tc.kgen.GenGaloisKeysNew(galEls, tc.sk)...)
+
+	var requests atomic.Int64
+	handler := NewEvaluationKeyServiceHandler(memEvk)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	transport := HTTPEvaluationKeyTransport{BaseURL: server.URL, Client: server.Client()}
+
+	t.Run("RemoteEvaluationKeySet/Fetch", func(t *testing.T) {
+
+		evk := NewRemoteEvaluationKeySet(transport)
+
+		memList := memEvk.GetGaloisKeysList()
+		slices.Sort(memList)
+		remoteList := evk.GetGaloisKeysList()
+		slices.Sort(remoteList)
+		require.Equal(t, memList, remoteList)
+
+		for _, galEl := range galEls {
+			gk, err := evk.GetGaloisKey(galEl)
+			require.NoError(t, err)
+			want, err := memEvk.GetGaloisKey(galEl)
+			require.NoError(t, err)
+			require.True(t, want.Equal(gk))
+		}
+
+		rlk, err := evk.GetRelinearizationKey()
+		require.NoError(t, err)
+		wantRlk, err := memEvk.GetRelinearizationKey()
+		require.NoError(t, err)
+		require.True(t, wantRlk.Equal(&rlk.EvaluationKey))
+
+		// Each key is fetched at most once: further retrievals are served
+		// from the cache without a network round-trip.
+		fetched := requests.Load()
+		gk0, err := evk.GetGaloisKey(galEls[0])
+		require.NoError(t, err)
+		gk1, err := evk.GetGaloisKey(galEls[0])
+		require.NoError(t, err)
+		require.True(t, gk0 == gk1)
+		_, err = evk.GetRelinearizationKey()
+		require.NoError(t, err)
+		require.Equal(t, fetched, requests.Load())
+
+		// A key missing on the service is reported as an error.
+		_, err = evk.GetGaloisKey(params.GaloisElement(4))
+		require.Error(t, err)
+	})
+
+	t.Run("RemoteEvaluationKeySet/Evaluator", func(t *testing.T) {
+
+		eval := NewEvaluator(params, NewRemoteEvaluationKeySet(transport))
+
+		ct := tc.enc.EncryptZeroNew(params.MaxLevel())
+		opOut := NewCiphertext(params, 1, params.MaxLevel())
+
+		for _, galEl := range galEls {
+			require.NoError(t, eval.Automorphism(ct, galEl, opOut))
+		}
+		require.Error(t, eval.Automorphism(ct, params.GaloisElement(4), opOut))
+	})
+
+	t.Run("RemoteEvaluationKeySet/TransportError", func(t *testing.T) {
+
+		evk := NewRemoteEvaluationKeySet(HTTPEvaluationKeyTransport{BaseURL: "http://127.0.0.1:0"})
+
+		require.Nil(t, evk.GetGaloisKeysList())
+		_, err := evk.GetGaloisKey(galEls[0])
+		require.Error(t, err)
+		_, err = evk.GetRelinearizationKey()
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,317 @@
+package rlwe
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/bits"
+
+	"github.com/tuneinsight/lattigo/v6/utils"
+	"github.com/tuneinsight/lattigo/v6/utils/buffer"
+	"github.com/tuneinsight/lattigo/v6/utils/structs"
+)
+
+// LWECiphertext is a generic type for RNS LWE ciphertexts (b, a) satisfying
+// b + <a, s> = m + e mod Q, where s is the coefficient vector of the RLWE
+// secret. LWE ciphertexts are produced by [ExtractLWE], which extracts
+// individual coefficients of an RLWE ciphertext, and can be packed back into
+// a single RLWE ciphertext with [RingPackingEvaluator.RepackLWE].
+type LWECiphertext struct {
+	*MetaData
+
+	// Value holds, for each modulus, the vector (b, a[0], ..., a[N-1]).
+	Value structs.Matrix[uint64]
+}
+
+// NewLWECiphertext allocates a new zero [LWECiphertext] of dimension N with
+// level+1 moduli.
+func NewLWECiphertext(N, level int) *LWECiphertext {
+	Value := make([][]uint64, level+1)
+	for i := range Value {
+		Value[i] = make([]uint64, N+1)
+	}
+	return &LWECiphertext{MetaData: &MetaData{}, Value: Value}
+}
+
+// N returns the dimension of the ciphertext, i.e. the size of its vector a.
+func (ct LWECiphertext) N() int {
+	return len(ct.Value[0]) - 1
+}
+
+// Level returns the level of the ciphertext.
+func (ct LWECiphertext) Level() int {
+	return len(ct.Value) - 1
+}
+
+// CopyNew returns a deep copy of the receiver.
+func (ct LWECiphertext) CopyNew() *LWECiphertext {
+	var meta *MetaData
+	if ct.MetaData != nil {
+		meta = &MetaData{}
+		*meta = *ct.MetaData
+	}
+	return &LWECiphertext{MetaData: meta, Value: ct.Value.CopyNew()}
+}
+
+// Equal performs a deep equal between the receiver and the operand.
+func (ct LWECiphertext) Equal(other *LWECiphertext) bool {
+	return ct.MetaData.Equal(other.MetaData) && ct.Value.Equal(other.Value)
+}
+
+// BinarySize returns the serialized size of the object in bytes.
+func (ct LWECiphertext) BinarySize() (size int) {
+	size++ // Whether or not there is metadata
+	if ct.MetaData != nil {
+		size += ct.MetaData.BinarySize()
+	}
+	return size + ct.Value.BinarySize()
+}
+
+// WriteTo writes the object on an [io.Writer]. It implements the [io.WriterTo]
+// interface, and will write exactly object.BinarySize() bytes on w.
+//
+// Unless w implements the [buffer.Writer] interface (see lattigo/utils/buffer/writer.go),
+// it will be wrapped into a [bufio.Writer]. Since this requires allocations, it
+// is preferable to pass a [buffer.Writer] directly:
+//
+//   - When writing multiple times to a [io.Writer], it is preferable to first wrap the
+//     [io.Writer] in a pre-allocated [bufio.Writer].
+//   - When writing to a pre-allocated var b []byte, it is preferable to pass
+//     buffer.NewBuffer(b) as w (see lattigo/utils/buffer/buffer.go).
+func (ct LWECiphertext) WriteTo(w io.Writer) (n int64, err error) {
+
+	switch w := w.(type) {
+	case buffer.Writer:
+
+		var inc int64
+
+		if ct.MetaData != nil {
+
+			if inc, err = buffer.WriteUint8(w, 1); err != nil {
+				return n, err
+			}
+
+			n += inc
+
+			if inc, err = ct.MetaData.WriteTo(w); err != nil {
+				return n, err
+			}
+
+			n += inc
+
+		} else {
+			if inc, err = buffer.WriteUint8(w, 0); err != nil {
+				return n, err
+			}
+
+			n += inc
+		}
+
+		inc, err = ct.Value.WriteTo(w)
+
+		return n + inc, err
+
+	default:
+		return ct.WriteTo(bufio.NewWriter(w))
+	}
+}
+
+// ReadFrom reads on the object from an [io.Writer]. It implements the
+// io.ReaderFrom interface.
+//
+// Unless r implements the [buffer.Reader] interface (see see lattigo/utils/buffer/reader.go),
+// it will be wrapped into a [bufio.Reader]. Since this requires allocation, it
+// is preferable to pass a buffer.Reader directly:
+//
+//   - When reading multiple values from a [io.Reader], it is preferable to first
+//     first wrap [io.Reader] in a pre-allocated [bufio.Reader].
+//   - When reading from a var b []byte, it is preferable to pass a buffer.NewBuffer(b)
+//     as w (see lattigo/utils/buffer/buffer.go).
+func (ct *LWECiphertext) ReadFrom(r io.Reader) (n int64, err error) {
+
+	switch r := r.(type) {
+	case buffer.Reader:
+
+		if ct == nil {
+			return 0, fmt.Errorf("cannot ReadFrom: target object is nil")
+		}
+
+		var inc int64
+
+		var hasMetaData uint8
+
+		if inc, err = buffer.ReadUint8(r, &hasMetaData); err != nil {
+			return n, err
+		}
+
+		n += inc
+
+		if hasMetaData == 1 {
+
+			if ct.MetaData == nil {
+				ct.MetaData = &MetaData{}
+			}
+
+			if inc, err = ct.MetaData.ReadFrom(r); err != nil {
+				return n, err
+			}
+
+			n += inc
+		}
+
+		inc, err = ct.Value.ReadFrom(r)
+
+		return n + inc, err
+
+	default:
+		return ct.ReadFrom(bufio.NewReader(r))
+	}
+}
+
+// MarshalBinary encodes the object into a binary form on a newly allocated slice of bytes.
+func (ct LWECiphertext) MarshalBinary() (p []byte, err error) {
+	buf := buffer.NewBufferSize(ct.BinarySize())
+	_, err = ct.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary decodes a slice of bytes generated by
+// [LWECiphertext.MarshalBinary] or [LWECiphertext.WriteTo] on the object.
+func (ct *LWECiphertext) UnmarshalBinary(p []byte) (err error) {
+	_, err = ct.ReadFrom(buffer.NewBuffer(p))
+	return
+}
+
+// ExtractLWE extracts the coefficients in idx of ct, a degree-one RLWE
+// ciphertext, as LWE ciphertexts under the coefficient vector of the RLWE
+// secret. The operation requires no evaluation key and is the inverse of
+// [RingPackingEvaluator.RepackLWE].
+func ExtractLWE(params ParameterProvider, ct *Ciphertext, idx []int) (cts map[int]*LWECiphertext, err error) {
+
+	p := params.GetRLWEParameters()
+
+	if ct.Degree() != 1 {
+		return nil, fmt.Errorf("invalid ciphertext: Degree() should be 1 but is %d", ct.Degree())
+	}
+
+	level := ct.Level()
+	N := ct.N()
+
+	ringQ := p.RingQ().AtLevel(level)
+
+	c0, c1 := ct.Value[0], ct.Value[1]
+
+	if ct.IsNTT {
+		c0INTT, c1INTT := ringQ.NewPoly(), ringQ.NewPoly()
+		ringQ.INTT(c0, c0INTT)
+		ringQ.INTT(c1, c1INTT)
+		c0, c1 = c0INTT, c1INTT
+	}
+
+	cts = make(map[int]*LWECiphertext, len(idx))
+
+	for _, k := range idx {
+
+		if k < 0 || k >= N {
+			return nil, fmt.Errorf("invalid idx: %d is outside of the range [0, %d)", k, N)
+		}
+
+		lwe := NewLWECiphertext(N, level)
+		*lwe.MetaData = *ct.MetaData
+		lwe.IsNTT = false
+
+		for i := 0; i < level+1; i++ {
+
+			qi := ringQ.SubRings[i].Modulus
+			c0i, c1i := c0.Coeffs[i], c1.Coeffs[i]
+			v := lwe.Value[i]
+
+			// b + <a, s> = (c0 + c1 * s)[k], with the negacyclic wrap-around
+			// of X^{N} = -1 flipping the sign of the coefficients of c1 that
+			// multiply s[j] for j > k.
+			v[0] = c0i[k]
+			for j := 0; j < k+1; j++ {
+				v[1+j] = c1i[k-j]
+			}
+			for j := k + 1; j < N; j++ {
+				if c := c1i[N+k-j]; c != 0 {
+					v[1+j] = qi - c
+				}
+			}
+		}
+
+		cts[k] = lwe
+	}
+
+	return
+}
+
+// RepackLWE repacks a map of LWE ciphertexts into a single RLWE ciphertext
+// encrypting P(X) = cts[i] * X^{i}, the inverse of [ExtractLWE]. All the LWE
+// ciphertexts must share the same dimension and level, and the dimension must
+// be the degree of one of the rings of eval.Parameters, for which the
+// corresponding repacking keys are required (see
+// [RingPackingEvaluationKey.GenRepackEvaluationKeys]).
+func (eval RingPackingEvaluator) RepackLWE(cts map[int]*LWECiphertext) (ct *Ciphertext, err error) {
+
+	if len(cts) == 0 {
+		return nil, fmt.Errorf("len(cts) = 0")
+	}
+
+	keys := utils.GetSortedKeys(cts)
+
+	ref := cts[keys[0]]
+	N := ref.N()
+	level := ref.Level()
+	logN := bits.Len64(uint64(N)) - 1
+
+	p, ok := eval.Parameters[logN]
+	if !ok || N != 1<<logN {
+		return nil, fmt.Errorf("invalid LWE ciphertexts: eval.Parameters has no ring of degree %d", N)
+	}
+
+	params := *p.GetRLWEParameters()
+	ringQ := params.RingQ().AtLevel(level)
+
+	ctsRLWE := make(map[int]*Ciphertext, len(cts))
+	for _, k := range keys {
+
+		lwe := cts[k]
+
+		if lwe.N() != N || lwe.Level() != level {
+			return nil, fmt.Errorf("invalid LWE ciphertexts: all ciphertexts must share the same dimension and level")
+		}
+
+		// Embeds the LWE ciphertext back into a degree-one RLWE ciphertext
+		// whose constant coefficient decrypts to b + <a, s>: the vector a is
+		// folded into the polynomial a[0] - a[N-1] * X - ... - a[1] * X^{N-1},
+		// and the coefficients of higher degree are garbage that the repacking
+		// zeroes.
+		ctRLWE := NewCiphertext(params, 1, level)
+		*ctRLWE.MetaData = *lwe.MetaData
+		ctRLWE.IsNTT = false
+
+		for i := 0; i < level+1; i++ {
+
+			qi := ringQ.SubRings[i].Modulus
+			v := lwe.Value[i]
+			c0, c1 := ctRLWE.Value[0].Coeffs[i], ctRLWE.Value[1].Coeffs[i]
+
+			c0[0] = v[0]
+			c1[0] = v[1]
+			for j := 1; j < N; j++ {
+				if c := v[1+N-j]; c != 0 {
+					c1[j] = qi - c
+				}
+			}
+		}
+
+		ctsRLWE[k] = ctRLWE
+	}
+
+	if ct, err = eval.Repack(ctsRLWE); err != nil {
+		return nil, fmt.Errorf("cannot RepackLWE: %w", err)
+	}
+
+	return
+}
@@ -0,0 +1,149 @@
+package rlwe
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/utils"
+	"github.com/tuneinsight/lattigo/v6/utils/buffer"
+)
+
+func TestLWE(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN:    10,
+		LogQ:    []int{60},
+		LogP:    []int{60},
+		NTTFlag: true,
+	})
+	require.NoError(t, err)
+
+	tc, err := NewTestContext(params)
+	require.NoError(t, err)
+
+	sk := tc.sk
+	enc := tc.enc
+	dec := tc.dec
+	level := params.MaxLevel()
+	ringQ := params.RingQ().AtLevel(level)
+
+	evkParams := EvaluationKeyParameters{
+		LevelQ: utils.Pointy(params.MaxLevelQ()),
+		LevelP: utils.Pointy(params.MaxLevelP()),
+	}
+
+	evkRP := RingPackingEvaluationKey{Parameters: map[int]ParameterProvider{params.LogN(): &params}}
+	evkRP.GenRepackEvaluationKeys(params, sk, evkParams)
+	eval := NewRingPackingEvaluator(&evkRP)
+
+	idx := []int{0, 1, 17, params.N() / 2, params.N() - 1}
+
+	t.Run(testString(params, level, params.MaxLevelP(), 0, "ExtractLWE"), func(t *testing.T) {
+
+		pt := genPlaintextNTT(params, level, 1<<40)
+
+		ct, err := enc.EncryptNew(pt)
+		require.NoError(t, err)
+
+		cts, err := ExtractLWE(params, ct, idx)
+		require.NoError(t, err)
+		require.Equal(t, len(idx), len(cts))
+
+		// Secret in the coefficient domain.
+		s := ringQ.NewPoly()
+		ringQ.IMForm(sk.Value.Q, s)
+		ringQ.INTT(s, s)
+
+		ptRef := pt.CopyNew()
+		if ptRef.IsNTT {
+			ringQ.INTT(ptRef.Value, ptRef.Value)
+		}
+
+		// Each LWE ciphertext satisfies b + <a, s> = m + e where m is the
+		// extracted coefficient of the plaintext.
+		diff := ringQ.NewPoly()
+		for _, k := range idx {
+
+			lwe := cts[k]
+
+			require.Equal(t, params.N(), lwe.N())
+			require.Equal(t, level, lwe.Level())
+
+			for i := 0; i < level+1; i++ {
+
+				Q := ringQ.SubRings[i].Modulus
+
+				acc := new(big.Int).SetUint64(lwe.Value[i][0])
+				tmp := new(big.Int)
+				for j := 0; j < params.N(); j++ {
+					acc.Add(acc, tmp.Mul(tmp.SetUint64(lwe.Value[i][1+j]), new(big.Int).SetUint64(s.Coeffs[i][j])))
+				}
+				acc.Mod(acc, new(big.Int).SetUint64(Q))
+
+				diff.Coeffs[i][k] = ring.CRed(acc.Uint64()+Q-ptRef.Value.Coeffs[i][k], Q)
+			}
+		}
+
+		// Logs the noise
+		require.GreaterOrEqual(t, float64(params.LogN()+1), ringQ.Log2OfStandardDeviation(diff))
+
+		_, err = ExtractLWE(params, ct, []int{params.N()})
+		require.Error(t, err)
+	})
+
+	t.Run(testString(params, level, params.MaxLevelP(), 0, "RepackLWE"), func(t *testing.T) {
+
+		pt := genPlaintextNTT(params, level, 1<<40)
+
+		ct, err := enc.EncryptNew(pt)
+		require.NoError(t, err)
+
+		cts, err := ExtractLWE(params, ct, idx)
+		require.NoError(t, err)
+
+		ctRepack, err := eval.RepackLWE(cts)
+		require.NoError(t, err)
+
+		ptHave := dec.DecryptNew(ctRepack)
+		if ptHave.IsNTT {
+			ringQ.INTT(ptHave.Value, ptHave.Value)
+		}
+
+		if pt.IsNTT {
+			ringQ.INTT(pt.Value, pt.Value)
+		}
+
+		// The repacked ciphertext encrypts the extracted coefficients at
+		// their original position and zeroes everywhere else.
+		for i := 0; i < level+1; i++ {
+			Q := ringQ.SubRings[i].Modulus
+			have := ptHave.Value.Coeffs[i]
+			ref := pt.Value.Coeffs[i]
+			for _, k := range idx {
+				have[k] = ring.CRed(have[k]+Q-ref[k], Q)
+			}
+		}
+
+		// Logs the noise
+		require.GreaterOrEqual(t, float64(params.LogN()+5), ringQ.Log2OfStandardDeviation(ptHave.Value))
+
+		_, err = eval.RepackLWE(map[int]*LWECiphertext{})
+		require.Error(t, err)
+	})
+
+	t.Run(testString(params, level, params.MaxLevelP(), 0, "WriteAndRead/LWE"), func(t *testing.T) {
+
+		pt := genPlaintextNTT(params, level, 1<<40)
+
+		ct, err := enc.EncryptNew(pt)
+		require.NoError(t, err)
+
+		cts, err := ExtractLWE(params, ct, []int{3})
+		require.NoError(t, err)
+
+		buffer.RequireSerializerCorrect(t, cts[3])
+	})
+}
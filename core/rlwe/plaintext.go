@@ -52,6 +52,42 @@ func (pt Plaintext) Equal(other *Plaintext) bool {
 	return pt.Element.Equal(&other.Element) && pt.Value.Equal(&other.Value)
 }
 
+// PlaintextOperand is a [Plaintext] whose value is stored in the NTT and
+// Montgomery domain, which is the pre-format that the evaluators expect for
+// plaintext multiplicands. Pre-formatting a plaintext that is reused as a
+// multiplicand across many calls to Mul or MulThenAdd saves the per-call
+// domain switching that the evaluators otherwise perform internally, which is
+// the same optimization that the linear-transformation encoder applies to its
+// diagonals. A [PlaintextOperand] is serialized like a [Plaintext], with the
+// pre-format recorded in its [MetaData].
+type PlaintextOperand struct {
+	Plaintext
+}
+
+// NewPlaintextOperand returns a copy of pt in the NTT and Montgomery domain.
+// Schemes whose plaintext-ciphertext multiplication applies an additional
+// pre-scaling (such as the integer schemes, which scale the multiplicand by
+// the plaintext modulus) expose their own constructor instead, which must be
+// used in place of this one.
+func NewPlaintextOperand(params ParameterProvider, pt *Plaintext) (op *PlaintextOperand) {
+
+	op = &PlaintextOperand{Plaintext: *pt.CopyNew()}
+
+	ringQ := params.GetRLWEParameters().RingQ().AtLevel(op.Level())
+
+	if !op.IsNTT {
+		ringQ.NTT(op.Value, op.Value)
+		op.IsNTT = true
+	}
+
+	if !op.IsMontgomery {
+		ringQ.MForm(op.Value, op.Value)
+		op.IsMontgomery = true
+	}
+
+	return
+}
+
 // NewPlaintextRandom generates a new uniformly distributed [Plaintext].
 func NewPlaintextRandom(prng sampling.PRNG, params ParameterProvider, level int) (pt *Plaintext) {
 	pt = NewPlaintext(params, level)
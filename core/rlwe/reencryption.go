@@ -0,0 +1,262 @@
+package rlwe
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/tuneinsight/lattigo/v6/utils/buffer"
+)
+
+// ReEncryptionRequest wraps a ciphertext that a server sends to a trusted client
+// for a client-assisted refresh: the client decrypts the ciphertext, re-encrypts
+// the underlying plaintext fresh and returns the result as a [ReEncryptionResponse].
+// This provides an alternative to bootstrapping for deployments where the client
+// is online and holds the decryption key.
+//
+// See [ReEncryptionBroker] for the server-side bookkeeping.
+type ReEncryptionRequest struct {
+	ID uint64
+	Ciphertext
+}
+
+// ReEncryptionResponse wraps the freshly re-encrypted ciphertext that a trusted
+// client returns in answer to a [ReEncryptionRequest]. The ID field echoes the
+// one of the originating request.
+type ReEncryptionResponse struct {
+	ID uint64
+	Ciphertext
+}
+
+// ReEncryptionBroker keeps track of the in-flight [ReEncryptionRequest] issued by
+// a server during a circuit evaluation and splices the refreshed ciphertexts back
+// into the running circuit when the matching [ReEncryptionResponse] arrives.
+//
+// The zero value is not usable; use [NewReEncryptionBroker].
+// ReEncryptionBroker is not safe for concurrent use.
+type ReEncryptionBroker struct {
+	nextID  uint64
+	pending map[uint64]*Ciphertext
+}
+
+// NewReEncryptionBroker creates a new [ReEncryptionBroker].
+func NewReEncryptionBroker() *ReEncryptionBroker {
+	return &ReEncryptionBroker{pending: map[uint64]*Ciphertext{}}
+}
+
+// RequestReEncryption issues a [ReEncryptionRequest] for the given ciphertext and
+// registers ct as the splice target: when the matching response is received through
+// [ReEncryptionBroker.Splice], the refreshed ciphertext is copied back into ct so
+// that the circuit holding it can resume with a fresh ciphertext.
+func (brk *ReEncryptionBroker) RequestReEncryption(ct *Ciphertext) ReEncryptionRequest {
+	brk.nextID++
+	brk.pending[brk.nextID] = ct
+	return ReEncryptionRequest{ID: brk.nextID, Ciphertext: *ct.CopyNew()}
+}
+
+// Pending returns the number of requests issued but not yet spliced back.
+func (brk *ReEncryptionBroker) Pending() int {
+	return len(brk.pending)
+}
+
+// Splice copies the refreshed ciphertext of the response back into the ciphertext
+// registered by [ReEncryptionBroker.RequestReEncryption] under the same ID, and
+// unregisters the request. It returns an error if no request with this ID is pending.
+func (brk *ReEncryptionBroker) Splice(resp ReEncryptionResponse) error {
+	ct, ok := brk.pending[resp.ID]
+	if !ok {
+		return fmt.Errorf("cannot Splice: no pending ReEncryptionRequest with ID %d", resp.ID)
+	}
+	resp.Ciphertext.Copy(ct)
+	delete(brk.pending, resp.ID)
+	return nil
+}
+
+// RespondReEncryptionRequest implements the client side of the client-assisted
+// refresh flow: it decrypts the ciphertext of the request with dec, re-encrypts
+// the resulting plaintext fresh with enc and returns the matching response.
+func RespondReEncryptionRequest(req ReEncryptionRequest, dec *Decryptor, enc *Encryptor) (ReEncryptionResponse, error) {
+	ct, err := enc.EncryptNew(dec.DecryptNew(&req.Ciphertext))
+	if err != nil {
+		return ReEncryptionResponse{}, fmt.Errorf("cannot RespondReEncryptionRequest: %w", err)
+	}
+	return ReEncryptionResponse{ID: req.ID, Ciphertext: *ct}, nil
+}
+
+// BinarySize returns the serialized size of the object in bytes.
+func (req ReEncryptionRequest) BinarySize() (size int) {
+	return 8 + req.Ciphertext.BinarySize()
+}
+
+// WriteTo writes the object on an [io.Writer]. It implements the [io.WriterTo]
+// interface, and will write exactly object.BinarySize() bytes on w.
+//
+// Unless w implements the [buffer.Writer] interface (see lattigo/utils/buffer/writer.go),
+// it will be wrapped into a [bufio.Writer]. Since this requires allocations, it
+// is preferable to pass a [buffer.Writer] directly:
+//
+//   - When writing multiple times to a [io.Writer], it is preferable to first wrap the
+//     io.Writer in a pre-allocated [bufio.Writer].
+//   - When writing to a pre-allocated var b []byte, it is preferable to pass
+//     buffer.NewBuffer(b) as w (see lattigo/utils/buffer/buffer.go).
+func (req ReEncryptionRequest) WriteTo(w io.Writer) (n int64, err error) {
+	switch w := w.(type) {
+	case buffer.Writer:
+
+		var inc int64
+
+		if inc, err = buffer.WriteUint64(w, req.ID); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		if inc, err = req.Ciphertext.WriteTo(w); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		return
+	default:
+		return req.WriteTo(bufio.NewWriter(w))
+	}
+}
+
+// ReadFrom reads on the object from an [io.Writer]. It implements the
+// [io.ReaderFrom] interface.
+//
+// Unless r implements the [buffer.Reader] interface (see see lattigo/utils/buffer/reader.go),
+// it will be wrapped into a [bufio.Reader]. Since this requires allocation, it
+// is preferable to pass a [buffer.Reader] directly:
+//
+//   - When reading multiple values from a [io.Reader], it is preferable to first
+//     first wrap io.Reader in a pre-allocated [bufio.Reader].
+//   - When reading from a var b []byte, it is preferable to pass a buffer.NewBuffer(b)
+//     as w (see lattigo/utils/buffer/buffer.go).
+func (req *ReEncryptionRequest) ReadFrom(r io.Reader) (n int64, err error) {
+	switch r := r.(type) {
+	case buffer.Reader:
+
+		var inc int64
+
+		if inc, err = buffer.ReadUint64(r, &req.ID); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		if inc, err = req.Ciphertext.ReadFrom(r); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		return
+	default:
+		return req.ReadFrom(bufio.NewReader(r))
+	}
+}
+
+// MarshalBinary encodes the object into a binary form on a newly allocated slice of bytes.
+func (req ReEncryptionRequest) MarshalBinary() (p []byte, err error) {
+	buf := buffer.NewBufferSize(req.BinarySize())
+	_, err = req.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary decodes a slice of bytes generated by
+// [ReEncryptionRequest.MarshalBinary] or [ReEncryptionRequest.WriteTo] on the object.
+func (req *ReEncryptionRequest) UnmarshalBinary(p []byte) (err error) {
+	_, err = req.ReadFrom(buffer.NewBuffer(p))
+	return
+}
+
+// BinarySize returns the serialized size of the object in bytes.
+func (resp ReEncryptionResponse) BinarySize() (size int) {
+	return 8 + resp.Ciphertext.BinarySize()
+}
+
+// WriteTo writes the object on an [io.Writer]. It implements the [io.WriterTo]
+// interface, and will write exactly object.BinarySize() bytes on w.
+//
+// Unless w implements the [buffer.Writer] interface (see lattigo/utils/buffer/writer.go),
+// it will be wrapped into a [bufio.Writer]. Since this requires allocations, it
+// is preferable to pass a [buffer.Writer] directly:
+//
+//   - When writing multiple times to a [io.Writer], it is preferable to first wrap the
+//     io.Writer in a pre-allocated [bufio.Writer].
+//   - When writing to a pre-allocated var b []byte, it is preferable to pass
+//     buffer.NewBuffer(b) as w (see lattigo/utils/buffer/buffer.go).
+func (resp ReEncryptionResponse) WriteTo(w io.Writer) (n int64, err error) {
+	switch w := w.(type) {
+	case buffer.Writer:
+
+		var inc int64
+
+		if inc, err = buffer.WriteUint64(w, resp.ID); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		if inc, err = resp.Ciphertext.WriteTo(w); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		return
+	default:
+		return resp.WriteTo(bufio.NewWriter(w))
+	}
+}
+
+// ReadFrom reads on the object from an [io.Writer]. It implements the
+// [io.ReaderFrom] interface.
+//
+// Unless r implements the [buffer.Reader] interface (see see lattigo/utils/buffer/reader.go),
+// it will be wrapped into a [bufio.Reader]. Since this requires allocation, it
+// is preferable to pass a [buffer.Reader] directly:
+//
+//   - When reading multiple values from a [io.Reader], it is preferable to first
+//     first wrap io.Reader in a pre-allocated [bufio.Reader].
+//   - When reading from a var b []byte, it is preferable to pass a buffer.NewBuffer(b)
+//     as w (see lattigo/utils/buffer/buffer.go).
+func (resp *ReEncryptionResponse) ReadFrom(r io.Reader) (n int64, err error) {
+	switch r := r.(type) {
+	case buffer.Reader:
+
+		var inc int64
+
+		if inc, err = buffer.ReadUint64(r, &resp.ID); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		if inc, err = resp.Ciphertext.ReadFrom(r); err != nil {
+			return n + inc, err
+		}
+
+		n += inc
+
+		return
+	default:
+		return resp.ReadFrom(bufio.NewReader(r))
+	}
+}
+
+// MarshalBinary encodes the object into a binary form on a newly allocated slice of bytes.
+func (resp ReEncryptionResponse) MarshalBinary() (p []byte, err error) {
+	buf := buffer.NewBufferSize(resp.BinarySize())
+	_, err = resp.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary decodes a slice of bytes generated by
+// [ReEncryptionResponse.MarshalBinary] or [ReEncryptionResponse.WriteTo] on the object.
+func (resp *ReEncryptionResponse) UnmarshalBinary(p []byte) (err error) {
+	_, err = resp.ReadFrom(buffer.NewBuffer(p))
+	return
+}
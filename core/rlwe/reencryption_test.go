@@ -0,0 +1,64 @@
+package rlwe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/utils/buffer"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestReEncryption(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{45, 45},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	tc, err := NewTestContext(params)
+	require.NoError(t, err)
+
+	prng, err := sampling.NewPRNG()
+	require.NoError(t, err)
+
+	t.Run("ReEncryption/Flow", func(t *testing.T) {
+
+		// Server-side: the circuit holds a ciphertext whose noise budget is exhausted
+		ct := NewCiphertextRandom(prng, params, 1, params.MaxLevel())
+		ct.IsNTT = params.NTTFlag()
+
+		brk := NewReEncryptionBroker()
+		req := brk.RequestReEncryption(ct)
+		require.Equal(t, 1, brk.Pending())
+
+		// Client-side: decrypt and re-encrypt fresh
+		resp, err := RespondReEncryptionRequest(req, tc.dec, tc.enc)
+		require.NoError(t, err)
+		require.Equal(t, req.ID, resp.ID)
+
+		// Server-side: splice the refreshed ciphertext back into the circuit
+		require.NoError(t, brk.Splice(resp))
+		require.Equal(t, 0, brk.Pending())
+		require.True(t, ct.Equal(&resp.Ciphertext))
+
+		// A response can only be spliced once
+		require.Error(t, brk.Splice(resp))
+	})
+
+	t.Run("ReEncryption/Serialization", func(t *testing.T) {
+
+		ct := NewCiphertextRandom(prng, params, 1, params.MaxLevel())
+		ct.IsNTT = params.NTTFlag()
+
+		brk := NewReEncryptionBroker()
+		req := brk.RequestReEncryption(ct)
+		buffer.RequireSerializerCorrect(t, &req)
+
+		resp, err := RespondReEncryptionRequest(req, tc.dec, tc.enc)
+		require.NoError(t, err)
+		buffer.RequireSerializerCorrect(t, &resp)
+	})
+}
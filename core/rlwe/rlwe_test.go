@@ -344,6 +344,46 @@ func testKeyGenerator(tc *TestContext, bpw2 int, t *testing.T) {
 		}
 	})
 
+	t.Run(testString(params, params.MaxLevelQ(), params.MaxLevelP(), bpw2, "KeyGenerator/GenPublicKey/Compressed=True"), func(t *testing.T) {
+
+		pk := NewCompressedPublicKey(params)
+
+		kgen.GenPublicKey(sk, pk)
+
+		require.True(t, pk.IsCompressed())
+
+		require.NoError(t, pk.Expand(params))
+
+		require.False(t, pk.IsCompressed())
+
+		if params.PCount() > 0 {
+
+			ringQP := params.RingQP()
+
+			zero := ringQP.NewPoly()
+
+			ringQP.MulCoeffsMontgomery(sk.Value, pk.Value[1], zero)
+			ringQP.Add(zero, pk.Value[0], zero)
+			ringQP.INTT(zero, zero)
+			ringQP.IMForm(zero, zero)
+
+			require.GreaterOrEqual(t, math.Log2(params.NoiseFreshSK())+1, params.RingQ().Log2OfStandardDeviation(zero.Q))
+			require.GreaterOrEqual(t, math.Log2(params.NoiseFreshSK())+1, params.RingP().Log2OfStandardDeviation(zero.P))
+		} else {
+
+			ringQ := params.RingQ()
+
+			zero := ringQ.NewPoly()
+
+			ringQ.MulCoeffsMontgomeryThenAdd(sk.Value.Q, pk.Value[1].Q, zero)
+			ringQ.Add(zero, pk.Value[0].Q, zero)
+			ringQ.INTT(zero, zero)
+			ringQ.IMForm(zero, zero)
+
+			require.GreaterOrEqual(t, math.Log2(params.NoiseFreshSK())+1, params.RingQ().Log2OfStandardDeviation(zero))
+		}
+	})
+
 	var levelsQ = []int{0}
 	if params.MaxLevelQ() > 0 {
 		levelsQ = append(levelsQ, params.MaxLevelQ())
@@ -540,6 +580,31 @@ func testEncryptor(tc *TestContext, level, bpw2 int, t *testing.T) {
 		require.GreaterOrEqual(t, math.Log2(params.NoiseFreshSK())+1, ringQ.Log2OfStandardDeviation(pt.Value))
 	})
 
+	t.Run(testString(params, level, params.MaxLevelP(), bpw2, "Encryptor/EncryptCompressed"), func(t *testing.T) {
+		ringQ := params.RingQ().AtLevel(level)
+
+		pt := NewPlaintext(params, level)
+
+		ctCompressed, err := enc.EncryptCompressedNew(pt)
+		require.NoError(t, err)
+		require.Equal(t, 0, ctCompressed.Degree())
+
+		ct, err := ctCompressed.Expand(params)
+		require.NoError(t, err)
+		require.Equal(t, 1, ct.Degree())
+
+		dec.Decrypt(ct, pt)
+
+		if pt.IsNTT {
+			ringQ.INTT(pt.Value, pt.Value)
+		}
+		require.GreaterOrEqual(t, math.Log2(params.NoiseFreshSK())+1, ringQ.Log2OfStandardDeviation(pt.Value))
+
+		// Compressed encryption leaks the encryption randomness under a public key.
+		_, err = enc.WithKey(pk).EncryptCompressedNew(pt)
+		require.Error(t, err)
+	})
+
 	t.Run(testString(params, level, params.MaxLevelP(), bpw2, "Encrypt/Sk/ShallowCopy"), func(t *testing.T) {
 		skEnc1 := NewEncryptor(params, sk)
 		skEnc2 := skEnc1.ShallowCopy()
@@ -1092,6 +1157,13 @@ func testWriteAndRead(tc *TestContext, bpw2 int, t *testing.T) {
 		buffer.RequireSerializerCorrect(t, plaintextWant)
 	})
 
+	t.Run(testString(params, levelQ, levelP, bpw2, "WriteAndRead/PlaintextOperand"), func(t *testing.T) {
+		prng, _ := sampling.NewPRNG()
+		pt := NewPlaintext(params, levelQ)
+		ring.NewUniformSampler(prng, params.RingQ()).Read(pt.Value)
+		buffer.RequireSerializerCorrect(t, NewPlaintextOperand(params, pt))
+	})
+
 	t.Run(testString(params, levelQ, levelP, bpw2, "WriteAndRead/Ciphertext"), func(t *testing.T) {
 
 		prng, _ := sampling.NewPRNG()
@@ -1109,6 +1181,12 @@ func testWriteAndRead(tc *TestContext, bpw2 int, t *testing.T) {
 		buffer.RequireSerializerCorrect(t, &rlk.GadgetCiphertext)
 	})
 
+	t.Run(testString(params, levelQ, levelP, bpw2, "WriteAndRead/CompressedCiphertext"), func(t *testing.T) {
+		ct, err := tc.enc.EncryptCompressedNew(NewPlaintext(params, levelQ))
+		require.NoError(t, err)
+		buffer.RequireSerializerCorrect(t, ct)
+	})
+
 	t.Run(testString(params, levelQ, levelP, bpw2, "WriteAndRead/Sk"), func(t *testing.T) {
 		buffer.RequireSerializerCorrect(t, sk)
 	})
@@ -1117,10 +1195,20 @@ func testWriteAndRead(tc *TestContext, bpw2 int, t *testing.T) {
 		buffer.RequireSerializerCorrect(t, pk)
 	})
 
+	t.Run(testString(params, levelQ, levelP, bpw2, "WriteAndRead/Pk/Compressed=True"), func(t *testing.T) {
+		pkCompressed := NewCompressedPublicKey(params)
+		tc.kgen.GenPublicKey(sk, pkCompressed)
+		buffer.RequireSerializerCorrect(t, pkCompressed)
+	})
+
 	t.Run(testString(params, levelQ, levelP, bpw2, "WriteAndRead/EvaluationKey/Compressed=False"), func(t *testing.T) {
 		buffer.RequireSerializerCorrect(t, tc.kgen.GenEvaluationKeyNew(sk, sk))
 	})
 
+	t.Run(testString(params, levelQ, levelP, bpw2, "WriteAndRead/EvaluationKey/Compressed=True"), func(t *testing.T) {
+		buffer.RequireSerializerCorrect(t, tc.kgen.GenEvaluationKeyNew(sk, sk, EvaluationKeyParameters{Compressed: true}))
+	})
+
 	t.Run(testString(params, levelQ, levelP, bpw2, "WriteAndRead/RelinearizationKey"), func(t *testing.T) {
 		buffer.RequireSerializerCorrect(t, tc.kgen.GenRelinearizationKeyNew(tc.sk))
 	})
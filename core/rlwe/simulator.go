@@ -0,0 +1,214 @@
+package rlwe
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// SimulatedCiphertext tracks the symbolic metadata of a ciphertext during a
+// dry run of a circuit with [Simulator]: its degree, level, scale and an
+// estimate of its noise, without any backing polynomials.
+type SimulatedCiphertext struct {
+	// Degree is the degree of the ciphertext.
+	Degree int
+	// Level is the level of the ciphertext.
+	Level int
+	// Scale is the scaling factor of the ciphertext.
+	Scale Scale
+	// Noise is the log2 of the estimated standard deviation of the noise of
+	// the ciphertext in the coefficient domain.
+	Noise float64
+}
+
+// Simulator executes evaluator operations on symbolic ciphertext metadata
+// only: levels, scales and first-order average-case noise estimates are
+// updated as the real evaluator would, but no polynomial arithmetic is
+// performed, so the dry run of a circuit takes microseconds. It enables
+// validating a parameter choice for a circuit (level budget, scale
+// management, output precision) before running the real evaluation.
+//
+// The noise model follows the same first-order heuristics as the a-priori
+// precision estimation of the ckks scheme: multiplications assume
+// unit-magnitude messages encoded at the operand scales, and the
+// key-switching noise is the additive hybrid-decomposition term. The
+// estimates are average-case and not bounds.
+type Simulator struct {
+	params      Parameters
+	sigma2Round float64
+	sigmaErr    float64
+}
+
+// NewSimulator instantiates a new [Simulator] for the given parameters.
+func NewSimulator(params ParameterProvider) Simulator {
+
+	p := *params.GetRLWEParameters()
+
+	return Simulator{
+		params: p,
+		// Rounding noise added by each rescaling: rounding the two ciphertext
+		// polynomials adds an error of variance (1 + h)/12 after decryption,
+		// with h the Hamming weight of the secret.
+		sigma2Round: (1 + float64(p.XsHammingWeight())) / 12.0,
+		sigmaErr:    p.NoiseFreshSK(),
+	}
+}
+
+// FreshCiphertext returns the symbolic metadata of a fresh public-key
+// encryption at the given level and scale.
+func (s Simulator) FreshCiphertext(level int, scale Scale) *SimulatedCiphertext {
+	return &SimulatedCiphertext{
+		Degree: 1,
+		Level:  level,
+		Scale:  scale,
+		Noise:  math.Log2(s.params.NoiseFreshPK()),
+	}
+}
+
+// Add simulates the homomorphic addition of op0 and op1. The operands must
+// have the same scale, as the real evaluation would otherwise not be correct;
+// scale matching must be simulated explicitly (e.g. with [Simulator.MulScalar]
+// or [Simulator.Rescale]).
+func (s Simulator) Add(op0, op1 *SimulatedCiphertext) (*SimulatedCiphertext, error) {
+
+	if !op0.Scale.Equal(op1.Scale) {
+		return nil, fmt.Errorf("cannot Add: scales do not match: %f != %f", op0.Scale.Float64(), op1.Scale.Float64())
+	}
+
+	return &SimulatedCiphertext{
+		Degree: utils.Max(op0.Degree, op1.Degree),
+		Level:  utils.Min(op0.Level, op1.Level),
+		Scale:  op0.Scale,
+		Noise:  log2SqrtSumExp2(op0.Noise, op1.Noise),
+	}, nil
+}
+
+// MulRelin simulates the homomorphic multiplication of op0 and op1 followed
+// by a relinearization: the output scale is the product of the operand scales
+// and the output noise accounts for the cross terms between each operand's
+// noise and the other operand's message, plus the key-switching noise of the
+// relinearization.
+func (s Simulator) MulRelin(op0, op1 *SimulatedCiphertext) (*SimulatedCiphertext, error) {
+
+	if op0.Degree != 1 || op1.Degree != 1 {
+		return nil, fmt.Errorf("cannot MulRelin: operands degree must be 1 but is %d and %d", op0.Degree, op1.Degree)
+	}
+
+	level := utils.Min(op0.Level, op1.Level)
+
+	// Ring expansion factor of the coefficient-wise product.
+	logSqrtN := 0.5 * math.Log2(float64(s.params.N()))
+
+	return &SimulatedCiphertext{
+		Degree: 1,
+		Level:  level,
+		Scale:  op0.Scale.Mul(op1.Scale),
+		Noise: log2SqrtSumExp2(
+			op0.Noise+op1.Scale.Log2()+logSqrtN,
+			op1.Noise+op0.Scale.Log2()+logSqrtN,
+			0.5*math.Log2(s.keySwitchSigma2(level)),
+		),
+	}, nil
+}
+
+// MulScalar simulates the multiplication of op by a scalar encoded at the
+// given scale, assuming a unit-magnitude scalar.
+func (s Simulator) MulScalar(op *SimulatedCiphertext, scale Scale) *SimulatedCiphertext {
+	return &SimulatedCiphertext{
+		Degree: op.Degree,
+		Level:  op.Level,
+		Scale:  op.Scale.Mul(scale),
+		Noise:  op.Noise + scale.Log2(),
+	}
+}
+
+// KeySwitch simulates a key-switched operation that leaves level and scale
+// unchanged, such as a rotation or a conjugation, adding the additive
+// key-switching noise term.
+func (s Simulator) KeySwitch(op *SimulatedCiphertext) *SimulatedCiphertext {
+	return &SimulatedCiphertext{
+		Degree: op.Degree,
+		Level:  op.Level,
+		Scale:  op.Scale,
+		Noise:  log2SqrtSumExp2(op.Noise, 0.5*math.Log2(s.keySwitchSigma2(op.Level))),
+	}
+}
+
+// Rescale simulates the division of op by its current top-level prime,
+// consuming one level, dividing the scale accordingly and adding the rounding
+// noise.
+func (s Simulator) Rescale(op *SimulatedCiphertext) (*SimulatedCiphertext, error) {
+
+	if op.Level == 0 {
+		return nil, fmt.Errorf("cannot Rescale: already at level 0")
+	}
+
+	qi := float64(s.params.Q()[op.Level])
+
+	return &SimulatedCiphertext{
+		Degree: op.Degree,
+		Level:  op.Level - 1,
+		Scale:  op.Scale.Div(NewScale(qi)),
+		Noise:  log2SqrtSumExp2(op.Noise-math.Log2(qi), 0.5*math.Log2(s.sigma2Round)),
+	}, nil
+}
+
+// DropLevel simulates dropping levels levels of op, which affects neither the
+// scale nor the noise.
+func (s Simulator) DropLevel(op *SimulatedCiphertext, levels int) (*SimulatedCiphertext, error) {
+
+	if levels < 0 || levels > op.Level {
+		return nil, fmt.Errorf("cannot DropLevel: levels must be in [0, %d] but is %d", op.Level, levels)
+	}
+
+	return &SimulatedCiphertext{
+		Degree: op.Degree,
+		Level:  op.Level - levels,
+		Scale:  op.Scale,
+		Noise:  op.Noise,
+	}, nil
+}
+
+// Precision returns the predicted slot-wise precision in bits of op, i.e.
+// -log2 of the expected slot-wise error relative to the scale, mapping the
+// coefficient-domain noise to the slots through the canonical embedding.
+func (s Simulator) Precision(op *SimulatedCiphertext) float64 {
+	return op.Scale.Log2() - (op.Noise + 0.5*math.Log2(float64(s.params.N())))
+}
+
+// keySwitchSigma2 returns the variance of the additive key-switching noise at
+// the given level: the gadget error of each of the d decomposition blocks is
+// scaled by a uniform factor of at most max(Qi), then divided by P during the
+// final ModDown.
+func (s Simulator) keySwitchSigma2(levelQ int) float64 {
+
+	p := s.params
+
+	d := float64(p.BaseRNSDecompositionVectorSize(levelQ, p.MaxLevelP()))
+
+	maxQi := 0.0
+	for _, qi := range p.Q()[:levelQ+1] {
+		maxQi = math.Max(maxQi, float64(qi))
+	}
+
+	ratio := 1.0
+	if p.PCount() > 0 {
+		pF, _ := new(big.Float).SetInt(p.PBigInt()).Float64()
+		ratio = maxQi / pF
+	}
+
+	return d * (float64(p.N()) / 12.0) * s.sigmaErr * s.sigmaErr * ratio * ratio
+}
+
+// log2SqrtSumExp2 returns log2(sqrt(sum(4^{x}))) over the inputs x, i.e. the
+// log2 standard deviation of a sum of independent terms given by their log2
+// standard deviations.
+func log2SqrtSumExp2(logs ...float64) (log2 float64) {
+	var sum float64
+	for _, x := range logs {
+		sum += math.Exp2(2 * x)
+	}
+	return 0.5 * math.Log2(sum)
+}
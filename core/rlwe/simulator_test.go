@@ -0,0 +1,76 @@
+package rlwe
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulator(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN:    12,
+		LogQ:    []int{55, 45, 45},
+		LogP:    []int{60},
+		NTTFlag: true,
+	})
+	require.NoError(t, err)
+
+	sim := NewSimulator(params)
+
+	scale := NewScale(math.Exp2(45))
+
+	t.Run("Depth2Circuit", func(t *testing.T) {
+
+		ct0 := sim.FreshCiphertext(params.MaxLevel(), scale)
+		ct1 := sim.FreshCiphertext(params.MaxLevel(), scale)
+
+		// (ct0 * ct1 -> rescale) * ct0 -> rescale
+		ct2, err := sim.MulRelin(ct0, ct1)
+		require.NoError(t, err)
+		require.Equal(t, params.MaxLevel(), ct2.Level)
+		require.Equal(t, scale.Mul(scale).Float64(), ct2.Scale.Float64())
+		require.Greater(t, ct2.Noise, ct0.Noise)
+
+		ct2, err = sim.Rescale(ct2)
+		require.NoError(t, err)
+		require.Equal(t, params.MaxLevel()-1, ct2.Level)
+
+		ct3, err := sim.MulRelin(ct2, ct0)
+		require.NoError(t, err)
+		ct3, err = sim.Rescale(ct3)
+		require.NoError(t, err)
+		require.Equal(t, params.MaxLevel()-2, ct3.Level)
+
+		// The precision must be positive and decrease with the depth
+		require.Greater(t, sim.Precision(ct0), sim.Precision(ct3))
+		require.Greater(t, sim.Precision(ct3), 0.0)
+	})
+
+	t.Run("KeySwitch", func(t *testing.T) {
+		ct := sim.FreshCiphertext(params.MaxLevel(), scale)
+		rot := sim.KeySwitch(ct)
+		require.Equal(t, ct.Level, rot.Level)
+		require.True(t, ct.Scale.Equal(rot.Scale))
+		require.Greater(t, rot.Noise, ct.Noise)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+
+		ct := sim.FreshCiphertext(0, scale)
+
+		// Rescale at level 0
+		_, err := sim.Rescale(ct)
+		require.Error(t, err)
+
+		// Mismatched scales
+		other := sim.FreshCiphertext(0, scale.Mul(scale))
+		_, err = sim.Add(ct, other)
+		require.Error(t, err)
+
+		// DropLevel below 0
+		_, err = sim.DropLevel(ct, 1)
+		require.Error(t, err)
+	})
+}
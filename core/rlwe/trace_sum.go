@@ -0,0 +1,85 @@
+package rlwe
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// TraceSum evaluates sums of n slots of ctIn (n must be a power of two dividing the number
+// of slots).
+//
+// If firstSlotOnly is false, each slot of the output holds the sum of the n consecutive
+// slots of ctIn starting at that slot (cyclically); in particular, for n equal to the number
+// of slots, every slot of the output holds the sum of all the slots. The evaluation uses the
+// minimal automorphism chain X -> X^{5^{2^i}} for 0 <= i < log2(n), i.e. log2(n)
+// automorphisms and as many Galois keys.
+//
+// If firstSlotOnly is true, the evaluation is delegated to [Evaluator.InnerSum]: only the
+// first slot of each group holds the sum (the remaining slots hold garbage), which halves
+// the average number of automorphisms thanks to hoisting and requires no additional keys.
+//
+// The required Galois keys are listed by [GaloisElementsForTraceSum] and must be loaded in
+// the evaluator.
+func (eval Evaluator) TraceSum(ctIn *Ciphertext, n int, firstSlotOnly bool, opOut *Ciphertext) (err error) {
+
+	if n < 1 || n&(n-1) != 0 {
+		return fmt.Errorf("cannot TraceSum: n must be a strictly positive power of two but is %d", n)
+	}
+
+	if firstSlotOnly {
+		return eval.InnerSum(ctIn, 1, n, opOut)
+	}
+
+	params := eval.GetRLWEParameters()
+
+	levelQ := utils.Min(ctIn.Level(), opOut.Level())
+
+	opOut.Resize(opOut.Degree(), levelQ)
+
+	if opOut != ctIn {
+		*opOut.MetaData = *ctIn.MetaData
+		opOut.Value[0].CopyLvl(levelQ, ctIn.Value[0])
+		opOut.Value[1].CopyLvl(levelQ, ctIn.Value[1])
+	}
+
+	if n == 1 {
+		return
+	}
+
+	ringQ := params.RingQ().AtLevel(levelQ)
+
+	ctTmp := NewCiphertext(params, 1, levelQ)
+
+	// Doubling trick: after the i-th step each slot holds the sum of 2^{i+1} consecutive slots
+	for k := 1; k < n; k <<= 1 {
+
+		if err = eval.Automorphism(opOut, params.GaloisElement(k), ctTmp); err != nil {
+			return fmt.Errorf("cannot TraceSum: %w", err)
+		}
+
+		ringQ.Add(opOut.Value[0], ctTmp.Value[0], opOut.Value[0])
+		ringQ.Add(opOut.Value[1], ctTmp.Value[1], opOut.Value[1])
+	}
+
+	return
+}
+
+// GaloisElementsForTraceSum returns the list of Galois elements necessary to apply the method
+// [Evaluator.TraceSum] with parameters n and firstSlotOnly.
+func GaloisElementsForTraceSum(params ParameterProvider, n int, firstSlotOnly bool) (galEls []uint64) {
+
+	if firstSlotOnly {
+		return GaloisElementsForInnerSum(params, 1, n)
+	}
+
+	p := params.GetRLWEParameters()
+
+	galEls = make([]uint64, 0, bits.Len64(uint64(n))-1)
+	for k := 1; k < n; k <<= 1 {
+		galEls = append(galEls, p.GaloisElement(k))
+	}
+
+	return
+}
@@ -237,7 +237,9 @@ func cksphase(params bgv.Parameters, P []*party, result *rlwe.Ciphertext) *rlwe.
 				panic(err)
 			}
 		}
-		cks.KeySwitch(result, cksCombined, encOut)
+		if err := cks.KeySwitch(result, cksCombined, encOut); err != nil {
+			panic(err)
+		}
 	})
 	l.Printf("\tdone (cloud: %s, party: %s)\n", elapsedCKSCloud, elapsedPCKSParty)
 
@@ -336,7 +336,9 @@ func pcksPhase(params bgv.Parameters, tpk *rlwe.PublicKey, encRes *rlwe.Cipherte
 			}
 		}
 
-		pcks.KeySwitch(encRes, pcksCombined, encOut)
+		if err = pcks.KeySwitch(encRes, pcksCombined, encOut); err != nil {
+			panic(err)
+		}
 	})
 	l.Printf("\tdone (cloud: %s, party: %s)\n", elapsedPCKSCloud, elapsedPCKSParty)
 
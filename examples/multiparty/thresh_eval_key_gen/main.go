@@ -249,7 +249,10 @@ func main() {
 	// if t < N, use the t-out-of-N scheme and performs the share-resharing procedure.
 	if t != N {
 		for _, pi := range P {
-			pi.Combiner = multiparty.NewCombiner(params, pi.shamirPk, shamirPks, t)
+			var err error
+			if pi.Combiner, err = multiparty.NewCombiner(params, pi.shamirPk, shamirPks, t); err != nil {
+				panic(err)
+			}
 		}
 
 		fmt.Println("Performing threshold setup")
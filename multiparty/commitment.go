@@ -0,0 +1,104 @@
+package multiparty
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// ShareCommitmentSize is the size in bytes of a [ShareCommitment] and of a
+// [ShareOpening].
+const ShareCommitmentSize = 32
+
+// ShareCommitment is a hiding and binding commitment to a protocol share,
+// computed as the blake2b hash of the share prefixed with a random opening.
+//
+// Exchanging commitments before the shares themselves upgrades any
+// aggregateable protocol round to a commit-then-reveal round: a rushing
+// adversary that waits for the honest parties' shares can no longer adapt its
+// own share to them, since its share is already fixed by its commitment. The
+// cost is a single additional exchange of [ShareCommitmentSize]-byte strings
+// per round.
+type ShareCommitment [ShareCommitmentSize]byte
+
+// ShareOpening is the random string that opens a [ShareCommitment]. It must be
+// kept secret until all commitments of the round have been received, and is
+// then revealed alongside the share.
+type ShareOpening [ShareCommitmentSize]byte
+
+// CommitToShare samples a fresh random opening and returns the commitment to
+// the given share under that opening. The share must not be modified between
+// the commit and the reveal, or the verification will fail.
+func CommitToShare(share io.WriterTo) (com ShareCommitment, opening ShareOpening, err error) {
+
+	if _, err = rand.Read(opening[:]); err != nil {
+		return ShareCommitment{}, ShareOpening{}, fmt.Errorf("cannot CommitToShare: %w", err)
+	}
+
+	if com, err = commitment(share, opening); err != nil {
+		return ShareCommitment{}, ShareOpening{}, fmt.Errorf("cannot CommitToShare: %w", err)
+	}
+
+	return
+}
+
+// Verify checks that the given share and opening match the commitment, in
+// constant time in the commitment values. It returns an error if they do not,
+// in which case the share must be rejected and the round aborted.
+func (com ShareCommitment) Verify(share io.WriterTo, opening ShareOpening) error {
+
+	want, err := commitment(share, opening)
+	if err != nil {
+		return fmt.Errorf("cannot Verify: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare(com[:], want[:]) != 1 {
+		return fmt.Errorf("invalid share: share and opening do not match the commitment")
+	}
+
+	return nil
+}
+
+// VerifyCommittedShares checks each revealed share against the commitment
+// received for it in the commit phase of the round. The three slices are
+// indexed by party and must have the same length. It returns an error
+// identifying the first party whose share does not match its commitment.
+func VerifyCommittedShares[T io.WriterTo](shares []T, openings []ShareOpening, coms []ShareCommitment) error {
+
+	if len(openings) != len(shares) || len(coms) != len(shares) {
+		return fmt.Errorf("invalid inputs: have %d shares, %d openings and %d commitments", len(shares), len(openings), len(coms))
+	}
+
+	for i := range shares {
+		if err := coms[i].Verify(shares[i], openings[i]); err != nil {
+			return fmt.Errorf("party %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+func commitment(share io.WriterTo, opening ShareOpening) (com ShareCommitment, err error) {
+
+	h, err := blake2b.New256(nil)
+
+	// Sanity check, this error should not happen (the key is nil).
+	if err != nil {
+		panic(err)
+	}
+
+	if _, err = h.Write(opening[:]); err != nil {
+		return ShareCommitment{}, err
+	}
+
+	if _, err = share.WriteTo(h); err != nil {
+		return ShareCommitment{}, err
+	}
+
+	copy(com[:], h.Sum(nil))
+
+	return
+}
@@ -1,6 +1,10 @@
 package multiparty
 
 import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
 	"github.com/tuneinsight/lattigo/v6/utils/sampling"
 )
 
@@ -10,3 +14,77 @@ import (
 type CRS interface {
 	sampling.PRNG
 }
+
+// CRSManager derives all per-protocol, per-round common reference strings of a
+// multiparty ceremony from a single shared seed, using domain-separation
+// labels, and records the derivation transcript. All parties instantiating a
+// CRSManager from the same seed and requesting the same label paths obtain
+// identical streams, replacing the ad-hoc passing of one sampler per protocol.
+type CRSManager struct {
+	seed       []byte
+	transcript []string
+}
+
+// NewCRSManager instantiates a new [CRSManager] from a shared public seed.
+func NewCRSManager(seed []byte) *CRSManager {
+	return &CRSManager{seed: append([]byte(nil), seed...)}
+}
+
+// Get derives the [CRS] identified by the given domain-separation labels
+// (e.g. protocol name, round number) and appends the label path to the
+// transcript. The derivation forks a seed-keyed [sampling.KeyedPRNG] once per
+// label (see [sampling.KeyedPRNG.Fork]) and is deterministic: requesting the
+// same path twice, or on another party's manager seeded identically, returns
+// the same stream, while any two distinct paths return computationally
+// independent streams.
+// The returned [CRS] is accepted by the SampleCRP method of every protocol.
+func (m *CRSManager) Get(labels ...string) (CRS, error) {
+
+	if len(labels) == 0 {
+		return nil, fmt.Errorf("cannot Get: at least one domain-separation label is required")
+	}
+
+	prng, err := sampling.NewKeyedPRNG(m.seed)
+	if err != nil {
+		return nil, fmt.Errorf("cannot Get: %w", err)
+	}
+
+	for _, label := range labels {
+		if prng, err = prng.Fork(label); err != nil {
+			return nil, fmt.Errorf("cannot Get: %w", err)
+		}
+	}
+
+	m.transcript = append(m.transcript, strings.Join(labels, "/"))
+
+	return prng, nil
+}
+
+// Transcript returns a copy of the ordered list of label paths derived so far,
+// enabling parties to cross-check that they agreed on the same derivations.
+func (m *CRSManager) Transcript() []string {
+	return append([]string(nil), m.transcript...)
+}
+
+// crsManagerState is the serialization layout of a [CRSManager].
+type crsManagerState struct {
+	Seed       []byte
+	Transcript []string
+}
+
+// MarshalBinary serializes the seed and the transcript of the manager.
+func (m CRSManager) MarshalBinary() ([]byte, error) {
+	return json.Marshal(crsManagerState{Seed: m.seed, Transcript: m.transcript})
+}
+
+// UnmarshalBinary deserializes a [CRSManager] serialized with
+// [CRSManager.MarshalBinary], restoring both the seed and the transcript.
+func (m *CRSManager) UnmarshalBinary(p []byte) (err error) {
+	var state crsManagerState
+	if err = json.Unmarshal(p, &state); err != nil {
+		return fmt.Errorf("cannot UnmarshalBinary: %w", err)
+	}
+	m.seed = state.Seed
+	m.transcript = state.Transcript
+	return
+}
@@ -0,0 +1,86 @@
+package multiparty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+func TestCRSManager(t *testing.T) {
+
+	params, err := rlwe.NewParametersFromLiteral(testInsecure[0].ParametersLiteral)
+	require.NoError(t, err)
+
+	seed := []byte{'l', 'a', 't', 't', 'i', 'g', 'o'}
+
+	readBytes := func(crs CRS) []byte {
+		p := make([]byte, 64)
+		_, err := crs.Read(p)
+		require.NoError(t, err)
+		return p
+	}
+
+	t.Run("Derivation", func(t *testing.T) {
+
+		m0 := NewCRSManager(seed)
+		m1 := NewCRSManager(seed)
+
+		// Identical paths on identically seeded managers give identical streams
+		crs0, err := m0.Get("PublicKeyGen", "round-1")
+		require.NoError(t, err)
+		crs1, err := m1.Get("PublicKeyGen", "round-1")
+		require.NoError(t, err)
+		require.Equal(t, readBytes(crs0), readBytes(crs1))
+
+		// Distinct paths give distinct streams
+		crs2, err := m0.Get("PublicKeyGen", "round-2")
+		require.NoError(t, err)
+		require.NotEqual(t, readBytes(crs0), readBytes(crs2))
+
+		// At least one label is required
+		_, err = m0.Get()
+		require.Error(t, err)
+	})
+
+	t.Run("SampleCRP", func(t *testing.T) {
+
+		ckg := NewPublicKeyGenProtocol(params)
+
+		crs0, err := NewCRSManager(seed).Get("PublicKeyGen")
+		require.NoError(t, err)
+		crs1, err := NewCRSManager(seed).Get("PublicKeyGen")
+		require.NoError(t, err)
+
+		crp0 := ckg.SampleCRP(crs0)
+		crp1 := ckg.SampleCRP(crs1)
+		require.True(t, crp0.Value.Equal(&crp1.Value))
+	})
+
+	t.Run("Transcript", func(t *testing.T) {
+
+		m := NewCRSManager(seed)
+
+		_, err := m.Get("RelinearizationKeyGen", "round-1")
+		require.NoError(t, err)
+		_, err = m.Get("RelinearizationKeyGen", "round-2")
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"RelinearizationKeyGen/round-1", "RelinearizationKeyGen/round-2"}, m.Transcript())
+
+		data, err := m.MarshalBinary()
+		require.NoError(t, err)
+
+		mNew := new(CRSManager)
+		require.NoError(t, mNew.UnmarshalBinary(data))
+		require.Equal(t, m.Transcript(), mNew.Transcript())
+
+		// The deserialized manager derives the same streams
+		crs0, err := m.Get("KeySwitch")
+		require.NoError(t, err)
+		crs1, err := mNew.Get("KeySwitch")
+		require.NoError(t, err)
+		require.Equal(t, readBytes(crs0), readBytes(crs1))
+	})
+}
@@ -0,0 +1,126 @@
+package multiparty
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+// ProtocolVersion is the version of the multiparty protocol suite implemented
+// by this library. Parties with different protocol versions must not exchange
+// shares.
+const ProtocolVersion = 1
+
+// Capabilities describes what a party supports, to be exchanged during a
+// handshake and validated with [Capabilities.Validate] before any share is
+// exchanged, so that incompatibilities surface as actionable errors instead of
+// mid-ceremony failures.
+type Capabilities struct {
+	// ProtocolVersion is the multiparty protocol version of the party, see
+	// [ProtocolVersion].
+	ProtocolVersion int
+
+	// Schemes lists the schemes supported by the party (e.g. "bgv", "ckks").
+	Schemes []string
+
+	// ParametersDigest is a digest of the party's cryptographic parameters,
+	// see [ParametersDigest].
+	ParametersDigest string
+
+	// Parties is the number of parties of the ceremony.
+	Parties int
+
+	// Threshold is the threshold t of the t-out-of-N access structure, or 0
+	// for the N-out-of-N scheme.
+	Threshold int
+}
+
+// ParametersDigest returns a hex-encoded digest of the parameters, binding the
+// full parameter set (ring degree, moduli, distributions, ...) to a short
+// string comparable during a handshake.
+func ParametersDigest(params rlwe.ParameterProvider) (string, error) {
+	data, err := params.GetRLWEParameters().MarshalBinary()
+	if err != nil {
+		return "", fmt.Errorf("cannot ParametersDigest: %w", err)
+	}
+	digest := blake2b.Sum256(data)
+	return hex.EncodeToString(digest[:]), nil
+}
+
+// NewCapabilities returns the [Capabilities] of a party supporting the given
+// schemes under the given parameters, for a ceremony of parties parties with
+// the given threshold (0 for the N-out-of-N scheme).
+func NewCapabilities(params rlwe.ParameterProvider, schemes []string, parties, threshold int) (Capabilities, error) {
+
+	digest, err := ParametersDigest(params)
+	if err != nil {
+		return Capabilities{}, fmt.Errorf("cannot NewCapabilities: %w", err)
+	}
+
+	return Capabilities{
+		ProtocolVersion:  ProtocolVersion,
+		Schemes:          schemes,
+		ParametersDigest: digest,
+		Parties:          parties,
+		Threshold:        threshold,
+	}, nil
+}
+
+// CommonSchemes returns the schemes supported by both parties, in the order of
+// the receiver.
+func (c Capabilities) CommonSchemes(other Capabilities) (common []string) {
+	supported := map[string]bool{}
+	for _, s := range other.Schemes {
+		supported[s] = true
+	}
+	for _, s := range c.Schemes {
+		if supported[s] {
+			common = append(common, s)
+		}
+	}
+	return
+}
+
+// Validate checks that the two parties can run a ceremony together, returning
+// an error identifying the first incompatibility: protocol version, parameter
+// digest, number of parties, threshold setting or supported schemes.
+func (c Capabilities) Validate(other Capabilities) error {
+
+	if c.ProtocolVersion != other.ProtocolVersion {
+		return fmt.Errorf("cannot Validate: protocol version mismatch: ours is %d, peer's is %d", c.ProtocolVersion, other.ProtocolVersion)
+	}
+
+	if c.ParametersDigest != other.ParametersDigest {
+		return fmt.Errorf("cannot Validate: parameters digest mismatch: ours is %s, peer's is %s: both parties must instantiate the same parameter literal", c.ParametersDigest, other.ParametersDigest)
+	}
+
+	if c.Parties != other.Parties {
+		return fmt.Errorf("cannot Validate: number of parties mismatch: ours is %d, peer's is %d", c.Parties, other.Parties)
+	}
+
+	if c.Threshold != other.Threshold {
+		return fmt.Errorf("cannot Validate: threshold mismatch: ours is %d, peer's is %d (0 meaning the N-out-of-N scheme)", c.Threshold, other.Threshold)
+	}
+
+	if len(c.CommonSchemes(other)) == 0 {
+		return fmt.Errorf("cannot Validate: no common scheme: ours are %v, peer's are %v", c.Schemes, other.Schemes)
+	}
+
+	return nil
+}
+
+// MarshalBinary encodes the object into a binary form on a newly allocated
+// slice of bytes.
+func (c Capabilities) MarshalBinary() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// UnmarshalBinary decodes a slice of bytes generated by
+// [Capabilities.MarshalBinary] on the object.
+func (c *Capabilities) UnmarshalBinary(p []byte) error {
+	return json.Unmarshal(p, c)
+}
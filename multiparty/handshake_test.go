@@ -0,0 +1,68 @@
+package multiparty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+func TestHandshake(t *testing.T) {
+
+	params, err := rlwe.NewParametersFromLiteral(rlwe.ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{50, 40},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	own, err := NewCapabilities(params, []string{"bgv", "ckks"}, 3, 2)
+	require.NoError(t, err)
+
+	t.Run("Compatible", func(t *testing.T) {
+		peer, err := NewCapabilities(params, []string{"ckks"}, 3, 2)
+		require.NoError(t, err)
+		require.NoError(t, own.Validate(peer))
+		require.Equal(t, []string{"ckks"}, own.CommonSchemes(peer))
+	})
+
+	t.Run("ParametersMismatch", func(t *testing.T) {
+		other, err := rlwe.NewParametersFromLiteral(rlwe.ParametersLiteral{
+			LogN: 11,
+			LogQ: []int{50, 40},
+			LogP: []int{60},
+		})
+		require.NoError(t, err)
+		peer, err := NewCapabilities(other, []string{"bgv"}, 3, 2)
+		require.NoError(t, err)
+		require.ErrorContains(t, own.Validate(peer), "parameters digest mismatch")
+	})
+
+	t.Run("ThresholdMismatch", func(t *testing.T) {
+		peer, err := NewCapabilities(params, []string{"bgv"}, 3, 0)
+		require.NoError(t, err)
+		require.ErrorContains(t, own.Validate(peer), "threshold mismatch")
+	})
+
+	t.Run("NoCommonScheme", func(t *testing.T) {
+		peer, err := NewCapabilities(params, []string{"bfv"}, 3, 2)
+		require.NoError(t, err)
+		require.ErrorContains(t, own.Validate(peer), "no common scheme")
+	})
+
+	t.Run("VersionMismatch", func(t *testing.T) {
+		peer := own
+		peer.ProtocolVersion++
+		require.ErrorContains(t, own.Validate(peer), "protocol version mismatch")
+	})
+
+	t.Run("Serialization", func(t *testing.T) {
+		data, err := own.MarshalBinary()
+		require.NoError(t, err)
+		var peer Capabilities
+		require.NoError(t, peer.UnmarshalBinary(data))
+		require.Equal(t, own, peer)
+		require.NoError(t, own.Validate(peer))
+	})
+}
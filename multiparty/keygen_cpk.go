@@ -1,6 +1,7 @@
 package multiparty
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/tuneinsight/lattigo/v6/core/rlwe"
@@ -87,6 +88,38 @@ func (ckg PublicKeyGenProtocol) AggregateShares(share1, share2 PublicKeyGenShare
 	ckg.params.RingQP().Add(share1.Value, share2.Value, shareOut.Value)
 }
 
+// GenShareCommitted generates the party's share as [PublicKeyGenProtocol.GenShare]
+// and commits to it, for the commit-then-reveal variant of the protocol: the
+// commitment is broadcast first, and the share is revealed alongside the opening
+// only once the commitments of all the parties have been received.
+func (ckg PublicKeyGenProtocol) GenShareCommitted(sk *rlwe.SecretKey, crp PublicKeyGenCRP, shareOut *PublicKeyGenShare) (ShareCommitment, ShareOpening, error) {
+	ckg.GenShare(sk, crp, shareOut)
+	return CommitToShare(*shareOut)
+}
+
+// AggregateCommittedShares verifies each revealed share against the commitment
+// received for it in the commit phase and aggregates the shares into shareOut,
+// which can be one of the shares. It returns an error identifying the first
+// party whose share does not match its commitment, in which case shareOut is
+// left untouched and the round must be aborted.
+func (ckg PublicKeyGenProtocol) AggregateCommittedShares(shares []PublicKeyGenShare, openings []ShareOpening, coms []ShareCommitment, shareOut *PublicKeyGenShare) error {
+
+	if len(shares) == 0 {
+		return fmt.Errorf("cannot AggregateCommittedShares: no shares")
+	}
+
+	if err := VerifyCommittedShares(shares, openings, coms); err != nil {
+		return fmt.Errorf("cannot AggregateCommittedShares: %w", err)
+	}
+
+	shareOut.Value.Copy(shares[0].Value)
+	for _, share := range shares[1:] {
+		ckg.AggregateShares(*shareOut, share, shareOut)
+	}
+
+	return nil
+}
+
 // GenPublicKey return the current aggregation of the received shares as a [rlwe.PublicKey].
 func (ckg PublicKeyGenProtocol) GenPublicKey(roundShare PublicKeyGenShare, crp PublicKeyGenCRP, pubkey *rlwe.PublicKey) {
 	pubkey.Value[0].Copy(roundShare.Value)
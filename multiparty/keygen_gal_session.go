@@ -0,0 +1,179 @@
+package multiparty
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// GaloisKeyGenSession tracks a resumable execution of the [GaloisKeyGenProtocol]
+// over a set of Galois elements. Shares can be aggregated per Galois element in
+// any order and across rounds: each element is finalized into its collective
+// [rlwe.GaloisKey] as soon as all parties have contributed, while the other
+// elements remain pending, and [GaloisKeyGenSession.Info] reports the completion
+// state of the session. The session is not safe for concurrent use.
+type GaloisKeyGenSession struct {
+	GaloisKeyGenProtocol
+
+	// Parties is the number of shares to aggregate per Galois element.
+	Parties int
+
+	evkParams []rlwe.EvaluationKeyParameters
+	states    map[uint64]*galoisKeyGenSessionState
+}
+
+type galoisKeyGenSessionState struct {
+	crp           GaloisKeyGenCRP
+	aggregate     GaloisKeyGenShare
+	contributions int
+	galoisKey     *rlwe.GaloisKey
+}
+
+// NewGaloisKeyGenSession instantiates a new [GaloisKeyGenSession] over the given
+// Galois elements, sampling one common reference polynomial per element from the
+// provided common reference string, in the order of galEls.
+func NewGaloisKeyGenSession(params rlwe.ParameterProvider, crs CRS, galEls []uint64, parties int, evkParams ...rlwe.EvaluationKeyParameters) (*GaloisKeyGenSession, error) {
+
+	if parties < 1 {
+		return nil, fmt.Errorf("cannot NewGaloisKeyGenSession: parties must be at least one but is %d", parties)
+	}
+
+	if len(galEls) == 0 {
+		return nil, fmt.Errorf("cannot NewGaloisKeyGenSession: no Galois element provided")
+	}
+
+	s := &GaloisKeyGenSession{
+		GaloisKeyGenProtocol: NewGaloisKeyGenProtocol(params),
+		Parties:              parties,
+		evkParams:            evkParams,
+		states:               make(map[uint64]*galoisKeyGenSessionState, len(galEls)),
+	}
+
+	for _, galEl := range galEls {
+
+		if _, ok := s.states[galEl]; ok {
+			return nil, fmt.Errorf("cannot NewGaloisKeyGenSession: duplicate Galois element %d", galEl)
+		}
+
+		s.states[galEl] = &galoisKeyGenSessionState{
+			crp:       s.SampleCRP(crs, evkParams...),
+			aggregate: s.AllocateShare(evkParams...),
+		}
+	}
+
+	return s, nil
+}
+
+// CRP returns the common reference polynomial of the given Galois element, to be
+// passed to [GaloisKeyGenProtocol.GenShare] by the parties.
+func (s *GaloisKeyGenSession) CRP(galEl uint64) (GaloisKeyGenCRP, error) {
+
+	state, ok := s.states[galEl]
+	if !ok {
+		return GaloisKeyGenCRP{}, fmt.Errorf("cannot CRP: Galois element %d is not part of the session", galEl)
+	}
+
+	return state.crp, nil
+}
+
+// AggregateShare aggregates one party's share on the session. Each party must
+// contribute exactly once per Galois element. When the last of the Parties
+// shares of an element is aggregated, the element is finalized into its
+// collective Galois key, after which further shares for it are rejected.
+func (s *GaloisKeyGenSession) AggregateShare(share GaloisKeyGenShare) (err error) {
+
+	state, ok := s.states[share.GaloisElement]
+	if !ok {
+		return fmt.Errorf("cannot AggregateShare: Galois element %d is not part of the session", share.GaloisElement)
+	}
+
+	if state.galoisKey != nil {
+		return fmt.Errorf("cannot AggregateShare: Galois element %d is already finalized", share.GaloisElement)
+	}
+
+	if state.contributions == 0 {
+		state.aggregate.GaloisElement = share.GaloisElement
+	}
+
+	if err = s.AggregateShares(state.aggregate, share, &state.aggregate); err != nil {
+		return fmt.Errorf("cannot AggregateShare: %w", err)
+	}
+
+	state.contributions++
+
+	if state.contributions == s.Parties {
+
+		gk := rlwe.NewGaloisKey(s.params, s.evkParams...)
+
+		if err = s.GenGaloisKey(state.aggregate, state.crp, gk); err != nil {
+			return fmt.Errorf("cannot AggregateShare: %w", err)
+		}
+
+		state.galoisKey = gk
+	}
+
+	return
+}
+
+// GaloisKey returns the finalized collective Galois key of the given element.
+// The method returns an error if the element is not part of the session or if
+// shares are still pending for it.
+func (s *GaloisKeyGenSession) GaloisKey(galEl uint64) (*rlwe.GaloisKey, error) {
+
+	state, ok := s.states[galEl]
+	if !ok {
+		return nil, fmt.Errorf("cannot GaloisKey: Galois element %d is not part of the session", galEl)
+	}
+
+	if state.galoisKey == nil {
+		return nil, fmt.Errorf("cannot GaloisKey: Galois element %d is still pending %d share(s)", galEl, s.Parties-state.contributions)
+	}
+
+	return state.galoisKey, nil
+}
+
+// GaloisKeys returns the finalized collective Galois keys of the session, sorted
+// by Galois element, without waiting for the pending elements.
+func (s *GaloisKeyGenSession) GaloisKeys() (gks []*rlwe.GaloisKey) {
+	for _, galEl := range utils.GetSortedKeys(s.states) {
+		if gk := s.states[galEl].galoisKey; gk != nil {
+			gks = append(gks, gk)
+		}
+	}
+	return
+}
+
+// GaloisKeyGenSessionInfo is a manifest reporting the completion state of a
+// [GaloisKeyGenSession].
+type GaloisKeyGenSessionInfo struct {
+	// Completed is the sorted list of the finalized Galois elements.
+	Completed []uint64
+	// Pending is the sorted list of the Galois elements still awaiting shares.
+	Pending []uint64
+	// Contributions maps each pending Galois element to the number of shares
+	// aggregated so far.
+	Contributions map[uint64]int
+}
+
+// Complete returns true if all the Galois elements of the session are finalized.
+func (info GaloisKeyGenSessionInfo) Complete() bool {
+	return len(info.Pending) == 0
+}
+
+// Info returns the [GaloisKeyGenSessionInfo] of the session.
+func (s *GaloisKeyGenSession) Info() (info GaloisKeyGenSessionInfo) {
+
+	info.Contributions = map[uint64]int{}
+
+	for _, galEl := range utils.GetSortedKeys(s.states) {
+		if state := s.states[galEl]; state.galoisKey != nil {
+			info.Completed = append(info.Completed, galEl)
+		} else {
+			info.Pending = append(info.Pending, galEl)
+			info.Contributions[galEl] = state.contributions
+		}
+	}
+
+	return
+}
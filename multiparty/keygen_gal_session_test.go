@@ -0,0 +1,89 @@
+package multiparty
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+func TestGaloisKeyGenSession(t *testing.T) {
+
+	params, err := rlwe.NewParametersFromLiteral(testInsecure[0].ParametersLiteral)
+	require.NoError(t, err)
+
+	tc := newTestContext(params)
+
+	galEls := []uint64{params.GaloisElement(2), params.GaloisElement(4), params.GaloisElement(8)}
+
+	session, err := NewGaloisKeyGenSession(params, tc.crs, galEls, nbParties)
+	require.NoError(t, err)
+
+	gkg := make([]GaloisKeyGenProtocol, nbParties)
+	for i := range gkg {
+		if i == 0 {
+			gkg[i] = NewGaloisKeyGenProtocol(params)
+		} else {
+			gkg[i] = gkg[0].ShallowCopy()
+		}
+	}
+
+	genShare := func(t *testing.T, party int, galEl uint64) GaloisKeyGenShare {
+		crp, err := session.CRP(galEl)
+		require.NoError(t, err)
+		share := gkg[party].AllocateShare()
+		require.NoError(t, gkg[party].GenShare(tc.skShares[party], galEl, crp, &share))
+		return share
+	}
+
+	// First round: all the shares of two elements, a single share of the third.
+	for i := 0; i < nbParties; i++ {
+		require.NoError(t, session.AggregateShare(genShare(t, i, galEls[0])))
+		require.NoError(t, session.AggregateShare(genShare(t, i, galEls[2])))
+	}
+	require.NoError(t, session.AggregateShare(genShare(t, 0, galEls[1])))
+
+	info := session.Info()
+	require.False(t, info.Complete())
+	require.Equal(t, []uint64{galEls[0], galEls[2]}, info.Completed)
+	require.Equal(t, []uint64{galEls[1]}, info.Pending)
+	require.Equal(t, 1, info.Contributions[galEls[1]])
+
+	// The finalized elements are available while the third is still pending.
+	require.Equal(t, 2, len(session.GaloisKeys()))
+
+	_, err = session.GaloisKey(galEls[1])
+	require.Error(t, err)
+
+	gk, err := session.GaloisKey(galEls[0])
+	require.NoError(t, err)
+
+	BaseRNSDecompositionVectorSize := params.BaseRNSDecompositionVectorSize(params.MaxLevelQ(), params.MaxLevelP())
+	noiseBound := math.Log2(math.Sqrt(float64(BaseRNSDecompositionVectorSize))*NoiseGaloisKey(params, nbParties)) + 1
+	require.GreaterOrEqual(t, noiseBound, rlwe.NoiseGaloisKey(gk, tc.skIdeal, params))
+
+	// Second round: the remaining shares finalize the pending element.
+	for i := 1; i < nbParties; i++ {
+		require.NoError(t, session.AggregateShare(genShare(t, i, galEls[1])))
+	}
+
+	info = session.Info()
+	require.True(t, info.Complete())
+	require.Equal(t, 3, len(session.GaloisKeys()))
+
+	gk, err = session.GaloisKey(galEls[1])
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, noiseBound, rlwe.NoiseGaloisKey(gk, tc.skIdeal, params))
+
+	// Shares for a finalized or unknown element are rejected.
+	require.Error(t, session.AggregateShare(genShare(t, 0, galEls[0])))
+	require.Error(t, session.AggregateShare(GaloisKeyGenShare{GaloisElement: params.GaloisElement(16)}))
+
+	_, err = NewGaloisKeyGenSession(params, tc.crs, []uint64{galEls[0], galEls[0]}, nbParties)
+	require.Error(t, err)
+
+	_, err = NewGaloisKeyGenSession(params, tc.crs, galEls, 0)
+	require.Error(t, err)
+}
@@ -68,12 +68,16 @@ func (pcks PublicKeySwitchProtocol) AllocateShare(levelQ int) (s PublicKeySwitch
 
 // GenShare computes a party's share in the PublicKeySwitch protocol from secret-key sk to public-key pk.
 // ct is the rlwe.Ciphertext to keyswitch. Note that ct.Value[0] is not used by the function and can be nil/zero.
+// The share is generated at the level of ct: a share allocated at a higher level is resized down, so that
+// only the limbs required by the key-switch are transmitted.
 //
 // Expected noise: ctNoise + encFreshPk + smudging
 func (pcks PublicKeySwitchProtocol) GenShare(sk *rlwe.SecretKey, pk *rlwe.PublicKey, ct *rlwe.Ciphertext, shareOut *PublicKeySwitchShare) {
 
 	levelQ := utils.Min(shareOut.Level(), ct.Value[1].Level())
 
+	shareOut.Resize(shareOut.Degree(), levelQ)
+
 	ringQ := pcks.params.RingQ().AtLevel(levelQ)
 
 	// Encrypt zero
@@ -116,16 +120,24 @@ func (pcks PublicKeySwitchProtocol) AggregateShares(share1, share2 PublicKeySwit
 	if levelQ1 != levelQ2 {
 		return fmt.Errorf("cannot AggregateShares: the two shares are at different levelQ")
 	}
+	if share2.Level() != levelQ1 || shareOut.Level() != levelQ1 {
+		return fmt.Errorf("cannot AggregateShares: shares levels do not match")
+	}
 	pcks.params.RingQ().AtLevel(levelQ1).Add(share1.Value[0], share2.Value[0], shareOut.Value[0])
 	pcks.params.RingQ().AtLevel(levelQ1).Add(share1.Value[1], share2.Value[1], shareOut.Value[1])
 	return
 }
 
-// KeySwitch performs the actual keyswitching operation on a ciphertext ct and put the result in opOut
-func (pcks PublicKeySwitchProtocol) KeySwitch(ctIn *rlwe.Ciphertext, combined PublicKeySwitchShare, opOut *rlwe.Ciphertext) {
+// KeySwitch performs the actual keyswitching operation on a ciphertext ct and put the result in opOut.
+// The method returns an error if the combined share is at a level smaller than the level of ctIn.
+func (pcks PublicKeySwitchProtocol) KeySwitch(ctIn *rlwe.Ciphertext, combined PublicKeySwitchShare, opOut *rlwe.Ciphertext) (err error) {
 
 	level := ctIn.Level()
 
+	if combined.Level() < level {
+		return fmt.Errorf("cannot KeySwitch: the combined share level (%d) is smaller than the ciphertext level (%d)", combined.Level(), level)
+	}
+
 	if ctIn != opOut {
 		opOut.Resize(ctIn.Degree(), level)
 		*opOut.MetaData = *ctIn.MetaData
@@ -134,6 +146,8 @@ func (pcks PublicKeySwitchProtocol) KeySwitch(ctIn *rlwe.Ciphertext, combined Pu
 	pcks.params.RingQ().AtLevel(level).Add(ctIn.Value[0], combined.Value[0], opOut.Value[0])
 
 	opOut.Value[1].CopyLvl(level, combined.Value[1])
+
+	return
 }
 
 // ShallowCopy creates a shallow copy of [PublicKeySwitchProtocol] in which all the read-only data-structures are
@@ -113,6 +113,8 @@ func (cks KeySwitchProtocol) SampleCRP(level int, crs CRS) KeySwitchCRP {
 
 // GenShare computes a party's share in the KeySwitchcol from secret-key skInput to secret-key skOutput.
 // ct is the [rlwe.Ciphertext] to keyswitch. Note that ct.Value[0] is not used by the function and can be nil/zero.
+// The share is generated at the level of ct: a share allocated at a higher level is resized down, so that
+// only the limbs required by the key-switch are transmitted.
 //
 // Expected noise: ctNoise + encFreshSk + smudging
 func (cks KeySwitchProtocol) GenShare(skInput, skOutput *rlwe.SecretKey, ct *rlwe.Ciphertext, shareOut *KeySwitchShare) {
@@ -194,6 +196,41 @@ func (cks KeySwitchProtocol) AggregateShares(share1, share2 KeySwitchShare, shar
 	return
 }
 
+// GenShareCommitted generates the party's share as [KeySwitchProtocol.GenShare]
+// and commits to it, for the commit-then-reveal variant of the protocol: the
+// commitment is broadcast first, and the share is revealed alongside the opening
+// only once the commitments of all the parties have been received.
+func (cks KeySwitchProtocol) GenShareCommitted(skInput, skOutput *rlwe.SecretKey, ct *rlwe.Ciphertext, shareOut *KeySwitchShare) (ShareCommitment, ShareOpening, error) {
+	cks.GenShare(skInput, skOutput, ct, shareOut)
+	return CommitToShare(*shareOut)
+}
+
+// AggregateCommittedShares verifies each revealed share against the commitment
+// received for it in the commit phase and aggregates the shares into shareOut,
+// which can be one of the shares. It returns an error identifying the first
+// party whose share does not match its commitment, in which case shareOut is
+// left untouched and the round must be aborted.
+func (cks KeySwitchProtocol) AggregateCommittedShares(shares []KeySwitchShare, openings []ShareOpening, coms []ShareCommitment, shareOut *KeySwitchShare) error {
+
+	if len(shares) == 0 {
+		return fmt.Errorf("cannot AggregateCommittedShares: no shares")
+	}
+
+	if err := VerifyCommittedShares(shares, openings, coms); err != nil {
+		return fmt.Errorf("cannot AggregateCommittedShares: %w", err)
+	}
+
+	shareOut.Value.Resize(shares[0].Level())
+	shareOut.Value.Copy(shares[0].Value)
+	for _, share := range shares[1:] {
+		if err := cks.AggregateShares(*shareOut, share, shareOut); err != nil {
+			return fmt.Errorf("cannot AggregateCommittedShares: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // AggregateShares is the second part of the unique round of the [KeySwitchProtocol] protocol. Upon receiving the j-1 elements each party computes:
 //
 // [ctx[0] + sum(\lambda_i * (skInput_i - skOutput_i) * ctx[0] + [e]_i), ctx[1]]
@@ -206,11 +243,16 @@ func (cks KeySwitchProtocol) AggregateSharesSmudgeError(share1, share2 KeySwitch
 	return
 }
 
-// KeySwitch performs the actual keyswitching operation on a ciphertext ct and put the result in opOut
-func (cks KeySwitchProtocol) KeySwitch(ctIn *rlwe.Ciphertext, combined KeySwitchShare, opOut *rlwe.Ciphertext) {
+// KeySwitch performs the actual keyswitching operation on a ciphertext ct and put the result in opOut.
+// The method returns an error if the combined share is at a level smaller than the level of ctIn.
+func (cks KeySwitchProtocol) KeySwitch(ctIn *rlwe.Ciphertext, combined KeySwitchShare, opOut *rlwe.Ciphertext) (err error) {
 
 	level := ctIn.Level()
 
+	if combined.Level() < level {
+		return fmt.Errorf("cannot KeySwitch: the combined share level (%d) is smaller than the ciphertext level (%d)", combined.Level(), level)
+	}
+
 	if ctIn != opOut {
 
 		opOut.Resize(ctIn.Degree(), level)
@@ -221,6 +263,8 @@ func (cks KeySwitchProtocol) KeySwitch(ctIn *rlwe.Ciphertext, combined KeySwitch
 	}
 
 	cks.params.RingQ().AtLevel(level).Add(ctIn.Value[0], combined.Value, opOut.Value[0])
+
+	return
 }
 
 // Level returns the level of the target share.
@@ -207,7 +207,10 @@ func benchThreshold(params rlwe.Parameters, levelQ, levelP, bpw2 int, t int, b *
 		}
 	})
 
-	p.Combiner = NewCombiner(params, shamirPks[0], shamirPks, t)
+	var err error
+	if p.Combiner, err = NewCombiner(params, shamirPks[0], shamirPks, t); err != nil {
+		b.Fatal(err)
+	}
 
 	b.Run(benchString(params, "Combiner/GenAdditiveShare", levelQ, levelP, bpw2)+fmt.Sprintf("/threshold=%d", t), func(b *testing.B) {
 		for i := 0; i < b.N; i++ {
@@ -96,6 +96,7 @@ func TestMultiParty(t *testing.T) {
 
 				testPublicKeyGenProtocol(tc, params.MaxLevelQ(), params.MaxLevelP(), bpw2, t)
 				testThreshold(tc, params.MaxLevelQ(), params.MaxLevelP(), bpw2, t)
+				testThresholdBatch(tc, params.MaxLevelQ(), params.MaxLevelP(), bpw2, t)
 				testRefreshShare(tc, params.MaxLevelQ(), params.MaxLevelP(), bpw2, t)
 
 				levelsQ := []int{0}
@@ -166,6 +167,48 @@ func testPublicKeyGenProtocol(tc *testContext, levelQ, levelP, bpw2 int, t *test
 
 		require.GreaterOrEqual(t, math.Log2(math.Sqrt(float64(nbParties))*params.NoiseFreshSK())+1, rlwe.NoisePublicKey(pk, tc.skIdeal, params))
 	})
+
+	t.Run(testString(params, "PublicKeyGen/Committed", levelQ, levelP, bpw2), func(t *testing.T) {
+
+		ckg := make([]PublicKeyGenProtocol, nbParties)
+		for i := range ckg {
+			if i == 0 {
+				ckg[i] = NewPublicKeyGenProtocol(params)
+			} else {
+				ckg[i] = ckg[0].ShallowCopy()
+			}
+		}
+
+		shares := make([]PublicKeyGenShare, nbParties)
+		coms := make([]ShareCommitment, nbParties)
+		openings := make([]ShareOpening, nbParties)
+
+		crp := ckg[0].SampleCRP(tc.crs)
+
+		// Commit phase: each party broadcasts its commitment before revealing its share.
+		var err error
+		for i := range shares {
+			shares[i] = ckg[i].AllocateShare()
+			coms[i], openings[i], err = ckg[i].GenShareCommitted(tc.skShares[i], crp, &shares[i])
+			require.NoError(t, err)
+		}
+
+		agg := ckg[0].AllocateShare()
+		require.NoError(t, ckg[0].AggregateCommittedShares(shares, openings, coms, &agg))
+
+		pk := rlwe.NewPublicKey(params)
+		ckg[0].GenPublicKey(agg, crp, pk)
+
+		require.GreaterOrEqual(t, math.Log2(math.Sqrt(float64(nbParties))*params.NoiseFreshSK())+1, rlwe.NoisePublicKey(pk, tc.skIdeal, params))
+
+		// A share modified after the commit phase is rejected.
+		shares[0].Value.Q.Coeffs[0][0]++
+		require.Error(t, ckg[0].AggregateCommittedShares(shares, openings, coms, &agg))
+		shares[0].Value.Q.Coeffs[0][0]--
+
+		// Mismatching slice lengths are rejected.
+		require.Error(t, ckg[0].AggregateCommittedShares(shares, openings[1:], coms, &agg))
+	})
 }
 
 func testRelinearizationKeyGenProtocol(tc *testContext, levelQ, levelP, bpw2 int, t *testing.T) {
@@ -377,7 +420,7 @@ func testKeySwitchProtocol(tc *testContext, levelQ, levelP, bpw2 int, t *testing
 
 		dec := rlwe.NewDecryptor(params, skOutIdeal)
 
-		cks[0].KeySwitch(ct, shares[0], ksCt)
+		require.NoError(t, cks[0].KeySwitch(ct, shares[0], ksCt))
 
 		pt := rlwe.NewPlaintext(params, ct.Level())
 
@@ -391,7 +434,7 @@ func testKeySwitchProtocol(tc *testContext, levelQ, levelP, bpw2 int, t *testing
 
 		require.GreaterOrEqual(t, math.Log2(NoiseKeySwitch(params, nbParties, params.NoiseFreshSK(), float64(sigmaSmudging)))+1, ringQ.Log2OfStandardDeviation(pt.Value))
 
-		cks[0].KeySwitch(ct, shares[0], ct)
+		require.NoError(t, cks[0].KeySwitch(ct, shares[0], ct))
 
 		dec.Decrypt(ct, pt)
 
@@ -400,6 +443,74 @@ func testKeySwitchProtocol(tc *testContext, levelQ, levelP, bpw2 int, t *testing
 		}
 
 		require.GreaterOrEqual(t, math.Log2(NoiseKeySwitch(params, nbParties, params.NoiseFreshSK(), float64(sigmaSmudging)))+1, ringQ.Log2OfStandardDeviation(pt.Value))
+
+		// A share allocated above the level of the ciphertext is generated at the level of the ciphertext.
+		full := cks[0].AllocateShare(params.MaxLevelQ())
+		cks[0].GenShare(tc.skShares[0], skout[0], ct, &full)
+		require.Equal(t, ct.Level(), full.Level())
+
+		// A combined share at a level smaller than the ciphertext is rejected.
+		if levelQ > 0 {
+			require.Error(t, cks[0].KeySwitch(ct, cks[0].AllocateShare(levelQ-1), ksCt))
+		}
+	})
+
+	t.Run(testString(params, "KeySwitch/Committed", levelQ, levelP, bpw2), func(t *testing.T) {
+
+		sigmaSmudging := 8 * rlwe.DefaultNoise
+
+		cks := make([]KeySwitchProtocol, nbParties)
+		var err error
+		for i := range cks {
+			if i == 0 {
+				cks[i], err = NewKeySwitchProtocol(params, ring.DiscreteGaussian{Sigma: sigmaSmudging, Bound: 6 * sigmaSmudging})
+				require.NoError(t, err)
+			} else {
+				cks[i] = cks[0].ShallowCopy()
+			}
+		}
+
+		skout := make([]*rlwe.SecretKey, nbParties)
+		skOutIdeal := rlwe.NewSecretKey(params)
+		for i := range skout {
+			skout[i] = tc.kgen.GenSecretKeyNew()
+			params.RingQP().Add(skOutIdeal.Value, skout[i].Value, skOutIdeal.Value)
+		}
+
+		ct := rlwe.NewCiphertext(params, 1, levelQ)
+		rlwe.NewEncryptor(params, tc.skIdeal).EncryptZero(ct)
+
+		shares := make([]KeySwitchShare, nbParties)
+		coms := make([]ShareCommitment, nbParties)
+		openings := make([]ShareOpening, nbParties)
+
+		// Commit phase: each party broadcasts its commitment before revealing its share.
+		for i := range shares {
+			shares[i] = cks[i].AllocateShare(ct.Level())
+			coms[i], openings[i], err = cks[i].GenShareCommitted(tc.skShares[i], skout[i], ct, &shares[i])
+			require.NoError(t, err)
+		}
+
+		agg := cks[0].AllocateShare(ct.Level())
+		require.NoError(t, cks[0].AggregateCommittedShares(shares, openings, coms, &agg))
+
+		ksCt := rlwe.NewCiphertext(params, 1, ct.Level())
+		require.NoError(t, cks[0].KeySwitch(ct, agg, ksCt))
+
+		pt := rlwe.NewPlaintext(params, ct.Level())
+		rlwe.NewDecryptor(params, skOutIdeal).Decrypt(ksCt, pt)
+
+		ringQ := params.RingQ().AtLevel(ct.Level())
+
+		if pt.IsNTT {
+			ringQ.INTT(pt.Value, pt.Value)
+		}
+
+		require.GreaterOrEqual(t, math.Log2(NoiseKeySwitch(params, nbParties, params.NoiseFreshSK(), float64(sigmaSmudging)))+1, ringQ.Log2OfStandardDeviation(pt.Value))
+
+		// A share modified after the commit phase is rejected.
+		shares[0].Value.Coeffs[0][0]++
+		require.Error(t, cks[0].AggregateCommittedShares(shares, openings, coms, &agg))
 	})
 }
 
@@ -447,7 +558,7 @@ func testPublicKeySwitchProtocol(tc *testContext, levelQ, levelP, bpw2 int, t *t
 		ksCt := rlwe.NewCiphertext(params, 1, levelQ)
 		dec := rlwe.NewDecryptor(params, skOut)
 
-		pcks[0].KeySwitch(ct, shares[0], ksCt)
+		require.NoError(t, pcks[0].KeySwitch(ct, shares[0], ksCt))
 
 		pt := rlwe.NewPlaintext(params, ct.Level())
 		dec.Decrypt(ksCt, pt)
@@ -460,7 +571,7 @@ func testPublicKeySwitchProtocol(tc *testContext, levelQ, levelP, bpw2 int, t *t
 
 		require.GreaterOrEqual(t, math.Log2(NoisePublicKeySwitch(params, nbParties, params.NoiseFreshSK(), float64(sigmaSmudging)))+1, ringQ.Log2OfStandardDeviation(pt.Value))
 
-		pcks[0].KeySwitch(ct, shares[0], ct)
+		require.NoError(t, pcks[0].KeySwitch(ct, shares[0], ct))
 
 		dec.Decrypt(ct, pt)
 
@@ -469,6 +580,17 @@ func testPublicKeySwitchProtocol(tc *testContext, levelQ, levelP, bpw2 int, t *t
 		}
 
 		require.GreaterOrEqual(t, math.Log2(NoisePublicKeySwitch(params, nbParties, params.NoiseFreshSK(), float64(sigmaSmudging)))+1, ringQ.Log2OfStandardDeviation(pt.Value))
+
+		// A share allocated above the level of the ciphertext is generated at the level of the ciphertext.
+		full := pcks[0].AllocateShare(params.MaxLevelQ())
+		pcks[0].GenShare(tc.skShares[0], pkOut, ct, &full)
+		require.Equal(t, ct.Level(), full.Level())
+
+		// Shares and combined shares at a different level than the ciphertext are rejected.
+		if levelQ > 0 {
+			require.Error(t, pcks[0].AggregateShares(shares[0], pcks[0].AllocateShare(levelQ-1), &shares[0]))
+			require.Error(t, pcks[0].KeySwitch(ct, pcks[0].AllocateShare(levelQ-1), ksCt))
+		}
 	})
 }
 
@@ -502,7 +624,9 @@ func testThreshold(tc *testContext, levelQ, levelP, bpw2 int, t *testing.T) {
 			}
 
 			for _, pi := range P {
-				pi.Combiner = NewCombiner(tc.params, pi.tpk, shamirPks, threshold)
+				var err error
+				pi.Combiner, err = NewCombiner(tc.params, pi.tpk, shamirPks, threshold)
+				require.NoError(t, err)
 			}
 
 			shares := make(map[*Party]map[*Party]ShamirSecretShareQP, tc.nParties())
@@ -553,10 +677,131 @@ func testThreshold(tc *testContext, levelQ, levelP, bpw2 int, t *testing.T) {
 			}
 
 			require.True(t, tc.skIdeal.Equal(recSk)) // reconstructed key should match the ideal sk
+
+			// Reduced-bandwidth combination: each active party premultiplies its share
+			// by its own Lagrange coefficient and the aggregator only sums.
+			preShares := make([]PremultipliedShareQP, threshold)
+			for i, pi := range activeParties {
+				preShares[i] = pi.Combiner.AllocatePremultipliedShareQP()
+				require.NoError(t, pi.Combiner.GenPremultipliedShareQP(activeShamirPks, pi.tpk, pi.tsks, &preShares[i]))
+			}
+
+			// Test binary encoding
+			buffer.RequireSerializerCorrect(t, &preShares[0])
+
+			recSkPre := rlwe.NewSecretKey(tc.params)
+			require.NoError(t, P[0].Combiner.AggregatePremultipliedSharesQP(preShares, recSkPre))
+			require.True(t, tc.skIdeal.Equal(recSkPre))
+
+			if threshold > 1 {
+				// A party declaring a different active set is detected by the aggregator.
+				preShares[threshold-1].ActiveSet[0] ^= 1
+				require.Error(t, P[0].Combiner.AggregatePremultipliedSharesQP(preShares, recSkPre))
+				preShares[threshold-1].ActiveSet[0] ^= 1
+
+				// A wrong number of shares is rejected.
+				require.Error(t, P[0].Combiner.AggregatePremultipliedSharesQP(preShares[:threshold-1], recSkPre))
+			}
+
+			// A party whose point is not in the used active set cannot generate a share.
+			if threshold < tc.nParties() {
+				pOut := P[threshold]
+				share := pOut.Combiner.AllocatePremultipliedShareQP()
+				require.Error(t, pOut.Combiner.GenPremultipliedShareQP(activeShamirPks, pOut.tpk, pOut.tsks, &share))
+			}
 		})
 	}
 }
 
+func testThresholdBatch(tc *testContext, levelQ, levelP, bpw2 int, t *testing.T) {
+	sk0Shards := tc.skShares
+
+	threshold := tc.nParties() / 2
+	batchSize := 3
+
+	t.Run(testString(tc.params, "ThresholdBatch", levelQ, levelP, bpw2)+fmt.Sprintf("/threshold=%d", threshold), func(t *testing.T) {
+
+		type Party struct {
+			Thresholdizer
+			Combiner
+			gen  ShamirPolynomialBatchQP
+			sk   *rlwe.SecretKey
+			tsks ShamirSecretShareBatchQP
+			tsk  *rlwe.SecretKey
+			tpk  ShamirPublicPoint
+		}
+
+		P := make([]*Party, tc.nParties())
+		shamirPks := make([]ShamirPublicPoint, tc.nParties())
+		for i := 0; i < tc.nParties(); i++ {
+			p := new(Party)
+			p.Thresholdizer = NewThresholdizer(tc.params)
+			p.sk = sk0Shards[i]
+			p.tsk = rlwe.NewSecretKey(tc.params)
+			p.tpk = ShamirPublicPoint(i + 1)
+			p.tsks = p.Thresholdizer.AllocateThresholdSecretShareBatch(batchSize)
+			P[i] = p
+			shamirPks[i] = p.tpk
+		}
+
+		for _, pi := range P {
+			var err error
+			pi.Combiner, err = NewCombiner(tc.params, pi.tpk, shamirPks, threshold)
+			require.NoError(t, err)
+		}
+
+		var err error
+		// Every party shares a batch of correlated secrets (here batchSize copies of its
+		// secret-key share) with a single ceremony, sending one message per recipient.
+		shares := make(map[*Party]map[*Party]ShamirSecretShareBatchQP, tc.nParties())
+		for _, pi := range P {
+
+			secrets := make([]*rlwe.SecretKey, batchSize)
+			for j := range secrets {
+				secrets[j] = pi.sk
+			}
+
+			pi.gen, err = pi.Thresholdizer.GenShamirPolynomialBatchQP(threshold, secrets)
+			require.NoError(t, err)
+
+			shares[pi] = make(map[*Party]ShamirSecretShareBatchQP)
+			for _, pj := range P {
+				shares[pi][pj] = pi.Thresholdizer.AllocateThresholdSecretShareBatch(batchSize)
+				share := shares[pi][pj]
+				require.NoError(t, pi.Thresholdizer.GenShamirSecretShareBatchQP(pj.tpk, pi.gen, &share))
+			}
+		}
+
+		// Each party aggregates the batches it has received
+		for _, pi := range P {
+			for _, pj := range P {
+				share := shares[pj][pi]
+				require.NoError(t, pi.Thresholdizer.AggregateSharesBatch(pi.tsks, share, &pi.tsks))
+			}
+		}
+
+		// Test binary encoding
+		buffer.RequireSerializerCorrect(t, &P[0].tsks)
+
+		activeParties := P[:threshold]
+		activeShamirPks := make([]ShamirPublicPoint, threshold)
+		for i, p := range activeParties {
+			activeShamirPks[i] = p.tpk
+		}
+
+		// Combining: each entry of the batch should reconstruct to the ideal secret-key
+		ringQP := tc.params.RingQP()
+		for j := 0; j < batchSize; j++ {
+			recSk := rlwe.NewSecretKey(tc.params)
+			for _, pi := range activeParties {
+				pi.Combiner.GenAdditiveShareQP(activeShamirPks, pi.tpk, ShamirSecretShareQP{pi.tsks.Value[j]}, pi.tsk)
+				ringQP.Add(pi.tsk.Value, recSk.Value, recSk.Value)
+			}
+			require.True(t, tc.skIdeal.Equal(recSk))
+		}
+	})
+}
+
 func testRefreshShare(tc *testContext, levelQ, levelP, bpw2 int, t *testing.T) {
 	t.Run(testString(tc.params, "RefreshShare", levelQ, levelP, bpw2), func(t *testing.T) {
 		params := tc.params
@@ -575,3 +820,42 @@ func testRefreshShare(tc *testContext, levelQ, levelP, bpw2 int, t *testing.T) {
 		buffer.RequireSerializerCorrect(t, &RefreshShare{EncToShareShare: share1, ShareToEncShare: share2, MetaData: *ciphertext.MetaData})
 	})
 }
+
+func TestShamirPublicPointRegistry(t *testing.T) {
+
+	params, err := rlwe.NewParametersFromLiteral(testInsecure[0].ParametersLiteral)
+	require.NoError(t, err)
+
+	reg := NewShamirPublicPointRegistry(params)
+
+	identities := []string{"party-0", "party-1", "party-2"}
+
+	points := make([]ShamirPublicPoint, len(identities))
+	for i, id := range identities {
+		points[i], err = reg.Register([]byte(id))
+		require.NoError(t, err)
+		require.NotEqual(t, ShamirPublicPoint(0), points[i])
+	}
+
+	// Derivation is deterministic and registration idempotent
+	for i, id := range identities {
+		require.Equal(t, points[i], reg.DeriveShamirPublicPoint([]byte(id)))
+		point, err := reg.Register([]byte(id))
+		require.NoError(t, err)
+		require.Equal(t, points[i], point)
+	}
+
+	// All points are distinct and below every RNS modulus, so that the
+	// pairwise differences are invertible in the Combiner
+	moduli := append(params.Q(), params.P()...)
+	seen := map[ShamirPublicPoint]bool{}
+	for _, point := range points {
+		require.False(t, seen[point])
+		seen[point] = true
+		for _, qi := range moduli {
+			require.Less(t, uint64(point), qi)
+		}
+	}
+
+	require.Len(t, reg.Points(), len(identities))
+}
@@ -0,0 +1,92 @@
+package multiparty
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// ShamirPublicPointRegistry derives [ShamirPublicPoint] values from arbitrary party
+// identifiers (e.g., names or serialized public keys) and keeps track of the points
+// in use, detecting accidental collisions. Derived points are guaranteed to be
+// non-zero and strictly smaller than every RNS modulus of the parameters, so that
+// the pairwise differences of any set of registered points are invertible modulo
+// each modulus, as required by the [Combiner].
+//
+// ShamirPublicPointRegistry is not safe for concurrent use.
+type ShamirPublicPointRegistry struct {
+	bound  uint64
+	points map[ShamirPublicPoint]string
+}
+
+// NewShamirPublicPointRegistry creates a new [ShamirPublicPointRegistry] for the
+// given parameters.
+func NewShamirPublicPointRegistry(params rlwe.ParameterProvider) *ShamirPublicPointRegistry {
+
+	p := params.GetRLWEParameters()
+
+	moduli := p.Q()
+	if P := p.P(); len(P) > 0 {
+		moduli = append(moduli, P...)
+	}
+
+	bound := moduli[0]
+	for _, qi := range moduli[1:] {
+		bound = utils.Min(bound, qi)
+	}
+
+	return &ShamirPublicPointRegistry{
+		bound:  bound,
+		points: map[ShamirPublicPoint]string{},
+	}
+}
+
+// DeriveShamirPublicPoint deterministically maps the given party identity to a
+// [ShamirPublicPoint] in [1, bound-1], where bound is the smallest RNS modulus of
+// the parameters. The derivation is collision-resistant (it hashes the identity
+// with blake2b) but callers distributing points among parties should register them
+// through [ShamirPublicPointRegistry.Register] to detect accidental collisions.
+func (reg ShamirPublicPointRegistry) DeriveShamirPublicPoint(identity []byte) ShamirPublicPoint {
+
+	// Unbiased reduction of a uniform 64-bit value to [1, bound-1]: values above
+	// the largest multiple of bound-1 are rejected by re-hashing with a counter.
+	m := reg.bound - 1
+	rem := (-m) % m // 2^64 mod m
+
+	var counter [8]byte
+	for i := uint64(0); ; i++ {
+		binary.LittleEndian.PutUint64(counter[:], i)
+		digest := blake2b.Sum256(append(counter[:], identity...))
+		if v := binary.LittleEndian.Uint64(digest[:8]); rem == 0 || v <= ^uint64(0)-rem {
+			return ShamirPublicPoint(v%m + 1)
+		}
+	}
+}
+
+// Register derives the [ShamirPublicPoint] of the given party identity and records
+// it in the registry. It returns an error if another identity already registered
+// the same point (collision), and is idempotent for a same identity.
+func (reg *ShamirPublicPointRegistry) Register(identity []byte) (ShamirPublicPoint, error) {
+
+	point := reg.DeriveShamirPublicPoint(identity)
+
+	if other, ok := reg.points[point]; ok {
+		if other != string(identity) {
+			return 0, fmt.Errorf("cannot Register: ShamirPublicPoint collision between identities %q and %q", other, identity)
+		}
+		return point, nil
+	}
+
+	reg.points[point] = string(identity)
+
+	return point, nil
+}
+
+// Points returns the list of the registered [ShamirPublicPoint].
+func (reg ShamirPublicPointRegistry) Points() []ShamirPublicPoint {
+	return utils.GetSortedKeys(reg.points)
+}
@@ -0,0 +1,138 @@
+package multiparty
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+// PolySchema describes the expected shape of one serialized ring polynomial
+// within a share payload.
+type PolySchema struct {
+	// Level is the expected level of the polynomial, i.e. its number of RNS
+	// components minus one. A level of -1 denotes an empty polynomial, such as
+	// the P part of a [ringqp.Poly] when the parameters have no auxiliary primes.
+	Level int
+	// N is the expected ring degree of the polynomial.
+	N int
+}
+
+// ShareSchema describes the expected shape of the serialized share payloads of
+// one protocol round: the polynomials composing a share, in their serialization
+// order, and the digest of the parameters the round is run under (see
+// [ParametersDigest]). Schemas are instantiated with the NewXShareSchema
+// methods of [ShareValidator].
+type ShareSchema struct {
+	// ParametersDigest is the digest of the parameters of the protocol
+	// instance the round belongs to.
+	ParametersDigest string
+	// Polys lists the expected polynomials of the payload, in their
+	// serialization order.
+	Polys []PolySchema
+}
+
+// ShareValidator checks incoming serialized share payloads against the schema
+// of the expected protocol round, before they are fully deserialized and
+// aggregated. Only the length headers of the payload are parsed, so a
+// malformed or truncated payload is rejected without allocating buffers for
+// its announced sizes, protecting an aggregator from resource-exhaustion, and
+// a well-formed share belonging to another round or protocol instance is
+// rejected before it can be mixed into the aggregation.
+type ShareValidator struct {
+	params rlwe.Parameters
+	digest string
+}
+
+// NewShareValidator instantiates a new [ShareValidator] for the given
+// parameters.
+func NewShareValidator(params rlwe.ParameterProvider) (ShareValidator, error) {
+	digest, err := ParametersDigest(params)
+	if err != nil {
+		return ShareValidator{}, fmt.Errorf("cannot NewShareValidator: %w", err)
+	}
+	return ShareValidator{params: *params.GetRLWEParameters(), digest: digest}, nil
+}
+
+// NewRingQPShareSchema returns the schema of a share composed of polyCount
+// polynomials in R_QP at the given levels, such as a [PublicKeyGenShare]
+// (polyCount=1 at the maximum levels). A levelP of -1 denotes parameters
+// without auxiliary primes.
+func (v ShareValidator) NewRingQPShareSchema(polyCount, levelQ, levelP int) ShareSchema {
+	polys := make([]PolySchema, 0, 2*polyCount)
+	for i := 0; i < polyCount; i++ {
+		polys = append(polys, PolySchema{Level: levelQ, N: v.params.N()}, PolySchema{Level: levelP, N: v.params.N()})
+	}
+	return ShareSchema{ParametersDigest: v.digest, Polys: polys}
+}
+
+// NewRingQShareSchema returns the schema of a share composed of polyCount
+// polynomials in R_Q at the given level, such as a [KeySwitchShare]
+// (polyCount=1 at the level of the ciphertext).
+func (v ShareValidator) NewRingQShareSchema(polyCount, level int) ShareSchema {
+	polys := make([]PolySchema, polyCount)
+	for i := range polys {
+		polys[i] = PolySchema{Level: level, N: v.params.N()}
+	}
+	return ShareSchema{ParametersDigest: v.digest, Polys: polys}
+}
+
+// Validate checks a serialized share payload against the expected schema,
+// returning an error identifying the first mismatch: the schema belonging to
+// another protocol instance than the validator's, a polynomial with an
+// unexpected level or ring degree, a truncated payload, or trailing bytes
+// after the expected polynomials. Only the length headers of the payload are
+// parsed; the coefficient data is not deserialized.
+func (v ShareValidator) Validate(payload []byte, schema ShareSchema) error {
+
+	if schema.ParametersDigest != v.digest {
+		return fmt.Errorf("cannot Validate: parameters digest mismatch: the schema belongs to another protocol instance")
+	}
+
+	read := 0
+
+	header := func() (c int, err error) {
+		if len(payload)-read < 8 {
+			return 0, fmt.Errorf("truncated payload: %d bytes left but expected an 8-byte length header", len(payload)-read)
+		}
+		c = int(binary.LittleEndian.Uint64(payload[read:]))
+		read += 8
+		return
+	}
+
+	for i, poly := range schema.Polys {
+
+		rows, err := header()
+		if err != nil {
+			return fmt.Errorf("cannot Validate: polynomial %d: %w", i, err)
+		}
+
+		if rows != poly.Level+1 {
+			return fmt.Errorf("cannot Validate: polynomial %d: level is %d but the schema expects %d", i, rows-1, poly.Level)
+		}
+
+		for j := 0; j < rows; j++ {
+
+			n, err := header()
+			if err != nil {
+				return fmt.Errorf("cannot Validate: polynomial %d: %w", i, err)
+			}
+
+			if n != poly.N {
+				return fmt.Errorf("cannot Validate: polynomial %d: ring degree is %d but the schema expects %d", i, n, poly.N)
+			}
+
+			if len(payload)-read < 8*n {
+				return fmt.Errorf("cannot Validate: polynomial %d: truncated payload: %d bytes left but expected %d coefficient bytes", i, len(payload)-read, 8*n)
+			}
+
+			read += 8 * n
+		}
+	}
+
+	if read != len(payload) {
+		return fmt.Errorf("cannot Validate: %d trailing bytes after the expected %d polynomials", len(payload)-read, len(schema.Polys))
+	}
+
+	return nil
+}
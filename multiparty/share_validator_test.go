@@ -0,0 +1,83 @@
+package multiparty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+func TestShareValidator(t *testing.T) {
+
+	params, err := rlwe.NewParametersFromLiteral(testInsecure[1].ParametersLiteral)
+	require.NoError(t, err)
+
+	validator, err := NewShareValidator(params)
+	require.NoError(t, err)
+
+	kgen := rlwe.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+
+	t.Run("RingQPShare", func(t *testing.T) {
+
+		crs, err := NewCRSManager([]byte{'l', 'a', 't', 't', 'i', 'g', 'o'}).Get("PublicKeyGen")
+		require.NoError(t, err)
+
+		ckg := NewPublicKeyGenProtocol(params)
+		share := ckg.AllocateShare()
+		ckg.GenShare(sk, ckg.SampleCRP(crs), &share)
+
+		payload, err := share.MarshalBinary()
+		require.NoError(t, err)
+
+		schema := validator.NewRingQPShareSchema(1, params.MaxLevel(), params.MaxLevelP())
+		require.NoError(t, validator.Validate(payload, schema))
+
+		// Share at another level than the expected round
+		require.ErrorContains(t, validator.Validate(payload, validator.NewRingQPShareSchema(1, params.MaxLevel()-1, params.MaxLevelP())), "level")
+
+		// Share from a protocol instance with other parameters
+		paramsOther, err := rlwe.NewParametersFromLiteral(testInsecure[0].ParametersLiteral)
+		require.NoError(t, err)
+		validatorOther, err := NewShareValidator(paramsOther)
+		require.NoError(t, err)
+		require.ErrorContains(t, validatorOther.Validate(payload, schema), "parameters digest mismatch")
+	})
+
+	t.Run("RingQShare", func(t *testing.T) {
+
+		cks, err := NewKeySwitchProtocol(params, params.Xe())
+		require.NoError(t, err)
+
+		level := params.MaxLevel() - 1
+		share := cks.AllocateShare(level)
+
+		payload, err := share.MarshalBinary()
+		require.NoError(t, err)
+
+		require.NoError(t, validator.Validate(payload, validator.NewRingQShareSchema(1, level)))
+
+		// Wrong polynomial count
+		require.ErrorContains(t, validator.Validate(payload, validator.NewRingQShareSchema(2, level)), "truncated payload")
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+
+		schema := validator.NewRingQShareSchema(1, params.MaxLevel())
+
+		share := KeySwitchShare{Value: params.RingQ().NewPoly()}
+		payload, err := share.MarshalBinary()
+		require.NoError(t, err)
+
+		// Truncated coefficient data
+		require.ErrorContains(t, validator.Validate(payload[:len(payload)-1], schema), "truncated payload")
+
+		// Trailing bytes
+		require.ErrorContains(t, validator.Validate(append(payload, 0), schema), "trailing bytes")
+
+		// Announced degree overflowing the payload
+		payload[8] = 0xFF
+		require.ErrorContains(t, validator.Validate(payload, schema), "ring degree")
+	})
+}
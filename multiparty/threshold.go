@@ -3,6 +3,7 @@ package multiparty
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/tuneinsight/lattigo/v6/core/rlwe"
 	"github.com/tuneinsight/lattigo/v6/ring"
@@ -150,9 +151,41 @@ func (thr Thresholdizer) AggregateShares(share1, share2 ShamirSecretShareQP, out
 	return
 }
 
+// ShamirPointConflict records a pair of Shamir public points whose difference is divisible
+// by one of the RNS moduli, making the corresponding Lagrange coefficient non-invertible
+// modulo that prime.
+type ShamirPointConflict struct {
+	This, That ShamirPublicPoint
+	Modulus    uint64
+}
+
+// NonInvertibleShamirPointsError is the error returned by [NewCombiner] when the difference
+// between two of the provided [ShamirPublicPoint] is not invertible modulo every prime of Q
+// and P. It lists all the offending point pairs and moduli.
+type NonInvertibleShamirPointsError struct {
+	Conflicts []ShamirPointConflict
+}
+
+// Error implements the error interface.
+func (e *NonInvertibleShamirPointsError) Error() string {
+	conflicts := make([]string, len(e.Conflicts))
+	for i, c := range e.Conflicts {
+		conflicts[i] = fmt.Sprintf("(%d-%d) mod %d = 0", c.That, c.This, c.Modulus)
+	}
+	return fmt.Sprintf("%d non-invertible Shamir point difference(s): %s", len(e.Conflicts), strings.Join(conflicts, ", "))
+}
+
 // NewCombiner creates a new [Combiner] struct from the parameters and the set of [ShamirPublicPoints]. Note that the other
 // parameter may contain the instantiator's own [ShamirPublicPoint].
-func NewCombiner(params rlwe.Parameters, own ShamirPublicPoint, others []ShamirPublicPoint, threshold int) Combiner {
+// It returns a [NonInvertibleShamirPointsError] if the difference between the instantiator's
+// point and one of the other points is divisible by a prime of Q or P, as the Lagrange
+// coefficients would then be silently wrong modulo that prime.
+func NewCombiner(params rlwe.Parameters, own ShamirPublicPoint, others []ShamirPublicPoint, threshold int) (Combiner, error) {
+
+	if err := checkShamirPoints(params, own, others); err != nil {
+		return Combiner{}, fmt.Errorf("cannot NewCombiner: %w", err)
+	}
+
 	cmb := Combiner{}
 	cmb.ringQ = params.RingQ()
 	cmb.ringQP = params.RingQP()
@@ -179,7 +212,36 @@ func NewCombiner(params rlwe.Parameters, own ShamirPublicPoint, others []ShamirP
 		}
 	}
 
-	return cmb
+	return cmb, nil
+}
+
+// checkShamirPoints verifies that the difference between the instantiator's point and every
+// other point is invertible modulo every prime of Q and P, and returns a
+// [NonInvertibleShamirPointsError] listing all the conflicting pairs and moduli otherwise.
+func checkShamirPoints(params rlwe.Parameters, own ShamirPublicPoint, others []ShamirPublicPoint) error {
+
+	moduli := params.RingQ().ModuliChain()
+	if ringP := params.RingP(); ringP != nil {
+		moduli = append(moduli, ringP.ModuliChain()...)
+	}
+
+	var conflicts []ShamirPointConflict
+	for _, spk := range others {
+		if spk == own {
+			continue
+		}
+		for _, qi := range moduli {
+			if uint64(spk)%qi == uint64(own)%qi {
+				conflicts = append(conflicts, ShamirPointConflict{This: own, That: spk, Modulus: qi})
+			}
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return &NonInvertibleShamirPointsError{Conflicts: conflicts}
+	}
+
+	return nil
 }
 func (cmb Combiner) GenAdditiveShareQ(activesPoints []ShamirPublicPoint, ownPoint ShamirPublicPoint, ownShare KeySwitchShare, skOut *KeySwitchShare) (err error) {
 
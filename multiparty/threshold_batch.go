@@ -0,0 +1,211 @@
+package multiparty
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/ring/ringqp"
+	"github.com/tuneinsight/lattigo/v6/utils/structs"
+)
+
+// ShamirPolynomialBatchQ represents a batch of [ShamirPolynomialQ] generated jointly
+// over a vector of secrets. It is used by the [Thresholdizer] type to produce
+// t-out-of-N-threshold shares of several correlated secrets (e.g., several smudging
+// errors) within a single sharing ceremony.
+//
+// See [Thresholdizer] type.
+type ShamirPolynomialBatchQ struct {
+	Value []ShamirPolynomialQ
+}
+
+// ShamirPolynomialBatchQP represents a batch of [ShamirPolynomialQP] generated jointly
+// over a vector of secrets. It is used by the [Thresholdizer] type to produce
+// t-out-of-N-threshold shares of several correlated secrets (e.g., a secret-key along
+// with several smudging errors) within a single sharing ceremony.
+//
+// See [Thresholdizer] type.
+type ShamirPolynomialBatchQP struct {
+	Value []ShamirPolynomialQP
+}
+
+// ShamirSecretShareBatchQ represents the t-out-of-N-threshold secret-shares of a batch
+// of secrets for a single recipient. It regroups one [ShamirSecretShareQ] per shared
+// secret so that the whole batch can be sent as a single share message.
+//
+// See [Thresholdizer] and [Combiner] types.
+type ShamirSecretShareBatchQ struct {
+	Value structs.Vector[ring.Poly]
+}
+
+// ShamirSecretShareBatchQP represents the t-out-of-N-threshold secret-shares of a batch
+// of secrets for a single recipient. It regroups one [ShamirSecretShareQP] per shared
+// secret so that the whole batch can be sent as a single share message.
+//
+// See [Thresholdizer] and [Combiner] types.
+type ShamirSecretShareBatchQP struct {
+	Value structs.Vector[ringqp.Poly]
+}
+
+// GenShamirPolynomialBatchQ generates a new secret [ShamirPolynomialBatchQ] over the given
+// vector of secrets, to be used in the [Thresholdizer.GenShamirSecretShareBatchQ] method.
+// It does so by sampling, for each secret, a random polynomial of degree threshold - 1 and
+// with its constant term equal to that secret.
+func (thr Thresholdizer) GenShamirPolynomialBatchQ(threshold int, secrets []*SmudgeError) (ShamirPolynomialBatchQ, error) {
+	if len(secrets) == 0 {
+		return ShamirPolynomialBatchQ{}, fmt.Errorf("secrets vector should be non-empty")
+	}
+	gen := make([]ShamirPolynomialQ, len(secrets))
+	for i := range secrets {
+		var err error
+		if gen[i], err = thr.GenShamirPolynomialQ(threshold, secrets[i]); err != nil {
+			return ShamirPolynomialBatchQ{}, err
+		}
+	}
+	return ShamirPolynomialBatchQ{Value: gen}, nil
+}
+
+// GenShamirPolynomialBatchQP generates a new secret [ShamirPolynomialBatchQP] over the given
+// vector of secrets, to be used in the [Thresholdizer.GenShamirSecretShareBatchQP] method.
+// It does so by sampling, for each secret, a random polynomial of degree threshold - 1 and
+// with its constant term equal to that secret.
+func (thr Thresholdizer) GenShamirPolynomialBatchQP(threshold int, secrets []*rlwe.SecretKey) (ShamirPolynomialBatchQP, error) {
+	if len(secrets) == 0 {
+		return ShamirPolynomialBatchQP{}, fmt.Errorf("secrets vector should be non-empty")
+	}
+	gen := make([]ShamirPolynomialQP, len(secrets))
+	for i := range secrets {
+		var err error
+		if gen[i], err = thr.GenShamirPolynomialQP(threshold, secrets[i]); err != nil {
+			return ShamirPolynomialBatchQP{}, err
+		}
+	}
+	return ShamirPolynomialBatchQP{Value: gen}, nil
+}
+
+// AllocateThresholdSecretShareBatchQ allocates a [ShamirSecretShareBatchQ] struct
+// holding the shares of n secrets.
+func (thr Thresholdizer) AllocateThresholdSecretShareBatchQ(n int) ShamirSecretShareBatchQ {
+	shares := make([]ring.Poly, n)
+	for i := range shares {
+		shares[i] = thr.ringQ.NewPoly()
+	}
+	return ShamirSecretShareBatchQ{Value: structs.Vector[ring.Poly](shares)}
+}
+
+// AllocateThresholdSecretShareBatch allocates a [ShamirSecretShareBatchQP] struct
+// holding the shares of n secrets.
+func (thr Thresholdizer) AllocateThresholdSecretShareBatch(n int) ShamirSecretShareBatchQP {
+	shares := make([]ringqp.Poly, n)
+	for i := range shares {
+		shares[i] = thr.ringQP.NewPoly()
+	}
+	return ShamirSecretShareBatchQP{Value: structs.Vector[ringqp.Poly](shares)}
+}
+
+// GenShamirSecretShareBatchQ generates the secret shares of a batch of secrets for the given
+// recipient, identified by its [ShamirPublicPoint]. The result is stored in shareOut and
+// should be sent to this party as a single message.
+func (thr Thresholdizer) GenShamirSecretShareBatchQ(recipient ShamirPublicPoint, secretPolys ShamirPolynomialBatchQ, shareOut *ShamirSecretShareBatchQ) error {
+	if len(secretPolys.Value) != len(shareOut.Value) {
+		return fmt.Errorf("cannot GenShamirSecretShareBatchQ: batch sizes do not match")
+	}
+	for i := range secretPolys.Value {
+		thr.ringQ.EvalPolyScalar(secretPolys.Value[i].Value, uint64(recipient), shareOut.Value[i])
+	}
+	return nil
+}
+
+// GenShamirSecretShareBatchQP generates the secret shares of a batch of secrets for the given
+// recipient, identified by its [ShamirPublicPoint]. The result is stored in shareOut and
+// should be sent to this party as a single message.
+func (thr Thresholdizer) GenShamirSecretShareBatchQP(recipient ShamirPublicPoint, secretPolys ShamirPolynomialBatchQP, shareOut *ShamirSecretShareBatchQP) error {
+	if len(secretPolys.Value) != len(shareOut.Value) {
+		return fmt.Errorf("cannot GenShamirSecretShareBatchQP: batch sizes do not match")
+	}
+	for i := range secretPolys.Value {
+		thr.ringQP.EvalPolyScalar(secretPolys.Value[i].Value, uint64(recipient), shareOut.Value[i])
+	}
+	return nil
+}
+
+// AggregateSharesBatchQ aggregates two [ShamirSecretShareBatchQ] component-wise and stores
+// the result in outShare.
+func (thr Thresholdizer) AggregateSharesBatchQ(share1, share2 ShamirSecretShareBatchQ, outShare *ShamirSecretShareBatchQ) (err error) {
+	if len(share1.Value) != len(share2.Value) || len(share1.Value) != len(outShare.Value) {
+		return fmt.Errorf("cannot AggregateSharesBatchQ: batch sizes do not match")
+	}
+	for i := range share1.Value {
+		s1 := ShamirSecretShareQ{share1.Value[i]}
+		s2 := ShamirSecretShareQ{share2.Value[i]}
+		out := ShamirSecretShareQ{outShare.Value[i]}
+		if err = thr.AggregateSharesQ(s1, s2, &out); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// AggregateSharesBatch aggregates two [ShamirSecretShareBatchQP] component-wise and stores
+// the result in outShare.
+func (thr Thresholdizer) AggregateSharesBatch(share1, share2 ShamirSecretShareBatchQP, outShare *ShamirSecretShareBatchQP) (err error) {
+	if len(share1.Value) != len(share2.Value) || len(share1.Value) != len(outShare.Value) {
+		return fmt.Errorf("cannot AggregateSharesBatch: batch sizes do not match")
+	}
+	for i := range share1.Value {
+		s1 := ShamirSecretShareQP{share1.Value[i]}
+		s2 := ShamirSecretShareQP{share2.Value[i]}
+		out := ShamirSecretShareQP{outShare.Value[i]}
+		if err = thr.AggregateShares(s1, s2, &out); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// BinarySize returns the serialized size of the object in bytes.
+func (s ShamirSecretShareBatchQP) BinarySize() int {
+	return s.Value.BinarySize()
+}
+
+// WriteTo writes the object on an [io.Writer]. It implements the [io.WriterTo]
+// interface, and will write exactly object.BinarySize() bytes on w.
+//
+// Unless w implements the [buffer.Writer] interface (see lattigo/utils/buffer/writer.go),
+// it will be wrapped into a [bufio.Writer]. Since this requires allocations, it
+// is preferable to pass a [buffer.Writer] directly:
+//
+//   - When writing multiple times to a [io.Writer], it is preferable to first wrap the
+//     [io.Writer] in a pre-allocated [bufio.Writer].
+//   - When writing to a pre-allocated var b []byte, it is preferable to pass
+//     buffer.NewBuffer(b) as w (see lattigo/utils/buffer/buffer.go).
+func (s ShamirSecretShareBatchQP) WriteTo(w io.Writer) (n int64, err error) {
+	return s.Value.WriteTo(w)
+}
+
+// ReadFrom reads on the object from an [io.Writer]. It implements the
+// [io.ReaderFrom] interface.
+//
+// Unless r implements the [buffer.Reader] interface (see see lattigo/utils/buffer/reader.go),
+// it will be wrapped into a [bufio.Reader]. Since this requires allocation, it
+// is preferable to pass a [buffer.Reader] directly:
+//
+//   - When reading multiple values from a [io.Reader], it is preferable to first
+//     first wrap [io.Reader] in a pre-allocated [bufio.Reader].
+//   - When reading from a var b []byte, it is preferable to pass a buffer.NewBuffer(b)
+//     as w (see lattigo/utils/buffer/buffer.go).
+func (s *ShamirSecretShareBatchQP) ReadFrom(r io.Reader) (n int64, err error) {
+	return s.Value.ReadFrom(r)
+}
+
+// MarshalBinary encodes the object into a binary form on a newly allocated slice of bytes.
+func (s ShamirSecretShareBatchQP) MarshalBinary() (p []byte, err error) {
+	return s.Value.MarshalBinary()
+}
+
+// UnmarshalBinary decodes a slice of bytes generated by
+// [ShamirSecretShareBatchQP.MarshalBinary] or [ShamirSecretShareBatchQP.WriteTo] on the object.
+func (s *ShamirSecretShareBatchQP) UnmarshalBinary(p []byte) (err error) {
+	return s.Value.UnmarshalBinary(p)
+}
@@ -0,0 +1,211 @@
+package multiparty
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/ring/ringqp"
+)
+
+// AccessStructureGroup describes one group of a hierarchical access structure:
+// a Threshold-out-of-len(Points) Shamir sharing among the parties identified
+// by Points.
+type AccessStructureGroup struct {
+	Threshold int
+	Points    []ShamirPublicPoint
+}
+
+// AccessStructure describes a compound threshold access structure as the
+// conjunction of per-group quorums: the secret can only be reconstructed by an
+// active set containing at least Groups[g].Threshold parties of every group g,
+// e.g. t1-out-of-N1 AND t2-out-of-N2 for two distinct organizations. It is
+// realized by additively splitting the secret into one sub-secret per group
+// and Shamir-sharing each sub-secret within its group (see
+// [Thresholdizer.GenHierarchicalShamirPolynomialsQP]).
+type AccessStructure struct {
+	Groups []AccessStructureGroup
+}
+
+// Validate checks that the access structure is well formed: at least one
+// group, every group threshold in [1, len(Points)], and no [ShamirPublicPoint]
+// appearing in two groups.
+func (a AccessStructure) Validate() error {
+
+	if len(a.Groups) == 0 {
+		return fmt.Errorf("invalid access structure: no groups")
+	}
+
+	seen := map[ShamirPublicPoint]bool{}
+	for g, group := range a.Groups {
+
+		if group.Threshold < 1 || group.Threshold > len(group.Points) {
+			return fmt.Errorf("invalid access structure: threshold of group %d should be in [1, %d] but is %d", g, len(group.Points), group.Threshold)
+		}
+
+		for _, point := range group.Points {
+			if seen[point] {
+				return fmt.Errorf("invalid access structure: point %d appears in two groups", point)
+			}
+			seen[point] = true
+		}
+	}
+
+	return nil
+}
+
+// GroupOf returns the index of the group the given point belongs to.
+func (a AccessStructure) GroupOf(point ShamirPublicPoint) (int, error) {
+	for g, group := range a.Groups {
+		for _, p := range group.Points {
+			if p == point {
+				return g, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("point %d is not part of the access structure", point)
+}
+
+// IsSatisfied returns whether the given active set satisfies the access
+// structure, i.e. whether every group has at least its threshold of active
+// members.
+func (a AccessStructure) IsSatisfied(actives []ShamirPublicPoint) bool {
+
+	activeSet := map[ShamirPublicPoint]bool{}
+	for _, active := range actives {
+		activeSet[active] = true
+	}
+
+	for _, group := range a.Groups {
+		n := 0
+		for _, p := range group.Points {
+			if activeSet[p] {
+				n++
+			}
+		}
+		if n < group.Threshold {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GenHierarchicalShamirPolynomialsQP additively splits the secret into one
+// sub-secret per group of the access structure and generates, for each group,
+// the [ShamirPolynomialQP] sharing its sub-secret with the group threshold.
+// The polynomial of group g is to be evaluated, with
+// [Thresholdizer.GenHierarchicalShamirSecretShareQP], at the points of the
+// members of group g only.
+func (thr Thresholdizer) GenHierarchicalShamirPolynomialsQP(structure AccessStructure, secret *rlwe.SecretKey) ([]ShamirPolynomialQP, error) {
+
+	if err := structure.Validate(); err != nil {
+		return nil, fmt.Errorf("cannot GenHierarchicalShamirPolynomialsQP: %w", err)
+	}
+
+	groups := len(structure.Groups)
+
+	// Additive split of the secret: the first groups-1 sub-secrets are
+	// uniform and the last one is the difference with the secret.
+	last := secret.Value.CopyNew()
+	subSecrets := make([]ringqp.Poly, groups)
+	for g := 0; g < groups-1; g++ {
+		subSecrets[g] = thr.ringQP.NewPoly()
+		thr.usamplerQP.Read(subSecrets[g])
+		thr.ringQP.Sub(*last, subSecrets[g], *last)
+	}
+	subSecrets[groups-1] = *last
+
+	polys := make([]ShamirPolynomialQP, groups)
+	for g := range polys {
+		var err error
+		if polys[g], err = thr.GenShamirPolynomialQP(structure.Groups[g].Threshold, &rlwe.SecretKey{Value: subSecrets[g]}); err != nil {
+			return nil, fmt.Errorf("cannot GenHierarchicalShamirPolynomialsQP: %w", err)
+		}
+	}
+
+	return polys, nil
+}
+
+// GenHierarchicalShamirSecretShareQP generates the secret share of the given
+// recipient by evaluating the polynomial of the recipient's group at its
+// point. The result is stored in shareOut and should be sent to this party.
+func (thr Thresholdizer) GenHierarchicalShamirSecretShareQP(recipient ShamirPublicPoint, structure AccessStructure, polys []ShamirPolynomialQP, shareOut *ShamirSecretShareQP) error {
+
+	g, err := structure.GroupOf(recipient)
+	if err != nil {
+		return fmt.Errorf("cannot GenHierarchicalShamirSecretShareQP: %w", err)
+	}
+
+	if g >= len(polys) {
+		return fmt.Errorf("cannot GenHierarchicalShamirSecretShareQP: no polynomial for group %d", g)
+	}
+
+	thr.GenShamirSecretShareQP(recipient, polys[g], shareOut)
+	return nil
+}
+
+// HierarchicalCombiner is a [Combiner] for hierarchical access structures: it
+// combines the instantiator's share within the quorum of its own group, after
+// having validated that the whole active set satisfies the access structure.
+// The sum of the additive shares of the per-group quorums reconstructs the
+// secret.
+type HierarchicalCombiner struct {
+	Combiner
+	structure AccessStructure
+	group     int
+}
+
+// NewHierarchicalCombiner creates a new [HierarchicalCombiner] for the party
+// identified by own, which must belong to one of the groups of the access
+// structure.
+func NewHierarchicalCombiner(params rlwe.Parameters, own ShamirPublicPoint, structure AccessStructure) (HierarchicalCombiner, error) {
+
+	if err := structure.Validate(); err != nil {
+		return HierarchicalCombiner{}, fmt.Errorf("cannot NewHierarchicalCombiner: %w", err)
+	}
+
+	g, err := structure.GroupOf(own)
+	if err != nil {
+		return HierarchicalCombiner{}, fmt.Errorf("cannot NewHierarchicalCombiner: %w", err)
+	}
+
+	cmb, err := NewCombiner(params, own, structure.Groups[g].Points, structure.Groups[g].Threshold)
+	if err != nil {
+		return HierarchicalCombiner{}, fmt.Errorf("cannot NewHierarchicalCombiner: %w", err)
+	}
+
+	return HierarchicalCombiner{Combiner: cmb, structure: structure, group: g}, nil
+}
+
+// GenAdditiveShareQP generates the additive share of the secret from the local
+// aggregated share ownShare and the set of active identities. It returns an
+// error if the active set does not satisfy the access structure or if the
+// instantiator is not part of the quorum of its group, formed by the first
+// threshold active members of the group in the order of the active set (on
+// which all the parties must agree).
+func (hcmb HierarchicalCombiner) GenAdditiveShareQP(actives []ShamirPublicPoint, ownPoint ShamirPublicPoint, ownShare ShamirSecretShareQP, skOut *rlwe.SecretKey) error {
+
+	if !hcmb.structure.IsSatisfied(actives) {
+		return fmt.Errorf("cannot GenAdditiveShareQP: the active set does not satisfy the access structure")
+	}
+
+	// Active members of the instantiator's own group, in the order of the
+	// active set.
+	groupActives := make([]ShamirPublicPoint, 0, len(actives))
+	for _, active := range actives {
+		if g, err := hcmb.structure.GroupOf(active); err == nil && g == hcmb.group {
+			groupActives = append(groupActives, active)
+		}
+	}
+
+	var inQuorum bool
+	for _, p := range groupActives[:hcmb.threshold] {
+		inQuorum = inQuorum || p == ownPoint
+	}
+
+	if !inQuorum {
+		return fmt.Errorf("cannot GenAdditiveShareQP: point %d is not part of the quorum of its group", ownPoint)
+	}
+
+	return hcmb.Combiner.GenAdditiveShareQP(groupActives, ownPoint, ownShare, skOut)
+}
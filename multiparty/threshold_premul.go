@@ -0,0 +1,348 @@
+package multiparty
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sort"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/utils/buffer"
+)
+
+// PremultipliedShareQP is the message sent by an active party in the
+// reduced-bandwidth variant of the threshold combination: the party
+// pre-multiplies its t-out-of-N share by its own Lagrange coefficient for the
+// declared active set, so that the aggregator only has to sum the received
+// shares instead of performing one RNS-scalar multiplication per party. The
+// ActiveSet digest binds the share to the active set it was generated for, and
+// is checked by the aggregator to detect parties disagreeing on the set.
+type PremultipliedShareQP struct {
+	ActiveSet [32]byte
+	Share     ShamirSecretShareQP
+}
+
+// PremultipliedShareQ is the RingQ variant of [PremultipliedShareQP], used for
+// the combination of [KeySwitchShare] threshold shares.
+type PremultipliedShareQ struct {
+	ActiveSet [32]byte
+	Share     KeySwitchShare
+}
+
+// AllocatePremultipliedShareQP allocates a party's [PremultipliedShareQP].
+func (cmb Combiner) AllocatePremultipliedShareQP() PremultipliedShareQP {
+	return PremultipliedShareQP{Share: ShamirSecretShareQP{cmb.ringQP.NewPoly()}}
+}
+
+// AllocatePremultipliedShareQ allocates a party's [PremultipliedShareQ].
+func (cmb Combiner) AllocatePremultipliedShareQ() PremultipliedShareQ {
+	return PremultipliedShareQ{Share: KeySwitchShare{cmb.ringQ.NewPoly()}}
+}
+
+// GenPremultipliedShareQP generates the party's Lagrange-premultiplied additive
+// share of the secret for the declared active set, identified by the
+// [ShamirPublicPoint] values of its members. Only the first threshold points of
+// activePoints are used, and all parties must declare them identically; the
+// share is tagged with a digest of the set so that the aggregator can enforce
+// the agreement. The instantiator's own point must be part of the used set.
+func (cmb Combiner) GenPremultipliedShareQP(activePoints []ShamirPublicPoint, ownPoint ShamirPublicPoint, ownShare ShamirSecretShareQP, shareOut *PremultipliedShareQP) (err error) {
+
+	if err = cmb.checkActiveSet(activePoints, ownPoint); err != nil {
+		return fmt.Errorf("cannot GenPremultipliedShareQP: %w", err)
+	}
+
+	prod := cmb.tmp2
+	copy(prod, cmb.one)
+
+	for _, active := range activePoints[:cmb.threshold] {
+		if active != ownPoint {
+			cmb.tmp1 = cmb.lagrangeCoeffs[active]
+			cmb.ringQP.MulRNSScalar(prod, cmb.tmp1, prod)
+		}
+	}
+
+	cmb.ringQP.MulRNSScalarMontgomery(ownShare.Poly, prod, shareOut.Share.Poly)
+	shareOut.ActiveSet = cmb.activeSetDigest(activePoints)
+
+	return
+}
+
+// GenPremultipliedShareQ is the RingQ variant of
+// [Combiner.GenPremultipliedShareQP], operating on [KeySwitchShare] threshold
+// shares.
+func (cmb Combiner) GenPremultipliedShareQ(activePoints []ShamirPublicPoint, ownPoint ShamirPublicPoint, ownShare KeySwitchShare, shareOut *PremultipliedShareQ) (err error) {
+
+	if err = cmb.checkActiveSet(activePoints, ownPoint); err != nil {
+		return fmt.Errorf("cannot GenPremultipliedShareQ: %w", err)
+	}
+
+	prod := cmb.tmp2
+	copy(prod, cmb.one)
+
+	for _, active := range activePoints[:cmb.threshold] {
+		if active != ownPoint {
+			cmb.tmp1 = cmb.lagrangeCoeffs[active]
+			cmb.ringQ.MulRNSScalar(prod, cmb.tmp1, prod)
+		}
+	}
+
+	cmb.ringQ.MulRNSScalarMontgomery(ownShare.Value, prod, shareOut.Share.Value)
+	shareOut.ActiveSet = cmb.activeSetDigest(activePoints)
+
+	return
+}
+
+// AggregatePremultipliedSharesQP sums the Lagrange-premultiplied shares of the
+// active parties into skOut. It first validates that exactly threshold shares
+// were received and that they all declare the same active set, returning an
+// error identifying the first share in disagreement otherwise.
+func (cmb Combiner) AggregatePremultipliedSharesQP(shares []PremultipliedShareQP, skOut *rlwe.SecretKey) (err error) {
+
+	if len(shares) != cmb.threshold {
+		return fmt.Errorf("cannot AggregatePremultipliedSharesQP: have %d shares but the threshold is %d", len(shares), cmb.threshold)
+	}
+
+	for i := range shares[1:] {
+		if !bytes.Equal(shares[i+1].ActiveSet[:], shares[0].ActiveSet[:]) {
+			return fmt.Errorf("cannot AggregatePremultipliedSharesQP: share %d declares a different active set", i+1)
+		}
+	}
+
+	skOut.Value.Copy(shares[0].Share.Poly)
+	for _, share := range shares[1:] {
+		cmb.ringQP.Add(skOut.Value, share.Share.Poly, skOut.Value)
+	}
+
+	return
+}
+
+// AggregatePremultipliedSharesQ is the RingQ variant of
+// [Combiner.AggregatePremultipliedSharesQP], summing [KeySwitchShare] threshold
+// shares into skOut.
+func (cmb Combiner) AggregatePremultipliedSharesQ(shares []PremultipliedShareQ, skOut *KeySwitchShare) (err error) {
+
+	if len(shares) != cmb.threshold {
+		return fmt.Errorf("cannot AggregatePremultipliedSharesQ: have %d shares but the threshold is %d", len(shares), cmb.threshold)
+	}
+
+	for i := range shares[1:] {
+		if !bytes.Equal(shares[i+1].ActiveSet[:], shares[0].ActiveSet[:]) {
+			return fmt.Errorf("cannot AggregatePremultipliedSharesQ: share %d declares a different active set", i+1)
+		}
+	}
+
+	skOut.Value.Copy(shares[0].Share.Value)
+	for _, share := range shares[1:] {
+		cmb.ringQ.Add(skOut.Value, share.Share.Value, skOut.Value)
+	}
+
+	return
+}
+
+// checkActiveSet validates the declared active set: it must contain at least
+// threshold points and the instantiator's own point must be among the first
+// threshold of them, since only those are used for the combination.
+func (cmb Combiner) checkActiveSet(activePoints []ShamirPublicPoint, ownPoint ShamirPublicPoint) error {
+
+	if len(activePoints) < cmb.threshold {
+		return fmt.Errorf("not enough active players to combine threshold shares")
+	}
+
+	for _, active := range activePoints[:cmb.threshold] {
+		if active == ownPoint {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("own point %d is not among the %d used active points", ownPoint, cmb.threshold)
+}
+
+// activeSetDigest returns an order-independent digest of the used active set,
+// i.e. the first threshold declared points.
+func (cmb Combiner) activeSetDigest(activePoints []ShamirPublicPoint) (digest [32]byte) {
+
+	used := make([]ShamirPublicPoint, cmb.threshold)
+	copy(used, activePoints[:cmb.threshold])
+	sort.Slice(used, func(i, j int) bool { return used[i] < used[j] })
+
+	buff := make([]byte, 8*len(used))
+	for i, point := range used {
+		binary.LittleEndian.PutUint64(buff[8*i:], uint64(point))
+	}
+
+	return blake2b.Sum256(buff)
+}
+
+// BinarySize returns the serialized size of the object in bytes.
+func (s PremultipliedShareQP) BinarySize() int {
+	return len(s.ActiveSet) + s.Share.BinarySize()
+}
+
+// WriteTo writes the object on an [io.Writer]. It implements the [io.WriterTo]
+// interface, and will write exactly object.BinarySize() bytes on w.
+//
+// Unless w implements the [buffer.Writer] interface (see lattigo/utils/buffer/writer.go),
+// it will be wrapped into a [bufio.Writer]. Since this requires allocations, it
+// is preferable to pass a [buffer.Writer] directly:
+//
+//   - When writing multiple times to a [io.Writer], it is preferable to first wrap the
+//     [io.Writer] in a pre-allocated [bufio.Writer].
+//   - When writing to a pre-allocated var b []byte, it is preferable to pass
+//     buffer.NewBuffer(b) as w (see lattigo/utils/buffer/buffer.go).
+func (s PremultipliedShareQP) WriteTo(w io.Writer) (n int64, err error) {
+	switch w := w.(type) {
+	case buffer.Writer:
+
+		var inc int64
+
+		if inc, err = buffer.Write(w, s.ActiveSet[:]); err != nil {
+			return n + inc, err
+		}
+		n += inc
+
+		if inc, err = s.Share.WriteTo(w); err != nil {
+			return n + inc, err
+		}
+		n += inc
+
+		return n, w.Flush()
+	default:
+		return s.WriteTo(bufio.NewWriter(w))
+	}
+}
+
+// ReadFrom reads on the object from an [io.Writer]. It implements the
+// [io.ReaderFrom] interface.
+//
+// Unless r implements the [buffer.Reader] interface (see see lattigo/utils/buffer/reader.go),
+// it will be wrapped into a [bufio.Reader]. Since this requires allocation, it
+// is preferable to pass a [buffer.Reader] directly:
+//
+//   - When reading multiple values from a [io.Reader], it is preferable to first
+//     first wrap [io.Reader] in a pre-allocated [bufio.Reader].
+//   - When reading from a var b []byte, it is preferable to pass a buffer.NewBuffer(b)
+//     as w (see lattigo/utils/buffer/buffer.go).
+func (s *PremultipliedShareQP) ReadFrom(r io.Reader) (n int64, err error) {
+	switch r := r.(type) {
+	case buffer.Reader:
+
+		var inc int64
+
+		if inc, err = buffer.Read(r, s.ActiveSet[:]); err != nil {
+			return n + inc, err
+		}
+		n += inc
+
+		if inc, err = s.Share.ReadFrom(r); err != nil {
+			return n + inc, err
+		}
+		n += inc
+
+		return
+	default:
+		return s.ReadFrom(bufio.NewReader(r))
+	}
+}
+
+// MarshalBinary encodes the object into a binary form on a newly allocated slice of bytes.
+func (s PremultipliedShareQP) MarshalBinary() (p []byte, err error) {
+	buf := buffer.NewBufferSize(s.BinarySize())
+	_, err = s.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary decodes a slice of bytes generated by
+// [PremultipliedShareQP.MarshalBinary] or [PremultipliedShareQP.WriteTo] on the object.
+func (s *PremultipliedShareQP) UnmarshalBinary(p []byte) (err error) {
+	_, err = s.ReadFrom(buffer.NewBuffer(p))
+	return
+}
+
+// BinarySize returns the serialized size of the object in bytes.
+func (s PremultipliedShareQ) BinarySize() int {
+	return len(s.ActiveSet) + s.Share.BinarySize()
+}
+
+// WriteTo writes the object on an [io.Writer]. It implements the [io.WriterTo]
+// interface, and will write exactly object.BinarySize() bytes on w.
+//
+// Unless w implements the [buffer.Writer] interface (see lattigo/utils/buffer/writer.go),
+// it will be wrapped into a [bufio.Writer]. Since this requires allocations, it
+// is preferable to pass a [buffer.Writer] directly:
+//
+//   - When writing multiple times to a [io.Writer], it is preferable to first wrap the
+//     [io.Writer] in a pre-allocated [bufio.Writer].
+//   - When writing to a pre-allocated var b []byte, it is preferable to pass
+//     buffer.NewBuffer(b) as w (see lattigo/utils/buffer/buffer.go).
+func (s PremultipliedShareQ) WriteTo(w io.Writer) (n int64, err error) {
+	switch w := w.(type) {
+	case buffer.Writer:
+
+		var inc int64
+
+		if inc, err = buffer.Write(w, s.ActiveSet[:]); err != nil {
+			return n + inc, err
+		}
+		n += inc
+
+		if inc, err = s.Share.WriteTo(w); err != nil {
+			return n + inc, err
+		}
+		n += inc
+
+		return n, w.Flush()
+	default:
+		return s.WriteTo(bufio.NewWriter(w))
+	}
+}
+
+// ReadFrom reads on the object from an [io.Writer]. It implements the
+// [io.ReaderFrom] interface.
+//
+// Unless r implements the [buffer.Reader] interface (see see lattigo/utils/buffer/reader.go),
+// it will be wrapped into a [bufio.Reader]. Since this requires allocation, it
+// is preferable to pass a [buffer.Reader] directly:
+//
+//   - When reading multiple values from a [io.Reader], it is preferable to first
+//     first wrap [io.Reader] in a pre-allocated [bufio.Reader].
+//   - When reading from a var b []byte, it is preferable to pass a buffer.NewBuffer(b)
+//     as w (see lattigo/utils/buffer/buffer.go).
+func (s *PremultipliedShareQ) ReadFrom(r io.Reader) (n int64, err error) {
+	switch r := r.(type) {
+	case buffer.Reader:
+
+		var inc int64
+
+		if inc, err = buffer.Read(r, s.ActiveSet[:]); err != nil {
+			return n + inc, err
+		}
+		n += inc
+
+		if inc, err = s.Share.ReadFrom(r); err != nil {
+			return n + inc, err
+		}
+		n += inc
+
+		return
+	default:
+		return s.ReadFrom(bufio.NewReader(r))
+	}
+}
+
+// MarshalBinary encodes the object into a binary form on a newly allocated slice of bytes.
+func (s PremultipliedShareQ) MarshalBinary() (p []byte, err error) {
+	buf := buffer.NewBufferSize(s.BinarySize())
+	_, err = s.WriteTo(buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary decodes a slice of bytes generated by
+// [PremultipliedShareQ.MarshalBinary] or [PremultipliedShareQ.WriteTo] on the object.
+func (s *PremultipliedShareQ) UnmarshalBinary(p []byte) (err error) {
+	_, err = s.ReadFrom(buffer.NewBuffer(p))
+	return
+}
@@ -0,0 +1,127 @@
+package multiparty
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+func TestCombinerShamirPointValidation(t *testing.T) {
+
+	params, err := rlwe.NewParametersFromLiteral(rlwe.ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{50, 40},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	// Small distinct points are invertible modulo every prime of Q and P.
+	points := []ShamirPublicPoint{1, 2, 3}
+	_, err = NewCombiner(params, points[0], points, 2)
+	require.NoError(t, err)
+
+	// A point congruent to another one modulo a prime of Q makes the Lagrange
+	// coefficient non-invertible modulo that prime.
+	q0 := params.RingQ().SubRings[0].Modulus
+	bad := ShamirPublicPoint(1 + q0)
+	_, err = NewCombiner(params, points[0], []ShamirPublicPoint{points[0], bad, points[2]}, 2)
+	require.Error(t, err)
+
+	var conflictErr *NonInvertibleShamirPointsError
+	require.True(t, errors.As(err, &conflictErr))
+	require.Len(t, conflictErr.Conflicts, 1)
+	require.Equal(t, points[0], conflictErr.Conflicts[0].This)
+	require.Equal(t, bad, conflictErr.Conflicts[0].That)
+	require.Equal(t, q0, conflictErr.Conflicts[0].Modulus)
+}
+
+func TestHierarchicalThreshold(t *testing.T) {
+
+	params, err := rlwe.NewParametersFromLiteral(rlwe.ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{50, 40},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	// 2-out-of-3 in the first group AND 2-out-of-3 in the second group.
+	structure := AccessStructure{Groups: []AccessStructureGroup{
+		{Threshold: 2, Points: []ShamirPublicPoint{1, 2, 3}},
+		{Threshold: 2, Points: []ShamirPublicPoint{4, 5, 6}},
+	}}
+	require.NoError(t, structure.Validate())
+
+	type Party struct {
+		Thresholdizer
+		HierarchicalCombiner
+		sk   *rlwe.SecretKey
+		tsks ShamirSecretShareQP
+		tpk  ShamirPublicPoint
+	}
+
+	kgen := rlwe.NewKeyGenerator(params)
+	ringQP := params.RingQP()
+	skIdeal := rlwe.NewSecretKey(params)
+
+	P := make([]*Party, 6)
+	for i := range P {
+		p := &Party{
+			Thresholdizer: NewThresholdizer(params),
+			sk:            kgen.GenSecretKeyNew(),
+			tpk:           ShamirPublicPoint(i + 1),
+		}
+		p.tsks = p.Thresholdizer.AllocateThresholdSecretShare()
+		p.HierarchicalCombiner, err = NewHierarchicalCombiner(params, p.tpk, structure)
+		require.NoError(t, err)
+		ringQP.Add(skIdeal.Value, p.sk.Value, skIdeal.Value)
+		P[i] = p
+	}
+
+	// Every party thresholdizes its secret under the access structure and
+	// distributes a share to every other party.
+	for _, pi := range P {
+		polys, err := pi.Thresholdizer.GenHierarchicalShamirPolynomialsQP(structure, pi.sk)
+		require.NoError(t, err)
+		for _, pj := range P {
+			share := pi.Thresholdizer.AllocateThresholdSecretShare()
+			require.NoError(t, pi.Thresholdizer.GenHierarchicalShamirSecretShareQP(pj.tpk, structure, polys, &share))
+			require.NoError(t, pj.Thresholdizer.AggregateShares(pj.tsks, share, &pj.tsks))
+		}
+	}
+
+	// An active set with a quorum in each group satisfies the structure, one
+	// without a quorum in the second group does not.
+	actives := []ShamirPublicPoint{1, 3, 4, 6}
+	require.True(t, structure.IsSatisfied(actives))
+	require.False(t, structure.IsSatisfied([]ShamirPublicPoint{1, 2, 3, 4}))
+
+	// The sum of the additive shares of the active parties reconstructs the
+	// ideal secret.
+	recSk := rlwe.NewSecretKey(params)
+	tsk := rlwe.NewSecretKey(params)
+	for _, i := range []int{0, 2, 3, 5} {
+		p := P[i]
+		require.NoError(t, p.HierarchicalCombiner.GenAdditiveShareQP(actives, p.tpk, p.tsks, tsk))
+		ringQP.Add(tsk.Value, recSk.Value, recSk.Value)
+	}
+	require.True(t, skIdeal.Equal(recSk))
+
+	// An active set not satisfying the structure is rejected.
+	require.Error(t, P[0].HierarchicalCombiner.GenAdditiveShareQP([]ShamirPublicPoint{1, 2, 3, 4}, P[0].tpk, P[0].tsks, tsk))
+
+	// A party outside of the quorum of its group is rejected.
+	require.Error(t, P[1].HierarchicalCombiner.GenAdditiveShareQP(actives, P[1].tpk, P[1].tsks, tsk))
+
+	// Malformed structures and unknown points are rejected.
+	require.Error(t, AccessStructure{}.Validate())
+	require.Error(t, AccessStructure{Groups: []AccessStructureGroup{{Threshold: 2, Points: []ShamirPublicPoint{1}}}}.Validate())
+	require.Error(t, AccessStructure{Groups: []AccessStructureGroup{
+		{Threshold: 1, Points: []ShamirPublicPoint{1}},
+		{Threshold: 1, Points: []ShamirPublicPoint{1}},
+	}}.Validate())
+	_, err = NewHierarchicalCombiner(params, ShamirPublicPoint(9), structure)
+	require.Error(t, err)
+}
@@ -0,0 +1,141 @@
+package multiparty
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/ring"
+)
+
+// TwoPartyProtocol bundles the collective public key, relinearization key and
+// key-switch protocols specialized for the very common semi-honest two-party
+// deployment. Compared to the generic N-party path:
+//
+//   - the CRP ceremony is reduced to the exchange of a single seed, from which
+//     all the protocol CRPs are derived via a [CRSManager];
+//   - the public key share and the first relinearization key share are
+//     generated and exchanged together, collapsing the whole setup into two
+//     messages per party;
+//   - the key-switch skips the share aggregation: with two parties, the single
+//     share received from the peer is the combined share.
+type TwoPartyProtocol struct {
+	params rlwe.Parameters
+	ckg    PublicKeyGenProtocol
+	rkg    RelinearizationKeyGenProtocol
+	cks    KeySwitchProtocol
+
+	ckgCRP PublicKeyGenCRP
+	rkgCRP RelinearizationKeyGenCRP
+
+	zero *rlwe.SecretKey
+}
+
+// TwoPartySetupRoundOneShare fuses the public key share and the first
+// relinearization key share into the single first setup message of a party.
+type TwoPartySetupRoundOneShare struct {
+	PublicKey PublicKeyGenShare
+	Relin     RelinearizationKeyGenShare
+}
+
+// TwoPartySetupRoundTwoShare is the second (and last) setup message of a
+// party, holding its second relinearization key share.
+type TwoPartySetupRoundTwoShare struct {
+	Relin RelinearizationKeyGenShare
+}
+
+// NewTwoPartyProtocol instantiates a new [TwoPartyProtocol] from the shared
+// seed and the noise flooding distribution of the key-switch protocol. Both
+// parties must instantiate the protocol from the same seed, as all the CRPs
+// are derived from it.
+func NewTwoPartyProtocol(params rlwe.ParameterProvider, seed []byte, noiseFlooding ring.DistributionParameters) (*TwoPartyProtocol, error) {
+
+	p := &TwoPartyProtocol{
+		params: *params.GetRLWEParameters(),
+		ckg:    NewPublicKeyGenProtocol(params),
+		rkg:    NewRelinearizationKeyGenProtocol(params),
+		zero:   rlwe.NewSecretKey(params),
+	}
+
+	var err error
+	if p.cks, err = NewKeySwitchProtocol(params, noiseFlooding); err != nil {
+		return nil, fmt.Errorf("cannot NewTwoPartyProtocol: %w", err)
+	}
+
+	crs := NewCRSManager(seed)
+
+	ckgCRS, err := crs.Get("two-party", "ckg")
+	if err != nil {
+		return nil, fmt.Errorf("cannot NewTwoPartyProtocol: %w", err)
+	}
+	p.ckgCRP = p.ckg.SampleCRP(ckgCRS)
+
+	rkgCRS, err := crs.Get("two-party", "rkg")
+	if err != nil {
+		return nil, fmt.Errorf("cannot NewTwoPartyProtocol: %w", err)
+	}
+	p.rkgCRP = p.rkg.SampleCRP(rkgCRS)
+
+	return p, nil
+}
+
+// GenSetupShareRoundOne generates the party's first setup message from its
+// secret key: its public key share and its first relinearization key share.
+// The returned ephemeral secret key must be kept for
+// [TwoPartyProtocol.GenSetupShareRoundTwo].
+func (p *TwoPartyProtocol) GenSetupShareRoundOne(sk *rlwe.SecretKey) (ephSk *rlwe.SecretKey, share TwoPartySetupRoundOneShare) {
+
+	share.PublicKey = p.ckg.AllocateShare()
+	p.ckg.GenShare(sk, p.ckgCRP, &share.PublicKey)
+
+	var ephSkOut *rlwe.SecretKey
+	ephSkOut, share.Relin, _ = p.rkg.AllocateShare()
+	p.rkg.GenShareRoundOne(sk, p.rkgCRP, ephSkOut, &share.Relin)
+
+	return ephSkOut, share
+}
+
+// GenSetupShareRoundTwo generates the party's second setup message from its
+// secret keys and the two first-round messages.
+func (p *TwoPartyProtocol) GenSetupShareRoundTwo(ephSk, sk *rlwe.SecretKey, own, other TwoPartySetupRoundOneShare) (share TwoPartySetupRoundTwoShare) {
+
+	_, round1, round2 := p.rkg.AllocateShare()
+	p.rkg.AggregateShares(own.Relin, other.Relin, &round1)
+	p.rkg.GenShareRoundTwo(ephSk, sk, round1, &round2)
+
+	share.Relin = round2
+	return
+}
+
+// Finalize returns the collective public key and relinearization key from the
+// two messages of each setup round.
+func (p *TwoPartyProtocol) Finalize(own1, other1 TwoPartySetupRoundOneShare, own2, other2 TwoPartySetupRoundTwoShare) (*rlwe.PublicKey, *rlwe.RelinearizationKey) {
+
+	pkAgg := p.ckg.AllocateShare()
+	p.ckg.AggregateShares(own1.PublicKey, other1.PublicKey, &pkAgg)
+	pk := rlwe.NewPublicKey(p.params)
+	p.ckg.GenPublicKey(pkAgg, p.ckgCRP, pk)
+
+	_, round1, round2 := p.rkg.AllocateShare()
+	p.rkg.AggregateShares(own1.Relin, other1.Relin, &round1)
+	p.rkg.AggregateShares(own2.Relin, other2.Relin, &round2)
+	rlk := rlwe.NewRelinearizationKey(p.params)
+	p.rkg.GenRelinearizationKey(round1, round2, rlk)
+
+	return pk, rlk
+}
+
+// GenKeySwitchShare generates the party's key-switch share moving its secret
+// key component of ct to zero. Sent to the peer, the share allows the peer to
+// decrypt ct with its own secret key alone (see [TwoPartyProtocol.KeySwitch]).
+func (p *TwoPartyProtocol) GenKeySwitchShare(sk *rlwe.SecretKey, ct *rlwe.Ciphertext) KeySwitchShare {
+	share := p.cks.AllocateShare(ct.Level())
+	p.cks.GenShare(sk, p.zero, ct, &share)
+	return share
+}
+
+// KeySwitch applies the peer's key-switch share to ct: with two parties, the
+// single received share is already the combined share and no aggregation is
+// needed. The result is decryptable under the receiver's secret key alone.
+func (p *TwoPartyProtocol) KeySwitch(ct *rlwe.Ciphertext, other KeySwitchShare, opOut *rlwe.Ciphertext) error {
+	return p.cks.KeySwitch(ct, other, opOut)
+}
@@ -0,0 +1,137 @@
+package multiparty
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/ring"
+)
+
+var testTwoPartyNoiseFlooding = ring.DiscreteGaussian{Sigma: 1 << 16, Bound: 6 * (1 << 16)}
+
+func TestTwoPartyProtocol(t *testing.T) {
+
+	params, err := rlwe.NewParametersFromLiteral(rlwe.ParametersLiteral{
+		LogN: 10,
+		LogQ: []int{50, 40},
+		LogP: []int{60},
+	})
+	require.NoError(t, err)
+
+	kgen := rlwe.NewKeyGenerator(params)
+	skA := kgen.GenSecretKeyNew()
+	skB := kgen.GenSecretKeyNew()
+
+	skIdeal := rlwe.NewSecretKey(params)
+	params.RingQP().Add(skA.Value, skB.Value, skIdeal.Value)
+
+	seed := []byte("two-party test seed")
+
+	pA, err := NewTwoPartyProtocol(params, seed, testTwoPartyNoiseFlooding)
+	require.NoError(t, err)
+	pB, err := NewTwoPartyProtocol(params, seed, testTwoPartyNoiseFlooding)
+	require.NoError(t, err)
+
+	// Setup: one message per party and per round
+	ephA, round1A := pA.GenSetupShareRoundOne(skA)
+	ephB, round1B := pB.GenSetupShareRoundOne(skB)
+
+	round2A := pA.GenSetupShareRoundTwo(ephA, skA, round1A, round1B)
+	round2B := pB.GenSetupShareRoundTwo(ephB, skB, round1B, round1A)
+
+	pk, rlk := pA.Finalize(round1A, round1B, round2A, round2B)
+
+	// Both parties finalize to the same keys
+	pkB, rlkB := pB.Finalize(round1B, round1A, round2B, round2A)
+	require.True(t, pk.Equal(pkB))
+	require.True(t, rlk.Equal(&rlkB.EvaluationKey))
+
+	t.Run("PublicKey", func(t *testing.T) {
+		require.Less(t, rlwe.NoisePublicKey(pk, skIdeal, params), math.Log2(math.Sqrt(2)*params.NoiseFreshSK())+1)
+	})
+
+	t.Run("RelinearizationKey", func(t *testing.T) {
+		decompSize := params.BaseRNSDecompositionVectorSize(params.MaxLevelQ(), params.MaxLevelP())
+		noiseBound := math.Log2(math.Sqrt(float64(decompSize))*NoiseRelinearizationKey(params, 2)) + 1
+		require.GreaterOrEqual(t, noiseBound, rlwe.NoiseRelinearizationKey(rlk, skIdeal, params))
+	})
+
+	t.Run("KeySwitch", func(t *testing.T) {
+
+		pt := rlwe.NewPlaintext(params, params.MaxLevel())
+		ct, err := rlwe.NewEncryptor(params, pk).EncryptNew(pt)
+		require.NoError(t, err)
+
+		// B sends its share to A, who can then decrypt alone
+		share := pB.GenKeySwitchShare(skB, ct)
+
+		opOut := rlwe.NewCiphertext(params, 1, ct.Level())
+		require.NoError(t, pA.KeySwitch(ct, share, opOut))
+
+		_, _, max := rlwe.Norm(opOut, rlwe.NewDecryptor(params, skA))
+		require.Less(t, max, 25.0)
+	})
+}
+
+func BenchmarkTwoPartyKeySwitch(b *testing.B) {
+
+	params, err := rlwe.NewParametersFromLiteral(rlwe.ParametersLiteral{
+		LogN: 13,
+		LogQ: []int{50, 40, 40},
+		LogP: []int{60},
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	kgen := rlwe.NewKeyGenerator(params)
+	skA := kgen.GenSecretKeyNew()
+	skB := kgen.GenSecretKeyNew()
+
+	p, err := NewTwoPartyProtocol(params, []byte("benchmark seed"), testTwoPartyNoiseFlooding)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	ct := rlwe.NewCiphertext(params, 1, params.MaxLevel())
+
+	// Critical path of the receiver: apply the single received share
+	b.Run("TwoParty", func(b *testing.B) {
+		share := p.GenKeySwitchShare(skB, ct)
+		opOut := rlwe.NewCiphertext(params, 1, ct.Level())
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := p.KeySwitch(ct, share, opOut); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// Critical path of the receiver on the generic N-party path: generate its
+	// own share, aggregate it with the received one, then key-switch
+	b.Run("Generic", func(b *testing.B) {
+		cks, err := NewKeySwitchProtocol(params, testTwoPartyNoiseFlooding)
+		if err != nil {
+			b.Fatal(err)
+		}
+		zero := rlwe.NewSecretKey(params)
+		shareB := cks.AllocateShare(ct.Level())
+		cks.GenShare(skB, zero, ct, &shareB)
+		shareA := cks.AllocateShare(ct.Level())
+		agg := cks.AllocateShare(ct.Level())
+		opOut := rlwe.NewCiphertext(params, 1, ct.Level())
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			cks.GenShare(skA, zero, ct, &shareA)
+			if err := cks.AggregateShares(shareA, shareB, &agg); err != nil {
+				b.Fatal(err)
+			}
+			if err := cks.KeySwitch(ct, agg, opOut); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package ring
+
+import (
+	"math"
+	"os"
+	"time"
+)
+
+// AutotuneEnvVar is the environment variable enabling the one-time runtime
+// selection of the compute kernels. When set to "1", the package times the
+// pure-Go and the assembly kernels of each operation on the actual host at
+// initialization and keeps the fastest one per operation. Static dispatch on
+// the CPU features alone can be suboptimal, e.g. on CPUs where executing
+// AVX-512 instructions triggers frequency downclocking; the microbenchmark
+// takes such effects into account. When unset, the static dispatch on the CPU
+// features is used.
+const AutotuneEnvVar = "LATTIGO_AUTOTUNE"
+
+// Per-operation kernel selection, defaulting to the static dispatch on the
+// CPU features (see hasVecKernels) and refined by autotuneKernels when
+// [AutotuneEnvVar] is set.
+var (
+	useVecNTT     = hasVecKernels
+	useVecINTT    = hasVecKernels
+	useVecMulMont = hasVecKernels
+)
+
+func init() {
+	if hasVecKernels && os.Getenv(AutotuneEnvVar) == "1" {
+		autotuneKernels()
+	}
+}
+
+// autotuneKernels times the pure-Go and the assembly kernels of the NTT, the
+// INTT and the Montgomery multiplication on the current host and selects, for
+// each operation, the fastest of the two. The benchmark operates on a single
+// 2^{12} degree sub-ring, which is large enough to exercise the vector loops,
+// and runs only once at package initialization.
+func autotuneKernels() {
+
+	const (
+		N = 1 << 12
+		Q = 0x1fffffffffe00001 // 61-bit NTT-friendly prime, 1 mod 2^{21}
+	)
+
+	s, err := NewSubRing(N, Q)
+	if err != nil {
+		return
+	}
+
+	if err = s.generateNTTConstants(); err != nil {
+		return
+	}
+
+	p1 := make([]uint64, N)
+	p2 := make([]uint64, N)
+	for i := range p1 {
+		p1[i] = uint64(i) % Q
+		p2[i] = uint64(i+1) % Q
+	}
+	p3 := make([]uint64, N)
+
+	useVecNTT = benchKernel(func() { nttLazyVec(p1, p3, N, Q, s.MRedConstant, s.RootsForward) }) <
+		benchKernel(func() { nttUnrolled16Lazy(p1, p3, N, Q, s.MRedConstant, s.RootsForward) })
+
+	useVecINTT = benchKernel(func() { inttLazyVec(p1, p3, N, Q, s.MRedConstant, s.RootsBackward) }) <
+		benchKernel(func() { inttLazyUnrolled16(p1, p3, N, Q, s.MRedConstant, s.RootsBackward) })
+
+	// mulcoeffsmontgomeryvec dispatches on useVecMulMont, so both paths are
+	// timed by toggling the flag; the init is single-threaded.
+	useVecMulMont = true
+	vec := benchKernel(func() { mulcoeffsmontgomeryvec(p1, p2, p3, Q, s.MRedConstant) })
+	useVecMulMont = false
+	useVecMulMont = vec < benchKernel(func() { mulcoeffsmontgomeryvec(p1, p2, p3, Q, s.MRedConstant) })
+}
+
+// benchKernel returns the fastest of a few timed batches of f, after a
+// warm-up call. Taking the minimum filters out scheduling noise.
+func benchKernel(f func()) (best time.Duration) {
+
+	f()
+
+	best = time.Duration(math.MaxInt64)
+	for r := 0; r < 3; r++ {
+		start := time.Now()
+		for i := 0; i < 16; i++ {
+			f()
+		}
+		if d := time.Since(start); d < best {
+			best = d
+		}
+	}
+
+	return
+}
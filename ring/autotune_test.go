@@ -0,0 +1,51 @@
+//go:build (amd64 || arm64) && !purego
+
+package ring
+
+import (
+	"testing"
+)
+
+// TestAutotuneKernels checks that the init-time microbenchmark runs and
+// leaves the package in a consistent state whatever its decisions.
+func TestAutotuneKernels(t *testing.T) {
+
+	if !hasVecKernels {
+		t.Skip("assembly kernels not available on this CPU")
+	}
+
+	nttPrev, inttPrev, mulPrev := useVecNTT, useVecINTT, useVecMulMont
+	defer func() {
+		useVecNTT, useVecINTT, useVecMulMont = nttPrev, inttPrev, mulPrev
+	}()
+
+	autotuneKernels()
+
+	t.Logf("useVecNTT=%v useVecINTT=%v useVecMulMont=%v", useVecNTT, useVecINTT, useVecMulMont)
+
+	// The transforms must remain correct under both kernel selections.
+	r, err := NewRing(1<<12, Qi60[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := r.NewPoly()
+	for i := range p.Coeffs[0] {
+		p.Coeffs[0][i] = uint64(i) % r.SubRings[0].Modulus
+	}
+
+	want := p.CopyNew()
+
+	for _, vec := range []bool{false, true} {
+
+		useVecNTT, useVecINTT = vec, vec
+
+		have := p.CopyNew()
+		r.NTT(*have, *have)
+		r.INTT(*have, *have)
+
+		if !have.Equal(want) {
+			t.Fatalf("NTT/INTT roundtrip mismatch with useVecNTT=%v", vec)
+		}
+	}
+}
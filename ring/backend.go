@@ -0,0 +1,61 @@
+package ring
+
+// Backend dispatches the compute-heavy ring operations to an alternative
+// implementation, typically CUDA/OpenCL kernels provided by an external
+// module. A backend may implement only a subset of the operations: each
+// method returns true if it handled the operation and false to fall back to
+// the CPU path, so e.g. a backend can offload only large-degree transforms.
+// Implementations must be safe for concurrent use, as a single backend is
+// shared by all the shallow copies of the rings it is attached to.
+//
+// A backend is attached to a ring with [Ring.WithBackend] and to an
+// evaluator at construction with the WithBackend method of the rlwe
+// Evaluator, which covers the NTTs, coefficient-wise multiplications and
+// base extensions dominating the cost of key-switching.
+type Backend interface {
+
+	// NTT evaluates p2 = NTT(p1) over the given SubRing. If lazy, the output
+	// is allowed to be in the range [0, 2*modulus-1] instead of [0, modulus-1].
+	NTT(s *SubRing, p1, p2 []uint64, lazy bool) (handled bool)
+
+	// INTT evaluates p2 = INTT(p1) over the given SubRing. If lazy, the output
+	// is allowed to be in the range [0, 2*modulus-1] instead of [0, modulus-1].
+	INTT(s *SubRing, p1, p2 []uint64, lazy bool) (handled bool)
+
+	// MulCoeffsMontgomery evaluates p3 = p1*p2 coefficient-wise with Montgomery
+	// reduction over the given SubRing. If lazy, the output is allowed to be in
+	// the range [0, 2*modulus-1] instead of [0, modulus-1].
+	MulCoeffsMontgomery(s *SubRing, p1, p2, p3 []uint64, lazy bool) (handled bool)
+
+	// ModUp extends the RNS basis of pFrom, with coefficients in the basis of
+	// rFrom at level levelFrom, to pTo in the basis of rTo at level levelTo.
+	// The extension is centered: the coefficients are mapped to their
+	// representative in [-modulus/2, modulus/2) before the extension. Both
+	// rings are given already set at the respective levels.
+	ModUp(rFrom, rTo *Ring, levelFrom, levelTo int, pFrom, pTo Poly) (handled bool)
+}
+
+// WithBackend returns a shallow copy of the receiver ring in which the
+// operations supported by the provided backend are dispatched to it, the CPU
+// path being used for the operations the backend does not handle. The
+// SubRings are value copies sharing the precomputed constants; the receiver
+// is left unmodified.
+func (r Ring) WithBackend(b Backend) *Ring {
+	subRings := make([]*SubRing, len(r.SubRings))
+	for i, s := range r.SubRings {
+		sCpy := *s
+		sCpy.backend = b
+		subRings[i] = &sCpy
+	}
+	r.SubRings = subRings
+	return &r
+}
+
+// Backend returns the backend attached to the receiver ring, or nil if the
+// ring dispatches all its operations to the CPU path.
+func (r Ring) Backend() Backend {
+	if len(r.SubRings) == 0 {
+		return nil
+	}
+	return r.SubRings[0].backend
+}
@@ -0,0 +1,133 @@
+package ring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+// testBackend delegates the operations it handles to the CPU reference
+// implementations, counting the dispatches.
+type testBackend struct {
+	handleNTT, handleMul, handleModUp bool
+	ntt, intt, mul, modUp             int
+}
+
+func (b *testBackend) NTT(s *SubRing, p1, p2 []uint64, lazy bool) bool {
+	if !b.handleNTT {
+		return false
+	}
+	b.ntt++
+	if lazy {
+		s.ntt.ForwardLazy(p1, p2)
+	} else {
+		s.ntt.Forward(p1, p2)
+	}
+	return true
+}
+
+func (b *testBackend) INTT(s *SubRing, p1, p2 []uint64, lazy bool) bool {
+	if !b.handleNTT {
+		return false
+	}
+	b.intt++
+	if lazy {
+		s.ntt.BackwardLazy(p1, p2)
+	} else {
+		s.ntt.Backward(p1, p2)
+	}
+	return true
+}
+
+func (b *testBackend) MulCoeffsMontgomery(s *SubRing, p1, p2, p3 []uint64, lazy bool) bool {
+	if !b.handleMul {
+		return false
+	}
+	b.mul++
+	if lazy {
+		mulcoeffsmontgomerylazyvec(p1, p2, p3, s.Modulus, s.MRedConstant)
+	} else {
+		mulcoeffsmontgomeryvec(p1, p2, p3, s.Modulus, s.MRedConstant)
+	}
+	return true
+}
+
+func (b *testBackend) ModUp(rFrom, rTo *Ring, levelFrom, levelTo int, pFrom, pTo Poly) bool {
+	if !b.handleModUp {
+		return false
+	}
+	b.modUp++
+	return false // exercises the fallback of a partially handled dispatch
+}
+
+func TestBackend(t *testing.T) {
+
+	prng, err := sampling.NewPRNG()
+	require.NoError(t, err)
+
+	r, err := NewRing(128, Qi60[:2])
+	require.NoError(t, err)
+
+	backend := &testBackend{handleNTT: true, handleMul: true, handleModUp: true}
+	rb := r.WithBackend(backend)
+
+	require.Nil(t, r.Backend())
+	require.NotNil(t, rb.Backend())
+
+	sampler := NewUniformSampler(prng, r)
+	p1 := sampler.ReadNew()
+	p2 := sampler.ReadNew()
+
+	t.Run("NTT", func(t *testing.T) {
+		want := r.NewPoly()
+		have := rb.NewPoly()
+		r.NTT(p1, want)
+		rb.NTT(p1, have)
+		require.True(t, want.Equal(&have))
+		r.INTT(want, want)
+		rb.INTT(have, have)
+		require.True(t, want.Equal(&have))
+		require.Equal(t, 2, backend.ntt)
+		require.Equal(t, 2, backend.intt)
+	})
+
+	t.Run("MulCoeffsMontgomery", func(t *testing.T) {
+		want := r.NewPoly()
+		have := rb.NewPoly()
+		r.MulCoeffsMontgomery(p1, p2, want)
+		rb.MulCoeffsMontgomery(p1, p2, have)
+		require.True(t, want.Equal(&have))
+		require.Equal(t, 2, backend.mul)
+	})
+
+	t.Run("ModUpFallback", func(t *testing.T) {
+
+		rP, err := NewRing(128, Pi60[:2])
+		require.NoError(t, err)
+
+		want := rP.NewPoly()
+		have := rP.NewPoly()
+
+		// The reference extension, with no backend attached.
+		NewBasisExtender(r, rP).ModUpQtoP(r.level, rP.level, p1, want)
+
+		// The backend is consulted but declines, so the CPU path must
+		// produce the same result.
+		NewBasisExtender(rb, rP).ModUpQtoP(r.level, rP.level, p1, have)
+
+		require.True(t, want.Equal(&have))
+		require.Equal(t, 1, backend.modUp)
+	})
+
+	t.Run("Unhandled", func(t *testing.T) {
+		backend.handleNTT = false
+		want := r.NewPoly()
+		have := rb.NewPoly()
+		r.NTT(p1, want)
+		rb.NTT(p1, have)
+		require.True(t, want.Equal(&have))
+		require.Equal(t, 2, backend.ntt)
+	})
+}
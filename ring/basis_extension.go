@@ -1,6 +1,7 @@
 package ring
 
 import (
+	"fmt"
 	"math"
 	"math/bits"
 	"unsafe"
@@ -8,6 +9,31 @@ import (
 	"github.com/tuneinsight/lattigo/v6/utils/bignum"
 )
 
+// BasisExtensionAlgorithm is a type for the algorithms performing the fast
+// RNS basis extension of a [BasisExtender].
+type BasisExtensionAlgorithm int
+
+const (
+	// HPS is the floating-point-corrected basis extension of
+	// https://eprint.iacr.org/2018/117.pdf. The extension is exact: the
+	// overflow term v*Q of the fast conversion is recovered from a float64
+	// accumulation of the normalized residues. It is the default algorithm.
+	HPS = BasisExtensionAlgorithm(0)
+	// BEHZ is the integer-only basis extension of
+	// https://eprint.iacr.org/2016/510.pdf. The output is only congruent to
+	// the input up to an additive multiple v*Q of the input modulus, with
+	// 0 <= v <= #moduli, which the caller must be able to absorb (as the
+	// BFV multiplication pipeline of BEHZ does).
+	BEHZ = BasisExtensionAlgorithm(1)
+)
+
+// maxHPSBasisSize is the maximum number of moduli of the input basis for the
+// HPS basis extension: the float64 reconstruction of the overflow term
+// accumulates one rounding error of at most 2^-52 per modulus, and k <= 2^20
+// keeps the total error (hence the per-coefficient failure probability for
+// uniform inputs) below 2^-32.
+const maxHPSBasisSize = 1 << 20
+
 // BasisExtender stores the necessary parameters for RNS basis extension.
 // The used algorithm is from https://eprint.iacr.org/2018/117.pdf.
 type BasisExtender struct {
@@ -18,6 +44,8 @@ type BasisExtender struct {
 	modDownConstantsPtoQ [][]uint64
 	modDownConstantsQtoP [][]uint64
 
+	algorithm BasisExtensionAlgorithm
+
 	buffQ Poly
 	buffP Poly
 }
@@ -49,10 +77,37 @@ func genmodDownConstants(ringQ, ringP *Ring) (constants [][]uint64) {
 }
 
 // NewBasisExtender creates a new BasisExtender, enabling RNS basis extension from Q to P and P to Q.
+// The exact [HPS] algorithm is used; see [NewBasisExtenderWithAlgorithm] to select the algorithm.
 func NewBasisExtender(ringQ, ringP *Ring) (be *BasisExtender) {
+	be, err := NewBasisExtenderWithAlgorithm(ringQ, ringP, HPS)
+	if err != nil {
+		// Cannot happen: the HPS basis-size condition is far above what
+		// NewRing accepts.
+		panic(err)
+	}
+	return
+}
+
+// NewBasisExtenderWithAlgorithm creates a new BasisExtender, enabling RNS basis extension
+// from Q to P and P to Q with the given [BasisExtensionAlgorithm].
+// For [HPS], the correctness condition on the number of moduli is checked and an
+// error is returned if it is not met.
+func NewBasisExtenderWithAlgorithm(ringQ, ringP *Ring, algorithm BasisExtensionAlgorithm) (be *BasisExtender, err error) {
+
+	switch algorithm {
+	case HPS:
+		if ringQ.ModuliChainLength() > maxHPSBasisSize || ringP.ModuliChainLength() > maxHPSBasisSize {
+			return nil, fmt.Errorf("invalid rings: HPS basis extension requires at most %d moduli per basis", maxHPSBasisSize)
+		}
+	case BEHZ:
+	default:
+		return nil, fmt.Errorf("invalid algorithm: must be HPS or BEHZ but is %d", algorithm)
+	}
 
 	be = new(BasisExtender)
 
+	be.algorithm = algorithm
+
 	be.ringQ = ringQ
 	be.ringP = ringP
 
@@ -75,7 +130,7 @@ func NewBasisExtender(ringQ, ringP *Ring) (be *BasisExtender) {
 	be.buffQ = ringQ.NewPoly()
 	be.buffP = ringP.NewPoly()
 
-	return
+	return be, nil
 }
 
 // ModUpConstants stores the necessary parameters for RNS basis extension.
@@ -176,6 +231,8 @@ func (be *BasisExtender) ShallowCopy() *BasisExtender {
 		modDownConstantsQtoP: be.modDownConstantsQtoP,
 		modDownConstantsPtoQ: be.modDownConstantsPtoQ,
 
+		algorithm: be.algorithm,
+
 		buffQ: be.ringQ.NewPoly(),
 		buffP: be.ringP.NewPoly(),
 	}
@@ -183,11 +240,24 @@ func (be *BasisExtender) ShallowCopy() *BasisExtender {
 
 // ModUpQtoP extends the RNS basis of a polynomial from Q to QP.
 // Given a polynomial with coefficients in basis {Q0,Q1....Qlevel},
-// it extends its basis from {Q0,Q1....Qlevel} to {Q0,Q1....Qlevel,P0,P1...Pj}
+// it extends its basis from {Q0,Q1....Qlevel} to {Q0,Q1....Qlevel,P0,P1...Pj}.
+// With the [BEHZ] algorithm, the output is only congruent to the positive
+// representative of the input up to an additive v*Q with 0 <= v <= levelQ+1.
 func (be *BasisExtender) ModUpQtoP(levelQ, levelP int, polQ, polP Poly) {
 
 	ringQ := be.ringQ.AtLevel(levelQ)
 	ringP := be.ringP.AtLevel(levelP)
+
+	if b := ringQ.Backend(); b != nil && b.ModUp(ringQ, ringP, levelQ, levelP, polQ, polP) {
+		return
+	}
+
+	if be.algorithm == BEHZ {
+		ModUpBEHZ(polQ.Coeffs[:levelQ+1], polP.Coeffs[:levelP+1], be.ringQ, be.ringP, be.constantsQtoP[levelQ])
+		ringP.Reduce(polP, polP)
+		return
+	}
+
 	buffQ := be.buffQ
 
 	QHalf := bignum.NewInt(ringQ.ModulusAtLevel[levelQ])
@@ -200,11 +270,24 @@ func (be *BasisExtender) ModUpQtoP(levelQ, levelP int, polQ, polP Poly) {
 
 // ModUpPtoQ extends the RNS basis of a polynomial from P to PQ.
 // Given a polynomial with coefficients in basis {P0,P1....Plevel},
-// it extends its basis from {P0,P1....Plevel} to {Q0,Q1...Qj}
+// it extends its basis from {P0,P1....Plevel} to {Q0,Q1...Qj}.
+// With the [BEHZ] algorithm, the output is only congruent to the positive
+// representative of the input up to an additive v*P with 0 <= v <= levelP+1.
 func (be *BasisExtender) ModUpPtoQ(levelP, levelQ int, polP, polQ Poly) {
 
 	ringQ := be.ringQ.AtLevel(levelQ)
 	ringP := be.ringP.AtLevel(levelP)
+
+	if b := ringP.Backend(); b != nil && b.ModUp(ringP, ringQ, levelP, levelQ, polP, polQ) {
+		return
+	}
+
+	if be.algorithm == BEHZ {
+		ModUpBEHZ(polP.Coeffs[:levelP+1], polQ.Coeffs[:levelQ+1], be.ringP, be.ringQ, be.constantsPtoQ[levelP])
+		ringQ.Reduce(polQ, polQ)
+		return
+	}
+
 	buffP := be.buffP
 
 	PHalf := bignum.NewInt(ringP.ModulusAtLevel[levelP])
@@ -306,6 +389,41 @@ func ModUpExact(p1, p2 [][]uint64, ringQ, ringP *Ring, MUC ModUpConstants) {
 	}
 }
 
+// ModUpBEHZ takes p1 mod Q and switches its basis to P with the integer-only
+// fast conversion of https://eprint.iacr.org/2016/510.pdf, returning the result
+// on p2. The result is congruent to x + v*Q for x the positive representative
+// of the input and some 0 <= v <= len(p1), i.e. the overflow term of the fast
+// conversion is not corrected.
+// Caution: returned values are in [0, 2P-1].
+func ModUpBEHZ(p1, p2 [][]uint64, ringQ, ringP *Ring, MUC ModUpConstants) {
+
+	// The correction term v stays zero: vtimesqmodp[0] = 0.
+	var v, rlo, rhi [8]uint64
+	var y0, y1, y2, y3, y4, y5, y6, y7 [32]uint64
+
+	levelQ := len(p1) - 1
+	levelP := len(p2) - 1
+
+	Q := ringQ.ModuliChain()
+	mredQ := ringQ.MRedConstants()
+
+	P := ringP.ModuliChain()
+	mredP := ringP.MRedConstants()
+
+	vtimesqmodp := MUC.vtimesqmodp
+	qoverqiinvqi := MUC.qoverqiinvqi
+	qoverqimodp := MUC.qoverqimodp
+
+	// We loop over each coefficient and apply the basis extension
+	for x := 0; x < len(p1[0]); x = x + 8 {
+		reconstructRNSLazy(0, levelQ+1, x, p1, &y0, &y1, &y2, &y3, &y4, &y5, &y6, &y7, Q, mredQ, qoverqiinvqi)
+		for j := 0; j < levelP+1; j++ {
+			/* #nosec G103 -- behavior and consequences well understood, possible buffer overflow if len(p2[j])%8 != 0*/
+			multSum(levelQ, (*[8]uint64)(unsafe.Pointer(&p2[j][x])), &rlo, &rhi, &v, &y0, &y1, &y2, &y3, &y4, &y5, &y6, &y7, P[j], mredP[j], vtimesqmodp[j], qoverqimodp[j])
+		}
+	}
+}
+
 // Decomposer is a structure that stores the parameters of the arbitrary decomposer.
 // This decomposer takes a p(x)_Q (in basis Q) and returns p(x) mod qi in basis QP, where
 // qi = prod(Q_i) for 0<=i<=L, where L is the number of factors in P.
@@ -592,6 +710,33 @@ func reconstructRNS(start, end, x int, p [][]uint64, v *[8]uint64, y0, y1, y2, y
 	v[7] = uint64(vi[7])
 }
 
+// reconstructRNSLazy is [reconstructRNS] without the floating-point
+// reconstruction of the correction term v, as used by the integer-only
+// [BEHZ] basis extension.
+func reconstructRNSLazy(start, end, x int, p [][]uint64, y0, y1, y2, y3, y4, y5, y6, y7 *[32]uint64, Q, QInv, QbMont []uint64) {
+
+	var qi, qiInv, qoverqiinvqi uint64
+
+	for i, j := start, 0; i < end; i, j = i+1, j+1 {
+
+		qoverqiinvqi = QbMont[i]
+		qi = Q[i]
+		qiInv = QInv[i]
+
+		/* #nosec G103 -- behavior and consequences well understood, possible buffer overflow if len(p[i])%8 != 0 */
+		pTmp := (*[8]uint64)(unsafe.Pointer(&p[i][x]))
+
+		y0[j] = MRed(pTmp[0], qoverqiinvqi, qi, qiInv)
+		y1[j] = MRed(pTmp[1], qoverqiinvqi, qi, qiInv)
+		y2[j] = MRed(pTmp[2], qoverqiinvqi, qi, qiInv)
+		y3[j] = MRed(pTmp[3], qoverqiinvqi, qi, qiInv)
+		y4[j] = MRed(pTmp[4], qoverqiinvqi, qi, qiInv)
+		y5[j] = MRed(pTmp[5], qoverqiinvqi, qi, qiInv)
+		y6[j] = MRed(pTmp[6], qoverqiinvqi, qi, qiInv)
+		y7[j] = MRed(pTmp[7], qoverqiinvqi, qi, qiInv)
+	}
+}
+
 // Caution, returns the values in [0, 2q-1]
 func multSum(level int, res, rlo, rhi, v *[8]uint64, y0, y1, y2, y3, y4, y5, y6, y7 *[32]uint64, q, qInv uint64, vtimesqmodp, qoverqimodp []uint64) {
 
@@ -0,0 +1,132 @@
+package ring
+
+import (
+	"math/big"
+
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+// CRTReconstructor stores the necessary pre-computations for the composition
+// (CRT reconstruction) and decomposition of big-integer values to and from
+// their RNS representation with respect to a moduli chain.
+//
+// Together with [BasisExtender] (RNS basis extension) and the DivFloor/DivRound
+// methods of the [Ring] type (RNS scaling), it provides a stable entry point for
+// implementing custom RNS operations (e.g., new rounding gadgets) on top of the
+// ring package without accessing its internals.
+type CRTReconstructor struct {
+	moduli            []uint64
+	modulusBigint     *big.Int
+	modulusBigintHalf *big.Int
+	crtReconstruction []*big.Int
+}
+
+// NewCRTReconstructor creates a new [CRTReconstructor] for the given moduli chain,
+// which must be composed of pairwise coprime moduli.
+func NewCRTReconstructor(moduli []uint64) (crt *CRTReconstructor) {
+
+	crt = new(CRTReconstructor)
+	crt.moduli = make([]uint64, len(moduli))
+	copy(crt.moduli, moduli)
+
+	crt.modulusBigint = bignum.NewInt(1)
+	for _, qi := range moduli {
+		crt.modulusBigint.Mul(crt.modulusBigint, bignum.NewInt(qi))
+	}
+
+	crt.modulusBigintHalf = new(big.Int).Rsh(crt.modulusBigint, 1)
+
+	QiB := new(big.Int)
+	tmp := new(big.Int)
+	crt.crtReconstruction = make([]*big.Int, len(moduli))
+	for i, qi := range moduli {
+		QiB.SetUint64(qi)
+		crt.crtReconstruction[i] = new(big.Int).Quo(crt.modulusBigint, QiB)
+		tmp.ModInverse(crt.crtReconstruction[i], QiB)
+		tmp.Mod(tmp, QiB)
+		crt.crtReconstruction[i].Mul(crt.crtReconstruction[i], tmp)
+	}
+
+	return
+}
+
+// Modulus returns the product of the moduli of the chain as a new Int.
+func (crt CRTReconstructor) Modulus() *big.Int {
+	return new(big.Int).Set(crt.modulusBigint)
+}
+
+// Compose reconstructs the big-integer value in [0, Q-1] from its RNS residues
+// and stores the result in value.
+func (crt CRTReconstructor) Compose(residues []uint64, value *big.Int) {
+
+	tmp := new(big.Int)
+
+	value.SetUint64(0)
+	for i := range crt.moduli {
+		value.Add(value, tmp.Mul(bignum.NewInt(residues[i]), crt.crtReconstruction[i]))
+	}
+
+	value.Mod(value, crt.modulusBigint)
+}
+
+// ComposeCentered reconstructs the big-integer value in [-Q/2, Q/2-1] from its
+// RNS residues and stores the result in value.
+func (crt CRTReconstructor) ComposeCentered(residues []uint64, value *big.Int) {
+
+	crt.Compose(residues, value)
+
+	if value.Cmp(crt.modulusBigintHalf) != -1 {
+		value.Sub(value, crt.modulusBigint)
+	}
+}
+
+// Decompose computes the RNS residues of the big-integer value (which can be
+// negative or larger than Q) and stores them in residues.
+func (crt CRTReconstructor) Decompose(value *big.Int, residues []uint64) {
+
+	QiB := new(big.Int)
+	tmp := new(big.Int)
+
+	for i, qi := range crt.moduli {
+		QiB.SetUint64(qi)
+		residues[i] = tmp.Mod(value, QiB).Uint64()
+	}
+}
+
+// ComposePoly reconstructs the big-integer coefficients of p and stores them in
+// coeffs, which must be pre-allocated with non-nil entries. It is equivalent to
+// [Ring.PolyToBigintCentered] with gap = 1, but amortizes the CRT reconstruction
+// constants across calls.
+func (crt CRTReconstructor) ComposePoly(p Poly, coeffs []*big.Int) {
+
+	tmp := new(big.Int)
+
+	for j := range coeffs {
+
+		coeffs[j].SetUint64(0)
+		for i := range crt.moduli {
+			coeffs[j].Add(coeffs[j], tmp.Mul(bignum.NewInt(p.Coeffs[i][j]), crt.crtReconstruction[i]))
+		}
+
+		coeffs[j].Mod(coeffs[j], crt.modulusBigint)
+
+		if coeffs[j].Cmp(crt.modulusBigintHalf) != -1 {
+			coeffs[j].Sub(coeffs[j], crt.modulusBigint)
+		}
+	}
+}
+
+// DecomposePoly computes the RNS residues of the big-integer coefficients coeffs
+// and stores them in p. It is equivalent to [Ring.SetCoefficientsBigint].
+func (crt CRTReconstructor) DecomposePoly(coeffs []*big.Int, p Poly) {
+
+	QiB := new(big.Int)
+	tmp := new(big.Int)
+
+	for i, qi := range crt.moduli {
+		QiB.SetUint64(qi)
+		for j, coeff := range coeffs {
+			p.Coeffs[i][j] = tmp.Mod(coeff, QiB).Uint64()
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package ring
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestCRTReconstructor(t *testing.T) {
+
+	r, err := NewRing(16, Qi60[:4])
+	require.NoError(t, err)
+
+	crt := NewCRTReconstructor(r.ModuliChain())
+
+	prng, err := sampling.NewPRNG()
+	require.NoError(t, err)
+
+	t.Run("Scalar", func(t *testing.T) {
+
+		value := sampling.RandInt(r.Modulus())
+
+		residues := make([]uint64, r.ModuliChainLength())
+		crt.Decompose(value, residues)
+
+		have := new(big.Int)
+		crt.Compose(residues, have)
+		require.Equal(t, 0, value.Cmp(have))
+
+		// Centered composition is congruent to value mod Q
+		crt.ComposeCentered(residues, have)
+		have.Mod(have, r.Modulus())
+		require.Equal(t, 0, value.Cmp(have))
+	})
+
+	t.Run("Poly", func(t *testing.T) {
+
+		p := r.NewPoly()
+		NewUniformSampler(prng, r).Read(p)
+
+		want := make([]*big.Int, r.N())
+		for i := range want {
+			want[i] = new(big.Int)
+		}
+		r.PolyToBigintCentered(p, 1, want)
+
+		have := make([]*big.Int, r.N())
+		for i := range have {
+			have[i] = new(big.Int)
+		}
+		crt.ComposePoly(p, have)
+
+		for i := range want {
+			require.Equal(t, 0, want[i].Cmp(have[i]))
+		}
+
+		pHave := r.NewPoly()
+		crt.DecomposePoly(have, pHave)
+		require.True(t, r.Equal(p, pHave))
+	})
+}
@@ -0,0 +1,83 @@
+package ring
+
+import (
+	"fmt"
+)
+
+// This file provides the arithmetic on Galois elements: the automorphisms
+// X -> X^galEl of the ring form a group isomorphic to Z_NthRoot^*, generated
+// by [GaloisGen] (rotations) and -1 (conjugation). The helpers below convert
+// between rotation offsets and Galois elements, and compose, invert and
+// decompose Galois elements, so that protocol code does not have to
+// re-implement the modular exponentiation tricks against the NthRoot.
+
+// GaloisElementForRotation returns the Galois element GaloisGen^k mod NthRoot
+// corresponding to a rotation of the slots by k positions. Negative k are
+// mapped to rotations in the opposite direction.
+func (r Ring) GaloisElementForRotation(k int) uint64 {
+	NthRoot := r.NthRoot()
+	return ModExp(GaloisGen, uint64(k)&(NthRoot-1), NthRoot)
+}
+
+// GaloisElementForConjugation returns the Galois element -1 mod NthRoot
+// corresponding to the conjugation. In the conjugate-invariant ring the
+// conjugation acts trivially and the identity element 1 is returned.
+func (r Ring) GaloisElementForConjugation() uint64 {
+	if r.Type() == ConjugateInvariant {
+		return 1
+	}
+	return r.NthRoot() - 1
+}
+
+// ComposeGaloisElements returns the Galois element corresponding to the
+// automorphism X -> X^galEl0 followed by X -> X^galEl1, i.e.
+// galEl0 * galEl1 mod NthRoot.
+func (r Ring) ComposeGaloisElements(galEl0, galEl1 uint64) uint64 {
+	return (galEl0 * galEl1) & (r.NthRoot() - 1)
+}
+
+// InverseGaloisElement returns the Galois element corresponding to the
+// inverse of the automorphism X -> X^galEl, i.e. galEl^-1 mod NthRoot.
+func (r Ring) InverseGaloisElement(galEl uint64) uint64 {
+	NthRoot := r.NthRoot()
+	// The order of the group of units mod NthRoot is a power of two
+	// dividing NthRoot, hence galEl^(NthRoot-1) = galEl^-1.
+	return ModExp(galEl, NthRoot-1, NthRoot)
+}
+
+// DecomposeGaloisElement decomposes a Galois element as
+// galEl = (-1)^conjugate * GaloisGen^k mod NthRoot, returning the rotation
+// offset k in [0, NthRoot/4) and whether the decomposition includes the
+// conjugation. An error is returned if galEl is not an odd integer smaller
+// than NthRoot.
+func (r Ring) DecomposeGaloisElement(galEl uint64) (k int, conjugate bool, err error) {
+
+	NthRoot := r.NthRoot()
+
+	if galEl&1 == 0 || galEl >= NthRoot {
+		return 0, false, fmt.Errorf("invalid galEl: must be an odd integer smaller than %d but is %d", NthRoot, galEl)
+	}
+
+	// Powers of GaloisGen are equal to 1 mod 4, their conjugates to -1 mod 4.
+	if conjugate = galEl&3 == 3; conjugate {
+		galEl = (NthRoot - galEl) & (NthRoot - 1)
+	}
+
+	var kuint uint64
+
+	x := NthRoot >> 3
+
+	for {
+
+		if ModExpPow2(GaloisGen, kuint, NthRoot) != ModExpPow2(galEl, x, NthRoot) {
+			kuint |= NthRoot >> 3
+		}
+
+		if x == 1 {
+			return int(kuint), conjugate, nil
+		}
+
+		x >>= 1
+		kuint >>= 1
+	}
+}
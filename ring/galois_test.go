@@ -0,0 +1,84 @@
+package ring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGaloisElements(t *testing.T) {
+
+	const N = 64
+
+	rings := map[string]*Ring{}
+
+	r, err := NewRing(N, Qi60[:1])
+	require.NoError(t, err)
+	rings["Standard"] = r
+
+	r, err = NewRingConjugateInvariant(N, Qi60[:1])
+	require.NoError(t, err)
+	rings["ConjugateInvariant"] = r
+
+	for name, r := range rings {
+
+		t.Run(name, func(t *testing.T) {
+
+			NthRoot := r.NthRoot()
+
+			t.Run("Rotation/RoundTrip", func(t *testing.T) {
+				for _, k := range []int{0, 1, 7, int(NthRoot)>>2 - 1, -1, -13} {
+
+					galEl := r.GaloisElementForRotation(k)
+					require.Equal(t, ModExp(GaloisGen, uint64(k)&(NthRoot-1), NthRoot), galEl)
+
+					have, conjugate, err := r.DecomposeGaloisElement(galEl)
+					require.NoError(t, err)
+					require.False(t, conjugate)
+					require.Equal(t, int(uint64(k)&(NthRoot>>2-1)), have)
+				}
+			})
+
+			t.Run("Composition", func(t *testing.T) {
+				galEl := r.ComposeGaloisElements(r.GaloisElementForRotation(3), r.GaloisElementForRotation(5))
+				require.Equal(t, r.GaloisElementForRotation(8), galEl)
+			})
+
+			t.Run("Inversion", func(t *testing.T) {
+				galEl := r.GaloisElementForRotation(11)
+				require.Equal(t, uint64(1), r.ComposeGaloisElements(galEl, r.InverseGaloisElement(galEl)))
+				require.Equal(t, r.GaloisElementForRotation(-11), r.InverseGaloisElement(galEl))
+			})
+
+			t.Run("Errors", func(t *testing.T) {
+				_, _, err := r.DecomposeGaloisElement(2)
+				require.Error(t, err)
+				_, _, err = r.DecomposeGaloisElement(NthRoot + 1)
+				require.Error(t, err)
+			})
+		})
+	}
+
+	t.Run("Standard/Conjugation", func(t *testing.T) {
+
+		r := rings["Standard"]
+
+		galEl := r.GaloisElementForConjugation()
+		require.Equal(t, r.NthRoot()-1, galEl)
+
+		k, conjugate, err := r.DecomposeGaloisElement(galEl)
+		require.NoError(t, err)
+		require.True(t, conjugate)
+		require.Equal(t, 0, k)
+
+		// Conjugation composed with a rotation.
+		k, conjugate, err = r.DecomposeGaloisElement(r.ComposeGaloisElements(galEl, r.GaloisElementForRotation(5)))
+		require.NoError(t, err)
+		require.True(t, conjugate)
+		require.Equal(t, 5, k)
+	})
+
+	t.Run("ConjugateInvariant/Conjugation", func(t *testing.T) {
+		require.Equal(t, uint64(1), rings["ConjugateInvariant"].GaloisElementForConjugation())
+	})
+}
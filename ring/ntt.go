@@ -215,6 +215,8 @@ func nttCoreLazy(p1, p2 []uint64, N int, Q, MRedConstant uint64, roots []uint64)
 
 	if N < MinimumRingDegreeForLoopUnrolledNTT {
 		nttLazy(p1, p2, N, Q, MRedConstant, roots)
+	} else if useVecNTT {
+		nttLazyVec(p1, p2, N, Q, MRedConstant, roots)
 	} else {
 		nttUnrolled16Lazy(p1, p2, N, Q, MRedConstant, roots)
 	}
@@ -559,6 +561,8 @@ func inttCoreLazy(p1, p2 []uint64, N int, Q, MRedConstant uint64, roots []uint64
 
 	if N < MinimumRingDegreeForLoopUnrolledNTT {
 		inttLazy(p1, p2, N, Q, MRedConstant, roots)
+	} else if useVecINTT {
+		inttLazyVec(p1, p2, N, Q, MRedConstant, roots)
 	} else {
 		inttLazyUnrolled16(p1, p2, N, Q, MRedConstant, roots)
 	}
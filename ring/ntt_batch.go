@@ -0,0 +1,73 @@
+package ring
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// NTTBatch evaluates p2[i] = NTT(p1[i]) for all i, distributing the
+// independent transforms over a pool of worker goroutines. The pool size is
+// given by workers; if workers < 1, runtime.GOMAXPROCS(0) workers are used.
+// The work is split at the granularity of a single modulus, so near-linear
+// speedups are obtained even for small batches of many-limb polynomials.
+func (r Ring) NTTBatch(p1, p2 []Poly, workers int) {
+	r.transformBatch(p1, p2, workers, (*SubRing).NTT)
+}
+
+// INTTBatch evaluates p2[i] = INTT(p1[i]) for all i, distributing the
+// independent transforms over a pool of worker goroutines. The pool size is
+// given by workers; if workers < 1, runtime.GOMAXPROCS(0) workers are used.
+// The work is split at the granularity of a single modulus, so near-linear
+// speedups are obtained even for small batches of many-limb polynomials.
+func (r Ring) INTTBatch(p1, p2 []Poly, workers int) {
+	r.transformBatch(p1, p2, workers, (*SubRing).INTT)
+}
+
+// transformBatch applies the provided SubRing transform to each modulus of
+// each polynomial of the batch, one (polynomial, modulus) pair per job.
+func (r Ring) transformBatch(p1, p2 []Poly, workers int, transform func(*SubRing, []uint64, []uint64)) {
+
+	if len(p1) != len(p2) {
+		// Sanity check, same contract as the single-polynomial transforms.
+		panic(fmt.Errorf("cannot transformBatch: len(p1)=%d != len(p2)=%d", len(p1), len(p2)))
+	}
+
+	jobs := len(p1) * (r.level + 1)
+
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	if workers > jobs {
+		workers = jobs
+	}
+
+	if workers <= 1 {
+		for i := range p1 {
+			for j, s := range r.SubRings[:r.level+1] {
+				transform(s, p1[i].Coeffs[j], p2[i].Coeffs[j])
+			}
+		}
+		return
+	}
+
+	ch := make(chan int, jobs)
+	for i := 0; i < jobs; i++ {
+		ch <- i
+	}
+	close(ch)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for job := range ch {
+				i, j := job/(r.level+1), job%(r.level+1)
+				transform(r.SubRings[j], p1[i].Coeffs[j], p2[i].Coeffs[j])
+			}
+		}()
+	}
+	wg.Wait()
+}
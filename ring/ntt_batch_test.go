@@ -0,0 +1,53 @@
+package ring
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestNTTBatch(t *testing.T) {
+
+	prng, err := sampling.NewPRNG()
+	require.NoError(t, err)
+
+	r, err := NewRing(128, Qi60[:3])
+	require.NoError(t, err)
+
+	sampler := NewUniformSampler(prng, r)
+
+	const batch = 5
+
+	p := make([]Poly, batch)
+	want := make([]Poly, batch)
+	for i := range p {
+		p[i] = sampler.ReadNew()
+		want[i] = r.NewPoly()
+		r.NTT(p[i], want[i])
+	}
+
+	for _, workers := range []int{0, 1, 3, 16} {
+		t.Run(fmt.Sprintf("workers=%d", workers), func(t *testing.T) {
+
+			have := make([]Poly, batch)
+			for i := range have {
+				have[i] = r.NewPoly()
+			}
+
+			r.NTTBatch(p, have, workers)
+			for i := range have {
+				require.True(t, want[i].Equal(&have[i]))
+			}
+
+			r.INTTBatch(have, have, workers)
+			for i := range have {
+				require.True(t, p[i].Equal(&have[i]))
+			}
+		})
+	}
+
+	require.Panics(t, func() { r.NTTBatch(p, p[:batch-1], 0) })
+}
@@ -0,0 +1,268 @@
+package ring
+
+import (
+	"fmt"
+)
+
+// MaxLogGapIncompleteNTT is the maximum number of NTT layers that can be
+// skipped by a [NumberTheoreticTransformerIncomplete].
+const MaxLogGapIncompleteNTT = 6
+
+// NumberTheoreticTransformerIncomplete computes an incomplete nega-cyclic NTT
+// in the ring Z[X]/(X^N+1): the last logGap layers of the transform are
+// skipped, leaving the polynomial as N/gap evaluations of degree-(gap-1)
+// residues modulo the factors (X^gap - psi_i^2), with gap = 2^logGap. The
+// pointwise multiplication of two polynomials in this representation is a
+// small-degree polynomial product per factor (see
+// [NumberTheoreticTransformerIncomplete.MulCoeffsMontgomery]), which trades a
+// slightly more expensive multiplication for logGap fewer butterfly layers per
+// transform, a trade-off that favors transform-dominated pipelines such as
+// BFV tensoring and base conversions.
+type NumberTheoreticTransformerIncomplete struct {
+	numberTheoreticTransformerBase
+
+	logGap int
+
+	// Montgomery form of psi_i^2, the constant of the factor
+	// (X^gap - psi_i^2) of the i-th residue block, in bit-reversed order.
+	// Derived lazily from the NTTTable, together with the normalization
+	// constant (N/gap)^-1 of the incomplete inverse transform.
+	blockRoots []uint64
+	nInv       uint64
+}
+
+// NewNumberTheoreticTransformerIncomplete instantiates a constructor for a
+// [NumberTheoreticTransformerIncomplete] skipping the last logGap layers,
+// to be used with [NewRingWithCustomNTT].
+func NewNumberTheoreticTransformerIncomplete(logGap int) func(*SubRing, int) NumberTheoreticTransformer {
+	return func(r *SubRing, n int) NumberTheoreticTransformer {
+		return &NumberTheoreticTransformerIncomplete{
+			numberTheoreticTransformerBase: numberTheoreticTransformerBase{
+				N:            r.N,
+				Modulus:      r.Modulus,
+				MRedConstant: r.MRedConstant,
+				BRedConstant: r.BRedConstant,
+				NTTTable:     r.NTTTable,
+			},
+			logGap: logGap,
+		}
+	}
+}
+
+// initIncompleteTables derives the block constants of the skipped layers and
+// the normalization constant of the incomplete inverse transform from the
+// NTTTable.
+func (rntt *NumberTheoreticTransformerIncomplete) initIncompleteTables() {
+
+	Q := rntt.Modulus
+	mredconstant := rntt.MRedConstant
+
+	m := rntt.N >> rntt.logGap
+
+	// The first skipped layer would split the blocks of size gap with the
+	// twiddles roots[m+i]: the i-th block holds the residue modulo
+	// (X^gap - roots[m+i]^2).
+	rntt.blockRoots = make([]uint64, m)
+	for i := range rntt.blockRoots {
+		rntt.blockRoots[i] = MRed(rntt.RootsForward[m+i], rntt.RootsForward[m+i], Q, mredconstant)
+	}
+
+	// Only log(N/gap) layers are evaluated, so the inverse transform is
+	// normalized by (N/gap)^-1 instead of N^-1.
+	rntt.nInv = MForm(ModExp(uint64(m), Q-2, Q), Q, rntt.BRedConstant)
+}
+
+// Forward writes the incomplete forward NTT in Z[X]/(X^N+1) of p1 on p2.
+func (rntt *NumberTheoreticTransformerIncomplete) Forward(p1, p2 []uint64) {
+	if rntt.blockRoots == nil {
+		rntt.initIncompleteTables()
+	}
+	nttIncompleteLazy(p1, p2, rntt.N, rntt.logGap, rntt.Modulus, rntt.MRedConstant, rntt.RootsForward)
+	reducevec(p2, p2, rntt.Modulus, rntt.BRedConstant)
+}
+
+// ForwardLazy writes the incomplete forward NTT in Z[X]/(X^N+1) of p1 on p2.
+// Returns values in the range [0, 4q-1].
+func (rntt *NumberTheoreticTransformerIncomplete) ForwardLazy(p1, p2 []uint64) {
+	if rntt.blockRoots == nil {
+		rntt.initIncompleteTables()
+	}
+	nttIncompleteLazy(p1, p2, rntt.N, rntt.logGap, rntt.Modulus, rntt.MRedConstant, rntt.RootsForward)
+}
+
+// Backward writes the incomplete backward NTT in Z[X]/(X^N+1) of p1 on p2.
+func (rntt *NumberTheoreticTransformerIncomplete) Backward(p1, p2 []uint64) {
+	if rntt.blockRoots == nil {
+		rntt.initIncompleteTables()
+	}
+	inttIncomplete(p1, p2, rntt.N, rntt.logGap, rntt.Modulus, rntt.MRedConstant, rntt.nInv, rntt.RootsBackward)
+}
+
+// BackwardLazy writes the incomplete backward NTT in Z[X]/(X^N+1) of p1 on p2.
+// Unlike the full transform, the output is fully reduced in [0, modulus-1].
+func (rntt *NumberTheoreticTransformerIncomplete) BackwardLazy(p1, p2 []uint64) {
+	if rntt.blockRoots == nil {
+		rntt.initIncompleteTables()
+	}
+	inttIncomplete(p1, p2, rntt.N, rntt.logGap, rntt.Modulus, rntt.MRedConstant, rntt.nInv, rntt.RootsBackward)
+}
+
+// MulCoeffsMontgomery evaluates p3 = p1*p2 in the incomplete-NTT
+// representation: for each residue block, the degree-(gap-1) polynomials are
+// multiplied modulo the block factor (X^gap - psi_i^2). The operand p2 must be
+// in the Montgomery domain; the output is not.
+func (rntt *NumberTheoreticTransformerIncomplete) MulCoeffsMontgomery(p1, p2, p3 []uint64) {
+
+	if rntt.blockRoots == nil {
+		rntt.initIncompleteTables()
+	}
+
+	N := rntt.N
+	gap := 1 << rntt.logGap
+	Q := rntt.Modulus
+	mredconstant := rntt.MRedConstant
+
+	var c [1 << MaxLogGapIncompleteNTT]uint64
+
+	for j, W := 0, 0; j < N; j, W = j+gap, W+1 {
+
+		a := p1[j : j+gap]
+		b := p2[j : j+gap]
+		F := rntt.blockRoots[W]
+
+		for k := 0; k < gap; k++ {
+
+			// Terms of degree k and of degree k+gap, the latter reduced
+			// modulo (X^gap - psi^2).
+			var lo, hi uint64
+			for u := 0; u <= k; u++ {
+				lo = CRed(lo+MRed(a[u], b[k-u], Q, mredconstant), Q)
+			}
+			for u := k + 1; u < gap; u++ {
+				hi = CRed(hi+MRed(a[u], b[gap+k-u], Q, mredconstant), Q)
+			}
+
+			c[k] = CRed(lo+MRed(hi, F, Q, mredconstant), Q)
+		}
+
+		copy(p3[j:j+gap], c[:gap])
+	}
+}
+
+// NewRingIncompleteNTT creates a new RNS Ring with degree N and coefficient
+// moduli Moduli, with an incomplete NTT skipping the last logGap layers (see
+// [NumberTheoreticTransformerIncomplete]). N must be a power of two larger
+// than 8, Moduli NTT-friendly primes, and logGap in
+// [1, min(log(N)-1, [MaxLogGapIncompleteNTT])].
+func NewRingIncompleteNTT(N int, Moduli []uint64, logGap int) (r *Ring, err error) {
+
+	if logGap < 1 || logGap > MaxLogGapIncompleteNTT || 1<<logGap > N>>1 {
+		return nil, fmt.Errorf("invalid logGap: must be in [1, min(log(N)-1, %d)] but is %d", MaxLogGapIncompleteNTT, logGap)
+	}
+
+	return NewRingWithCustomNTT(N, Moduli, NewNumberTheoreticTransformerIncomplete(logGap), 2*N)
+}
+
+// MulCoeffsMontgomeryIncomplete evaluates p3 = p1*p2 in the incomplete-NTT
+// representation (see
+// [NumberTheoreticTransformerIncomplete.MulCoeffsMontgomery]), for p2 in the
+// Montgomery domain. An error is returned if the receiver was not created
+// with an incomplete NTT (see [NewRingIncompleteNTT]).
+func (r Ring) MulCoeffsMontgomeryIncomplete(p1, p2, p3 Poly) error {
+
+	for i, s := range r.SubRings[:r.level+1] {
+
+		rntt, ok := s.ntt.(*NumberTheoreticTransformerIncomplete)
+
+		if !ok {
+			return fmt.Errorf("cannot MulCoeffsMontgomeryIncomplete: ring was not instantiated with an incomplete NTT")
+		}
+
+		rntt.MulCoeffsMontgomery(p1.Coeffs[i], p2.Coeffs[i], p3.Coeffs[i])
+	}
+
+	return nil
+}
+
+// nttIncompleteLazy evaluates p2 = NTT(p1) skipping the last logGap layers,
+// with outputs in the range [0, 4q-1].
+func nttIncompleteLazy(p1, p2 []uint64, N, logGap int, Q, MRedConstant uint64, roots []uint64) {
+
+	var j1, j2, t int
+	var F uint64
+
+	fourQ := 4 * Q
+	twoQ := 2 * Q
+
+	t = N >> 1
+	F = roots[1]
+	j1 = 0
+	j2 = j1 + t
+
+	for jx, jy := j1, j1+t; jx < j2; jx, jy = jx+1, jy+1 {
+		p2[jx], p2[jy] = butterfly(p1[jx], p1[jy], F, twoQ, fourQ, Q, MRedConstant)
+	}
+
+	for m := 2; m < N>>logGap; m <<= 1 {
+
+		t >>= 1
+
+		for i := 0; i < m; i++ {
+
+			j1 = (i * t) << 1
+
+			j2 = j1 + t
+
+			F = roots[m+i]
+
+			for jx, jy := j1, j1+t; jx < j2; jx, jy = jx+1, jy+1 {
+				p2[jx], p2[jy] = butterfly(p2[jx], p2[jy], F, twoQ, fourQ, Q, MRedConstant)
+			}
+		}
+	}
+}
+
+// inttIncomplete evaluates p2 = INTT(p1) for p1 in the incomplete-NTT
+// representation with the last logGap layers skipped, with fully reduced
+// outputs. Inputs must be in the range [0, 4q-1].
+func inttIncomplete(p1, p2 []uint64, N, logGap int, Q, MRedConstant, nInv uint64, roots []uint64) {
+
+	var h, t int
+	var F uint64
+
+	t = 1 << logGap
+	h = N >> (logGap + 1)
+	twoQ := Q << 1
+	fourQ := Q << 2
+
+	for i, j1, j2 := 0, 0, t; i < h; i, j1, j2 = i+1, j1+2*t, j2+2*t {
+
+		F = roots[h+i]
+
+		for jx, jy := j1, j1+t; jx < j2; jx, jy = jx+1, jy+1 {
+			p2[jx], p2[jy] = invbutterfly(p1[jx], p1[jy], F, twoQ, fourQ, Q, MRedConstant)
+		}
+	}
+
+	t <<= 1
+
+	for m := N >> (logGap + 1); m > 1; m >>= 1 {
+
+		h = m >> 1
+
+		for i, j1, j2 := 0, 0, t; i < h; i, j1, j2 = i+1, j1+2*t, j2+2*t {
+
+			F = roots[h+i]
+
+			for jx, jy := j1, j1+t; jx < j2; jx, jy = jx+1, jy+1 {
+				p2[jx], p2[jy] = invbutterfly(p2[jx], p2[jy], F, twoQ, fourQ, Q, MRedConstant)
+			}
+		}
+
+		t <<= 1
+	}
+
+	for i := range p2[:N] {
+		p2[i] = MRed(p2[i], nInv, Q, MRedConstant)
+	}
+}
@@ -0,0 +1,90 @@
+package ring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestIncompleteNTT(t *testing.T) {
+
+	const N = 128
+
+	moduli := Qi60[:2]
+
+	rStd, err := NewRing(N, moduli)
+	require.NoError(t, err)
+
+	for _, logGap := range []int{1, 2, 3} {
+
+		rInc, err := NewRingIncompleteNTT(N, moduli, logGap)
+		require.NoError(t, err)
+
+		require.Equal(t, Standard, rInc.Type())
+
+		t.Run(testString("RoundTrip", rInc), func(t *testing.T) {
+
+			p := rInc.NewPoly()
+			for i := range p.Coeffs {
+				for j := range p.Coeffs[i] {
+					p.Coeffs[i][j] = sampling.RandUint64() % moduli[i]
+				}
+			}
+
+			pHat := rInc.NewPoly()
+			pTest := rInc.NewPoly()
+
+			rInc.NTT(p, pHat)
+			rInc.INTT(pHat, pTest)
+
+			require.True(t, rInc.Equal(p, pTest))
+		})
+
+		t.Run(testString("Mul", rInc), func(t *testing.T) {
+
+			a := rInc.NewPoly()
+			b := rInc.NewPoly()
+			for i := range a.Coeffs {
+				for j := range a.Coeffs[i] {
+					a.Coeffs[i][j] = sampling.RandUint64() % moduli[i]
+					b.Coeffs[i][j] = sampling.RandUint64() % moduli[i]
+				}
+			}
+
+			// Reference product through the full NTT.
+			aHat := rStd.NewPoly()
+			bHat := rStd.NewPoly()
+			want := rStd.NewPoly()
+			rStd.NTT(a, aHat)
+			rStd.NTT(b, bHat)
+			rStd.MForm(bHat, bHat)
+			rStd.MulCoeffsMontgomery(aHat, bHat, want)
+			rStd.INTT(want, want)
+
+			// Product through the incomplete NTT, with block-wise
+			// small-degree polynomial products.
+			cInc := rInc.NewPoly()
+			rInc.NTT(a, aHat)
+			rInc.MForm(b, cInc)
+			rInc.NTT(cInc, bHat)
+			require.NoError(t, rInc.MulCoeffsMontgomeryIncomplete(aHat, bHat, cInc))
+			rInc.INTT(cInc, cInc)
+
+			require.True(t, rInc.Equal(want, cInc))
+		})
+	}
+
+	t.Run("Errors", func(t *testing.T) {
+
+		_, err := NewRingIncompleteNTT(N, moduli, 0)
+		require.Error(t, err)
+
+		_, err = NewRingIncompleteNTT(N, moduli, MaxLogGapIncompleteNTT+1)
+		require.Error(t, err)
+
+		p := rStd.NewPoly()
+		require.Error(t, rStd.MulCoeffsMontgomeryIncomplete(p, p, p))
+	})
+}
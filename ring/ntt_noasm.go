@@ -0,0 +1,23 @@
+//go:build (!amd64 && !arm64) || purego
+
+package ring
+
+// hasVecKernels is false on platforms without the assembly kernels: the
+// pure-Go paths are always used.
+const hasVecKernels = false
+
+func mredVec(p1, p2, p3 *uint64, n int, q, mredconstant uint64) {
+	panic("unreachable: assembly kernels are not available on this platform")
+}
+
+func mredLazyVec(p1, p2, p3 *uint64, n int, q, mredconstant uint64) {
+	panic("unreachable: assembly kernels are not available on this platform")
+}
+
+func nttLazyVec(p1, p2 []uint64, N int, Q, MRedConstant uint64, roots []uint64) {
+	panic("unreachable: assembly kernels are not available on this platform")
+}
+
+func inttLazyVec(p1, p2 []uint64, N int, Q, MRedConstant uint64, roots []uint64) {
+	panic("unreachable: assembly kernels are not available on this platform")
+}
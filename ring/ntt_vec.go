@@ -0,0 +1,94 @@
+//go:build (amd64 || arm64) && !purego
+
+package ring
+
+// nttLazyVec evaluates p2 = NTT(p1) with p2 in [0, 2*modulus-1], using the
+// assembly butterfly kernel for the stages operating on blocks of at least 8
+// coefficients; the last stages fall back to the scalar butterfly.
+func nttLazyVec(p1, p2 []uint64, N int, Q, MRedConstant uint64, roots []uint64) {
+
+	fourQ := 4 * Q
+	twoQ := 2 * Q
+
+	// First stage: butterflies between the two halves of p1, written on p2.
+	t := N >> 1
+	if &p1[0] != &p2[0] {
+		copy(p2[:N], p1[:N])
+	}
+	butterflyVec(&p2[0], &p2[t], t, roots[1], twoQ, fourQ, Q, MRedConstant)
+
+	for m := 2; m < N; m <<= 1 {
+
+		t >>= 1
+
+		if t >= 8 {
+
+			for i := 0; i < m; i++ {
+				j1 := (i * t) << 1
+				butterflyVec(&p2[j1], &p2[j1+t], t, roots[m+i], twoQ, fourQ, Q, MRedConstant)
+			}
+
+		} else {
+
+			for i := 0; i < m; i++ {
+
+				j1 := (i * t) << 1
+				j2 := j1 + t
+				F := roots[m+i]
+
+				for jx, jy := j1, j1+t; jx < j2; jx, jy = jx+1, jy+1 {
+					p2[jx], p2[jy] = butterfly(p2[jx], p2[jy], F, twoQ, fourQ, Q, MRedConstant)
+				}
+			}
+		}
+	}
+}
+
+// inttLazyVec evaluates p2 = INTT(p1) with p2 in [0, 2*modulus-1], using the
+// assembly inverse butterfly kernel for the stages operating on blocks of at
+// least 8 coefficients; the first stages fall back to the scalar butterfly.
+func inttLazyVec(p1, p2 []uint64, N int, Q, MRedConstant uint64, roots []uint64) {
+
+	twoQ := Q << 1
+	fourQ := Q << 2
+
+	// First stage: butterflies on pairs of p1, written on p2.
+	t := 1
+	h := N >> 1
+
+	for i, j1, j2 := 0, 0, t; i < h; i, j1, j2 = i+1, j1+2*t, j2+2*t {
+
+		F := roots[h+i]
+
+		for jx, jy := j1, j1+t; jx < j2; jx, jy = jx+1, jy+1 {
+			p2[jx], p2[jy] = invbutterfly(p1[jx], p1[jy], F, twoQ, fourQ, Q, MRedConstant)
+		}
+	}
+
+	t <<= 1
+
+	for m := N >> 1; m > 1; m >>= 1 {
+
+		h = m >> 1
+
+		if t >= 8 {
+
+			for i, j1 := 0, 0; i < h; i, j1 = i+1, j1+2*t {
+				invbutterflyVec(&p2[j1], &p2[j1+t], t, roots[h+i], twoQ, fourQ, Q, MRedConstant)
+			}
+
+		} else {
+
+			for i, j1, j2 := 0, 0, t; i < h; i, j1, j2 = i+1, j1+2*t, j2+2*t {
+
+				F := roots[h+i]
+
+				for jx, jy := j1, j1+t; jx < j2; jx, jy = jx+1, jy+1 {
+					p2[jx], p2[jy] = invbutterfly(p2[jx], p2[jy], F, twoQ, fourQ, Q, MRedConstant)
+				}
+			}
+		}
+
+		t <<= 1
+	}
+}
@@ -0,0 +1,24 @@
+//go:build amd64 && !purego
+
+package ring
+
+import (
+	"golang.org/x/sys/cpu"
+)
+
+// hasVecKernels reports whether the AVX-512 NTT and modular multiplication kernels
+// can be used on the current CPU. The kernels require AVX-512F (vector min and
+// 32x32 multiplies) and AVX-512DQ (VPMULLQ).
+var hasVecKernels = cpu.X86.HasAVX512F && cpu.X86.HasAVX512DQ
+
+//go:noescape
+func butterflyVec(x, y *uint64, n int, psi, twoQ, fourQ, q, mredconstant uint64)
+
+//go:noescape
+func invbutterflyVec(x, y *uint64, n int, psi, twoQ, fourQ, q, mredconstant uint64)
+
+//go:noescape
+func mredVec(p1, p2, p3 *uint64, n int, q, mredconstant uint64)
+
+//go:noescape
+func mredLazyVec(p1, p2, p3 *uint64, n int, q, mredconstant uint64)
@@ -0,0 +1,213 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// The kernels below vectorize the Montgomery butterfly and coefficient-wise
+// Montgomery multiplication over 8 coefficients per iteration using AVX-512F
+// and AVX-512DQ (VPMULLQ) instructions. AVX-512 has no 64x64 -> high-64
+// multiplication instruction, so the high half is recomposed from four
+// 32x32 -> 64 partial products (VPMULUDQ).
+
+// MULHI(a, ahi, b, bhi, out, t1, t2, t3) computes out = floor(a*b / 2^64)
+// lane-wise. ahi = a >> 32 and bhi = b >> 32 must be provided by the caller;
+// Z27 must hold the lane-wise mask 0xffffffff.
+#define MULHI(a, ahi, b, bhi, out, t1, t2, t3) \
+	VPMULUDQ a, b, t1     \ // t1 = lo32(a) * lo32(b)
+	VPMULUDQ ahi, b, t2   \ // t2 = hi32(a) * lo32(b)
+	VPMULUDQ a, bhi, t3   \ // t3 = lo32(a) * hi32(b)
+	VPMULUDQ ahi, bhi, out\ // out = hi32(a) * hi32(b)
+	VPSRLQ   $32, t1, t1  \
+	VPADDQ   t1, t2, t2   \ // t2 = hi32(a)*lo32(b) + carry, < 2^64
+	VPANDQ   Z27, t2, t1  \
+	VPSRLQ   $32, t2, t2  \
+	VPADDQ   t1, t3, t3   \ // t3 = lo32(a)*hi32(b) + cross carry, < 2^64
+	VPSRLQ   $32, t3, t3  \
+	VPADDQ   t2, out, out \
+	VPADDQ   t3, out, out
+
+// func butterflyVec(x, y *uint64, n int, psi, twoQ, fourQ, q, mredconstant uint64)
+// Computes x[i], y[i] = butterfly(x[i], y[i], psi, twoQ, fourQ, q, mredconstant)
+// for i in [0, n), n a non-zero multiple of 8.
+TEXT ·butterflyVec(SB), NOSPLIT, $0-64
+	MOVQ x+0(FP), SI
+	MOVQ y+8(FP), DI
+	MOVQ n+16(FP), CX
+
+	VPBROADCASTQ psi+24(FP), Z20
+	VPBROADCASTQ twoQ+32(FP), Z21
+	VPBROADCASTQ fourQ+40(FP), Z22
+	VPBROADCASTQ q+48(FP), Z23
+	VPBROADCASTQ mredconstant+56(FP), Z24
+
+	MOVL         $0xffffffff, AX
+	VPBROADCASTQ AX, Z27
+	VPSRLQ       $32, Z20, Z26 // hi32(psi)
+	VPSRLQ       $32, Z23, Z28 // hi32(q)
+
+butterflyLoop:
+	VMOVDQU64 (SI), Z0 // U
+	VMOVDQU64 (DI), Z1 // V
+
+	// U = U mod 4q (lazy: single conditional subtraction)
+	VPSUBQ  Z22, Z0, Z2
+	VPMINUQ Z2, Z0, Z0
+
+	// V = MRedLazy(V, psi, q, mredconstant)
+	VPSRLQ  $32, Z1, Z3
+	MULHI(Z1, Z3, Z20, Z26, Z4, Z5, Z6, Z7) // Z4 = floor(V*psi / 2^64)
+	VPMULLQ Z20, Z1, Z8                     // lo64(V*psi)
+	VPMULLQ Z24, Z8, Z8                     // m = lo64 * mredconstant
+	VPSRLQ  $32, Z8, Z9
+	MULHI(Z8, Z9, Z23, Z28, Z10, Z5, Z6, Z7) // Z10 = floor(m*q / 2^64)
+	VPSUBQ  Z10, Z4, Z4
+	VPADDQ  Z23, Z4, Z4                      // V = hi - H + q, < 2q
+
+	// x = U + V, y = U + 2q - V
+	VPADDQ    Z4, Z0, Z5
+	VMOVDQU64 Z5, (SI)
+	VPADDQ    Z21, Z0, Z6
+	VPSUBQ    Z4, Z6, Z6
+	VMOVDQU64 Z6, (DI)
+
+	ADDQ $64, SI
+	ADDQ $64, DI
+	SUBQ $8, CX
+	JNZ  butterflyLoop
+
+	VZEROUPPER
+	RET
+
+// func invbutterflyVec(x, y *uint64, n int, psi, twoQ, fourQ, q, mredconstant uint64)
+// Computes x[i], y[i] = invbutterfly(x[i], y[i], psi, twoQ, fourQ, q, mredconstant)
+// for i in [0, n), n a non-zero multiple of 8.
+TEXT ·invbutterflyVec(SB), NOSPLIT, $0-64
+	MOVQ x+0(FP), SI
+	MOVQ y+8(FP), DI
+	MOVQ n+16(FP), CX
+
+	VPBROADCASTQ psi+24(FP), Z20
+	VPBROADCASTQ twoQ+32(FP), Z21
+	VPBROADCASTQ fourQ+40(FP), Z22
+	VPBROADCASTQ q+48(FP), Z23
+	VPBROADCASTQ mredconstant+56(FP), Z24
+
+	MOVL         $0xffffffff, AX
+	VPBROADCASTQ AX, Z27
+	VPSRLQ       $32, Z20, Z26 // hi32(psi)
+	VPSRLQ       $32, Z23, Z28 // hi32(q)
+
+invbutterflyLoop:
+	VMOVDQU64 (SI), Z0 // U
+	VMOVDQU64 (DI), Z1 // V
+
+	// x = U + V mod 2q (lazy: single conditional subtraction)
+	VPADDQ    Z1, Z0, Z2
+	VPSUBQ    Z21, Z2, Z3
+	VPMINUQ   Z3, Z2, Z2
+	VMOVDQU64 Z2, (SI)
+
+	// y = MRedLazy(U + 4q - V, psi, q, mredconstant)
+	VPADDQ  Z22, Z0, Z4
+	VPSUBQ  Z1, Z4, Z4
+	VPSRLQ  $32, Z4, Z5
+	MULHI(Z4, Z5, Z20, Z26, Z6, Z7, Z8, Z9) // Z6 = floor((U+4q-V)*psi / 2^64)
+	VPMULLQ Z20, Z4, Z10
+	VPMULLQ Z24, Z10, Z10
+	VPSRLQ  $32, Z10, Z11
+	MULHI(Z10, Z11, Z23, Z28, Z12, Z7, Z8, Z9) // Z12 = floor(m*q / 2^64)
+	VPSUBQ  Z12, Z6, Z6
+	VPADDQ  Z23, Z6, Z6
+	VMOVDQU64 Z6, (DI)
+
+	ADDQ $64, SI
+	ADDQ $64, DI
+	SUBQ $8, CX
+	JNZ  invbutterflyLoop
+
+	VZEROUPPER
+	RET
+
+// func mredVec(p1, p2, p3 *uint64, n int, q, mredconstant uint64)
+// Computes p3[i] = MRed(p1[i], p2[i], q, mredconstant) for i in [0, n),
+// n a non-zero multiple of 8.
+TEXT ·mredVec(SB), NOSPLIT, $0-48
+	MOVQ p1+0(FP), SI
+	MOVQ p2+8(FP), DX
+	MOVQ p3+16(FP), DI
+	MOVQ n+24(FP), CX
+
+	VPBROADCASTQ q+32(FP), Z23
+	VPBROADCASTQ mredconstant+40(FP), Z24
+
+	MOVL         $0xffffffff, AX
+	VPBROADCASTQ AX, Z27
+	VPSRLQ       $32, Z23, Z28 // hi32(q)
+
+mredLoop:
+	VMOVDQU64 (SI), Z0
+	VMOVDQU64 (DX), Z1
+
+	VPSRLQ  $32, Z0, Z2
+	VPSRLQ  $32, Z1, Z3
+	MULHI(Z0, Z2, Z1, Z3, Z4, Z5, Z6, Z7) // Z4 = floor(p1*p2 / 2^64)
+	VPMULLQ Z1, Z0, Z8
+	VPMULLQ Z24, Z8, Z8
+	VPSRLQ  $32, Z8, Z9
+	MULHI(Z8, Z9, Z23, Z28, Z10, Z5, Z6, Z7) // Z10 = floor(m*q / 2^64)
+	VPSUBQ  Z10, Z4, Z4
+	VPADDQ  Z23, Z4, Z4
+
+	// Final reduction to [0, q)
+	VPSUBQ    Z23, Z4, Z5
+	VPMINUQ   Z5, Z4, Z4
+	VMOVDQU64 Z4, (DI)
+
+	ADDQ $64, SI
+	ADDQ $64, DX
+	ADDQ $64, DI
+	SUBQ $8, CX
+	JNZ  mredLoop
+
+	VZEROUPPER
+	RET
+
+// func mredLazyVec(p1, p2, p3 *uint64, n int, q, mredconstant uint64)
+// Computes p3[i] = MRedLazy(p1[i], p2[i], q, mredconstant) for i in [0, n),
+// n a non-zero multiple of 8.
+TEXT ·mredLazyVec(SB), NOSPLIT, $0-48
+	MOVQ p1+0(FP), SI
+	MOVQ p2+8(FP), DX
+	MOVQ p3+16(FP), DI
+	MOVQ n+24(FP), CX
+
+	VPBROADCASTQ q+32(FP), Z23
+	VPBROADCASTQ mredconstant+40(FP), Z24
+
+	MOVL         $0xffffffff, AX
+	VPBROADCASTQ AX, Z27
+	VPSRLQ       $32, Z23, Z28 // hi32(q)
+
+mredLazyLoop:
+	VMOVDQU64 (SI), Z0
+	VMOVDQU64 (DX), Z1
+
+	VPSRLQ  $32, Z0, Z2
+	VPSRLQ  $32, Z1, Z3
+	MULHI(Z0, Z2, Z1, Z3, Z4, Z5, Z6, Z7) // Z4 = floor(p1*p2 / 2^64)
+	VPMULLQ Z1, Z0, Z8
+	VPMULLQ Z24, Z8, Z8
+	VPSRLQ  $32, Z8, Z9
+	MULHI(Z8, Z9, Z23, Z28, Z10, Z5, Z6, Z7) // Z10 = floor(m*q / 2^64)
+	VPSUBQ  Z10, Z4, Z4
+	VPADDQ  Z23, Z4, Z4
+
+	VMOVDQU64 Z4, (DI)
+
+	ADDQ $64, SI
+	ADDQ $64, DX
+	ADDQ $64, DI
+	SUBQ $8, CX
+	JNZ  mredLazyLoop
+
+	VZEROUPPER
+	RET
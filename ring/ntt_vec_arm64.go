@@ -0,0 +1,23 @@
+//go:build arm64 && !purego
+
+package ring
+
+// hasVecKernels is always true on arm64: the kernels only rely on the baseline
+// 64-bit multiplier (MUL/UMULH). NEON has no 64x64-bit vector multiplication,
+// so the Montgomery arithmetic is carried out on the scalar pipes with two
+// interleaved coefficients per iteration, which keeps both multiplier ports of
+// Neoverse and Apple Silicon cores busy; the loads, stores and additions use
+// paired (LDP/STP) accesses.
+const hasVecKernels = true
+
+//go:noescape
+func butterflyVec(x, y *uint64, n int, psi, twoQ, fourQ, q, mredconstant uint64)
+
+//go:noescape
+func invbutterflyVec(x, y *uint64, n int, psi, twoQ, fourQ, q, mredconstant uint64)
+
+//go:noescape
+func mredVec(p1, p2, p3 *uint64, n int, q, mredconstant uint64)
+
+//go:noescape
+func mredLazyVec(p1, p2, p3 *uint64, n int, q, mredconstant uint64)
@@ -0,0 +1,195 @@
+//go:build arm64 && !purego
+
+#include "textflag.h"
+
+// The kernels below compute the Montgomery butterfly and coefficient-wise
+// Montgomery multiplication over two interleaved coefficients per iteration.
+// NEON has no 64x64-bit vector multiplication, so the products are computed
+// on the scalar multiplier (MUL/UMULH), interleaved to fill both multiplier
+// ports; loads and stores use paired accesses.
+
+// func butterflyVec(x, y *uint64, n int, psi, twoQ, fourQ, q, mredconstant uint64)
+// Computes x[i], y[i] = butterfly(x[i], y[i], psi, twoQ, fourQ, q, mredconstant)
+// for i in [0, n), n a non-zero multiple of 2.
+TEXT ·butterflyVec(SB), NOSPLIT, $0-64
+	MOVD x+0(FP), R0
+	MOVD y+8(FP), R1
+	MOVD n+16(FP), R2
+	MOVD psi+24(FP), R3
+	MOVD twoQ+32(FP), R4
+	MOVD fourQ+40(FP), R5
+	MOVD q+48(FP), R6
+	MOVD mredconstant+56(FP), R7
+
+butterflyLoop:
+	LDP (R0), (R8, R9)   // U0, U1
+	LDP (R1), (R10, R11) // V0, V1
+
+	// U = U mod 4q (lazy: single conditional subtraction)
+	SUBS R5, R8, R12
+	CSEL CS, R12, R8, R8
+	SUBS R5, R9, R12
+	CSEL CS, R12, R9, R9
+
+	// V = MRedLazy(V, psi, q, mredconstant)
+	UMULH R3, R10, R13
+	UMULH R3, R11, R14
+	MUL   R3, R10, R15
+	MUL   R3, R11, R16
+	MUL   R7, R15, R15
+	MUL   R7, R16, R16
+	UMULH R6, R15, R15
+	UMULH R6, R16, R16
+	SUB   R15, R13, R13
+	SUB   R16, R14, R14
+	ADD   R6, R13, R13 // V0 = hi - H + q, < 2q
+	ADD   R6, R14, R14 // V1 = hi - H + q, < 2q
+
+	// x = U + V, y = U + 2q - V
+	ADD R13, R8, R15
+	ADD R14, R9, R16
+	STP (R15, R16), (R0)
+	ADD R4, R8, R15
+	SUB R13, R15, R15
+	ADD R4, R9, R16
+	SUB R14, R16, R16
+	STP (R15, R16), (R1)
+
+	ADD  $16, R0
+	ADD  $16, R1
+	SUBS $2, R2
+	BNE  butterflyLoop
+
+	RET
+
+// func invbutterflyVec(x, y *uint64, n int, psi, twoQ, fourQ, q, mredconstant uint64)
+// Computes x[i], y[i] = invbutterfly(x[i], y[i], psi, twoQ, fourQ, q, mredconstant)
+// for i in [0, n), n a non-zero multiple of 2.
+TEXT ·invbutterflyVec(SB), NOSPLIT, $0-64
+	MOVD x+0(FP), R0
+	MOVD y+8(FP), R1
+	MOVD n+16(FP), R2
+	MOVD psi+24(FP), R3
+	MOVD twoQ+32(FP), R4
+	MOVD fourQ+40(FP), R5
+	MOVD q+48(FP), R6
+	MOVD mredconstant+56(FP), R7
+
+invbutterflyLoop:
+	LDP (R0), (R8, R9)   // U0, U1
+	LDP (R1), (R10, R11) // V0, V1
+
+	// x = U + V mod 2q (lazy: single conditional subtraction)
+	ADD  R10, R8, R12
+	ADD  R11, R9, R13
+	SUBS R4, R12, R14
+	CSEL CS, R14, R12, R12
+	SUBS R4, R13, R14
+	CSEL CS, R14, R13, R13
+	STP  (R12, R13), (R0)
+
+	// y = MRedLazy(U + 4q - V, psi, q, mredconstant)
+	ADD   R5, R8, R8
+	SUB   R10, R8, R8
+	ADD   R5, R9, R9
+	SUB   R11, R9, R9
+	UMULH R3, R8, R13
+	UMULH R3, R9, R14
+	MUL   R3, R8, R15
+	MUL   R3, R9, R16
+	MUL   R7, R15, R15
+	MUL   R7, R16, R16
+	UMULH R6, R15, R15
+	UMULH R6, R16, R16
+	SUB   R15, R13, R13
+	SUB   R16, R14, R14
+	ADD   R6, R13, R13
+	ADD   R6, R14, R14
+	STP   (R13, R14), (R1)
+
+	ADD  $16, R0
+	ADD  $16, R1
+	SUBS $2, R2
+	BNE  invbutterflyLoop
+
+	RET
+
+// func mredVec(p1, p2, p3 *uint64, n int, q, mredconstant uint64)
+// Computes p3[i] = MRed(p1[i], p2[i], q, mredconstant) for i in [0, n),
+// n a non-zero multiple of 2.
+TEXT ·mredVec(SB), NOSPLIT, $0-48
+	MOVD p1+0(FP), R0
+	MOVD p2+8(FP), R1
+	MOVD p3+16(FP), R2
+	MOVD n+24(FP), R3
+	MOVD q+32(FP), R6
+	MOVD mredconstant+40(FP), R7
+
+mredLoop:
+	LDP (R0), (R8, R9)
+	LDP (R1), (R10, R11)
+
+	UMULH R10, R8, R13
+	UMULH R11, R9, R14
+	MUL   R10, R8, R15
+	MUL   R11, R9, R16
+	MUL   R7, R15, R15
+	MUL   R7, R16, R16
+	UMULH R6, R15, R15
+	UMULH R6, R16, R16
+	SUB   R15, R13, R13
+	SUB   R16, R14, R14
+	ADD   R6, R13, R13
+	ADD   R6, R14, R14
+
+	// Final reduction to [0, q)
+	SUBS R6, R13, R15
+	CSEL CS, R15, R13, R13
+	SUBS R6, R14, R15
+	CSEL CS, R15, R14, R14
+	STP  (R13, R14), (R2)
+
+	ADD  $16, R0
+	ADD  $16, R1
+	ADD  $16, R2
+	SUBS $2, R3
+	BNE  mredLoop
+
+	RET
+
+// func mredLazyVec(p1, p2, p3 *uint64, n int, q, mredconstant uint64)
+// Computes p3[i] = MRedLazy(p1[i], p2[i], q, mredconstant) for i in [0, n),
+// n a non-zero multiple of 2.
+TEXT ·mredLazyVec(SB), NOSPLIT, $0-48
+	MOVD p1+0(FP), R0
+	MOVD p2+8(FP), R1
+	MOVD p3+16(FP), R2
+	MOVD n+24(FP), R3
+	MOVD q+32(FP), R6
+	MOVD mredconstant+40(FP), R7
+
+mredLazyLoop:
+	LDP (R0), (R8, R9)
+	LDP (R1), (R10, R11)
+
+	UMULH R10, R8, R13
+	UMULH R11, R9, R14
+	MUL   R10, R8, R15
+	MUL   R11, R9, R16
+	MUL   R7, R15, R15
+	MUL   R7, R16, R16
+	UMULH R6, R15, R15
+	UMULH R6, R16, R16
+	SUB   R15, R13, R13
+	SUB   R16, R14, R14
+	ADD   R6, R13, R13
+	ADD   R6, R14, R14
+	STP   (R13, R14), (R2)
+
+	ADD  $16, R0
+	ADD  $16, R1
+	ADD  $16, R2
+	SUBS $2, R3
+	BNE  mredLazyLoop
+
+	RET
@@ -0,0 +1,71 @@
+//go:build (amd64 || arm64) && !purego
+
+package ring
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+// TestNTTVecKernels checks that the assembly kernels agree with the pure-Go
+// implementations on random inputs.
+func TestNTTVecKernels(t *testing.T) {
+
+	if !hasVecKernels {
+		t.Skip("assembly kernels not available on this CPU")
+	}
+
+	prng, err := sampling.NewPRNG()
+	require.NoError(t, err)
+
+	for _, logN := range []int{4, 8, 12} {
+
+		N := 1 << logN
+
+		r, err := NewRing(N, Qi60[:1])
+		require.NoError(t, err)
+
+		s := r.SubRings[0]
+		Q, MRedConstant := s.Modulus, s.MRedConstant
+
+		sampler := NewUniformSampler(prng, r)
+
+		t.Run(fmt.Sprintf("NTTLazy/N=%d", N), func(t *testing.T) {
+			p := sampler.ReadNew().Coeffs[0]
+			have := make([]uint64, N)
+			want := make([]uint64, N)
+			nttLazyVec(p, have, N, Q, MRedConstant, s.RootsForward)
+			// nttLazy is the scalar reference mirrored by the AVX-512 driver:
+			// nttUnrolled16Lazy skips reductions on alternate stages and only
+			// matches modulo Q.
+			nttLazy(p, want, N, Q, MRedConstant, s.RootsForward)
+			require.Equal(t, want, have)
+		})
+
+		t.Run(fmt.Sprintf("INTTLazy/N=%d", N), func(t *testing.T) {
+			p := sampler.ReadNew().Coeffs[0]
+			have := make([]uint64, N)
+			want := make([]uint64, N)
+			inttLazyVec(p, have, N, Q, MRedConstant, s.RootsBackward)
+			inttLazyUnrolled16(p, want, N, Q, MRedConstant, s.RootsBackward)
+			require.Equal(t, want, have)
+		})
+
+		t.Run(fmt.Sprintf("MRedVec/N=%d", N), func(t *testing.T) {
+			p1 := sampler.ReadNew().Coeffs[0]
+			p2 := sampler.ReadNew().Coeffs[0]
+			have := make([]uint64, N)
+			haveLazy := make([]uint64, N)
+			mredVec(&p1[0], &p2[0], &have[0], N, Q, MRedConstant)
+			mredLazyVec(&p1[0], &p2[0], &haveLazy[0], N, Q, MRedConstant)
+			for i := range have {
+				require.Equal(t, MRed(p1[i], p2[i], Q, MRedConstant), have[i])
+				require.Equal(t, MRedLazy(p1[i], p2[i], Q, MRedConstant), haveLazy[i])
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+//go:build numa && linux
+
+package ring
+
+import (
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// This file implements the NUMA-aware allocation hints enabled by the `numa`
+// build tag. On multi-socket servers, the coefficients of very large
+// polynomials are by default placed on the NUMA node of the allocating
+// goroutine (first-touch policy), and the key-switch inner loops of the other
+// sockets then pay cross-node memory traffic for every access. When built
+// with `-tags=numa`, the backing memory of polynomials of degree at least
+// 2^{16} is interleaved page-by-page across all the allowed NUMA nodes,
+// spreading the traffic evenly over the memory controllers.
+//
+// The hints are best effort: they silently degrade to the first-touch policy
+// on kernels without NUMA support or when the mbind system call is denied.
+const numaMinN = 1 << 16
+
+const (
+	mpolInterleave   = 3
+	mpolFMemsAllowed = 1 << 2
+
+	// numaMaxNodes is the size of the node masks passed to the kernel, an
+	// upper bound on the number of NUMA nodes of the machine.
+	numaMaxNodes = 1024
+)
+
+var numaNodes struct {
+	once sync.Once
+	mask [numaMaxNodes / 64]uint64
+	ok   bool
+}
+
+// numaAllowedNodes returns the mask of the NUMA nodes the process is allowed
+// to allocate from, queried once through get_mempolicy(MPOL_F_MEMS_ALLOWED).
+func numaAllowedNodes() (*[numaMaxNodes / 64]uint64, bool) {
+	numaNodes.once.Do(func() {
+		/* #nosec G103 -- the mask outlives the system call */
+		_, _, errno := unix.Syscall6(
+			unix.SYS_GET_MEMPOLICY,
+			0,
+			uintptr(unsafe.Pointer(&numaNodes.mask[0])),
+			numaMaxNodes,
+			0,
+			mpolFMemsAllowed,
+			0)
+		numaNodes.ok = errno == 0
+	})
+	return &numaNodes.mask, numaNodes.ok
+}
+
+// numaInterleave hints the kernel to interleave the pages backing the
+// coefficients across all the allowed NUMA nodes. Polynomials below the
+// numaMinN degree threshold are left on the first-touch policy, and failures
+// are ignored.
+func numaInterleave(coeffs []uint64) {
+
+	if len(coeffs) < numaMinN {
+		return
+	}
+
+	mask, ok := numaAllowedNodes()
+	if !ok {
+		return
+	}
+
+	// Only the pages fully contained in the slice are rebound, as the
+	// boundary pages can be shared with neighboring heap objects.
+	page := uintptr(unix.Getpagesize())
+	/* #nosec G103 -- the backing array is heap-allocated and does not move */
+	addr := uintptr(unsafe.Pointer(&coeffs[0]))
+	start := (addr + page - 1) &^ (page - 1)
+	end := (addr + uintptr(len(coeffs))*8) &^ (page - 1)
+
+	if end <= start {
+		return
+	}
+
+	/* #nosec G103 -- bounds checked above */
+	unix.Syscall6(
+		unix.SYS_MBIND,
+		start,
+		end-start,
+		mpolInterleave,
+		uintptr(unsafe.Pointer(&mask[0])),
+		numaMaxNodes,
+		0)
+}
@@ -0,0 +1,7 @@
+//go:build !numa || !linux
+
+package ring
+
+// numaInterleave is a no-op unless the `numa` build tag is set on Linux: the
+// backing memory of the polynomial follows the default first-touch placement.
+func numaInterleave(coeffs []uint64) {}
@@ -0,0 +1,36 @@
+//go:build numa && linux
+
+package ring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNUMA(t *testing.T) {
+
+	t.Run("Interleave", func(t *testing.T) {
+
+		// The hints are best effort: the allocation must remain usable
+		// whether or not the kernel honored them.
+		N := numaMinN
+		pol := NewPoly(N, 1)
+
+		for i := range pol.Coeffs {
+			require.Len(t, pol.Coeffs[i], N)
+			pol.Coeffs[i][0] = 1
+			pol.Coeffs[i][N-1] = 1
+		}
+
+		pol.Resize(3)
+		require.Equal(t, 3, pol.Level())
+	})
+
+	t.Run("Prefetch", func(t *testing.T) {
+		coeffs := make([]uint64, 1<<12)
+		Prefetch(coeffs)
+		Prefetch(coeffs[:7])
+		Prefetch(nil)
+	})
+}
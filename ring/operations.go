@@ -266,11 +266,16 @@ func (r Ring) MulDoubleRNSScalarThenAdd(p1 Poly, scalar0, scalar1 RNSScalar, p2
 }
 
 // EvalPolyScalar evaluate p2 = p1(scalar) coefficient-wise in the ring.
+// The evaluation uses a Horner scheme with a fused multiply-then-add pass per
+// step and the Montgomery form of scalar precomputed once per sub-ring.
 func (r Ring) EvalPolyScalar(p1 []Poly, scalar uint64, p2 Poly) {
 	p2.Copy(p1[len(p1)-1])
-	for i := len(p1) - 1; i > 0; i-- {
-		r.MulScalar(p2, scalar, p2)
-		r.Add(p2, p1[i-1], p2)
+	for i, s := range r.SubRings[:r.level+1] {
+		scalarMont := MForm(scalar, s.Modulus, s.BRedConstant)
+		acc := p2.Coeffs[i]
+		for j := len(p1) - 1; j > 0; j-- {
+			s.MulScalarMontgomeryThenAddPoly(acc, scalarMont, p1[j-1].Coeffs[i], acc)
+		}
 	}
 }
 
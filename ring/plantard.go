@@ -0,0 +1,243 @@
+package ring
+
+import (
+	"math/bits"
+)
+
+// This file implements Plantard modular multiplication ("Efficient Word Size
+// Modular Arithmetic", IEEE TETC 2021) as an alternative to the
+// Montgomery/Barrett arithmetic of [SubRing]: a multiplication by a constant
+// in the Plantard domain requires one less multiplication than [MRed], which
+// reduces the instruction count of the NTT butterflies for moduli below 2^62.
+
+// GenPRedConstant generates the constant -q^-1 mod 2^128 required by [PRed],
+// decomposed as [2]uint64{lo, hi}, for odd q.
+func GenPRedConstant(q uint64) (predconstant [2]uint64) {
+
+	// q^-1 mod 2^64, lifted to 2^128 with one Newton iteration:
+	// x*(2 - q*x) mod 2^128.
+	x := GenMRedConstant(q)
+
+	qxHi, _ := bits.Mul64(q, x) // q*x = qxHi*2^64 + 1
+
+	// [1, -qxHi] = 2 - q*x mod 2^128
+	predconstant[0] = x
+	predconstant[1] = x * -qxHi
+
+	// -q^-1 mod 2^128
+	predconstant[1] = ^predconstant[1]
+	predconstant[0] = -predconstant[0]
+	if predconstant[0] == 0 {
+		predconstant[1]++
+	}
+
+	return
+}
+
+// PRed computes x*y*(2^-128) mod q in the range [0, q-1], for q < 2^62 and
+// predconstant = -q^-1 mod 2^128 (see [GenPRedConstant]).
+func PRed(x, y, q uint64, predconstant [2]uint64) (r uint64) {
+
+	abHi, abLo := bits.Mul64(x, y)
+
+	// Top word of a*b*predconstant mod 2^128
+	mHi, _ := bits.Mul64(abLo, predconstant[0])
+	mHi += abLo*predconstant[1] + abHi*predconstant[0]
+
+	// The correction term 2 makes the estimated quotient exact for
+	// x*y <= q*2^64 and q < 2^63 (a wrap-around of mHi+2 only occurs
+	// for results that are 0 mod q, for which it returns 0).
+	r, _ = bits.Mul64(mHi+2, q)
+
+	if r >= q {
+		r -= q
+	}
+
+	return
+}
+
+// PForm switches a to the Plantard domain by computing a*2^128 mod q, so that
+// [PRed] with one operand in the Plantard domain returns the product of the
+// standard-domain operands.
+func PForm(a, q uint64, bredconstant [2]uint64) uint64 {
+	return MForm(MForm(a, q, bredconstant), q, bredconstant)
+}
+
+// NumberTheoreticTransformerPlantard computes the standard nega-cyclic NTT in
+// the ring Z[X]/(X^N+1), with the twiddle multiplications evaluated with
+// [PRed] instead of [MRed]. It requires moduli below 2^62. The transform
+// constants of the [NTTTable] are shared with the Montgomery path; the tables
+// in the Plantard domain are derived from them on the first transform.
+type NumberTheoreticTransformerPlantard struct {
+	numberTheoreticTransformerBase
+
+	predconstant [2]uint64
+
+	// Powers of the 2N-th primitive root (resp. of its inverse) in the
+	// Plantard domain, in bit-reversed order, and N^-1 mod Modulus in the
+	// Plantard domain. Derived lazily from the NTTTable.
+	rootsForward  []uint64
+	rootsBackward []uint64
+	nInv          uint64
+}
+
+// NewNumberTheoreticTransformerPlantard instantiates a new [NumberTheoreticTransformerPlantard].
+func NewNumberTheoreticTransformerPlantard(r *SubRing, n int) NumberTheoreticTransformer {
+	return &NumberTheoreticTransformerPlantard{
+		numberTheoreticTransformerBase: numberTheoreticTransformerBase{
+			N:            r.N,
+			Modulus:      r.Modulus,
+			MRedConstant: r.MRedConstant,
+			BRedConstant: r.BRedConstant,
+			NTTTable:     r.NTTTable,
+		},
+		predconstant: GenPRedConstant(r.Modulus),
+	}
+}
+
+// initPlantardTables derives the Plantard-domain tables from the
+// Montgomery-domain constants of the NTTTable: a*2^64 mod q maps to
+// a*2^128 mod q with one more [MForm].
+func (rntt *NumberTheoreticTransformerPlantard) initPlantardTables() {
+
+	Q := rntt.Modulus
+	brc := rntt.BRedConstant
+
+	rntt.rootsForward = make([]uint64, len(rntt.RootsForward))
+	rntt.rootsBackward = make([]uint64, len(rntt.RootsBackward))
+
+	for i := range rntt.rootsForward {
+		rntt.rootsForward[i] = MForm(rntt.RootsForward[i], Q, brc)
+		rntt.rootsBackward[i] = MForm(rntt.RootsBackward[i], Q, brc)
+	}
+
+	rntt.nInv = MForm(rntt.NInv, Q, brc)
+}
+
+// Forward writes the forward NTT in Z[X]/(X^N+1) of p1 on p2.
+func (rntt *NumberTheoreticTransformerPlantard) Forward(p1, p2 []uint64) {
+	if rntt.rootsForward == nil {
+		rntt.initPlantardTables()
+	}
+	nttPlantardLazy(p1, p2, rntt.N, rntt.Modulus, rntt.predconstant, rntt.rootsForward)
+	reducevec(p2, p2, rntt.Modulus, rntt.BRedConstant)
+}
+
+// ForwardLazy writes the forward NTT in Z[X]/(X^N+1) of p1 on p2.
+// Returns values in the range [0, 4q-1].
+func (rntt *NumberTheoreticTransformerPlantard) ForwardLazy(p1, p2 []uint64) {
+	if rntt.rootsForward == nil {
+		rntt.initPlantardTables()
+	}
+	nttPlantardLazy(p1, p2, rntt.N, rntt.Modulus, rntt.predconstant, rntt.rootsForward)
+}
+
+// Backward writes the backward NTT in Z[X]/(X^N+1) of p1 on p2.
+func (rntt *NumberTheoreticTransformerPlantard) Backward(p1, p2 []uint64) {
+	if rntt.rootsForward == nil {
+		rntt.initPlantardTables()
+	}
+	inttPlantard(p1, p2, rntt.N, rntt.Modulus, rntt.predconstant, rntt.nInv, rntt.rootsBackward)
+}
+
+// BackwardLazy writes the backward NTT in Z[X]/(X^N+1) of p1 on p2.
+// Unlike the Montgomery path, the output is fully reduced in [0, modulus-1].
+func (rntt *NumberTheoreticTransformerPlantard) BackwardLazy(p1, p2 []uint64) {
+	if rntt.rootsForward == nil {
+		rntt.initPlantardTables()
+	}
+	inttPlantard(p1, p2, rntt.N, rntt.Modulus, rntt.predconstant, rntt.nInv, rntt.rootsBackward)
+}
+
+// butterflyPlantard computes X, Y = U + V*Psi, U - V*Psi mod q. V*Psi is
+// fully reduced by [PRed], so reducing the inputs below 2q keeps all the
+// intermediate values below 4q < 2^64 for q < 2^62: inputs in [0, 4q-1] give
+// outputs in [0, 4q-1].
+func butterflyPlantard(U, V, Psi, twoQ, Q uint64, predconstant [2]uint64) (uint64, uint64) {
+	if U >= twoQ {
+		U -= twoQ
+	}
+	V = PRed(V, Psi, Q, predconstant)
+	return U + V, U + twoQ - V
+}
+
+// invbutterflyPlantard computes X, Y = U + V, (U - V) * Psi mod q. Inputs in
+// [0, 2q-1] give outputs in [0, 2q-1].
+func invbutterflyPlantard(U, V, Psi, twoQ, Q uint64, predconstant [2]uint64) (X, Y uint64) {
+	X = U + V
+	if X >= twoQ {
+		X -= twoQ
+	}
+	Y = PRed(U+twoQ-V, Psi, Q, predconstant)
+	return
+}
+
+// nttPlantardLazy evaluates p2 = NTT(p1) with outputs in the range [0, 4q-1].
+func nttPlantardLazy(p1, p2 []uint64, N int, Q uint64, predconstant [2]uint64, roots []uint64) {
+
+	twoQ := 2 * Q
+
+	t := N >> 1
+	F := roots[1]
+
+	for jx, jy := 0, t; jx < t; jx, jy = jx+1, jy+1 {
+		p2[jx], p2[jy] = butterflyPlantard(p1[jx], p1[jy], F, twoQ, Q, predconstant)
+	}
+
+	for m := 2; m < N; m <<= 1 {
+
+		t >>= 1
+
+		for i := 0; i < m; i++ {
+
+			j1 := (i * t) << 1
+			j2 := j1 + t
+
+			F = roots[m+i]
+
+			for jx, jy := j1, j1+t; jx < j2; jx, jy = jx+1, jy+1 {
+				p2[jx], p2[jy] = butterflyPlantard(p2[jx], p2[jy], F, twoQ, Q, predconstant)
+			}
+		}
+	}
+}
+
+// inttPlantard evaluates p2 = INTT(p1) with fully reduced outputs.
+func inttPlantard(p1, p2 []uint64, N int, Q uint64, predconstant [2]uint64, nInv uint64, roots []uint64) {
+
+	twoQ := 2 * Q
+
+	t := 1
+	h := N >> 1
+
+	for i, j1, j2 := 0, 0, t; i < h; i, j1, j2 = i+1, j1+2*t, j2+2*t {
+
+		F := roots[h+i]
+
+		for jx, jy := j1, j1+t; jx < j2; jx, jy = jx+1, jy+1 {
+			p2[jx], p2[jy] = invbutterflyPlantard(p1[jx], p1[jy], F, twoQ, Q, predconstant)
+		}
+	}
+
+	t <<= 1
+
+	for m := N >> 1; m > 1; m >>= 1 {
+
+		h = m >> 1
+
+		for i, j1, j2 := 0, 0, t; i < h; i, j1, j2 = i+1, j1+2*t, j2+2*t {
+
+			F := roots[h+i]
+
+			for jx, jy := j1, j1+t; jx < j2; jx, jy = jx+1, jy+1 {
+				p2[jx], p2[jy] = invbutterflyPlantard(p2[jx], p2[jy], F, twoQ, Q, predconstant)
+			}
+		}
+
+		t <<= 1
+	}
+
+	for i := range p2 {
+		p2[i] = PRed(p2[i], nInv, Q, predconstant)
+	}
+}
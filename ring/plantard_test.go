@@ -0,0 +1,73 @@
+package ring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestPlantard(t *testing.T) {
+
+	const N = 128
+
+	t.Run("PRed", func(t *testing.T) {
+
+		for _, q := range []uint64{Qi60[0], Pi60[0], 0x3ee0001, 0x3ffffffffffffeef} {
+
+			require.True(t, IsPrime(q))
+
+			brc := GenBRedConstant(q)
+			prc := GenPRedConstant(q)
+
+			check := func(x, y uint64) {
+				// One operand in the Plantard domain, the other lazy in [0, 4q-1].
+				require.Equal(t, BRed(x%q, y%q, q, brc), PRed(x%(4*q), PForm(y%q, q, brc), q, prc)%q)
+			}
+
+			check(0, 0)
+			check(1, 1)
+			check(q-1, q-1)
+			check(4*q-1, q-1)
+
+			for i := 0; i < 1024; i++ {
+				check(sampling.RandUint64(), sampling.RandUint64())
+			}
+		}
+	})
+
+	t.Run("NTT", func(t *testing.T) {
+
+		rp, err := NewRingPlantard(N, Qi60[:3])
+		require.NoError(t, err)
+		require.Equal(t, Standard, rp.Type())
+
+		r, err := NewRing(N, Qi60[:3])
+		require.NoError(t, err)
+
+		prng, err := sampling.NewPRNG()
+		require.NoError(t, err)
+
+		a := NewUniformSampler(prng, r).ReadNew()
+
+		want := r.NewPoly()
+		r.NTT(a, want)
+
+		have := rp.NewPoly()
+		rp.NTT(a, have)
+		require.True(t, want.Equal(&have))
+
+		rp.INTT(have, have) // also tests the in-place transform
+		require.True(t, a.Equal(&have))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+
+		// Modulus not below 2^62.
+		_, err := NewRingPlantard(N, []uint64{0x7fffffffe0001}) // 51 bits, ok
+		require.NoError(t, err)
+		_, err = NewRingPlantard(N, []uint64{1<<62 + 1})
+		require.Error(t, err)
+	})
+}
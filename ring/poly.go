@@ -19,6 +19,7 @@ func NewPoly(N, Level int) (pol Poly) {
 	Coeffs := make([][]uint64, Level+1)
 	for i := range Coeffs {
 		Coeffs[i] = make([]uint64, N)
+		numaInterleave(Coeffs[i])
 	}
 	return Poly{Coeffs: Coeffs}
 }
@@ -35,6 +36,7 @@ func (pol *Poly) Resize(level int) {
 		pol.Coeffs = append(pol.Coeffs, make([][]uint64, level-prevLevel)...)
 		for i := prevLevel + 1; i < level+1; i++ {
 			pol.Coeffs[i] = make([]uint64, N)
+			numaInterleave(pol.Coeffs[i])
 		}
 	}
 }
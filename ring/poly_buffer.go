@@ -0,0 +1,44 @@
+package ring
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// PolyBufferSize returns the number of bytes required by [NewPolyFromBuffer]
+// to back a polynomial of degree N with Level+1 moduli.
+func PolyBufferSize(N, Level int) int {
+	return 8 * N * (Level + 1)
+}
+
+// NewPolyFromBuffer constructs a polynomial of degree N with Level+1 moduli
+// whose coefficients alias the caller-provided buffer, without copies. The
+// buffer can live outside of the Go heap, e.g. in an mmap'd shared-memory
+// segment, enabling zero-copy handoff of polynomials between processes.
+//
+// The buffer must be 8-byte aligned and at least [PolyBufferSize](N, Level)
+// bytes long; its content is reinterpreted in place as native-endian uint64
+// coefficients, modulus after modulus. The caller must ensure that the buffer
+// outlives the returned polynomial and must synchronize concurrent access to
+// the shared memory itself.
+func NewPolyFromBuffer(N, Level int, buff []byte) (pol Poly, err error) {
+
+	if size := PolyBufferSize(N, Level); len(buff) < size {
+		return Poly{}, fmt.Errorf("invalid buffer: must be at least %d bytes but is %d", size, len(buff))
+	}
+
+	/* #nosec G103 -- behavior and consequences well understood, the buffer is reinterpreted in place as uint64 coefficients */
+	if uintptr(unsafe.Pointer(&buff[0]))&7 != 0 {
+		return Poly{}, fmt.Errorf("invalid buffer: must be 8-byte aligned")
+	}
+
+	/* #nosec G103 -- behavior and consequences well understood, bounds and alignment checked above */
+	coeffs := unsafe.Slice((*uint64)(unsafe.Pointer(&buff[0])), N*(Level+1))
+
+	pol.Coeffs = make([][]uint64, Level+1)
+	for i := range pol.Coeffs {
+		pol.Coeffs[i] = coeffs[i*N : (i+1)*N : (i+1)*N]
+	}
+
+	return
+}
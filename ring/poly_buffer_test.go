@@ -0,0 +1,73 @@
+package ring
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestPolyFromBuffer(t *testing.T) {
+
+	const (
+		N     = 64
+		level = 2
+	)
+
+	r, err := NewRing(N, Qi60[:level+1])
+	require.NoError(t, err)
+
+	// 8-byte aligned backing memory, standing in for e.g. an mmap'd segment.
+	backing := make([]uint64, N*(level+1))
+	/* #nosec G103 -- test-only reinterpretation of an aligned uint64 slice as bytes */
+	buff := unsafe.Slice((*byte)(unsafe.Pointer(&backing[0])), 8*len(backing))
+
+	pol, err := NewPolyFromBuffer(N, level, buff)
+	require.NoError(t, err)
+	require.Equal(t, N, pol.N())
+	require.Equal(t, level, pol.Level())
+
+	t.Run("Aliasing", func(t *testing.T) {
+
+		for i := range pol.Coeffs {
+			for j := range pol.Coeffs[i] {
+				pol.Coeffs[i][j] = sampling.RandUint64() % Qi60[i]
+				require.Equal(t, pol.Coeffs[i][j], backing[i*N+j])
+			}
+		}
+
+		backing[0] = 42
+		require.Equal(t, uint64(42), pol.Coeffs[0][0])
+		pol.Coeffs[0][0] = 0
+		backing[0] = 0
+	})
+
+	t.Run("RingOperations", func(t *testing.T) {
+
+		for i := range pol.Coeffs {
+			for j := range pol.Coeffs[i] {
+				pol.Coeffs[i][j] = sampling.RandUint64() % Qi60[i]
+			}
+		}
+
+		want := *pol.CopyNew()
+
+		r.NTT(pol, pol)
+		r.INTT(pol, pol)
+
+		require.True(t, r.Equal(want, pol))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+
+		// Too short.
+		_, err := NewPolyFromBuffer(N, level, buff[:PolyBufferSize(N, level)-1])
+		require.Error(t, err)
+
+		// Misaligned.
+		_, err = NewPolyFromBuffer(N, level-1, buff[1:])
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,94 @@
+package ring
+
+import (
+	"fmt"
+	"sync"
+)
+
+// PolyPool is a [sync.Pool]-backed allocator of [Poly] scratch values, with
+// one pool per level so that a Get always returns a polynomial of the
+// requested dimensions. It relieves the garbage collector in hot paths that
+// need temporary polynomials whose level is only known at call time, where a
+// fixed pre-allocated buffer cannot be used.
+//
+// A PolyPool is safe for concurrent use. Applications performing a very large
+// number of operations can pre-populate the pools with [PolyPool.Warm] to
+// avoid allocations in steady state.
+type PolyPool struct {
+	n     int
+	pools []sync.Pool
+}
+
+// NewPolyPool creates a new [PolyPool] allocating polynomials of degree N with
+// levels in [0, MaxLevel].
+func NewPolyPool(N, MaxLevel int) (p *PolyPool) {
+
+	p = &PolyPool{
+		n:     N,
+		pools: make([]sync.Pool, MaxLevel+1),
+	}
+
+	for i := range p.pools {
+		level := i
+		p.pools[i].New = func() any {
+			pol := NewPoly(N, level)
+			return &pol
+		}
+	}
+
+	return
+}
+
+// NewPolyPool creates a new [PolyPool] allocating polynomials with the degree
+// and maximum level of the receiver.
+func (r Ring) NewPolyPool() *PolyPool {
+	return NewPolyPool(r.N(), r.MaxLevel())
+}
+
+// Get returns a polynomial of degree N at the given level, recycling one
+// previously released with [PolyPool.Put] if available.
+// Caution: the coefficients of a recycled polynomial are not zeroed.
+func (p *PolyPool) Get(level int) Poly {
+
+	// Sanity check
+	if level < 0 || level >= len(p.pools) {
+		panic(fmt.Sprintf("cannot Get: level=%d must be in [0, %d]", level, len(p.pools)-1))
+	}
+
+	return *(p.pools[level].Get().(*Poly))
+}
+
+// Put releases a polynomial back to the pool for reuse. Polynomials whose
+// dimensions do not match the pool are silently dropped, so that polynomials
+// resized by the caller cannot poison the pool.
+func (p *PolyPool) Put(pol Poly) {
+
+	level := pol.Level()
+
+	if pol.N() != p.n || level >= len(p.pools) {
+		return
+	}
+
+	for i := range pol.Coeffs {
+		if len(pol.Coeffs[i]) != p.n {
+			return
+		}
+	}
+
+	p.pools[level].Put(&pol)
+}
+
+// Warm pre-populates the pool at the given level with count polynomials, so
+// that subsequent Gets do not allocate.
+func (p *PolyPool) Warm(level, count int) {
+
+	polys := make([]Poly, count)
+
+	for i := range polys {
+		polys[i] = p.Get(level)
+	}
+
+	for i := range polys {
+		p.Put(polys[i])
+	}
+}
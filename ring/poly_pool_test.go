@@ -0,0 +1,47 @@
+package ring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolyPool(t *testing.T) {
+
+	const N = 64
+
+	r, err := NewRing(N, Qi60[:3])
+	require.NoError(t, err)
+
+	pool := r.NewPolyPool()
+
+	t.Run("Get", func(t *testing.T) {
+		for level := 0; level < r.MaxLevel()+1; level++ {
+			pol := pool.Get(level)
+			require.Equal(t, N, pol.N())
+			require.Equal(t, level, pol.Level())
+			pool.Put(pol)
+		}
+	})
+
+	t.Run("Warm", func(t *testing.T) {
+		pool.Warm(r.MaxLevel(), 4)
+		pol := pool.Get(r.MaxLevel())
+		require.Equal(t, r.MaxLevel(), pol.Level())
+		pool.Put(pol)
+	})
+
+	t.Run("Put/ForeignDimensions", func(t *testing.T) {
+		// Dropped without panicking, and does not poison the pool.
+		pool.Put(NewPoly(2*N, 0))
+		pool.Put(NewPoly(N, r.MaxLevel()+1))
+		pol := pool.Get(0)
+		require.Equal(t, N, pol.N())
+		pool.Put(pol)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		require.Panics(t, func() { pool.Get(-1) })
+		require.Panics(t, func() { pool.Get(r.MaxLevel() + 1) })
+	})
+}
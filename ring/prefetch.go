@@ -0,0 +1,20 @@
+//go:build numa && (amd64 || arm64) && !purego
+
+package ring
+
+// prefetchPtr requests the cache line containing p ahead of its use.
+//
+//go:noescape
+func prefetchPtr(p *uint64)
+
+// Prefetch requests that the coefficients be staged into the cache hierarchy
+// ahead of their use, one request per cache line. The key-switch inner loops
+// use it to overlap the fetch of the next decomposition limb of the
+// evaluation key — possibly resident on a remote NUMA node — with the
+// computation on the current one. It is a no-op unless the `numa` build tag
+// is set on a supported architecture.
+func Prefetch(coeffs []uint64) {
+	for i := 0; i < len(coeffs); i += 8 {
+		prefetchPtr(&coeffs[i])
+	}
+}
@@ -0,0 +1,9 @@
+//go:build numa && !purego
+
+#include "textflag.h"
+
+// func prefetchPtr(p *uint64)
+TEXT ·prefetchPtr(SB), NOSPLIT, $0-8
+	MOVQ       p+0(FP), AX
+	PREFETCHT1 (AX)
+	RET
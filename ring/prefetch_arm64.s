@@ -0,0 +1,9 @@
+//go:build numa && !purego
+
+#include "textflag.h"
+
+// func prefetchPtr(p *uint64)
+TEXT ·prefetchPtr(SB), NOSPLIT, $0-8
+	MOVD p+0(FP), R0
+	PRFM (R0), PLDL2KEEP
+	RET
@@ -0,0 +1,8 @@
+//go:build !numa || purego || (!amd64 && !arm64)
+
+package ring
+
+// Prefetch requests that the coefficients be staged into the cache hierarchy
+// ahead of their use. It is a no-op unless the `numa` build tag is set on a
+// supported architecture.
+func Prefetch(coeffs []uint64) {}
@@ -0,0 +1,241 @@
+package ring
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	"github.com/tuneinsight/lattigo/v6/utils"
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+)
+
+// NumberTheoreticTransformerPrimeCyclotomic computes the CRT transform of the
+// prime cyclotomic ring Z_q[X]/Phi_p(X) of degree N = p-1, with p an odd
+// prime: the forward transform evaluates a polynomial at the p-th primitive
+// roots of unity zeta^j for j = 1, ..., p-1, so that the coefficient-wise
+// operations on the transformed polynomials act slot-wise, exactly as the
+// power-of-two NTT does for Z_q[X]/(X^N + 1). The transform is evaluated
+// naively in O(N^2) operations per modulus.
+type NumberTheoreticTransformerPrimeCyclotomic struct {
+	numberTheoreticTransformerBase
+}
+
+// NewNumberTheoreticTransformerPrimeCyclotomic instantiates a new [NumberTheoreticTransformerPrimeCyclotomic].
+func NewNumberTheoreticTransformerPrimeCyclotomic(r *SubRing, n int) NumberTheoreticTransformer {
+	return NumberTheoreticTransformerPrimeCyclotomic{
+		numberTheoreticTransformerBase: numberTheoreticTransformerBase{
+			N:            r.N,
+			Modulus:      r.Modulus,
+			MRedConstant: r.MRedConstant,
+			BRedConstant: r.BRedConstant,
+			NTTTable:     r.NTTTable,
+		},
+	}
+}
+
+// Forward writes the forward transform of p1 on p2: p2[j-1] = p1(zeta^j).
+func (rntt NumberTheoreticTransformerPrimeCyclotomic) Forward(p1, p2 []uint64) {
+	primeCyclotomicDFT(p1, p2, rntt.N, rntt.Modulus, rntt.MRedConstant, rntt.RootsForward)
+}
+
+// ForwardLazy writes the forward transform of p1 on p2.
+// Unlike the power-of-two NTT, the output is fully reduced in [0, modulus-1].
+func (rntt NumberTheoreticTransformerPrimeCyclotomic) ForwardLazy(p1, p2 []uint64) {
+	primeCyclotomicDFT(p1, p2, rntt.N, rntt.Modulus, rntt.MRedConstant, rntt.RootsForward)
+}
+
+// Backward writes the backward transform of p1 on p2.
+func (rntt NumberTheoreticTransformerPrimeCyclotomic) Backward(p1, p2 []uint64) {
+	primeCyclotomicIDFT(p1, p2, rntt.N, rntt.Modulus, rntt.MRedConstant, rntt.NInv, rntt.RootsBackward)
+}
+
+// BackwardLazy writes the backward transform of p1 on p2.
+// Unlike the power-of-two NTT, the output is fully reduced in [0, modulus-1].
+func (rntt NumberTheoreticTransformerPrimeCyclotomic) BackwardLazy(p1, p2 []uint64) {
+	primeCyclotomicIDFT(p1, p2, rntt.N, rntt.Modulus, rntt.MRedConstant, rntt.NInv, rntt.RootsBackward)
+}
+
+// primeCyclotomicDFT evaluates p2[j-1] = p1(zeta^j) for j = 1, ..., p-1, with
+// roots[k] = zeta^k in the Montgomery domain.
+func primeCyclotomicDFT(p1, p2 []uint64, N int, Q, MRedConstant uint64, roots []uint64) {
+
+	p := N + 1
+
+	in := p1
+	if &p1[0] == &p2[0] {
+		in = make([]uint64, N)
+		copy(in, p1)
+	}
+
+	for j := 1; j <= N; j++ {
+		var acc uint64
+		k := 0
+		for i := 0; i < N; i++ {
+			acc = CRed(acc+MRed(in[i], roots[k], Q, MRedConstant), Q)
+			if k += j; k >= p {
+				k -= p
+			}
+		}
+		p2[j-1] = acc
+	}
+}
+
+// primeCyclotomicIDFT inverts [primeCyclotomicDFT]: the missing evaluation at
+// zeta^0 is recovered from the constraint that the coefficient of degree p-1
+// of the inverse length-p DFT is zero, i.e. F_0 = -sum_j F_j*zeta^j, and the
+// coefficients follow as f_i = p^-1 * (F_0 + sum_j F_j*zeta^-ij), with
+// roots[k] = zeta^-k in the Montgomery domain and nInv = p^-1 in the
+// Montgomery domain.
+func primeCyclotomicIDFT(p1, p2 []uint64, N int, Q, MRedConstant, nInv uint64, roots []uint64) {
+
+	p := N + 1
+
+	in := p1
+	if &p1[0] == &p2[0] {
+		in = make([]uint64, N)
+		copy(in, p1)
+	}
+
+	var F0 uint64
+	for j := 1; j <= N; j++ {
+		F0 = CRed(F0+MRed(in[j-1], roots[p-j], Q, MRedConstant), Q)
+	}
+	if F0 != 0 {
+		F0 = Q - F0
+	}
+
+	for i := 0; i < N; i++ {
+		acc := F0
+		k := 0
+		for j := 1; j <= N; j++ {
+			if k += i; k >= p {
+				k -= p
+			}
+			acc = CRed(acc+MRed(in[j-1], roots[k], Q, MRedConstant), Q)
+		}
+		p2[i] = MRed(acc, nInv, Q, MRedConstant)
+	}
+}
+
+// generateNTTConstantsPrimeCyclotomic generates the transform constants of a
+// prime cyclotomic SubRing: the naturally ordered powers of a primitive p-th
+// root of unity (and of its inverse) in the Montgomery domain, and the
+// Montgomery form of p^-1.
+func (s *SubRing) generateNTTConstantsPrimeCyclotomic() (err error) {
+
+	Modulus := s.Modulus
+	p := s.NthRoot
+
+	if !IsPrime(Modulus) {
+		return fmt.Errorf("invalid modulus: %d is not prime)", Modulus)
+	}
+
+	if Modulus%p != 1 {
+		return fmt.Errorf("invalid modulus: %d != 1 mod %d)", Modulus, p)
+	}
+
+	if s.PrimitiveRoot != 0 && s.Factors != nil {
+		if err = CheckPrimitiveRoot(s.PrimitiveRoot, s.Modulus, s.Factors); err != nil {
+			return
+		}
+	} else {
+		if s.PrimitiveRoot, s.Factors, err = PrimitiveRoot(Modulus, s.Factors); err != nil {
+			return
+		}
+	}
+
+	s.NInv = MForm(ModExp(p, Modulus-2, Modulus), Modulus, s.BRedConstant)
+
+	PsiMont := MForm(ModExp(s.PrimitiveRoot, (Modulus-1)/p, Modulus), Modulus, s.BRedConstant)
+	PsiInvMont := MForm(ModExp(s.PrimitiveRoot, Modulus-((Modulus-1)/p)-1, Modulus), Modulus, s.BRedConstant)
+
+	s.RootsForward = make([]uint64, p)
+	s.RootsBackward = make([]uint64, p)
+
+	s.RootsForward[0] = MForm(1, Modulus, s.BRedConstant)
+	s.RootsBackward[0] = MForm(1, Modulus, s.BRedConstant)
+
+	for k := uint64(1); k < p; k++ {
+		s.RootsForward[k] = MRed(s.RootsForward[k-1], PsiMont, Modulus, s.MRedConstant)
+		s.RootsBackward[k] = MRed(s.RootsBackward[k-1], PsiInvMont, Modulus, s.MRedConstant)
+	}
+
+	return
+}
+
+// NewRingPrimeCyclotomic creates a new RNS Ring over the prime cyclotomic
+// polynomial Phi_p(X) = 1 + X + ... + X^(p-1), with p = N+1 an odd prime:
+// such rings offer richer slot structures than the power-of-two cyclotomics,
+// e.g. for bgv-style packing. N must be a multiple of 8 (required by the
+// loop-unrolled coefficient-wise operations) and all the moduli must be
+// distinct primes equal to 1 mod p. Prime-power cyclotomics Phi_{p^k} with
+// k > 1 are not supported yet.
+func NewRingPrimeCyclotomic(N int, ModuliChain []uint64) (r *Ring, err error) {
+
+	r = new(Ring)
+
+	if N < MinimumRingDegreeForLoopUnrolledOperations || N%MinimumRingDegreeForLoopUnrolledOperations != 0 {
+		return nil, fmt.Errorf("invalid ring degree: must be a non-zero multiple of %d", MinimumRingDegreeForLoopUnrolledOperations)
+	}
+
+	if !IsPrime(uint64(N + 1)) {
+		return nil, fmt.Errorf("invalid ring degree: N+1=%d is not prime", N+1)
+	}
+
+	if len(ModuliChain) == 0 {
+		return nil, fmt.Errorf("invalid ModuliChain (must be a non-empty []uint64)")
+	}
+
+	if !utils.AllDistinct(ModuliChain) {
+		return nil, fmt.Errorf("invalid ModuliChain (moduli are not distinct)")
+	}
+
+	// Computes bigQ for all levels
+	r.ModulusAtLevel = make([]*big.Int, len(ModuliChain))
+	r.ModulusAtLevel[0] = bignum.NewInt(ModuliChain[0])
+	for i := 1; i < len(ModuliChain); i++ {
+		r.ModulusAtLevel[i] = new(big.Int).Mul(r.ModulusAtLevel[i-1], bignum.NewInt(ModuliChain[i]))
+	}
+
+	r.SubRings = make([]*SubRing, len(ModuliChain))
+
+	for i := range r.SubRings {
+		if r.SubRings[i], err = newSubRingPrimeCyclotomic(N, ModuliChain[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	r.RescaleConstants = rewRescaleConstants(r.SubRings)
+
+	r.level = len(ModuliChain) - 1
+
+	return r, r.generateNTTConstants(nil, nil)
+}
+
+// newSubRingPrimeCyclotomic creates a new prime cyclotomic SubRing of degree
+// N = p-1 and modulus Modulus, with the p-th root of unity.
+func newSubRingPrimeCyclotomic(N int, Modulus uint64) (s *SubRing, err error) {
+
+	s = &SubRing{}
+
+	s.N = N
+
+	s.Modulus = Modulus
+	s.Mask = (1 << uint64(bits.Len64(Modulus-1))) - 1
+
+	// Computes the fast modular reduction constants for the Ring
+	s.BRedConstant = GenBRedConstant(Modulus)
+
+	// If qi is not a power of 2, we can compute the MRed (otherwise, it
+	// would return an error as there is no valid Montgomery form mod a power of 2)
+	if (Modulus&(Modulus-1)) != 0 && Modulus != 0 {
+		s.MRedConstant = GenMRedConstant(Modulus)
+	}
+
+	s.NTTTable = new(NTTTable)
+	s.NthRoot = uint64(N + 1)
+
+	s.ntt = NewNumberTheoreticTransformerPrimeCyclotomic(s, N)
+
+	return
+}
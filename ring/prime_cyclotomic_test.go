@@ -0,0 +1,92 @@
+package ring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestPrimeCyclotomic(t *testing.T) {
+
+	const N = 256 // p = 257
+
+	// Primes of the form k*2*(N+1) + 1 close to 2^55 (the NTT-friendly prime
+	// generator assumes a power-of-two NthRoot, so it cannot be used here).
+	moduli := make([]uint64, 0, 2)
+	for q := uint64(1)<<55/(2*(N+1))*(2*(N+1)) + 1; len(moduli) < 2; q += 2 * (N + 1) {
+		if IsPrime(q) {
+			moduli = append(moduli, q)
+		}
+	}
+
+	r, err := NewRingFromType(N, moduli, PrimeCyclotomic)
+	require.NoError(t, err)
+	require.Equal(t, PrimeCyclotomic, r.Type())
+
+	prng, err := sampling.NewPRNG()
+	require.NoError(t, err)
+
+	sampler := NewUniformSampler(prng, r)
+
+	t.Run("NTT/RoundTrip", func(t *testing.T) {
+
+		p1 := sampler.ReadNew()
+		p2 := r.NewPoly()
+
+		r.NTT(p1, p2)
+		r.INTT(p2, p2) // also tests the in-place transform
+
+		require.True(t, p1.Equal(&p2))
+	})
+
+	t.Run("Mul", func(t *testing.T) {
+
+		a := sampler.ReadNew()
+		b := sampler.ReadNew()
+
+		have := r.NewPoly()
+		r.NTT(a, have)
+		r.MForm(have, have)
+
+		tmp := r.NewPoly()
+		r.NTT(b, tmp)
+		r.MulCoeffsMontgomery(have, tmp, have)
+		r.INTT(have, have)
+
+		// Schoolbook product mod Phi_p: X^p = 1 in Z_q[X]/Phi_p, so the
+		// convolution indices wrap mod p, and the residual degree p-1 term
+		// reduces as X^(p-1) = -(1 + X + ... + X^(p-2)).
+		for k, s := range r.SubRings {
+
+			q, brc := s.Modulus, s.BRedConstant
+
+			d := make([]uint64, N+1)
+			for i := 0; i < N; i++ {
+				for j := 0; j < N; j++ {
+					d[(i+j)%(N+1)] = CRed(d[(i+j)%(N+1)]+BRed(a.Coeffs[k][i], b.Coeffs[k][j], q, brc), q)
+				}
+			}
+
+			for i := 0; i < N; i++ {
+				require.Equal(t, CRed(d[i]+q-d[N], q), have.Coeffs[k][i])
+			}
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+
+		// N+1 not prime.
+		_, err := NewRingFromType(24, moduli, PrimeCyclotomic)
+		require.Error(t, err)
+
+		// N not a multiple of 8.
+		_, err = NewRingFromType(12, moduli, PrimeCyclotomic)
+		require.Error(t, err)
+
+		// Modulus not equal to 1 mod p.
+		_, err = NewRingFromType(N, Qi60[:1], PrimeCyclotomic)
+		require.Error(t, err)
+	})
+}
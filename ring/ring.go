@@ -30,6 +30,7 @@ type Type int
 const (
 	Standard           = Type(0) // Z[X]/(X^N + 1) (Default)
 	ConjugateInvariant = Type(1) // Z[X+X^-1]/(X^2N + 1)
+	PrimeCyclotomic    = Type(2) // Z[X]/Phi_p(X) with p = N+1 prime
 )
 
 // String returns the string representation of the ring Type
@@ -39,6 +40,8 @@ func (rt Type) String() string {
 		return "Standard"
 	case ConjugateInvariant:
 		return "ConjugateInvariant"
+	case PrimeCyclotomic:
+		return "PrimeCyclotomic"
 	default:
 		return "Invalid"
 	}
@@ -57,6 +60,8 @@ func (rt *Type) UnmarshalJSON(b []byte) error {
 		*rt = Standard
 	case "ConjugateInvariant":
 		*rt = ConjugateInvariant
+	case "PrimeCyclotomic":
+		*rt = PrimeCyclotomic
 	}
 
 	return nil
@@ -245,6 +250,19 @@ func NewRing(N int, Moduli []uint64) (r *Ring, err error) {
 	return NewRingWithCustomNTT(N, Moduli, NewNumberTheoreticTransformerStandard, 2*N)
 }
 
+// NewRingPlantard creates a new RNS Ring with degree N and coefficient moduli Moduli with Standard NTT evaluated
+// with Plantard modular arithmetic (see [NumberTheoreticTransformerPlantard]). N must be a power of two larger than 8.
+// Moduli should be a non-empty []uint64 with distinct prime elements below 2^62, all equal to 1 modulo 2*N.
+// An error is returned with a nil *Ring in the case of non NTT-enabling parameters.
+func NewRingPlantard(N int, Moduli []uint64) (r *Ring, err error) {
+	for _, q := range Moduli {
+		if q >= 1<<62 {
+			return nil, fmt.Errorf("invalid modulus: %d is not below 2^62", q)
+		}
+	}
+	return NewRingWithCustomNTT(N, Moduli, NewNumberTheoreticTransformerPlantard, 2*N)
+}
+
 // NewRingConjugateInvariant creates a new RNS Ring with degree N and coefficient moduli Moduli with Conjugate Invariant NTT. N must be a power of two larger than 8. Moduli should be
 // a non-empty []uint64 with distinct prime elements. All moduli must also be equal to 1 modulo 4*N.
 // An error is returned with a nil *Ring in the case of non NTT-enabling parameters.
@@ -253,6 +271,7 @@ func NewRingConjugateInvariant(N int, Moduli []uint64) (r *Ring, err error) {
 }
 
 // NewRingFromType creates a new RNS Ring with degree N and coefficient moduli Moduli for which the type of NTT is determined by the ringType argument.
+// If ringType==PrimeCyclotomic, the ring is instantiated over the prime cyclotomic polynomial Phi_p(X) with p = N+1 prime (see [NewRingPrimeCyclotomic]).
 // If ringType==Standard, the ring is instantiated with standard NTT with the Nth root of unity 2*N. If ringType==ConjugateInvariant, the ring
 // is instantiated with a ConjugateInvariant NTT with Nth root of unity 4*N. N must be a power of two larger than 8.
 // Moduli should be a non-empty []uint64 with distinct prime elements. All moduli must also be equal to 1 modulo the root of unity.
@@ -263,6 +282,8 @@ func NewRingFromType(N int, Moduli []uint64, ringType Type) (r *Ring, err error)
 		return NewRingWithCustomNTT(N, Moduli, NewNumberTheoreticTransformerStandard, 2*N)
 	case ConjugateInvariant:
 		return NewRingWithCustomNTT(N, Moduli, NewNumberTheoreticTransformerConjugateInvariant, 4*N)
+	case PrimeCyclotomic:
+		return NewRingPrimeCyclotomic(N, Moduli)
 	default:
 		return nil, fmt.Errorf("invalid ring type")
 	}
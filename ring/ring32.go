@@ -0,0 +1,366 @@
+package ring
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// This file implements a 32-bit RNS limb mode: the coefficients are stored as
+// []uint32 and all the modular arithmetic is carried out with at most 32x32
+// bit multiplications, which map to a single widening multiply on 32-bit and
+// WASM targets where the 64-bit Montgomery arithmetic of [SubRing] is
+// emulated. The moduli are restricted to < 2^30 so that the lazy butterfly
+// bound 4q fits in an uint32, mirroring the < 2^62 bound of the 64-bit mode.
+
+// MaxModulus32 is the largest modulus allowed in the 32-bit limb mode.
+const MaxModulus32 = 1<<30 - 1
+
+// CRed32 reduces a mod q for a in [0, 2q-1].
+func CRed32(a, q uint32) uint32 {
+	if a >= q {
+		a -= q
+	}
+	return a
+}
+
+// GenMRedConstant32 generates the constant q^-1 mod 2^32 required by
+// [MRed32], for odd q.
+func GenMRedConstant32(q uint32) (mredconstant uint32) {
+
+	mredconstant = 1
+
+	for i := 0; i < 5; i++ { // 2^(2^5) = 2^32
+		mredconstant *= 2 - q*mredconstant
+	}
+
+	return
+}
+
+// MRed32 computes x*y*(2^-32) mod q, the 32-bit analogue of [MRed].
+func MRed32(x, y, q, mredconstant uint32) (r uint32) {
+	t := uint64(x) * uint64(y)
+	hhi := uint32((uint64(uint32(t)*mredconstant) * uint64(q)) >> 32)
+	r = uint32(t>>32) - hhi + q
+	if r >= q {
+		r -= q
+	}
+	return
+}
+
+// MRedLazy32 computes x*y*(2^-32) mod q with a result in [0, 2q-1], the
+// 32-bit analogue of [MRedLazy].
+func MRedLazy32(x, y, q, mredconstant uint32) (r uint32) {
+	t := uint64(x) * uint64(y)
+	hhi := uint32((uint64(uint32(t)*mredconstant) * uint64(q)) >> 32)
+	return uint32(t>>32) - hhi + q
+}
+
+// MForm32 switches a to the Montgomery domain by computing a*2^32 mod q.
+func MForm32(a, q uint32) uint32 {
+	return uint32((uint64(a) << 32) % uint64(q))
+}
+
+// IMForm32 switches a from the Montgomery domain back to the standard domain
+// by computing a*(2^-32) mod q.
+func IMForm32(a, q, mredconstant uint32) uint32 {
+	return MRed32(a, 1, q, mredconstant)
+}
+
+// butterfly32 computes X, Y = U + V*Psi, U - V*Psi mod q. Unlike [butterfly],
+// the inputs are reduced below 2q before the addition so that, with q < 2^30,
+// all the intermediate values stay below 4q < 2^32: inputs in [0, 4q-1] give
+// outputs in [0, 4q-1].
+func butterfly32(U, V, Psi, twoQ, Q, MRedConstant uint32) (uint32, uint32) {
+	if U >= twoQ {
+		U -= twoQ
+	}
+	V = MRedLazy32(V, Psi, Q, MRedConstant)
+	return U + V, U + twoQ - V
+}
+
+// invbutterfly32 computes X, Y = U + V, (U - V) * Psi mod q. Inputs in
+// [0, 2q-1] give outputs in [0, 2q-1], with all the intermediate values below
+// 4q < 2^32.
+func invbutterfly32(U, V, Psi, twoQ, Q, MRedConstant uint32) (X, Y uint32) {
+	X = U + V
+	if X >= twoQ {
+		X -= twoQ
+	}
+	Y = MRedLazy32(U+twoQ-V, Psi, Q, MRedConstant)
+	return
+}
+
+// SubRing32 is the 32-bit analogue of [SubRing], storing the constants of a
+// single modulus of the 32-bit limb mode.
+type SubRing32 struct {
+	N            int
+	Modulus      uint32
+	MRedConstant uint32
+
+	// NInv is N^-1 mod Modulus in the Montgomery domain.
+	NInv uint32
+	// RootsForward and RootsBackward are the powers of the 2N-th primitive
+	// root and of its inverse in the Montgomery domain, in bit-reversed order.
+	RootsForward  []uint32
+	RootsBackward []uint32
+}
+
+// Ring32 is the 32-bit analogue of [Ring]: an RNS ring of degree N over
+// moduli of at most 30 bits, with coefficients stored as []uint32. It
+// provides the arithmetic required for encryption and decryption on
+// constrained 32-bit and WASM targets; the richer operations of [Ring]
+// remain 64-bit only.
+type Ring32 struct {
+	SubRings []*SubRing32
+}
+
+// NewRing32 creates a new 32-bit RNS ring with degree N and the provided
+// moduli. N must be a power of two larger than 8, and the moduli distinct
+// primes of at most 30 bits, all equal to 1 modulo 2N.
+func NewRing32(N int, Moduli []uint32) (r *Ring32, err error) {
+
+	if N < MinimumRingDegreeForLoopUnrolledOperations || (N&(N-1)) != 0 && N != 0 {
+		return nil, fmt.Errorf("invalid ring degree: must be a power of 2 greater than %d", MinimumRingDegreeForLoopUnrolledOperations)
+	}
+
+	if len(Moduli) == 0 {
+		return nil, fmt.Errorf("invalid Moduli (must be a non-empty []uint32)")
+	}
+
+	r = &Ring32{SubRings: make([]*SubRing32, len(Moduli))}
+
+	for i, q := range Moduli {
+		if r.SubRings[i], err = newSubRing32(N, q); err != nil {
+			return nil, err
+		}
+	}
+
+	return
+}
+
+func newSubRing32(N int, Modulus uint32) (s *SubRing32, err error) {
+
+	if Modulus > MaxModulus32 {
+		return nil, fmt.Errorf("invalid modulus: %d is larger than %d", Modulus, MaxModulus32)
+	}
+
+	if !IsPrime(uint64(Modulus)) {
+		return nil, fmt.Errorf("invalid modulus: %d is not prime)", Modulus)
+	}
+
+	NthRoot := uint64(N) << 1
+
+	if uint64(Modulus)&(NthRoot-1) != 1 {
+		return nil, fmt.Errorf("invalid modulus: %d != 1 mod NthRoot)", Modulus)
+	}
+
+	s = &SubRing32{
+		N:            N,
+		Modulus:      Modulus,
+		MRedConstant: GenMRedConstant32(Modulus),
+	}
+
+	g, _, err := PrimitiveRoot(uint64(Modulus), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	Q := uint64(Modulus)
+
+	s.NInv = MForm32(uint32(ModExp(NthRoot>>1, Q-2, Q)), Modulus)
+
+	PsiMont := MForm32(uint32(ModExp(g, (Q-1)/NthRoot, Q)), Modulus)
+	PsiInvMont := MForm32(uint32(ModExp(g, Q-((Q-1)/NthRoot)-1, Q)), Modulus)
+
+	logNthRoot := bits.Len64(NthRoot>>1) - 1
+
+	s.RootsForward = make([]uint32, NthRoot>>1)
+	s.RootsBackward = make([]uint32, NthRoot>>1)
+
+	s.RootsForward[0] = MForm32(1, Modulus)
+	s.RootsBackward[0] = MForm32(1, Modulus)
+
+	for j := uint64(1); j < NthRoot>>1; j++ {
+
+		indexReversePrev := utils.BitReverse64(j-1, logNthRoot)
+		indexReverseNext := utils.BitReverse64(j, logNthRoot)
+
+		s.RootsForward[indexReverseNext] = MRed32(s.RootsForward[indexReversePrev], PsiMont, Modulus, s.MRedConstant)
+		s.RootsBackward[indexReverseNext] = MRed32(s.RootsBackward[indexReversePrev], PsiInvMont, Modulus, s.MRedConstant)
+	}
+
+	return
+}
+
+// NewPoly creates a new polynomial with all coefficients set to 0, one limb
+// per modulus.
+func (r Ring32) NewPoly() [][]uint32 {
+	p := make([][]uint32, len(r.SubRings))
+	for i := range p {
+		p[i] = make([]uint32, r.SubRings[i].N)
+	}
+	return p
+}
+
+// NTT evaluates p2 = NTT(p1).
+func (r Ring32) NTT(p1, p2 [][]uint32) {
+	for i, s := range r.SubRings {
+		s.NTT(p1[i], p2[i])
+	}
+}
+
+// INTT evaluates p2 = INTT(p1).
+func (r Ring32) INTT(p1, p2 [][]uint32) {
+	for i, s := range r.SubRings {
+		s.INTT(p1[i], p2[i])
+	}
+}
+
+// Add evaluates p3 = p1 + p2 coefficient-wise.
+func (r Ring32) Add(p1, p2, p3 [][]uint32) {
+	for i, s := range r.SubRings {
+		q := s.Modulus
+		for j := range p1[i] {
+			p3[i][j] = CRed32(p1[i][j]+p2[i][j], q)
+		}
+	}
+}
+
+// Sub evaluates p3 = p1 - p2 coefficient-wise.
+func (r Ring32) Sub(p1, p2, p3 [][]uint32) {
+	for i, s := range r.SubRings {
+		q := s.Modulus
+		for j := range p1[i] {
+			p3[i][j] = CRed32(p1[i][j]+q-p2[i][j], q)
+		}
+	}
+}
+
+// Neg evaluates p2 = -p1 coefficient-wise.
+func (r Ring32) Neg(p1, p2 [][]uint32) {
+	for i, s := range r.SubRings {
+		q := s.Modulus
+		for j := range p1[i] {
+			p2[i][j] = CRed32(q-p1[i][j], q)
+		}
+	}
+}
+
+// MForm switches p1 to the Montgomery domain.
+func (r Ring32) MForm(p1, p2 [][]uint32) {
+	for i, s := range r.SubRings {
+		q, mredconstant := s.Modulus, s.MRedConstant
+		rSquare := MForm32(MForm32(1, q), q) // 2^64 mod q, in the Montgomery domain
+		for j := range p1[i] {
+			p2[i][j] = MRed32(p1[i][j], rSquare, q, mredconstant)
+		}
+	}
+}
+
+// IMForm switches p1 back from the Montgomery domain.
+func (r Ring32) IMForm(p1, p2 [][]uint32) {
+	for i, s := range r.SubRings {
+		q, mredconstant := s.Modulus, s.MRedConstant
+		for j := range p1[i] {
+			p2[i][j] = IMForm32(p1[i][j], q, mredconstant)
+		}
+	}
+}
+
+// MulCoeffsMontgomery evaluates p3 = p1 * p2 coefficient-wise, with p2 in the
+// Montgomery domain.
+func (r Ring32) MulCoeffsMontgomery(p1, p2, p3 [][]uint32) {
+	for i, s := range r.SubRings {
+		q, mredconstant := s.Modulus, s.MRedConstant
+		for j := range p1[i] {
+			p3[i][j] = MRed32(p1[i][j], p2[i][j], q, mredconstant)
+		}
+	}
+}
+
+// NTT evaluates p2 = NTT(p1).
+func (s *SubRing32) NTT(p1, p2 []uint32) {
+
+	N := s.N
+	Q := s.Modulus
+	MRedConstant := s.MRedConstant
+	roots := s.RootsForward
+
+	twoQ := 2 * Q
+
+	t := N >> 1
+	F := roots[1]
+
+	for jx, jy := 0, t; jx < t; jx, jy = jx+1, jy+1 {
+		p2[jx], p2[jy] = butterfly32(p1[jx], p1[jy], F, twoQ, Q, MRedConstant)
+	}
+
+	for m := 2; m < N; m <<= 1 {
+
+		t >>= 1
+
+		for i := 0; i < m; i++ {
+
+			j1 := (i * t) << 1
+			j2 := j1 + t
+
+			F = roots[m+i]
+
+			for jx, jy := j1, j1+t; jx < j2; jx, jy = jx+1, jy+1 {
+				p2[jx], p2[jy] = butterfly32(p2[jx], p2[jy], F, twoQ, Q, MRedConstant)
+			}
+		}
+	}
+
+	// The lazy butterfly outputs are bounded by 4q.
+	for i := range p2 {
+		p2[i] = CRed32(CRed32(p2[i], twoQ), Q)
+	}
+}
+
+// INTT evaluates p2 = INTT(p1).
+func (s *SubRing32) INTT(p1, p2 []uint32) {
+
+	N := s.N
+	Q := s.Modulus
+	MRedConstant := s.MRedConstant
+	roots := s.RootsBackward
+
+	twoQ := 2 * Q
+
+	t := 1
+	h := N >> 1
+
+	for i, j1, j2 := 0, 0, t; i < h; i, j1, j2 = i+1, j1+2*t, j2+2*t {
+
+		F := roots[h+i]
+
+		for jx, jy := j1, j1+t; jx < j2; jx, jy = jx+1, jy+1 {
+			p2[jx], p2[jy] = invbutterfly32(p1[jx], p1[jy], F, twoQ, Q, MRedConstant)
+		}
+	}
+
+	t <<= 1
+
+	for m := N >> 1; m > 1; m >>= 1 {
+
+		h = m >> 1
+
+		for i, j1, j2 := 0, 0, t; i < h; i, j1, j2 = i+1, j1+2*t, j2+2*t {
+
+			F := roots[h+i]
+
+			for jx, jy := j1, j1+t; jx < j2; jx, jy = jx+1, jy+1 {
+				p2[jx], p2[jy] = invbutterfly32(p2[jx], p2[jy], F, twoQ, Q, MRedConstant)
+			}
+		}
+
+		t <<= 1
+	}
+
+	for i := range p2 {
+		p2[i] = MRed32(p2[i], s.NInv, Q, MRedConstant)
+	}
+}
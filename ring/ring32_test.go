@@ -0,0 +1,167 @@
+package ring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestRing32(t *testing.T) {
+
+	const N = 128
+
+	// Sub-2^30 NTT-friendly primes, so that the same moduli can be used by
+	// both the 32-bit and the reference 64-bit rings.
+	g := NewNTTFriendlyPrimesGenerator(29, uint64(2*N))
+	moduli64, err := g.NextDownstreamPrimes(3)
+	require.NoError(t, err)
+
+	moduli := make([]uint32, len(moduli64))
+	for i := range moduli {
+		moduli[i] = uint32(moduli64[i])
+	}
+
+	r32, err := NewRing32(N, moduli)
+	require.NoError(t, err)
+
+	r64, err := NewRing(N, moduli64)
+	require.NoError(t, err)
+
+	prng, err := sampling.NewPRNG()
+	require.NoError(t, err)
+
+	sampler := NewUniformSampler(prng, r64)
+
+	// toPoly32 copies a 64-bit polynomial into the 32-bit layout.
+	toPoly32 := func(p Poly) [][]uint32 {
+		q := r32.NewPoly()
+		for i := range q {
+			for j := range q[i] {
+				q[i][j] = uint32(p.Coeffs[i][j])
+			}
+		}
+		return q
+	}
+
+	requireEqual := func(t *testing.T, p64 Poly, p32 [][]uint32) {
+		for i := range p32 {
+			for j := range p32[i] {
+				require.Equal(t, uint32(p64.Coeffs[i][j]), p32[i][j])
+			}
+		}
+	}
+
+	t.Run("ModularArithmetic", func(t *testing.T) {
+
+		for _, s := range r32.SubRings {
+
+			q := s.Modulus
+			mredconstant := s.MRedConstant
+
+			for _, x := range []uint32{0, 1, q >> 1, q - 1} {
+				for _, y := range []uint32{0, 1, q >> 1, q - 1} {
+					want := uint32((uint64(x) * uint64(y)) % uint64(q))
+					require.Equal(t, want, MRed32(MForm32(x, q), y, q, mredconstant))
+					require.Equal(t, want, CRed32(MRedLazy32(MForm32(x, q), y, q, mredconstant), q))
+				}
+				require.Equal(t, x, IMForm32(MForm32(x, q), q, mredconstant))
+			}
+		}
+	})
+
+	t.Run("NTT", func(t *testing.T) {
+
+		a := sampler.ReadNew()
+
+		have := toPoly32(a)
+		r32.NTT(have, have) // also tests the in-place transform
+
+		want := r64.NewPoly()
+		r64.NTT(a, want)
+
+		requireEqual(t, want, have)
+
+		r32.INTT(have, have)
+		requireEqual(t, a, have)
+	})
+
+	t.Run("Arithmetic", func(t *testing.T) {
+
+		a := sampler.ReadNew()
+		b := sampler.ReadNew()
+
+		a32 := toPoly32(a)
+		b32 := toPoly32(b)
+
+		want := r64.NewPoly()
+		have := r32.NewPoly()
+
+		r64.Add(a, b, want)
+		r32.Add(a32, b32, have)
+		requireEqual(t, want, have)
+
+		r64.Sub(a, b, want)
+		r32.Sub(a32, b32, have)
+		requireEqual(t, want, have)
+
+		r64.Neg(a, want)
+		r32.Neg(a32, have)
+		requireEqual(t, want, have)
+
+		// The Montgomery domains differ (2^32 vs 2^64), so only the
+		// roundtrip can be compared.
+		r32.MForm(a32, have)
+		r32.IMForm(have, have)
+		requireEqual(t, a, have)
+	})
+
+	t.Run("Mul", func(t *testing.T) {
+
+		a := sampler.ReadNew()
+		b := sampler.ReadNew()
+
+		a32 := toPoly32(a)
+		b32 := toPoly32(b)
+
+		want := r64.NewPoly()
+		r64.NTT(a, a)
+		r64.NTT(b, b)
+		r64.MForm(a, a)
+		r64.MulCoeffsMontgomery(a, b, want)
+		r64.INTT(want, want)
+
+		have := r32.NewPoly()
+		r32.NTT(a32, a32)
+		r32.NTT(b32, b32)
+		r32.MForm(a32, a32)
+		r32.MulCoeffsMontgomery(a32, b32, have)
+		r32.INTT(have, have)
+
+		requireEqual(t, want, have)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+
+		// Modulus too large for the 32-bit limb mode.
+		_, err := NewRing32(N, []uint32{1<<31 - 1})
+		require.Error(t, err)
+
+		// Modulus not prime.
+		_, err = NewRing32(N, []uint32{moduli[0] + 2})
+		require.Error(t, err)
+
+		// Modulus not equal to 1 mod 2N.
+		_, err = NewRing32(N, []uint32{536871001}) // prime, != 1 mod 256
+		require.Error(t, err)
+
+		// N not a power of two.
+		_, err = NewRing32(N+1, moduli)
+		require.Error(t, err)
+
+		// Empty moduli chain.
+		_, err = NewRing32(N, nil)
+		require.Error(t, err)
+	})
+}
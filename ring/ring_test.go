@@ -484,6 +484,42 @@ func testSampler(tc *testParams, t *testing.T) {
 		require.InDelta(t, math.Log2(1e21), tc.ringQ.Log2OfStandardDeviation(pol), 1)
 	})
 
+	t.Run(testString("Sampler/GaussianBigint", tc.ringQ), func(t *testing.T) {
+
+		// sigma = 2^100, beyond the precision of the float64-based sampler.
+		sigma := new(big.Float).SetPrec(128).SetMantExp(big.NewFloat(1), 100)
+		bound := new(big.Int).Lsh(big.NewInt(1), 106)
+
+		dist := DiscreteGaussianBigint{Sigma: sigma, Bound: bound}
+
+		sampler, err := NewSampler(tc.prng, tc.ringQ, dist, false)
+		require.NoError(t, err)
+
+		pol := sampler.ReadNew()
+
+		require.InDelta(t, 100, tc.ringQ.Log2OfStandardDeviation(pol), 1)
+
+		coeffs := make([]*big.Int, N)
+		for i := range coeffs {
+			coeffs[i] = new(big.Int)
+		}
+
+		tc.ringQ.PolyToBigintCentered(pol, 1, coeffs)
+
+		for i := range coeffs {
+			require.True(t, coeffs[i].CmpAbs(bound) < 1)
+		}
+	})
+
+	t.Run(testString("Sampler/GaussianBigint/Errors", tc.ringQ), func(t *testing.T) {
+
+		_, err := NewSampler(tc.prng, tc.ringQ, DiscreteGaussianBigint{}, false)
+		require.Error(t, err)
+
+		_, err = NewSampler(tc.prng, tc.ringQ, DiscreteGaussianBigint{Sigma: new(big.Float)}, false)
+		require.Error(t, err)
+	})
+
 	for _, p := range []float64{.5, 1. / 3., 128. / 65536.} {
 		t.Run(testString(fmt.Sprintf("Sampler/Ternary/p=%1.2f", p), tc.ringQ), func(t *testing.T) {
 
@@ -748,6 +784,64 @@ func testExtendBasis(tc *testParams, t *testing.T) {
 		}
 	})
 
+	t.Run(testString("ModUp/QToP/BEHZ", tc.ringQ), func(t *testing.T) {
+
+		prng, _ := sampling.NewPRNG()
+
+		basisextender, err := NewBasisExtenderWithAlgorithm(tc.ringQ, tc.ringP, BEHZ)
+		require.NoError(t, err)
+
+		levelQ := tc.ringQ.Level() - 1
+		levelP := tc.ringP.Level() - 1
+
+		ringQ := tc.ringQ.AtLevel(levelQ)
+		ringP := tc.ringP.AtLevel(levelP)
+
+		Q := ringQ.Modulus()
+
+		coeffs := make([]*big.Int, N)
+		for i := 0; i < N; i++ {
+			coeffs[i] = bignum.RandInt(prng, Q)
+		}
+
+		PolQHave := ringQ.NewPoly()
+		PolPTest := ringP.NewPoly()
+
+		ringQ.SetCoefficientsBigint(coeffs, PolQHave)
+
+		basisextender.ModUpQtoP(levelQ, levelP, PolQHave, PolPTest)
+		ringP.Reduce(PolPTest, PolPTest)
+
+		// The BEHZ conversion returns x + v*Q with 0 <= v <= levelQ+1, for a
+		// single v shared by all the moduli of P.
+		P := ringP.ModuliChain()[:levelP+1]
+		want := new(big.Int)
+		for j := 0; j < N; j++ {
+
+			var match bool
+			for v := 0; v < levelQ+2 && !match; v++ {
+
+				want.Mul(Q, bignum.NewInt(v))
+				want.Add(want, coeffs[j])
+
+				match = true
+				for i := range P {
+					if new(big.Int).Mod(want, bignum.NewInt(P[i])).Uint64() != PolPTest.Coeffs[i][j] {
+						match = false
+						break
+					}
+				}
+			}
+
+			require.True(t, match)
+		}
+	})
+
+	t.Run(testString("ModUp/Algorithm/Errors", tc.ringQ), func(t *testing.T) {
+		_, err := NewBasisExtenderWithAlgorithm(tc.ringQ, tc.ringP, BasisExtensionAlgorithm(-1))
+		require.Error(t, err)
+	})
+
 	t.Run(testString("ModUp/PToQ", tc.ringQ), func(t *testing.T) {
 
 		prng, _ := sampling.NewPRNG()
@@ -8,9 +8,10 @@ import (
 )
 
 const (
-	discreteGaussianName = "DiscreteGaussian"
-	ternaryDistName      = "Ternary"
-	uniformDistName      = "Uniform"
+	discreteGaussianName       = "DiscreteGaussian"
+	discreteGaussianBigintName = "DiscreteGaussianBigint"
+	ternaryDistName            = "Ternary"
+	uniformDistName            = "Uniform"
 )
 
 // Sampler is an interface for random polynomial samplers.
@@ -25,9 +26,12 @@ type Sampler interface {
 
 // DistributionParameters is an interface for distribution
 // parameters in the ring.
-// There are three implementation of this interface:
+// There are four implementation of this interface:
 //   - DiscreteGaussian for sampling polynomials with discretized
 //     gaussian coefficient of given standard deviation and bound.
+//   - DiscreteGaussianBigint for sampling polynomials with discretized
+//     gaussian coefficient of arbitrary-precision standard deviation
+//     and bound.
 //   - Ternary for sampling polynomials with coefficients in [-1, 1].
 //   - Uniform for sampling polynomial with uniformly random
 //     coefficients in the ring.
@@ -51,7 +55,9 @@ type DiscreteGaussian struct {
 //   - If P is set, each coefficient in the polynomial is sampled in [-1, 0, 1]
 //     with probabilities [0.5*P, 1-P, 0.5*P].
 //   - if H is set, the coefficients are sampled uniformly in the set of ternary
-//     polynomials with H non-zero coefficients (i.e., of hamming weight H).
+//     polynomials with exactly H non-zero coefficients (i.e., of hamming weight H),
+//     with a rejection-free algorithm whose PRNG consumption and running time are
+//     independent of the sampled values.
 type Ternary struct {
 	P float64
 	H int
@@ -65,6 +71,8 @@ func NewSampler(prng sampling.PRNG, baseRing *Ring, X DistributionParameters, mo
 	switch X := X.(type) {
 	case DiscreteGaussian:
 		return NewGaussianSampler(prng, baseRing, X, montgomery), nil
+	case DiscreteGaussianBigint:
+		return NewGaussianBigintSampler(prng, baseRing, X, montgomery)
 	case Ternary:
 		return NewTernarySampler(prng, baseRing, X, montgomery)
 	case Uniform:
@@ -0,0 +1,185 @@
+package ring
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/tuneinsight/lattigo/v6/utils/bignum"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+// DiscreteGaussianBigint represents the parameters of a discrete Gaussian
+// distribution with arbitrary-precision standard deviation Sigma and bounds
+// [-Bound, Bound]. It is the arbitrary-precision counterpart of
+// [DiscreteGaussian], for standard deviations beyond the range or precision of
+// a float64, e.g. the noise-flooding (smudging) distributions of multiparty
+// protocols with sigma of the order of 2^100 and more.
+//
+// The precision of Sigma determines the precision of the sampling: samples are
+// drawn with Sigma.Prec()+64 bits of precision before discretization. A nil
+// Bound disables the truncation.
+type DiscreteGaussianBigint struct {
+	Sigma *big.Float
+	Bound *big.Int
+}
+
+// Type returns a string representation of the distribution name.
+func (d DiscreteGaussianBigint) Type() string {
+	return discreteGaussianBigintName
+}
+
+func (d DiscreteGaussianBigint) MarshalJSON() ([]byte, error) {
+	var bound string
+	if d.Bound != nil {
+		bound = d.Bound.String()
+	}
+	return json.Marshal(struct {
+		Type         string
+		Sigma, Bound string `json:",omitempty"`
+	}{d.Type(), d.Sigma.Text('e', -1), bound})
+}
+
+func (d DiscreteGaussianBigint) mustBeDist() {}
+
+// GaussianBigintSampler keeps the state of a truncated Gaussian polynomial
+// sampler with arbitrary-precision standard deviation. The samples are
+// discretizations of a continuous Gaussian evaluated with
+// Sigma.Prec()+64 bits of precision (Box-Muller over [big.Float]), so that
+// large smudging noise is sampled correctly instead of being clamped to the
+// float64 range.
+type GaussianBigintSampler struct {
+	*baseSampler
+	xe         DiscreteGaussianBigint
+	prec       uint
+	montgomery bool
+}
+
+// NewGaussianBigintSampler creates a new instance of [GaussianBigintSampler]
+// from a PRNG, a ring definition and the truncated arbitrary-precision
+// Gaussian distribution parameters. Sigma is the desired standard deviation
+// and Bound, if not nil, the maximum coefficient norm in absolute value.
+func NewGaussianBigintSampler(prng sampling.PRNG, baseRing *Ring, X DiscreteGaussianBigint, montgomery bool) (g *GaussianBigintSampler, err error) {
+
+	if X.Sigma == nil || X.Sigma.Cmp(new(big.Float)) != 1 {
+		return nil, fmt.Errorf("invalid distribution: Sigma must be a strictly positive *big.Float")
+	}
+
+	return &GaussianBigintSampler{
+		baseSampler: &baseSampler{
+			prng:     prng,
+			baseRing: baseRing,
+		},
+		xe:         X,
+		prec:       X.Sigma.Prec() + 64,
+		montgomery: montgomery,
+	}, nil
+}
+
+// AtLevel returns an instance of the target GaussianBigintSampler that operates at the target level.
+// This instance is not thread safe and cannot be used concurrently to the base instance.
+func (g *GaussianBigintSampler) AtLevel(level int) Sampler {
+	return &GaussianBigintSampler{
+		baseSampler: g.baseSampler.AtLevel(level),
+		xe:          g.xe,
+		prec:        g.prec,
+		montgomery:  g.montgomery,
+	}
+}
+
+// Read samples a truncated Gaussian polynomial on "pol" at the maximum level in the default ring, standard deviation and bound.
+func (g *GaussianBigintSampler) Read(pol Poly) {
+	g.read(pol, func(a, b, c uint64) uint64 {
+		return b
+	})
+}
+
+// ReadNew samples a new truncated Gaussian polynomial at the maximum level in the default ring, standard deviation and bound.
+func (g *GaussianBigintSampler) ReadNew() (pol Poly) {
+	pol = g.baseRing.NewPoly()
+	g.Read(pol)
+	return pol
+}
+
+// ReadAndAdd samples a truncated Gaussian polynomial at the given level for the receiver's default standard deviation and bound and adds it on "pol".
+func (g *GaussianBigintSampler) ReadAndAdd(pol Poly) {
+	g.read(pol, func(a, b, c uint64) uint64 {
+		return CRed(a+b, c)
+	})
+}
+
+func (g *GaussianBigintSampler) read(pol Poly, f func(a, b, c uint64) uint64) {
+
+	r := g.baseRing
+
+	level := r.level
+
+	moduli := r.ModuliChain()[:level+1]
+
+	Qi := make([]*big.Int, len(moduli))
+	for i, qi := range moduli {
+		Qi[i] = bignum.NewInt(qi)
+	}
+
+	prec := g.prec
+	bound := g.xe.Bound
+
+	// 2^prec and 2*Pi, shared across coefficients.
+	scale := new(big.Int).Lsh(bignum.NewInt(1), prec)
+	twoPi := bignum.Pi(prec)
+	twoPi.Add(twoPi, twoPi)
+
+	u := new(big.Float).SetPrec(prec)
+	v := new(big.Float).SetPrec(prec)
+	scaleFlo := new(big.Float).SetPrec(prec).SetInt(scale)
+
+	coeff := new(big.Int)
+	sample := new(big.Int)
+	sampleAbs := new(big.Int)
+
+	N := r.N()
+
+	coeffs := pol.Coeffs
+
+	for i := 0; i < N; i++ {
+
+		for {
+
+			// Box-Muller transform over big.Float: norm = sqrt(-2*ln(u1)) * cos(2*Pi*u2)
+			// with u1 uniform in (0, 1] and u2 uniform in [0, 1).
+			u1 := bignum.RandInt(g.prng, scale)
+			u1.Add(u1, bignum.NewInt(1))
+			u2 := bignum.RandInt(g.prng, scale)
+
+			u.SetInt(u1)
+			u.Quo(u, scaleFlo)
+
+			norm := bignum.Log(u)
+			norm.Add(norm, norm)
+			norm.Neg(norm)
+			norm.Sqrt(norm)
+
+			v.SetInt(u2)
+			v.Quo(v, scaleFlo)
+			v.Mul(v, twoPi)
+
+			norm.Mul(norm, bignum.Cos(v))
+
+			// Scales by sigma and discretizes by rounding.
+			norm.Mul(norm, g.xe.Sigma)
+			bignum.Round(norm).Int(sample)
+
+			if bound == nil || sampleAbs.Abs(sample).Cmp(bound) < 1 {
+				break
+			}
+		}
+
+		for j, qi := range moduli {
+			coeffs[j][i] = f(coeffs[j][i], coeff.Mod(sample, Qi[j]).Uint64(), qi)
+		}
+	}
+
+	if g.montgomery {
+		g.baseRing.MForm(pol, pol)
+	}
+}
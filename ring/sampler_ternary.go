@@ -1,6 +1,7 @@
 package ring
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math"
 	"math/bits"
@@ -192,15 +193,20 @@ func (ts *TernarySampler) sampleProba(pol Poly, f func(a, b, c uint64) uint64) {
 	}
 }
 
+// sampleSparse samples a polynomial uniformly in the set of ternary polynomials with exactly hw
+// non-zero coefficients. The non-zero positions are selected with a Fisher-Yates shuffle whose
+// indices are derived from fresh 64-bit words through a multiply-shift map, which is rejection-free:
+// the number of PRNG bytes consumed and the number of operations performed are independent of the
+// sampled values.
 func (ts *TernarySampler) sampleSparse(pol Poly, f func(a, b, c uint64) uint64) {
 
 	N := ts.baseRing.N()
 
-	if ts.hw > N {
-		ts.hw = N
+	hw := ts.hw
+	if hw > N {
+		hw = N
 	}
 
-	var mask, j uint64
 	var coeff uint8
 
 	moduli := ts.baseRing.ModuliChain()[:ts.baseRing.Level()+1]
@@ -210,27 +216,27 @@ func (ts *TernarySampler) sampleSparse(pol Poly, f func(a, b, c uint64) uint64)
 		index[i] = i
 	}
 
-	randomBytes := make([]byte, (uint64(math.Ceil(float64(ts.hw) / 8.0)))) // We sample ceil(hw/8) bytes
-	pointer := uint8(0)
+	// We sample 8 bytes per non-zero coefficient for the shuffle and 1 bit for its sign.
+	randomBytes := make([]byte, 8*hw+(hw+7)>>3)
 
 	if _, err := ts.prng.Read(randomBytes); err != nil {
 		// Sanity check, this error should not happen.
 		panic(err)
 	}
 
+	signBytes := randomBytes[8*hw:]
+
 	coeffs := pol.Coeffs
 
 	m := ts.matrixValues
 
-	for i := 0; i < ts.hw; i++ {
-		mask = (1 << uint64(bits.Len64(uint64(N-i)))) - 1 // rejection sampling of a random variable between [0, len(index)]
+	for i := 0; i < hw; i++ {
 
-		j = randInt32(ts.prng, mask)
-		for j >= uint64(N-i) {
-			j = randInt32(ts.prng, mask)
-		}
+		// Maps a uniform 64-bit word to [0, len(index)) with a multiply-shift, whose bias of at
+		// most len(index)/2^64 is negligible for any practical ring degree.
+		j, _ := bits.Mul64(binary.LittleEndian.Uint64(randomBytes[8*i:]), uint64(N-i))
 
-		coeff = (uint8(randomBytes[0]) >> (i & 7)) & 1 // random binary digit [0, 1] from the random bytes (0 = 1, 1 = -1)
+		coeff = (signBytes[i>>3] >> (i & 7)) & 1 // random binary digit [0, 1] from the random bytes (0 = 1, 1 = -1)
 
 		idxj := index[j]
 
@@ -241,13 +247,6 @@ func (ts *TernarySampler) sampleSparse(pol Poly, f func(a, b, c uint64) uint64)
 		// Remove the element in position j of the slice (order not preserved)
 		index[j] = index[len(index)-1]
 		index = index[:len(index)-1]
-
-		pointer++
-
-		if pointer == 8 {
-			randomBytes = randomBytes[1:]
-			pointer = 0
-		}
 	}
 
 	for _, i := range index {
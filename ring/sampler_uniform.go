@@ -134,23 +134,6 @@ func RandUniform(prng sampling.PRNG, v uint64, mask uint64) (randomInt uint64) {
 	}
 }
 
-// randInt32 samples a uniform variable in the range [0, mask], where mask is of the form 2^n-1, with n in [0, 32].
-func randInt32(prng sampling.PRNG, mask uint64) uint64 {
-
-	// generate random 4 bytes
-	randomBytes := make([]byte, 4)
-	if _, err := prng.Read(randomBytes); err != nil {
-		// Sanity check, this error should not happen.
-		panic(err)
-	}
-
-	// convert 4 bytes to a uint32
-	randomUint32 := uint64(binary.BigEndian.Uint32(randomBytes))
-
-	// return required bits
-	return mask & randomUint32
-}
-
 // randInt64 samples a uniform variable in the range [0, mask], where mask is of the form 2^n-1, with n in [0, 64].
 func randInt64(prng sampling.PRNG, mask uint64) uint64 {
 
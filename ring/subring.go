@@ -15,6 +15,10 @@ import (
 type SubRing struct {
 	ntt NumberTheoreticTransformer
 
+	// Optional compute backend, see [Backend]. If nil, all the
+	// operations are evaluated with the CPU path.
+	backend Backend
+
 	// Polynomial nb.Coefficients
 	N int
 
@@ -81,6 +85,16 @@ func (s *SubRing) Type() Type {
 		return Standard
 	case NumberTheoreticTransformerConjugateInvariant:
 		return ConjugateInvariant
+	case NumberTheoreticTransformerPrimeCyclotomic:
+		return PrimeCyclotomic
+	case *NumberTheoreticTransformerPlantard:
+		// The Plantard transformer evaluates the standard nega-cyclic NTT
+		// with a different modular arithmetic.
+		return Standard
+	case *NumberTheoreticTransformerIncomplete:
+		// The incomplete transformer evaluates a truncation of the standard
+		// nega-cyclic NTT.
+		return Standard
 	default:
 		// Sanity check
 		panic(fmt.Errorf("invalid NumberTheoreticTransformer type: %T", s.ntt))
@@ -97,6 +111,12 @@ func (s *SubRing) generateNTTConstants() (err error) {
 		return fmt.Errorf("invalid t parameters (missing)")
 	}
 
+	// The prime cyclotomic transform has its own constants layout: naturally
+	// ordered p-th roots instead of bit-reversed power-of-two 2N-th roots.
+	if _, isPrimeCyclotomic := s.ntt.(NumberTheoreticTransformerPrimeCyclotomic); isPrimeCyclotomic {
+		return s.generateNTTConstantsPrimeCyclotomic()
+	}
+
 	Modulus := s.Modulus
 	NthRoot := s.NthRoot
 
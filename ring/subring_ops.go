@@ -95,6 +95,9 @@ func (s *SubRing) MulCoeffsBarrettThenAddLazy(p1, p2, p3 []uint64) {
 // Iteration is done with respect to len(p1).
 // All input must have a size which is a multiple of 8.
 func (s *SubRing) MulCoeffsMontgomery(p1, p2, p3 []uint64) {
+	if s.backend != nil && s.backend.MulCoeffsMontgomery(s, p1, p2, p3, false) {
+		return
+	}
 	mulcoeffsmontgomeryvec(p1, p2, p3, s.Modulus, s.MRedConstant)
 }
 
@@ -102,6 +105,9 @@ func (s *SubRing) MulCoeffsMontgomery(p1, p2, p3 []uint64) {
 // Iteration is done with respect to len(p1).
 // All input must have a size which is a multiple of 8.
 func (s *SubRing) MulCoeffsMontgomeryLazy(p1, p2, p3 []uint64) {
+	if s.backend != nil && s.backend.MulCoeffsMontgomery(s, p1, p2, p3, true) {
+		return
+	}
 	mulcoeffsmontgomerylazyvec(p1, p2, p3, s.Modulus, s.MRedConstant)
 }
 
@@ -217,6 +223,13 @@ func (s *SubRing) MulScalarMontgomeryThenAdd(p1 []uint64, scalarMont uint64, p2
 	mulscalarmontgomerythenaddvec(p1, scalarMont, p2, s.Modulus, s.MRedConstant)
 }
 
+// MulScalarMontgomeryThenAddPoly evaluates p3 = p1*scalarMont + p2 (mod modulus).
+// Iteration is done with respect to len(p1).
+// All input must have a size which is a multiple of 8.
+func (s *SubRing) MulScalarMontgomeryThenAddPoly(p1 []uint64, scalarMont uint64, p2, p3 []uint64) {
+	mulscalarmontgomerythenaddpolyvec(p1, scalarMont, p2, p3, s.Modulus, s.MRedConstant)
+}
+
 // MulScalarMontgomeryThenAddScalar evaluates p2 = scalar + p1*scalarMont (mod modulus).
 // Iteration is done with respect to len(p1).
 // All input must have a size which is a multiple of 8.
@@ -233,21 +246,33 @@ func (s *SubRing) SubThenMulScalarMontgomeryTwoModulus(p1, p2 []uint64, scalarMo
 
 // NTT evaluates p2 = NTT(p1).
 func (s *SubRing) NTT(p1, p2 []uint64) {
+	if s.backend != nil && s.backend.NTT(s, p1, p2, false) {
+		return
+	}
 	s.ntt.Forward(p1, p2)
 }
 
 // NTTLazy evaluates p2 = NTT(p1) with p2 in [0, 2*modulus-1].
 func (s *SubRing) NTTLazy(p1, p2 []uint64) {
+	if s.backend != nil && s.backend.NTT(s, p1, p2, true) {
+		return
+	}
 	s.ntt.ForwardLazy(p1, p2)
 }
 
 // INTT evaluates p2 = INTT(p1).
 func (s *SubRing) INTT(p1, p2 []uint64) {
+	if s.backend != nil && s.backend.INTT(s, p1, p2, false) {
+		return
+	}
 	s.ntt.Backward(p1, p2)
 }
 
 // INTTLazy evaluates p2 = INTT(p1) with p2 in [0, 2*modulus-1].
 func (s *SubRing) INTTLazy(p1, p2 []uint64) {
+	if s.backend != nil && s.backend.INTT(s, p1, p2, true) {
+		return
+	}
 	s.ntt.BackwardLazy(p1, p2)
 }
 
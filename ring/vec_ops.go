@@ -314,6 +314,11 @@ func mulcoeffsmontgomeryvec(p1, p2, p3 []uint64, modulus, mredconstant uint64) {
 
 	N := len(p1)
 
+	if useVecMulMont && N >= 8 {
+		mredVec(&p1[0], &p2[0], &p3[0], N, modulus, mredconstant)
+		return
+	}
+
 	for j := 0; j < N; j = j + 8 {
 		/* #nosec G103 -- behavior and consequences well understood, possible buffer overflow if len(p1)%8 */
 		x := (*[8]uint64)(unsafe.Pointer(&p1[j]))
@@ -337,6 +342,11 @@ func mulcoeffsmontgomerylazyvec(p1, p2, p3 []uint64, modulus, mredconstant uint6
 
 	N := len(p1)
 
+	if useVecMulMont && N >= 8 {
+		mredLazyVec(&p1[0], &p2[0], &p3[0], N, modulus, mredconstant)
+		return
+	}
+
 	for j := 0; j < N; j = j + 8 {
 
 		/* #nosec G103 -- behavior and consequences well understood, possible buffer overflow if len(p1)%8 */
@@ -727,6 +737,30 @@ func mulscalarmontgomerythenaddvec(p1 []uint64, scalarMont uint64, p2 []uint64,
 	}
 }
 
+func mulscalarmontgomerythenaddpolyvec(p1 []uint64, scalarMont uint64, p2, p3 []uint64, modulus, mredconstant uint64) {
+
+	N := len(p1)
+
+	for j := 0; j < N; j = j + 8 {
+
+		/* #nosec G103 -- behavior and consequences well understood, possible buffer overflow if len(p1)%8 */
+		x := (*[8]uint64)(unsafe.Pointer(&p1[j]))
+		/* #nosec G103 -- behavior and consequences well understood, possible buffer overflow if len(p2)%8 */
+		y := (*[8]uint64)(unsafe.Pointer(&p2[j]))
+		/* #nosec G103 -- behavior and consequences well understood, possible buffer overflow if len(p3)%8 */
+		z := (*[8]uint64)(unsafe.Pointer(&p3[j]))
+
+		z[0] = CRed(MRed(x[0], scalarMont, modulus, mredconstant)+y[0], modulus)
+		z[1] = CRed(MRed(x[1], scalarMont, modulus, mredconstant)+y[1], modulus)
+		z[2] = CRed(MRed(x[2], scalarMont, modulus, mredconstant)+y[2], modulus)
+		z[3] = CRed(MRed(x[3], scalarMont, modulus, mredconstant)+y[3], modulus)
+		z[4] = CRed(MRed(x[4], scalarMont, modulus, mredconstant)+y[4], modulus)
+		z[5] = CRed(MRed(x[5], scalarMont, modulus, mredconstant)+y[5], modulus)
+		z[6] = CRed(MRed(x[6], scalarMont, modulus, mredconstant)+y[6], modulus)
+		z[7] = CRed(MRed(x[7], scalarMont, modulus, mredconstant)+y[7], modulus)
+	}
+}
+
 func mulscalarmontgomerythenaddscalarvec(p1 []uint64, scalar0, scalarMont1 uint64, p2 []uint64, modulus, mredconstant uint64) {
 
 	N := len(p1)
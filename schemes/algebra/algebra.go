@@ -0,0 +1,257 @@
+// Package algebra provides a small scheme-agnostic expression builder to describe
+// ciphertext computations such as "sum_i (a_i * x_i) + b" symbolically. The same
+// expression can be executed both on a plaintext reference (see [EvaluateReference])
+// and on any scheme's evaluator (see [Evaluate]), enabling correctness comparisons
+// and parameter-choice validation before deployment.
+package algebra
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes"
+)
+
+type op int
+
+const (
+	opVar op = iota
+	opAdd
+	opSub
+	opMul
+	opRescale
+)
+
+// Expr is a node of a symbolic ciphertext-algebra expression. Expressions are
+// immutable: each builder method returns a new node referencing its operands.
+type Expr struct {
+	op       op
+	lhs, rhs *Expr
+	name     string
+}
+
+// Var returns a leaf expression referring to the input variable with the given name.
+// The variable is bound to a ciphertext or a plaintext operand at evaluation time.
+func Var(name string) *Expr {
+	return &Expr{op: opVar, name: name}
+}
+
+// Add returns the expression e + other.
+func (e *Expr) Add(other *Expr) *Expr {
+	return &Expr{op: opAdd, lhs: e, rhs: other}
+}
+
+// Sub returns the expression e - other.
+func (e *Expr) Sub(other *Expr) *Expr {
+	return &Expr{op: opSub, lhs: e, rhs: other}
+}
+
+// Mul returns the expression e * other. When evaluated homomorphically, the product
+// is relinearized if both operands are ciphertexts.
+func (e *Expr) Mul(other *Expr) *Expr {
+	return &Expr{op: opMul, lhs: e, rhs: other}
+}
+
+// Rescale returns the expression e followed by a rescaling operation. It is a no-op
+// in the plaintext reference evaluation.
+func (e *Expr) Rescale() *Expr {
+	return &Expr{op: opRescale, lhs: e}
+}
+
+// Sum returns the expression exprs[0] + exprs[1] + ... as a left-to-right addition chain.
+func Sum(exprs ...*Expr) *Expr {
+	if len(exprs) == 0 {
+		return nil
+	}
+	acc := exprs[0]
+	for _, e := range exprs[1:] {
+		acc = acc.Add(e)
+	}
+	return acc
+}
+
+// String returns a human-readable infix representation of the expression.
+func (e *Expr) String() string {
+	switch e.op {
+	case opVar:
+		return e.name
+	case opAdd:
+		return fmt.Sprintf("(%s + %s)", e.lhs, e.rhs)
+	case opSub:
+		return fmt.Sprintf("(%s - %s)", e.lhs, e.rhs)
+	case opMul:
+		return fmt.Sprintf("(%s * %s)", e.lhs, e.rhs)
+	case opRescale:
+		return fmt.Sprintf("rescale(%s)", e.lhs)
+	default:
+		return "?"
+	}
+}
+
+// Variables returns the set of variable names appearing in the expression.
+func (e *Expr) Variables() (names []string) {
+	seen := map[string]bool{}
+	var walk func(*Expr)
+	walk = func(n *Expr) {
+		if n == nil {
+			return
+		}
+		if n.op == opVar {
+			if !seen[n.name] {
+				seen[n.name] = true
+				names = append(names, n.name)
+			}
+			return
+		}
+		walk(n.lhs)
+		walk(n.rhs)
+	}
+	walk(e)
+	return
+}
+
+// Evaluate executes the expression on the given scheme's evaluator. Variables are
+// resolved first against the ciphertexts map and then against the plaintext operands
+// map; an expression whose root resolves to a plaintext operand cannot be evaluated.
+func Evaluate(eval schemes.Evaluator, e *Expr, cts map[string]*rlwe.Ciphertext, ops map[string]rlwe.Operand) (*rlwe.Ciphertext, error) {
+	out, err := evaluate(eval, e, cts, ops)
+	if err != nil {
+		return nil, err
+	}
+	ct, ok := out.(*rlwe.Ciphertext)
+	if !ok {
+		return nil, fmt.Errorf("cannot Evaluate: expression %s resolves to a plaintext operand", e)
+	}
+	return ct, nil
+}
+
+func evaluate(eval schemes.Evaluator, e *Expr, cts map[string]*rlwe.Ciphertext, ops map[string]rlwe.Operand) (rlwe.Operand, error) {
+
+	if e == nil {
+		return nil, fmt.Errorf("cannot evaluate: nil expression")
+	}
+
+	if e.op == opVar {
+		if ct, ok := cts[e.name]; ok {
+			return ct, nil
+		}
+		if op, ok := ops[e.name]; ok {
+			return op, nil
+		}
+		return nil, fmt.Errorf("cannot evaluate: unbound variable %q", e.name)
+	}
+
+	if e.op == opRescale {
+		lhs, err := evaluate(eval, e.lhs, cts, ops)
+		if err != nil {
+			return nil, err
+		}
+		ct, ok := lhs.(*rlwe.Ciphertext)
+		if !ok {
+			return nil, fmt.Errorf("cannot evaluate: rescale of a plaintext operand")
+		}
+		out := ct.CopyNew()
+		if err := eval.Rescale(out, out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+
+	lhs, err := evaluate(eval, e.lhs, cts, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	rhs, err := evaluate(eval, e.rhs, cts, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	// Commutative operations accept a plaintext operand on either side
+	ctLhs, ok := lhs.(*rlwe.Ciphertext)
+	if !ok {
+		if e.op == opSub {
+			return nil, fmt.Errorf("cannot evaluate: subtraction from a plaintext operand")
+		}
+		if ctRhs, okRhs := rhs.(*rlwe.Ciphertext); okRhs {
+			ctLhs, rhs = ctRhs, lhs
+		} else {
+			return nil, fmt.Errorf("cannot evaluate: operation between two plaintext operands")
+		}
+	}
+
+	switch e.op {
+	case opAdd:
+		return eval.AddNew(ctLhs, rhs)
+	case opSub:
+		return eval.SubNew(ctLhs, rhs)
+	case opMul:
+		if _, ok := rhs.(*rlwe.Ciphertext); ok {
+			return eval.MulRelinNew(ctLhs, rhs)
+		}
+		return eval.MulNew(ctLhs, rhs)
+	default:
+		return nil, fmt.Errorf("cannot evaluate: unknown operation")
+	}
+}
+
+// Reference regroups the slot-wise arithmetic needed to execute an expression on
+// plaintext vectors of type T.
+type Reference[T any] struct {
+	// Add evaluates c = a + b slot-wise.
+	Add func(a, b, c []T)
+	// Sub evaluates c = a - b slot-wise.
+	Sub func(a, b, c []T)
+	// Mul evaluates c = a * b slot-wise.
+	Mul func(a, b, c []T)
+}
+
+// EvaluateReference executes the expression on plaintext vectors, slot-wise, using
+// the arithmetic of ref. All bound vectors must have the same length. Rescale nodes
+// are no-ops.
+func EvaluateReference[T any](ref Reference[T], e *Expr, vars map[string][]T) ([]T, error) {
+
+	if e == nil {
+		return nil, fmt.Errorf("cannot EvaluateReference: nil expression")
+	}
+
+	if e.op == opVar {
+		v, ok := vars[e.name]
+		if !ok {
+			return nil, fmt.Errorf("cannot EvaluateReference: unbound variable %q", e.name)
+		}
+		return v, nil
+	}
+
+	if e.op == opRescale {
+		return EvaluateReference(ref, e.lhs, vars)
+	}
+
+	lhs, err := EvaluateReference(ref, e.lhs, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	rhs, err := EvaluateReference(ref, e.rhs, vars)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(lhs) != len(rhs) {
+		return nil, fmt.Errorf("cannot EvaluateReference: operand lengths do not match")
+	}
+
+	out := make([]T, len(lhs))
+	switch e.op {
+	case opAdd:
+		ref.Add(lhs, rhs, out)
+	case opSub:
+		ref.Sub(lhs, rhs, out)
+	case opMul:
+		ref.Mul(lhs, rhs, out)
+	default:
+		return nil, fmt.Errorf("cannot EvaluateReference: unknown operation")
+	}
+
+	return out, nil
+}
@@ -0,0 +1,118 @@
+package algebra
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/schemes/bgv"
+)
+
+func TestAlgebra(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	tc := bgv.NewTestContext(bgv.ParametersLiteral{
+		LogN:             10,
+		LogQ:             []int{60, 60, 60},
+		LogP:             []int{61},
+		PlaintextModulus: 0x10001,
+	}, false)
+
+	params := tc.Params
+	T := params.PlaintextModulus()
+
+	newTestVector := func() (values []uint64, ct *rlwe.Ciphertext) {
+		values = tc.Sampler.ReadNew().Coeffs[0][:params.MaxSlots()]
+		pt := bgv.NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, tc.Ecd.Encode(values, pt))
+		ct, err := tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+		return
+	}
+
+	ref := Reference[uint64]{
+		Add: func(a, b, c []uint64) {
+			for i := range c {
+				c[i] = (a[i] + b[i]) % T
+			}
+		},
+		Sub: func(a, b, c []uint64) {
+			for i := range c {
+				c[i] = (a[i] + T - b[i]) % T
+			}
+		},
+		Mul: func(a, b, c []uint64) {
+			for i := range c {
+				c[i] = (a[i] * b[i]) % T
+			}
+		},
+	}
+
+	t.Run("SumOfProducts", func(t *testing.T) {
+
+		// sum_i (a_i * x_i) + b with plaintext a_i and ciphertext x_i, b
+		vars := map[string][]uint64{}
+		cts := map[string]*rlwe.Ciphertext{}
+		ops := map[string]rlwe.Operand{}
+
+		terms := make([]*Expr, 3)
+		for i, name := range []string{"0", "1", "2"} {
+
+			aValues := tc.Sampler.ReadNew().Coeffs[0][:params.MaxSlots()]
+			aPt := bgv.NewPlaintext(params, params.MaxLevel())
+			require.NoError(t, tc.Ecd.Encode(aValues, aPt))
+
+			xValues, xCt := newTestVector()
+
+			vars["a"+name], ops["a"+name] = aValues, aPt
+			vars["x"+name], cts["x"+name] = xValues, xCt
+
+			terms[i] = Var("a" + name).Mul(Var("x" + name))
+		}
+
+		bValues, bCt := newTestVector()
+		vars["b"], cts["b"] = bValues, bCt
+
+		expr := Sum(terms...).Add(Var("b"))
+		require.ElementsMatch(t, []string{"a0", "x0", "a1", "x1", "a2", "x2", "b"}, expr.Variables())
+
+		want, err := EvaluateReference(ref, expr, vars)
+		require.NoError(t, err)
+
+		have, err := Evaluate(tc.Evl, expr, cts, ops)
+		require.NoError(t, err)
+
+		bgv.VerifyTestVectors(params, tc.Ecd, tc.Dec, have, want, t)
+	})
+
+	t.Run("CiphertextProduct", func(t *testing.T) {
+
+		xValues, xCt := newTestVector()
+		yValues, yCt := newTestVector()
+
+		// (x * y) - x, exercising relinearization and rescaling
+		expr := Var("x").Mul(Var("y")).Rescale().Sub(Var("x"))
+
+		want, err := EvaluateReference(ref, expr, map[string][]uint64{"x": xValues, "y": yValues})
+		require.NoError(t, err)
+
+		have, err := Evaluate(tc.Evl, expr, map[string]*rlwe.Ciphertext{"x": xCt, "y": yCt}, nil)
+		require.NoError(t, err)
+
+		bgv.VerifyTestVectors(params, tc.Ecd, tc.Dec, have, want, t)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+
+		_, err := Evaluate(tc.Evl, Var("missing"), nil, nil)
+		require.Error(t, err)
+
+		_, err = EvaluateReference(ref, Var("missing"), nil)
+		require.Error(t, err)
+
+		aPt := bgv.NewPlaintext(params, params.MaxLevel())
+		_, err = Evaluate(tc.Evl, Var("a"), nil, map[string]rlwe.Operand{"a": aPt})
+		require.Error(t, err) // root resolves to a plaintext operand
+	})
+}
@@ -413,6 +413,32 @@ func testEvaluatorBvg(tc *TestContext, t *testing.T) {
 		})
 	}
 
+	for _, lvl := range testLevel {
+		t.Run(name("Evaluator/Mul/Ct/PlaintextOperand/Inplace", tc, lvl), func(t *testing.T) {
+			if lvl == 0 {
+				t.Skip("Skipping: Level = 0")
+			}
+
+			values0, _, ciphertext := NewTestVector(tc.Params, tc.Ecd, tc.Enc, lvl, tc.Params.NewScale(3))
+			values1, plaintext, _ := NewTestVector(tc.Params, tc.Ecd, tc.Enc, lvl, tc.Params.NewScale(7))
+
+			op := tc.Evl.NewPlaintextOperand(plaintext)
+
+			p0 := ring.Poly{Coeffs: [][]uint64{values0}}
+			p1 := ring.Poly{Coeffs: [][]uint64{values1}}
+
+			// The pre-formatted operand is reusable across calls.
+			receiver, err := tc.Evl.MulNew(ciphertext, op)
+			require.NoError(t, err)
+			require.NoError(t, tc.Evl.Mul(ciphertext, op, ciphertext))
+			require.True(t, ciphertext.Equal(receiver))
+
+			tc.Params.RingT().MulCoeffsBarrett(p0, p1, p0)
+
+			VerifyTestVectors(tc.Params, tc.Ecd, tc.Dec, ciphertext, p0.Coeffs[0], t)
+		})
+	}
+
 	for _, lvl := range testLevel {
 		t.Run(name("Evaluator/Mul/Ct/Scalar/Inplace", tc, lvl), func(t *testing.T) {
 			if lvl == 0 {
@@ -540,6 +566,36 @@ func testEvaluatorBvg(tc *TestContext, t *testing.T) {
 		})
 	}
 
+	for _, lvl := range testLevel {
+		t.Run(name("Evaluator/MulThenAdd/Ct/PlaintextOperand/Inplace", tc, lvl), func(t *testing.T) {
+			if lvl == 0 {
+				t.Skip("Skipping: Level = 0")
+			}
+
+			values0, _, ciphertext0 := NewTestVector(tc.Params, tc.Ecd, tc.Enc, lvl, tc.Params.DefaultScale())
+			values1, plaintext1, _ := NewTestVector(tc.Params, tc.Ecd, tc.Enc, lvl, tc.Params.NewScale(2))
+			values2, _, ciphertext2 := NewTestVector(tc.Params, tc.Ecd, tc.Enc, lvl, tc.Params.NewScale(7))
+
+			op := tc.Evl.NewPlaintextOperand(plaintext1)
+			opRef := op.CopyNew()
+
+			p0 := ring.Poly{Coeffs: [][]uint64{values0}}
+			p1 := ring.Poly{Coeffs: [][]uint64{values1}}
+			p2 := ring.Poly{Coeffs: [][]uint64{values2}}
+
+			require.True(t, ciphertext0.Scale.Cmp(op.Scale) != 0)
+			require.True(t, ciphertext0.Scale.Cmp(ciphertext2.Scale) != 0)
+
+			require.NoError(t, tc.Evl.MulThenAdd(ciphertext0, op, ciphertext2))
+			tc.Params.RingT().MulCoeffsBarrettThenAdd(p0, p1, p2)
+
+			// The scale matching operation must not mutate the pre-formatted operand.
+			require.True(t, op.Equal(opRef))
+
+			VerifyTestVectors(tc.Params, tc.Ecd, tc.Dec, ciphertext2, p2.Coeffs[0], t)
+		})
+	}
+
 	for _, lvl := range testLevel {
 		t.Run(name("Evaluator/MulThenAdd/Ct/Scalar/Inplace", tc, lvl), func(t *testing.T) {
 			if lvl == 0 {
@@ -663,6 +719,21 @@ func testEvaluatorBvg(tc *TestContext, t *testing.T) {
 			}
 		})
 	}
+
+	for _, lvl := range testLevel[:] {
+		t.Run(name("Evaluator/RescaleToLevel", tc, lvl), func(t *testing.T) {
+
+			values0, _, ciphertext0 := NewTestVector(tc.Params, tc.Ecd, tc.Enc, lvl, tc.Params.DefaultScale())
+
+			require.NoError(t, tc.Evl.RescaleToLevel(ciphertext0, 0, ciphertext0))
+			require.Equal(t, 0, ciphertext0.Level())
+
+			VerifyTestVectors(tc.Params, tc.Ecd, tc.Dec, ciphertext0, values0, t)
+
+			require.Error(t, tc.Evl.RescaleToLevel(ciphertext0, -1, ciphertext0))
+			require.Error(t, tc.Evl.RescaleToLevel(ciphertext0, ciphertext0.Level()+1, ciphertext0))
+		})
+	}
 }
 
 func testEvaluatorBfv(tc *TestContext, t *testing.T) {
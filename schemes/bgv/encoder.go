@@ -477,6 +477,41 @@ func (ecd Encoder) Decode(pt *rlwe.Plaintext, values interface{}) (err error) {
 	}
 }
 
+// DecodeInPlaceUnsafe decodes a plaintext and returns the decoded values as a view over the
+// encoder's internal scratch buffers, avoiding the per-call output allocation and final copy
+// of [Encoder.Decode]. The returned slice holds the values modulo PlaintextModulus and has
+// length MaxSlots() (batched plaintext) or N (non-batched plaintext).
+//
+// UNSAFE: the returned slice aliases internal scratch and is only valid until the next call
+// to any method of the encoder; it must not be modified or retained. Use [Encoder.ShallowCopy]
+// to decode concurrently.
+func (ecd Encoder) DecodeInPlaceUnsafe(pt *rlwe.Plaintext) (values []uint64, err error) {
+
+	bufT := ecd.bufT
+
+	if pt.IsNTT {
+		ecd.parameters.RingQ().AtLevel(pt.Level()).INTT(pt.Value, ecd.bufQ)
+		ecd.RingQ2T(pt.Level(), true, ecd.bufQ, bufT)
+	} else {
+		ecd.RingQ2T(pt.Level(), true, pt.Value, bufT)
+	}
+
+	if pt.IsBatched {
+		// bufQ is free at this point and its level-zero coefficients are large
+		// enough to receive the decoded slots.
+		values = ecd.bufQ.Coeffs[0][:ecd.parameters.MaxSlots()]
+		if err = ecd.DecodeRingT(bufT, pt.Scale, values); err != nil {
+			return nil, err
+		}
+		return values, nil
+	}
+
+	ringT := ecd.parameters.RingT()
+	ringT.MulScalar(bufT, ring.ModExp(pt.Scale.Uint64(), ringT.SubRings[0].Modulus-2, ringT.SubRings[0].Modulus), bufT)
+
+	return bufT.Coeffs[0], nil
+}
+
 // ShallowCopy returns a lightweight copy of the target object
 // that can be used concurrently with the original object.
 func (ecd Encoder) ShallowCopy() (e *Encoder) {
@@ -0,0 +1,152 @@
+package bgv
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+// ErrorCorrectingEncoder wraps an [Encoder] with a repetition code over the
+// slots: each value is embedded in Redundancy slots before encryption, and
+// decoding recovers it by majority vote over the copies. This allows to
+// correct up to floor((Redundancy-1)/2) corrupted copies per value, for
+// protocols in which decryption occasionally flips the last bit of a slot
+// (e.g. threshold decryption with large flooding noise, or aggressively
+// compressed ciphertexts). The price is a slot capacity reduced by a factor
+// Redundancy.
+type ErrorCorrectingEncoder struct {
+	*Encoder
+	// Redundancy is the number of copies of each value, an odd
+	// integer at least 3.
+	Redundancy int
+}
+
+// NewErrorCorrectingEncoder creates a new [ErrorCorrectingEncoder] embedding
+// each value in redundancy slots. redundancy must be an odd integer at least
+// 3, at most [Parameters.MaxSlots].
+func NewErrorCorrectingEncoder(parameters Parameters, redundancy int) (*ErrorCorrectingEncoder, error) {
+
+	if redundancy < 3 || redundancy&1 == 0 {
+		return nil, fmt.Errorf("invalid redundancy: must be an odd integer at least 3 but is %d", redundancy)
+	}
+
+	if redundancy > parameters.MaxSlots() {
+		return nil, fmt.Errorf("invalid redundancy: must be at most %d slots but is %d", parameters.MaxSlots(), redundancy)
+	}
+
+	return &ErrorCorrectingEncoder{Encoder: NewEncoder(parameters), Redundancy: redundancy}, nil
+}
+
+// MaxSlots returns the number of values that a plaintext can store under the
+// repetition code, i.e. [Parameters.MaxSlots] divided by the redundancy.
+func (ecd ErrorCorrectingEncoder) MaxSlots() int {
+	return ecd.parameters.MaxSlots() / ecd.Redundancy
+}
+
+// expand replicates each value of values over Redundancy consecutive blocks
+// of size [ErrorCorrectingEncoder.MaxSlots].
+func expand[T Integer](ecd ErrorCorrectingEncoder, values []T) ([]T, error) {
+
+	blockSize := ecd.MaxSlots()
+
+	if len(values) > blockSize {
+		return nil, fmt.Errorf("cannot encode: len(values)=%d exceeds the error-correcting capacity of %d slots", len(values), blockSize)
+	}
+
+	expanded := make([]T, ecd.parameters.MaxSlots())
+	for k := 0; k < ecd.Redundancy; k++ {
+		copy(expanded[k*blockSize:], values[:len(values)])
+	}
+
+	return expanded, nil
+}
+
+// correct recovers each value from its Redundancy copies by majority vote,
+// writing the result on values. An error is returned if some value has no
+// majority among its copies, i.e. if more than floor((Redundancy-1)/2) of
+// them are corrupted.
+func correct[T Integer](ecd ErrorCorrectingEncoder, expanded, values []T) error {
+
+	blockSize := ecd.MaxSlots()
+
+	if len(values) > blockSize {
+		return fmt.Errorf("cannot decode: len(values)=%d exceeds the error-correcting capacity of %d slots", len(values), blockSize)
+	}
+
+	for i := range values {
+
+		// Boyer-Moore majority vote over the Redundancy copies.
+		var candidate T
+		var count int
+		for k := 0; k < ecd.Redundancy; k++ {
+			if v := expanded[k*blockSize+i]; count == 0 || v == candidate {
+				candidate = v
+				count++
+			} else {
+				count--
+			}
+		}
+
+		count = 0
+		for k := 0; k < ecd.Redundancy; k++ {
+			if expanded[k*blockSize+i] == candidate {
+				count++
+			}
+		}
+
+		if count <= ecd.Redundancy>>1 {
+			return fmt.Errorf("cannot decode: value %d has no majority among its %d copies", i, ecd.Redundancy)
+		}
+
+		values[i] = candidate
+	}
+
+	return nil
+}
+
+// Encode encodes an IntegerSlice of size at most
+// [ErrorCorrectingEncoder.MaxSlots] on a pre-allocated plaintext, embedding
+// each value in Redundancy slots.
+func (ecd ErrorCorrectingEncoder) Encode(values interface{}, pt *rlwe.Plaintext) (err error) {
+
+	switch values := values.(type) {
+	case []uint64:
+		var expanded []uint64
+		if expanded, err = expand(ecd, values); err != nil {
+			return
+		}
+		return ecd.Encoder.Encode(expanded, pt)
+	case []int64:
+		var expanded []int64
+		if expanded, err = expand(ecd, values); err != nil {
+			return
+		}
+		return ecd.Encoder.Encode(expanded, pt)
+	default:
+		return fmt.Errorf("cannot encode: supported values.(type) are []uint64 and []int64 but is %T", values)
+	}
+}
+
+// Decode decodes a plaintext encoded with
+// [ErrorCorrectingEncoder.Encode] on an IntegerSlice of size at most
+// [ErrorCorrectingEncoder.MaxSlots], correcting up to
+// floor((Redundancy-1)/2) corrupted copies of each value.
+func (ecd ErrorCorrectingEncoder) Decode(pt *rlwe.Plaintext, values interface{}) (err error) {
+
+	switch values := values.(type) {
+	case []uint64:
+		expanded := make([]uint64, ecd.parameters.MaxSlots())
+		if err = ecd.Encoder.Decode(pt, expanded); err != nil {
+			return
+		}
+		return correct(ecd, expanded, values)
+	case []int64:
+		expanded := make([]int64, ecd.parameters.MaxSlots())
+		if err = ecd.Encoder.Decode(pt, expanded); err != nil {
+			return
+		}
+		return correct(ecd, expanded, values)
+	default:
+		return fmt.Errorf("cannot decode: supported values.(type) are []uint64 and []int64 but is %T", values)
+	}
+}
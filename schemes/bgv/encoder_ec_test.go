@@ -0,0 +1,89 @@
+package bgv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCorrectingEncoder(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	tc := NewTestContext(ParametersLiteral{
+		LogN:             10,
+		LogQ:             []int{60, 60},
+		LogP:             []int{61},
+		PlaintextModulus: 0x10001,
+	}, false)
+
+	params := tc.Params
+
+	const redundancy = 5
+
+	ecd, err := NewErrorCorrectingEncoder(params, redundancy)
+	require.NoError(t, err)
+	require.Equal(t, params.MaxSlots()/redundancy, ecd.MaxSlots())
+
+	T := params.PlaintextModulus()
+
+	values := make([]uint64, ecd.MaxSlots())
+	for i := range values {
+		values[i] = uint64(i) % T
+	}
+
+	t.Run("RoundTrip", func(t *testing.T) {
+
+		pt := NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, ecd.Encode(values, pt))
+
+		ct, err := tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+
+		have := make([]uint64, ecd.MaxSlots())
+		require.NoError(t, ecd.Decode(tc.Dec.DecryptNew(ct), have))
+		require.Equal(t, values, have)
+	})
+
+	t.Run("Correction", func(t *testing.T) {
+
+		pt := NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, ecd.Encode(values, pt))
+
+		// Corrupts floor((redundancy-1)/2) copies of each value, the
+		// maximum that the majority vote can correct.
+		expanded := make([]uint64, params.MaxSlots())
+		require.NoError(t, ecd.Encoder.Decode(pt, expanded))
+		for k := 0; k < redundancy>>1; k++ {
+			for i := 0; i < ecd.MaxSlots(); i++ {
+				expanded[k*ecd.MaxSlots()+i] ^= uint64(k + 1)
+			}
+		}
+		require.NoError(t, ecd.Encoder.Encode(expanded, pt))
+
+		have := make([]uint64, ecd.MaxSlots())
+		require.NoError(t, ecd.Decode(pt, have))
+		require.Equal(t, values, have)
+
+		// One more (distinctly) corrupted copy and the majority is lost.
+		for i := 0; i < ecd.MaxSlots(); i++ {
+			expanded[(redundancy>>1)*ecd.MaxSlots()+i] ^= uint64(redundancy>>1) + 1
+		}
+		require.NoError(t, ecd.Encoder.Encode(expanded, pt))
+		require.Error(t, ecd.Decode(pt, have))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+
+		// Even or too small redundancy.
+		_, err := NewErrorCorrectingEncoder(params, 4)
+		require.Error(t, err)
+		_, err = NewErrorCorrectingEncoder(params, 1)
+		require.Error(t, err)
+
+		// Too many values for the reduced capacity.
+		pt := NewPlaintext(params, params.MaxLevel())
+		require.Error(t, ecd.Encode(make([]uint64, ecd.MaxSlots()+1), pt))
+		require.NoError(t, ecd.Encode(values, pt))
+		require.Error(t, ecd.Decode(pt, make([]uint64, ecd.MaxSlots()+1)))
+	})
+}
@@ -0,0 +1,49 @@
+package bgv
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeInPlaceUnsafe(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	tc := NewTestContext(ParametersLiteral{
+		LogN:             10,
+		LogQ:             []int{60, 60},
+		LogP:             []int{61},
+		PlaintextModulus: 0x10001,
+	}, false)
+
+	params := tc.Params
+
+	t.Run("DecodeInPlaceUnsafe/Batched", func(t *testing.T) {
+
+		values, pt, _ := NewTestVector(params, tc.Ecd, nil, params.MaxLevel(), params.DefaultScale())
+
+		// The view must match the copying decode path (computed first: any
+		// subsequent encoder call invalidates the returned view)
+		want := make([]uint64, params.MaxSlots())
+		require.NoError(t, tc.Ecd.Decode(pt, want))
+
+		have, err := tc.Ecd.DecodeInPlaceUnsafe(pt)
+		require.NoError(t, err)
+		require.True(t, slices.Equal(values, have))
+		require.True(t, slices.Equal(want, have))
+	})
+
+	t.Run("DecodeInPlaceUnsafe/NonBatched", func(t *testing.T) {
+
+		_, pt, _ := NewTestVector(params, tc.Ecd, nil, params.MaxLevel(), params.DefaultScale())
+		pt.IsBatched = false
+
+		want := make([]uint64, params.N())
+		require.NoError(t, tc.Ecd.Decode(pt, want))
+
+		have, err := tc.Ecd.DecodeInPlaceUnsafe(pt)
+		require.NoError(t, err)
+		require.True(t, slices.Equal(want, have))
+	})
+}
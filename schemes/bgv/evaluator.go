@@ -158,6 +158,7 @@ func (eval Evaluator) WithKey(evk rlwe.EvaluationKeySet) *Evaluator {
 		Evaluator:        eval.Evaluator.WithKey(evk),
 		evaluatorBuffers: eval.evaluatorBuffers,
 		Encoder:          eval.Encoder,
+		ScaleInvariant:   eval.ScaleInvariant,
 	}
 }
 
@@ -437,6 +438,33 @@ func (eval Evaluator) DropLevel(op0 *rlwe.Ciphertext, levels int) {
 	op0.Resize(op0.Degree(), op0.Level()-levels)
 }
 
+// NewPlaintextOperand returns a copy of pt in the pre-format expected by the
+// plaintext-ciphertext multiplication of the scheme: NTT domain and Montgomery
+// domain, additionally scaled by the plaintext modulus T. Multiplications with
+// a pre-formatted operand skip the per-call domain switching, which is
+// worthwhile when the same plaintext multiplicand is reused across many calls
+// to Mul or MulThenAdd. This constructor must be used in place of the generic
+// [rlwe.NewPlaintextOperand], which does not apply the scaling by T.
+func (eval Evaluator) NewPlaintextOperand(pt *rlwe.Plaintext) (op *rlwe.PlaintextOperand) {
+
+	op = &rlwe.PlaintextOperand{Plaintext: *pt.CopyNew()}
+
+	ringQ := eval.parameters.RingQ().AtLevel(op.Level())
+
+	if !op.IsNTT {
+		ringQ.NTT(op.Value, op.Value)
+		op.IsNTT = true
+	}
+
+	if !op.IsMontgomery {
+		// Multiply by T * 2^{64} * 2^{64} -> result multipled by T and switched in the Montgomery domain
+		ringQ.MulRNSScalarMontgomery(op.Value, eval.tMontgomery, op.Value)
+		op.IsMontgomery = true
+	}
+
+	return
+}
+
 // Mul multiplies op0 with op1 without relinearization using either standard tensoring (BGV/CKKS-style) when [Evaluator.ScaleInvariant]
 // is set to false or scale-invariant tensoring (BFV-style) otherwise, i.e., [Evaluator.MulScaleInvariant], and returns the result in opOut.
 // This tensoring increases the noise by a multiplicative factor of the plaintext and noise norms of the operands and will usually
@@ -455,6 +483,10 @@ func (eval Evaluator) DropLevel(op0 *rlwe.Ciphertext, levels int) {
 // If op1 is an [rlwe.ElementInterface][[ring.Poly]]:
 //   - the level of opOut will be updated to min(op0.Level(), op1.Level())
 //   - the scale of opOut will be updated to op0.Scale * op1.Scale
+//
+// Plaintexts that are reused as multiplicands across many calls can be
+// pre-formatted with [Evaluator.NewPlaintextOperand] to skip the internal
+// per-call domain switching.
 func (eval Evaluator) Mul(op0 *rlwe.Ciphertext, op1 rlwe.Operand, opOut *rlwe.Ciphertext) (err error) {
 
 	if eval.ScaleInvariant {
@@ -740,8 +772,13 @@ func (eval Evaluator) tensorStandard(op0 *rlwe.Ciphertext, op1 *rlwe.Element[rin
 
 		c00 := eval.buffQ[0]
 
-		// Multiply by T * 2^{64} * 2^{64} -> result multipled by T and switched in the Montgomery domain
-		ringQ.MulRNSScalarMontgomery(op1.El().Value[0], eval.tMontgomery, c00)
+		if op1.IsMontgomery {
+			// Plaintext operand already in the T * 2^{64} pre-format (see Evaluator.NewPlaintextOperand)
+			c00 = op1.El().Value[0]
+		} else {
+			// Multiply by T * 2^{64} * 2^{64} -> result multipled by T and switched in the Montgomery domain
+			ringQ.MulRNSScalarMontgomery(op1.El().Value[0], eval.tMontgomery, c00)
+		}
 		for i := range opOut.Value {
 			ringQ.MulCoeffsMontgomery(op0.Value[i], c00, opOut.Value[i])
 		}
@@ -1373,8 +1410,13 @@ func (eval Evaluator) mulRelinThenAdd(op0 *rlwe.Ciphertext, op1 *rlwe.Element[ri
 
 		c00 := eval.buffQ[0]
 
-		// Multiply by T * 2^{64} * 2^{64} -> result multipled by T and switched in the Montgomery domain
-		ringQ.MulRNSScalarMontgomery(op1.El().Value[0], eval.tMontgomery, c00)
+		if op1.IsMontgomery {
+			// Plaintext operand already in the T * 2^{64} pre-format (see Evaluator.NewPlaintextOperand)
+			c00 = op1.El().Value[0]
+		} else {
+			// Multiply by T * 2^{64} * 2^{64} -> result multipled by T and switched in the Montgomery domain
+			ringQ.MulRNSScalarMontgomery(op1.El().Value[0], eval.tMontgomery, c00)
+		}
 
 		// If op0.Scale * op1.Scale != opOut.Scale then
 		// updates op1.Scale and opOut.Scale
@@ -1391,7 +1433,9 @@ func (eval Evaluator) mulRelinThenAdd(op0 *rlwe.Ciphertext, op1 *rlwe.Element[ri
 		}
 
 		if r0 != 1 {
-			ringQ.MulScalar(c00, r0, c00)
+			// Writes on the buffer to not mutate op1 when it is pre-formatted
+			ringQ.MulScalar(c00, r0, eval.buffQ[0])
+			c00 = eval.buffQ[0]
 		}
 
 		for i := range op0.Value {
@@ -1444,6 +1488,63 @@ func (eval Evaluator) Rescale(op0, opOut *rlwe.Ciphertext) (err error) {
 	return
 }
 
+// RescaleToLevel divides (rounded) op0 by the op0.Level()-targetLevel last primes of the moduli
+// chain and returns the result at targetLevel in opOut. The consecutive rescalings are fused in a
+// single pass over the data (one call to [ring.Ring.DivRoundByLastModulusManyNTT]), which is
+// measurably faster than repeated calls to [Evaluator.Rescale] when dropping several levels at once.
+// The procedure will return an error if:
+//   - targetLevel is not in [0, op0.Level()]
+//   - opOut.Level() < targetLevel (not enough space to store the result)
+//
+// The scale of opOut will be updated to op0.Scale * prod(qi^{-1}) mod PlaintextModulus where the
+// product ranges over the primes consumed by the rescaling operations.
+// Note that if the evaluator has been instantiated as scale-invariant (BFV-style), then RescaleToLevel is a nop.
+func (eval Evaluator) RescaleToLevel(op0 *rlwe.Ciphertext, targetLevel int, opOut *rlwe.Ciphertext) (err error) {
+
+	if eval.ScaleInvariant {
+		return nil
+	}
+
+	if op0.MetaData == nil || opOut.MetaData == nil {
+		return fmt.Errorf("cannot RescaleToLevel: op0.MetaData or opOut.MetaData is nil")
+	}
+
+	if targetLevel < 0 || targetLevel > op0.Level() {
+		return fmt.Errorf("cannot RescaleToLevel: targetLevel must be in [0, op0.Level()=%d] but is %d", op0.Level(), targetLevel)
+	}
+
+	if opOut.Level() < targetLevel {
+		return fmt.Errorf("cannot RescaleToLevel: opOut.Level() < targetLevel")
+	}
+
+	nbRescales := op0.Level() - targetLevel
+
+	if nbRescales == 0 {
+		if op0 != opOut {
+			opOut.Resize(op0.Degree(), targetLevel)
+			opOut.Copy(op0)
+		}
+		return
+	}
+
+	level := op0.Level()
+	ringQ := eval.parameters.RingQ().AtLevel(level)
+
+	for i := range opOut.Value {
+		ringQ.DivRoundByLastModulusManyNTT(nbRescales, op0.Value[i], eval.buffQ[0], opOut.Value[i])
+	}
+
+	opOut.Resize(opOut.Degree(), targetLevel)
+
+	*opOut.MetaData = *op0.MetaData
+	opOut.Scale = op0.Scale
+	for i := 0; i < nbRescales; i++ {
+		opOut.Scale = opOut.Scale.Div(eval.parameters.NewScale(ringQ.SubRings[level-i].Modulus))
+	}
+
+	return
+}
+
 // RelinearizeNew applies the relinearization procedure on op0 and returns the result in a new opOut.
 func (eval Evaluator) RelinearizeNew(op0 *rlwe.Ciphertext) (opOut *rlwe.Ciphertext, err error) {
 	opOut = NewCiphertext(eval.parameters, 1, op0.Level())
@@ -0,0 +1,101 @@
+package bgv
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/ring"
+)
+
+// This file provides explicit in-place variants of the binary evaluator
+// methods, i.e. variants that read and write the same ciphertext. The
+// aliasing behaviour of the three-operand methods differs per operation:
+// [Evaluator.Add], [Evaluator.Sub], [Evaluator.Mul], [Evaluator.MulRelin]
+// and their scale-invariant counterparts accept opOut aliasing either input,
+// while [Evaluator.MulThenAdd] and [Evaluator.MulRelinThenAdd] reject it.
+// Moreover, all of them detect aliasing by comparing element pointers only:
+// two distinct ciphertexts sharing backing coefficient arrays (e.g. after a
+// shallow struct copy) defeat the detection and silently corrupt the result.
+// The in-place variants make the receiver/output aliasing explicit and
+// reject such partially aliased operands upfront.
+
+// AddInPlace adds op1 to op0 in place, i.e. op0 = op0 + op1.
+// op1 may be the same element as op0 (doubling); any other operand sharing
+// buffers with op0 is rejected. See [Evaluator.Add] for the supported
+// operand types and the scale matching behaviour.
+func (eval Evaluator) AddInPlace(op0 *rlwe.Ciphertext, op1 rlwe.Operand) (err error) {
+	if err = checkInPlaceAliasing(op0, op1); err != nil {
+		return fmt.Errorf("cannot AddInPlace: %w", err)
+	}
+	return eval.Add(op0, op1, op0)
+}
+
+// SubInPlace subtracts op1 from op0 in place, i.e. op0 = op0 - op1.
+// op1 may be the same element as op0 (zeroing); any other operand sharing
+// buffers with op0 is rejected. See [Evaluator.Sub] for the supported
+// operand types and the scale matching behaviour.
+func (eval Evaluator) SubInPlace(op0 *rlwe.Ciphertext, op1 rlwe.Operand) (err error) {
+	if err = checkInPlaceAliasing(op0, op1); err != nil {
+		return fmt.Errorf("cannot SubInPlace: %w", err)
+	}
+	return eval.Sub(op0, op1, op0)
+}
+
+// MulInPlace multiplies op0 with op1 in place without relinearization, i.e.
+// op0 = op0 * op1. op1 may be the same element as op0 (squaring); any other
+// operand sharing buffers with op0 is rejected. See [Evaluator.Mul] for the
+// supported operand types and the resulting degree, level and scale.
+func (eval Evaluator) MulInPlace(op0 *rlwe.Ciphertext, op1 rlwe.Operand) (err error) {
+	if err = checkInPlaceAliasing(op0, op1); err != nil {
+		return fmt.Errorf("cannot MulInPlace: %w", err)
+	}
+	return eval.Mul(op0, op1, op0)
+}
+
+// MulRelinInPlace multiplies op0 with op1 in place with relinearization, i.e.
+// op0 = relin(op0 * op1). op1 may be the same element as op0 (squaring); any
+// other operand sharing buffers with op0 is rejected. See
+// [Evaluator.MulRelin] for the supported operand types and the resulting
+// level and scale.
+func (eval Evaluator) MulRelinInPlace(op0 *rlwe.Ciphertext, op1 rlwe.Operand) (err error) {
+	if err = checkInPlaceAliasing(op0, op1); err != nil {
+		return fmt.Errorf("cannot MulRelinInPlace: %w", err)
+	}
+	return eval.MulRelin(op0, op1, op0)
+}
+
+// MulScaleInvariantInPlace multiplies op0 with op1 in place without
+// relinearization and using scale invariant tensoring (BFV-style), i.e.
+// op0 = op0 * op1. op1 may be the same element as op0 (squaring); any other
+// operand sharing buffers with op0 is rejected. See
+// [Evaluator.MulScaleInvariant] for the supported operand types and the
+// resulting degree, level and scale.
+func (eval Evaluator) MulScaleInvariantInPlace(op0 *rlwe.Ciphertext, op1 rlwe.Operand) (err error) {
+	if err = checkInPlaceAliasing(op0, op1); err != nil {
+		return fmt.Errorf("cannot MulScaleInvariantInPlace: %w", err)
+	}
+	return eval.MulScaleInvariant(op0, op1, op0)
+}
+
+// MulRelinScaleInvariantInPlace multiplies op0 with op1 in place with
+// relinearization and using scale invariant tensoring (BFV-style), i.e.
+// op0 = relin(op0 * op1). op1 may be the same element as op0 (squaring); any
+// other operand sharing buffers with op0 is rejected. See
+// [Evaluator.MulRelinScaleInvariant] for the supported operand types and the
+// resulting level and scale.
+func (eval Evaluator) MulRelinScaleInvariantInPlace(op0 *rlwe.Ciphertext, op1 rlwe.Operand) (err error) {
+	if err = checkInPlaceAliasing(op0, op1); err != nil {
+		return fmt.Errorf("cannot MulRelinScaleInvariantInPlace: %w", err)
+	}
+	return eval.MulRelinScaleInvariant(op0, op1, op0)
+}
+
+// checkInPlaceAliasing returns an error if op1 is an element distinct from
+// op0 that nonetheless shares backing buffers with it: such operands defeat
+// the pointer-based aliasing detection of the three-operand methods.
+func checkInPlaceAliasing(op0 *rlwe.Ciphertext, op1 rlwe.Operand) (err error) {
+	if el, isEl := op1.(rlwe.ElementInterface[ring.Poly]); isEl && el.El() != op0.El() && op0.Aliases(el.El()) {
+		return fmt.Errorf("op1 shares buffers with op0 but is not the same element")
+	}
+	return
+}
@@ -0,0 +1,92 @@
+package bgv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+func TestEvaluatorInPlace(t *testing.T) {
+
+	tc := NewTestContext(ParametersLiteral{
+		LogN:             7,
+		LogQ:             []int{60, 60},
+		LogP:             []int{61},
+		PlaintextModulus: 0x101,
+	}, false)
+
+	params := tc.Params
+	slots := params.MaxSlots()
+	t0 := params.PlaintextModulus()
+
+	values := make([]uint64, slots)
+	for i := range values {
+		values[i] = uint64(i) % t0
+	}
+
+	encrypt := func() *rlwe.Ciphertext {
+		pt := NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, tc.Ecd.Encode(values, pt))
+		ct, err := tc.Enc.EncryptNew(pt)
+		require.NoError(t, err)
+		return ct
+	}
+
+	decrypt := func(ct *rlwe.Ciphertext) []uint64 {
+		have := make([]uint64, slots)
+		require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(ct), have))
+		return have
+	}
+
+	t.Run("AddInPlace", func(t *testing.T) {
+
+		ct0 := encrypt()
+		ct1 := encrypt()
+
+		require.NoError(t, tc.Evl.AddInPlace(ct0, ct1))
+
+		want := make([]uint64, slots)
+		for i := range want {
+			want[i] = (2 * values[i]) % t0
+		}
+
+		require.Equal(t, want, decrypt(ct0))
+
+		// Doubling: op1 is the same element as op0.
+		require.NoError(t, tc.Evl.AddInPlace(ct1, ct1))
+		require.Equal(t, want, decrypt(ct1))
+	})
+
+	t.Run("MulRelinInPlace", func(t *testing.T) {
+
+		ct0 := encrypt()
+
+		// Squaring: op1 is the same element as op0.
+		require.NoError(t, tc.Evl.MulRelinInPlace(ct0, ct0))
+
+		want := make([]uint64, slots)
+		for i := range want {
+			want[i] = (values[i] * values[i]) % t0
+		}
+
+		require.Equal(t, want, decrypt(ct0))
+		require.Equal(t, 1, ct0.Degree())
+	})
+
+	t.Run("RejectPartialAliasing", func(t *testing.T) {
+
+		ct0 := encrypt()
+
+		// A distinct element sharing the buffers of ct0: the pointer-based
+		// aliasing detection of the three-operand methods cannot see it.
+		alias := &rlwe.Ciphertext{Element: ct0.Element}
+		require.NotSame(t, ct0.El(), alias.El())
+
+		require.Error(t, tc.Evl.AddInPlace(ct0, alias))
+		require.Error(t, tc.Evl.SubInPlace(ct0, alias))
+		require.Error(t, tc.Evl.MulInPlace(ct0, alias))
+		require.Error(t, tc.Evl.MulRelinInPlace(ct0, alias))
+	})
+}
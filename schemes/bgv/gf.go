@@ -0,0 +1,424 @@
+package bgv
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+// GF implements the arithmetic of the Galois extension field GF(p^d), where p is
+// the plaintext modulus and d the extension degree. When the plaintext modulus
+// does not split completely over the ring (i.e., the multiplicative order d of
+// p modulo 2N is larger than 1), the plaintext slots are valued in GF(p^d) and
+// this type provides the matching cleartext-side field arithmetic.
+//
+// Elements are represented by their coefficient vector of length d over F_p, in
+// ascending degree order, modulo a monic irreducible polynomial of degree d.
+//
+// The slot-wise Frobenius automorphism x -> x^p on ciphertexts is obtained through
+// [Evaluator.Frobenius]; [GF.Frobenius] is its cleartext-side equivalent.
+type GF struct {
+	// P is the characteristic of the field (the plaintext modulus).
+	P uint64
+	// D is the extension degree.
+	D int
+	// Modulus is the monic irreducible polynomial of degree D defining the field,
+	// in ascending degree order (length D+1).
+	Modulus []uint64
+}
+
+// NewGF creates a new [GF] for the field GF(p^d), deterministically deriving a monic
+// irreducible polynomial of degree d over F_p. It returns an error if p is not an
+// odd prime below 2^32 or if d < 1.
+func NewGF(p uint64, d int) (*GF, error) {
+
+	if d < 1 {
+		return nil, fmt.Errorf("cannot NewGF: degree d=%d should be >= 1", d)
+	}
+
+	if p < 3 || p >= 1<<32 {
+		return nil, fmt.Errorf("cannot NewGF: characteristic p=%d should be an odd prime below 2^32", p)
+	}
+
+	gf := &GF{P: p, D: d}
+
+	// Deterministic search: enumerates the monic degree-d polynomials by their
+	// lower coefficients until an irreducible one is found. The density of
+	// irreducible polynomials is ~1/d, so the search terminates quickly.
+	f := make([]uint64, d+1)
+	f[d] = 1
+	for {
+		for i := 0; ; i++ {
+			f[i]++
+			if f[i] < p {
+				break
+			}
+			f[i] = 0
+		}
+		if gf.isIrreducible(f) {
+			gf.Modulus = f
+			return gf, nil
+		}
+	}
+}
+
+// NewElement returns the zero element of the field.
+func (gf *GF) NewElement() []uint64 {
+	return make([]uint64, gf.D)
+}
+
+// Add evaluates c = a + b in GF(p^d).
+func (gf *GF) Add(a, b, c []uint64) {
+	for i := range c {
+		c[i] = (a[i] + b[i]) % gf.P
+	}
+}
+
+// Sub evaluates c = a - b in GF(p^d).
+func (gf *GF) Sub(a, b, c []uint64) {
+	for i := range c {
+		c[i] = (a[i] + gf.P - b[i]) % gf.P
+	}
+}
+
+// Mul evaluates c = a * b in GF(p^d).
+func (gf *GF) Mul(a, b, c []uint64) {
+	copy(c, gf.mulMod(a, b))
+}
+
+// Pow evaluates c = a^e in GF(p^d) by square-and-multiply.
+func (gf *GF) Pow(a []uint64, e uint64, c []uint64) {
+
+	acc := gf.NewElement()
+	acc[0] = 1
+
+	base := make([]uint64, gf.D)
+	copy(base, a)
+
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			acc = gf.mulMod(acc, base)
+		}
+		base = gf.mulMod(base, base)
+	}
+
+	copy(c, acc)
+}
+
+// Inverse evaluates c = a^-1 in GF(p^d) using Fermat's little theorem
+// (a^(p^d - 2) = a^-1). It returns an error if a is zero.
+func (gf *GF) Inverse(a, c []uint64) error {
+
+	if gf.isZero(a) {
+		return fmt.Errorf("cannot Inverse: element is zero")
+	}
+
+	// a^(p^d - 2) = a^(p^d - 1) * a^-1 computed as (a^(p-1))^(1 + p + ... + p^(d-1)) * a^-1:
+	// evaluates iteratively a^(p^d - 2) = a^((p-2) * p^0) * prod_{i=1}^{d-1} a^((p-1) * p^i)
+	// through the Frobenius map to keep all exponents below p.
+	apm2 := gf.NewElement()
+	gf.Pow(a, gf.P-2, apm2)
+
+	apm1 := gf.mulMod(apm2, a) // a^(p-1)
+
+	acc := apm2
+	frob := apm1
+	for i := 1; i < gf.D; i++ {
+		frob = gf.frobenius(frob) // a^((p-1) * p^i)
+		acc = gf.mulMod(acc, frob)
+	}
+
+	copy(c, acc)
+
+	return nil
+}
+
+// Frobenius evaluates c = a^(p^k) in GF(p^d), the k-th power of the Frobenius
+// automorphism. It is the cleartext-side equivalent of [Evaluator.Frobenius].
+func (gf *GF) Frobenius(a []uint64, k int, c []uint64) {
+
+	acc := make([]uint64, gf.D)
+	copy(acc, a)
+
+	for i := 0; i < ((k%gf.D)+gf.D)%gf.D; i++ {
+		acc = gf.frobenius(acc)
+	}
+
+	copy(c, acc)
+}
+
+// Equal returns true if a = b in GF(p^d).
+func (gf *GF) Equal(a, b []uint64) bool {
+	for i := range a {
+		if a[i]%gf.P != b[i]%gf.P {
+			return false
+		}
+	}
+	return true
+}
+
+func (gf *GF) isZero(a []uint64) bool {
+	for _, ai := range a {
+		if ai%gf.P != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// frobenius evaluates a^p through square-and-multiply.
+func (gf *GF) frobenius(a []uint64) []uint64 {
+	c := gf.NewElement()
+	gf.Pow(a, gf.P, c)
+	return c
+}
+
+// mulMod evaluates the product of two coefficient vectors modulo the defining
+// polynomial, with coefficient arithmetic in F_p.
+func (gf *GF) mulMod(a, b []uint64) []uint64 {
+
+	p := gf.P
+
+	prod := make([]uint64, len(a)+len(b)-1)
+	for i := range a {
+		if a[i] == 0 {
+			continue
+		}
+		for j := range b {
+			prod[i+j] = (prod[i+j] + a[i]*b[j]) % p
+		}
+	}
+
+	return gf.reduce(prod)
+}
+
+// reduce reduces a coefficient vector modulo the defining polynomial.
+func (gf *GF) reduce(a []uint64) []uint64 {
+
+	p := gf.P
+	d := gf.D
+
+	r := make([]uint64, len(a))
+	copy(r, a)
+
+	// The defining polynomial is monic: x^d = -(f[d-1]x^{d-1} + ... + f[0])
+	for i := len(r) - 1; i >= d; i-- {
+		if r[i] == 0 {
+			continue
+		}
+		c := r[i]
+		r[i] = 0
+		for j := 0; j < d; j++ {
+			r[i-d+j] = (r[i-d+j] + (p-gf.Modulus[j]%p)*c%p) % p
+		}
+	}
+
+	return r[:d]
+}
+
+// isIrreducible implements Rabin's irreducibility test for the monic polynomial f
+// over F_p: f of degree d is irreducible iff x^(p^d) = x (mod f) and, for every
+// prime divisor q of d, gcd(x^(p^(d/q)) - x, f) = 1.
+func (gf *GF) isIrreducible(f []uint64) bool {
+
+	d := len(f) - 1
+
+	if d == 1 {
+		return true
+	}
+
+	// x^(p^k) mod f for k = 1..d, computed iteratively
+	xpk := make([][]uint64, d+1)
+	x := make([]uint64, d)
+	if d > 1 {
+		x[1] = 1
+	}
+	xpk[0] = x
+	for k := 1; k <= d; k++ {
+		xpk[k] = gf.powModF(xpk[k-1], gf.P, f)
+	}
+
+	// x^(p^d) = x (mod f)
+	if !polyEqual(xpk[d], x, gf.P) {
+		return false
+	}
+
+	for _, q := range primeFactors(d) {
+		diff := make([]uint64, d)
+		for i := range diff {
+			diff[i] = (xpk[d/q][i] + gf.P - x[i]) % gf.P
+		}
+		if len(gf.polyGCD(diff, f)) != 1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// powModF evaluates a^e modulo the monic polynomial f over F_p.
+func (gf *GF) powModF(a []uint64, e uint64, f []uint64) []uint64 {
+
+	d := len(f) - 1
+
+	acc := make([]uint64, d)
+	acc[0] = 1
+
+	base := make([]uint64, d)
+	copy(base, a)
+
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			acc = gf.mulModF(acc, base, f)
+		}
+		base = gf.mulModF(base, base, f)
+	}
+
+	return acc
+}
+
+// mulModF evaluates a*b modulo the monic polynomial f over F_p.
+func (gf *GF) mulModF(a, b, f []uint64) []uint64 {
+
+	p := gf.P
+	d := len(f) - 1
+
+	prod := make([]uint64, len(a)+len(b)-1)
+	for i := range a {
+		if a[i] == 0 {
+			continue
+		}
+		for j := range b {
+			prod[i+j] = (prod[i+j] + a[i]*b[j]) % p
+		}
+	}
+
+	for i := len(prod) - 1; i >= d; i-- {
+		if prod[i] == 0 {
+			continue
+		}
+		c := prod[i]
+		prod[i] = 0
+		for j := 0; j < d; j++ {
+			prod[i-d+j] = (prod[i-d+j] + (p-f[j]%p)*c%p) % p
+		}
+	}
+
+	return prod[:d]
+}
+
+// polyGCD returns the monic greatest common divisor of two polynomials over F_p.
+func (gf *GF) polyGCD(a, b []uint64) []uint64 {
+
+	p := gf.P
+
+	a, b = trim(a, p), trim(b, p)
+
+	for len(b) > 0 {
+		a, b = b, polyMod(a, b, p)
+	}
+
+	// Normalizes to a monic polynomial
+	if len(a) > 0 {
+		inv := modInverse(a[len(a)-1], p)
+		out := make([]uint64, len(a))
+		for i := range a {
+			out[i] = a[i] * inv % p
+		}
+		return out
+	}
+
+	return a
+}
+
+// polyMod returns a mod b over F_p, with b non-zero.
+func polyMod(a, b []uint64, p uint64) []uint64 {
+
+	r := make([]uint64, len(a))
+	copy(r, a)
+
+	invLead := modInverse(b[len(b)-1], p)
+
+	for len(r) >= len(b) {
+		c := r[len(r)-1] * invLead % p
+		offset := len(r) - len(b)
+		for i := range b {
+			r[offset+i] = (r[offset+i] + (p-b[i]%p)*c%p) % p
+		}
+		r = trim(r, p)
+	}
+
+	return r
+}
+
+func trim(a []uint64, p uint64) []uint64 {
+	n := len(a)
+	for n > 0 && a[n-1]%p == 0 {
+		n--
+	}
+	return a[:n]
+}
+
+func polyEqual(a, b []uint64, p uint64) bool {
+	for i := range a {
+		if a[i]%p != b[i]%p {
+			return false
+		}
+	}
+	return true
+}
+
+func modInverse(a, p uint64) uint64 {
+	// p is prime: a^-1 = a^(p-2) mod p
+	var r uint64 = 1
+	base := a % p
+	for e := p - 2; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			r = r * base % p
+		}
+		base = base * base % p
+	}
+	return r
+}
+
+func primeFactors(n int) (factors []int) {
+	for q := 2; q*q <= n; q++ {
+		if n%q == 0 {
+			factors = append(factors, q)
+			for n%q == 0 {
+				n /= q
+			}
+		}
+	}
+	if n > 1 {
+		factors = append(factors, n)
+	}
+	return
+}
+
+// GaloisElementForFrobenius returns the Galois element corresponding to the ring
+// automorphism X -> X^(p^k), which acts slot-wise as the k-th power of the
+// Frobenius automorphism x -> x^(p^k) when the slots are valued in GF(p^d).
+func (p Parameters) GaloisElementForFrobenius(k int) uint64 {
+	mask := p.RingQ().NthRoot() - 1
+	galEl := uint64(1)
+	for i := 0; i < k; i++ {
+		galEl = (galEl * (p.PlaintextModulus() & mask)) & mask
+	}
+	return galEl
+}
+
+// Frobenius applies the slot-wise Frobenius automorphism x -> x^(p^k) on op0 and
+// returns the result in opOut. The corresponding Galois key (see
+// [Parameters.GaloisElementForFrobenius]) must have been generated and attributed
+// to the evaluator.
+func (eval Evaluator) Frobenius(op0 *rlwe.Ciphertext, k int, opOut *rlwe.Ciphertext) (err error) {
+	return eval.Automorphism(op0, eval.parameters.GaloisElementForFrobenius(k), opOut)
+}
+
+// FrobeniusNew applies the slot-wise Frobenius automorphism x -> x^(p^k) on op0 and
+// returns the result in a new opOut.
+func (eval Evaluator) FrobeniusNew(op0 *rlwe.Ciphertext, k int) (opOut *rlwe.Ciphertext, err error) {
+	opOut = NewCiphertext(eval.parameters, op0.Degree(), op0.Level())
+	return opOut, eval.Frobenius(op0, k, opOut)
+}
@@ -0,0 +1,93 @@
+package bgv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGF(t *testing.T) {
+
+	for _, tc := range []struct {
+		p uint64
+		d int
+	}{
+		{p: 17, d: 1},
+		{p: 17, d: 4},
+		{p: 65537, d: 2},
+	} {
+
+		gf, err := NewGF(tc.p, tc.d)
+		require.NoError(t, err)
+		require.Len(t, gf.Modulus, tc.d+1)
+		require.Equal(t, uint64(1), gf.Modulus[tc.d]) // monic
+
+		// Deterministic pseudo-random non-zero elements
+		a, b := gf.NewElement(), gf.NewElement()
+		for i := 0; i < tc.d; i++ {
+			a[i] = (uint64(i)*0x9E3779B9 + 1) % tc.p
+			b[i] = (uint64(i)*0x85EBCA6B + 2) % tc.p
+		}
+		a[0], b[0] = 1, 2
+
+		t.Run("Mul/Commutative", func(t *testing.T) {
+			ab, ba := gf.NewElement(), gf.NewElement()
+			gf.Mul(a, b, ab)
+			gf.Mul(b, a, ba)
+			require.True(t, gf.Equal(ab, ba))
+		})
+
+		t.Run("Inverse", func(t *testing.T) {
+			aInv, prod := gf.NewElement(), gf.NewElement()
+			require.NoError(t, gf.Inverse(a, aInv))
+			gf.Mul(a, aInv, prod)
+			one := gf.NewElement()
+			one[0] = 1
+			require.True(t, gf.Equal(prod, one))
+
+			require.Error(t, gf.Inverse(gf.NewElement(), aInv))
+		})
+
+		t.Run("Frobenius/Identity", func(t *testing.T) {
+			// The Frobenius automorphism has order d: a^(p^d) = a
+			c := gf.NewElement()
+			gf.Frobenius(a, tc.d, c)
+			require.True(t, gf.Equal(a, c))
+		})
+
+		t.Run("Frobenius/Homomorphism", func(t *testing.T) {
+			// phi(a*b) = phi(a)*phi(b) and phi(a+b) = phi(a)+phi(b)
+			ab, phiAB := gf.NewElement(), gf.NewElement()
+			gf.Mul(a, b, ab)
+			gf.Frobenius(ab, 1, phiAB)
+
+			phiA, phiB, want := gf.NewElement(), gf.NewElement(), gf.NewElement()
+			gf.Frobenius(a, 1, phiA)
+			gf.Frobenius(b, 1, phiB)
+			gf.Mul(phiA, phiB, want)
+			require.True(t, gf.Equal(phiAB, want))
+
+			sum, phiSum := gf.NewElement(), gf.NewElement()
+			gf.Add(a, b, sum)
+			gf.Frobenius(sum, 1, phiSum)
+			gf.Add(phiA, phiB, want)
+			require.True(t, gf.Equal(phiSum, want))
+		})
+	}
+}
+
+func TestGaloisElementForFrobenius(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN:             10,
+		LogQ:             []int{60},
+		PlaintextModulus: 0x10001,
+	})
+	require.NoError(t, err)
+
+	nthRoot := params.RingQ().NthRoot()
+
+	require.Equal(t, uint64(1), params.GaloisElementForFrobenius(0))
+	require.Equal(t, params.PlaintextModulus()%nthRoot, params.GaloisElementForFrobenius(1))
+	require.Equal(t, params.PlaintextModulus()*params.PlaintextModulus()%nthRoot, params.GaloisElementForFrobenius(2))
+}
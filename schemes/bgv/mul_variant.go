@@ -0,0 +1,137 @@
+package bgv
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/ring"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// MulVariant identifies one of the two ciphertext-ciphertext tensoring
+// strategies of the [Evaluator].
+type MulVariant int
+
+const (
+	// MulVariantAuto selects the variant per multiplication according to
+	// [Evaluator.PreferredMulVariant].
+	MulVariantAuto MulVariant = iota
+	// MulVariantStandard tensors the ciphertexts mod Q (BGV-style). The output
+	// noise is the product of the operand noises, which is the tighter bound
+	// for fresh, low-noise operands.
+	MulVariantStandard
+	// MulVariantLifted lifts the ciphertexts to the extended basis Q*QMul
+	// before tensoring and scales the result by T/Q (BFV-style scale-invariant
+	// tensoring). The output noise grows linearly in the operand noise, at the
+	// cost of an additive rounding error of about log2(T) bits.
+	MulVariantLifted
+)
+
+// String implements the [fmt.Stringer] interface.
+func (v MulVariant) String() string {
+	switch v {
+	case MulVariantAuto:
+		return "Auto"
+	case MulVariantStandard:
+		return "Standard"
+	case MulVariantLifted:
+		return "Lifted"
+	default:
+		return "Unknown"
+	}
+}
+
+// PreferredMulVariant returns the tensoring variant expected to preserve the
+// most noise budget for a ciphertext-ciphertext multiplication at the given
+// level. The standard tensoring squares the operand noise, so it is preferred
+// while the remaining budget log2(Q_level/T) is large compared to its
+// per-multiplication growth of about log2(T*N) bits; once the budget at the
+// level falls below four times this growth, the lifted tensoring and its
+// noise growth linear in the operand noise become the better trade-off.
+func (eval Evaluator) PreferredMulVariant(level int) MulVariant {
+
+	p := eval.parameters
+
+	var logQ float64
+	for _, qi := range p.Q()[:level+1] {
+		logQ += math.Log2(float64(qi))
+	}
+
+	growth := p.LogT() + float64(p.LogN()) + 1
+
+	if logQ-p.LogT() < 4*growth {
+		return MulVariantLifted
+	}
+
+	return MulVariantStandard
+}
+
+// MulRelinVariant multiplies op0 with op1 with relinearization, using the
+// given tensoring variant for ciphertext-ciphertext products instead of the
+// variant implied by the [Evaluator.ScaleInvariant] flag, and returns the
+// result in opOut. [MulVariantAuto] resolves the variant per call with
+// [Evaluator.PreferredMulVariant] at the multiplication level. Plaintext,
+// scalar and vector operands are tensoring-agnostic and follow the operands of
+// [Evaluator.MulRelin]. Note that the output scale depends on the selected
+// variant, so mixing variants on operands that are later added together
+// relies on the automatic scale matching of [Evaluator.Add].
+func (eval Evaluator) MulRelinVariant(op0 *rlwe.Ciphertext, op1 rlwe.Operand, variant MulVariant, opOut *rlwe.Ciphertext) (err error) {
+
+	if variant == MulVariantAuto {
+		level := op0.Level()
+		if el, ok := op1.(rlwe.ElementInterface[ring.Poly]); ok {
+			level = utils.Min(level, el.El().Level())
+		}
+		variant = eval.PreferredMulVariant(level)
+	}
+
+	switch variant {
+	case MulVariantStandard:
+
+		switch op1 := op1.(type) {
+		case rlwe.ElementInterface[ring.Poly]:
+
+			_, level, err := eval.InitOutputBinaryOp(op0.El(), op1.El(), 2, opOut.El())
+			if err != nil {
+				return fmt.Errorf("cannot MulRelinVariant: %w", err)
+			}
+
+			opOut.Resize(opOut.Degree(), level)
+
+			if err = eval.tensorStandard(op0, op1.El(), true, opOut); err != nil {
+				return fmt.Errorf("cannot MulRelinVariant: %w", err)
+			}
+
+			return nil
+
+		default:
+			return eval.MulRelin(op0, op1, opOut)
+		}
+
+	case MulVariantLifted:
+		return eval.MulRelinScaleInvariant(op0, op1, opOut)
+
+	default:
+		return fmt.Errorf("cannot MulRelinVariant: invalid variant %d", variant)
+	}
+}
+
+// MulRelinVariantNew multiplies op0 with op1 with relinearization using the
+// given tensoring variant and returns the result in a new *[rlwe.Ciphertext].
+// See [Evaluator.MulRelinVariant] for additional information.
+func (eval Evaluator) MulRelinVariantNew(op0 *rlwe.Ciphertext, op1 rlwe.Operand, variant MulVariant) (opOut *rlwe.Ciphertext, err error) {
+
+	switch op1 := op1.(type) {
+	case rlwe.ElementInterface[ring.Poly]:
+		opOut = NewCiphertext(eval.parameters, 1, utils.Min(op0.Level(), op1.El().Level()))
+	default:
+		opOut = NewCiphertext(eval.parameters, op0.Degree(), op0.Level())
+	}
+
+	if err = eval.MulRelinVariant(op0, op1, variant, opOut); err != nil {
+		return nil, fmt.Errorf("cannot MulRelinVariantNew: %w", err)
+	}
+
+	return
+}
@@ -0,0 +1,41 @@
+package bgv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMulVariant(t *testing.T) {
+
+	p := testInsecure
+	p.PlaintextModulus = testPlaintextModulus[0]
+
+	tc := NewTestContext(p, false)
+
+	params := tc.Params
+	rT := params.RingT().SubRings[0]
+
+	// The standard variant is preferred while the budget is ample, the lifted
+	// one once it runs out at the lower levels.
+	require.Equal(t, MulVariantStandard, tc.Evl.PreferredMulVariant(params.MaxLevel()))
+	require.Equal(t, MulVariantLifted, tc.Evl.PreferredMulVariant(0))
+
+	for _, variant := range []MulVariant{MulVariantAuto, MulVariantStandard, MulVariantLifted} {
+
+		t.Run("Evaluator/MulRelinVariant="+variant.String()+"/"+tc.String(), func(t *testing.T) {
+
+			values0, _, ciphertext0 := NewTestVector(params, tc.Ecd, tc.Enc, params.MaxLevel(), params.DefaultScale())
+			values1, _, ciphertext1 := NewTestVector(params, tc.Ecd, tc.Enc, params.MaxLevel(), params.DefaultScale())
+
+			ciphertext2, err := tc.Evl.MulRelinVariantNew(ciphertext0, ciphertext1, variant)
+			require.NoError(t, err)
+			require.Equal(t, 1, ciphertext2.Degree())
+
+			want := make([]uint64, len(values0))
+			rT.MulCoeffsBarrett(values0, values1, want)
+
+			VerifyTestVectors(params, tc.Ecd, tc.Dec, ciphertext2, want, t)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package bgv
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+// SelfTest runs a short known-answer test of the scheme under the given
+// parameters: key generation, encryption, addition, relinearized
+// multiplication, rotation and rescaling are evaluated on fixed test vectors
+// and checked against the expected plaintext results. It returns an error
+// identifying the first failing operation, enabling services to catch
+// miscompiled assembly or broken CPU feature paths at startup, before serving
+// traffic.
+func SelfTest(params Parameters) (err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("self-test panic: %v", r)
+		}
+	}()
+
+	T := params.PlaintextModulus()
+	slots := params.MaxSlots()
+	rT := params.RingT().SubRings[0]
+
+	kgen := rlwe.NewKeyGenerator(params)
+	sk, pk := kgen.GenKeyPairNew()
+
+	galEl := params.GaloisElement(1)
+	evk := rlwe.NewMemEvaluationKeySet(kgen.GenRelinearizationKeyNew(sk), kgen.GenGaloisKeysNew([]uint64{galEl}, sk)...)
+
+	ecd := NewEncoder(params)
+	enc := rlwe.NewEncryptor(params, pk)
+	dec := rlwe.NewDecryptor(params, sk)
+	eval := NewEvaluator(params, evk)
+
+	values0 := make([]uint64, slots)
+	values1 := make([]uint64, slots)
+	for i := range values0 {
+		values0[i] = uint64(i) % T
+		values1[i] = uint64(2*i+1) % T
+	}
+
+	encrypt := func(values []uint64) (*rlwe.Ciphertext, error) {
+		pt := NewPlaintext(params, params.MaxLevel())
+		if err := ecd.Encode(values, pt); err != nil {
+			return nil, err
+		}
+		return enc.EncryptNew(pt)
+	}
+
+	check := func(op string, ct *rlwe.Ciphertext, want []uint64) error {
+		have := make([]uint64, slots)
+		if err := ecd.Decode(dec.DecryptNew(ct), have); err != nil {
+			return fmt.Errorf("self-test failed: %s: %w", op, err)
+		}
+		for i := range want {
+			if have[i] != want[i] {
+				return fmt.Errorf("self-test failed: %s: slot %d holds %d, expected %d", op, i, have[i], want[i])
+			}
+		}
+		return nil
+	}
+
+	var ct0, ct1 *rlwe.Ciphertext
+	if ct0, err = encrypt(values0); err != nil {
+		return fmt.Errorf("self-test failed: encrypt: %w", err)
+	}
+	if ct1, err = encrypt(values1); err != nil {
+		return fmt.Errorf("self-test failed: encrypt: %w", err)
+	}
+
+	// Encrypt/Decrypt
+	if err = check("encrypt/decrypt", ct0, values0); err != nil {
+		return
+	}
+
+	// Add
+	want := make([]uint64, slots)
+	rT.Add(values0, values1, want)
+
+	ctAdd, err := eval.AddNew(ct0, ct1)
+	if err != nil {
+		return fmt.Errorf("self-test failed: add: %w", err)
+	}
+	if err = check("add", ctAdd, want); err != nil {
+		return
+	}
+
+	// MulRelin
+	rT.MulCoeffsBarrett(values0, values1, want)
+
+	ctMul, err := eval.MulRelinNew(ct0, ct1)
+	if err != nil {
+		return fmt.Errorf("self-test failed: mul: %w", err)
+	}
+	if err = check("mul", ctMul, want); err != nil {
+		return
+	}
+
+	// Rescale
+	if params.MaxLevel() > 0 {
+		if err = eval.Rescale(ctMul, ctMul); err != nil {
+			return fmt.Errorf("self-test failed: rescale: %w", err)
+		}
+		if err = check("rescale", ctMul, want); err != nil {
+			return
+		}
+	}
+
+	// Rotation by one slot
+	// left rotation within each of the two rows of the plaintext matrix
+	copy(want, values0[1:])
+	want[slots>>1-1] = values0[0]
+	want[slots-1] = values0[slots>>1]
+
+	ctRot, err := eval.RotateColumnsNew(ct0, 1)
+	if err != nil {
+		return fmt.Errorf("self-test failed: rotate: %w", err)
+	}
+	return check("rotate", ctRot, want)
+}
@@ -0,0 +1,18 @@
+package bgv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfTest(t *testing.T) {
+
+	for _, plaintextModulus := range testPlaintextModulus {
+		p := testInsecure
+		p.PlaintextModulus = plaintextModulus
+		params, err := NewParametersFromLiteral(p)
+		require.NoError(t, err)
+		require.NoError(t, SelfTest(params))
+	}
+}
@@ -0,0 +1,211 @@
+package bgv
+
+import (
+	"fmt"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+// ShadowCiphertext is a pair of ciphertexts encrypting the same values under
+// two independent modulus chains: a primary ciphertext under the production
+// parameters and a small shadow ciphertext under an independent chain of tiny
+// moduli. Carrying the shadow through the same sequence of operations and
+// cross-checking the two decryptions catches soft errors and hardware faults
+// in long-running computations: a fault would have to corrupt both chains
+// into consistent plaintexts to go unnoticed.
+//
+// The shadow chain uses the same ring degree and plaintext modulus as the
+// primary one, so that both ciphertexts decode to the same slot vector, and
+// the same number of moduli, so that rescaling keeps the two chains in
+// lockstep. Since the shadow moduli are smaller, the shadow ciphertext is
+// at least as secure as the primary one.
+type ShadowCiphertext struct {
+	Primary *rlwe.Ciphertext
+	Shadow  *rlwe.Ciphertext
+}
+
+// Level returns the level of the primary ciphertext. The shadow ciphertext
+// is kept at the same level by the [ShadowEvaluator].
+func (ct ShadowCiphertext) Level() int {
+	return ct.Primary.Level()
+}
+
+// CopyNew creates a deep copy of the target [ShadowCiphertext] and returns it.
+func (ct ShadowCiphertext) CopyNew() *ShadowCiphertext {
+	return &ShadowCiphertext{Primary: ct.Primary.CopyNew(), Shadow: ct.Shadow.CopyNew()}
+}
+
+// checkShadowParameters verifies that shadowParams defines a valid shadow
+// chain for params.
+func checkShadowParameters(params, shadowParams Parameters) error {
+	switch {
+	case shadowParams.PlaintextModulus() != params.PlaintextModulus():
+		return fmt.Errorf("invalid shadow parameters: plaintext modulus differs from the primary parameters")
+	case shadowParams.MaxSlots() != params.MaxSlots():
+		return fmt.Errorf("invalid shadow parameters: number of slots differs from the primary parameters")
+	case shadowParams.QCount() != params.QCount():
+		return fmt.Errorf("invalid shadow parameters: number of moduli differs from the primary parameters")
+	}
+	return nil
+}
+
+// ShadowEncryptor encrypts the same values under the primary and the shadow
+// modulus chains, producing [ShadowCiphertext].
+type ShadowEncryptor struct {
+	params, shadowParams Parameters
+	ecd, shadowEcd       *Encoder
+	enc, shadowEnc       *rlwe.Encryptor
+}
+
+// NewShadowEncryptor instantiates a new [ShadowEncryptor] from the primary
+// and shadow parameters and an encryption key under each chain.
+func NewShadowEncryptor(params, shadowParams Parameters, key, shadowKey rlwe.EncryptionKey) (*ShadowEncryptor, error) {
+	if err := checkShadowParameters(params, shadowParams); err != nil {
+		return nil, err
+	}
+	return &ShadowEncryptor{
+		params:       params,
+		shadowParams: shadowParams,
+		ecd:          NewEncoder(params),
+		shadowEcd:    NewEncoder(shadowParams),
+		enc:          rlwe.NewEncryptor(params, key),
+		shadowEnc:    rlwe.NewEncryptor(shadowParams, shadowKey),
+	}, nil
+}
+
+// EncryptNew encodes and encrypts the values under both chains and returns
+// the resulting [ShadowCiphertext] at the maximum level.
+func (enc ShadowEncryptor) EncryptNew(values []uint64) (ct *ShadowCiphertext, err error) {
+
+	ct = &ShadowCiphertext{}
+
+	pt := NewPlaintext(enc.params, enc.params.MaxLevel())
+	if err = enc.ecd.Encode(values, pt); err != nil {
+		return nil, fmt.Errorf("cannot EncryptNew: %w", err)
+	}
+	if ct.Primary, err = enc.enc.EncryptNew(pt); err != nil {
+		return nil, fmt.Errorf("cannot EncryptNew: %w", err)
+	}
+
+	ptShadow := NewPlaintext(enc.shadowParams, enc.shadowParams.MaxLevel())
+	if err = enc.shadowEcd.Encode(values, ptShadow); err != nil {
+		return nil, fmt.Errorf("cannot EncryptNew: %w", err)
+	}
+	if ct.Shadow, err = enc.shadowEnc.EncryptNew(ptShadow); err != nil {
+		return nil, fmt.Errorf("cannot EncryptNew: %w", err)
+	}
+
+	return
+}
+
+// ShadowDecryptor decrypts [ShadowCiphertext] and cross-checks the two
+// chains for consistency.
+type ShadowDecryptor struct {
+	slots          int
+	ecd, shadowEcd *Encoder
+	dec, shadowDec *rlwe.Decryptor
+}
+
+// NewShadowDecryptor instantiates a new [ShadowDecryptor] from the primary
+// and shadow parameters and the secret key under each chain.
+func NewShadowDecryptor(params, shadowParams Parameters, sk, shadowSk *rlwe.SecretKey) (*ShadowDecryptor, error) {
+	if err := checkShadowParameters(params, shadowParams); err != nil {
+		return nil, err
+	}
+	return &ShadowDecryptor{
+		slots:     params.MaxSlots(),
+		ecd:       NewEncoder(params),
+		shadowEcd: NewEncoder(shadowParams),
+		dec:       rlwe.NewDecryptor(params, sk),
+		shadowDec: rlwe.NewDecryptor(shadowParams, shadowSk),
+	}, nil
+}
+
+// DecryptNew decrypts and decodes both ciphertexts and returns the slot
+// values of the primary one. It returns an error identifying the first
+// inconsistent slot if the two chains disagree, which indicates that a fault
+// corrupted the computation.
+func (dec ShadowDecryptor) DecryptNew(ct *ShadowCiphertext) (values []uint64, err error) {
+
+	values = make([]uint64, dec.slots)
+	if err = dec.ecd.Decode(dec.dec.DecryptNew(ct.Primary), values); err != nil {
+		return nil, fmt.Errorf("cannot DecryptNew: %w", err)
+	}
+
+	shadowValues := make([]uint64, dec.slots)
+	if err = dec.shadowEcd.Decode(dec.shadowDec.DecryptNew(ct.Shadow), shadowValues); err != nil {
+		return nil, fmt.Errorf("cannot DecryptNew: %w", err)
+	}
+
+	for i := range values {
+		if values[i] != shadowValues[i] {
+			return nil, fmt.Errorf("cannot DecryptNew: shadow mismatch at slot %d: primary holds %d, shadow holds %d", i, values[i], shadowValues[i])
+		}
+	}
+
+	return
+}
+
+// ShadowEvaluator mirrors every operation on the primary ciphertext onto the
+// shadow ciphertext, keeping the two chains in lockstep.
+type ShadowEvaluator struct {
+	eval, shadowEval *Evaluator
+}
+
+// NewShadowEvaluator instantiates a new [ShadowEvaluator] from the primary
+// and shadow parameters and an [rlwe.EvaluationKeySet] under each chain.
+func NewShadowEvaluator(params, shadowParams Parameters, evk, shadowEvk rlwe.EvaluationKeySet) (*ShadowEvaluator, error) {
+	if err := checkShadowParameters(params, shadowParams); err != nil {
+		return nil, err
+	}
+	return &ShadowEvaluator{
+		eval:       NewEvaluator(params, evk),
+		shadowEval: NewEvaluator(shadowParams, shadowEvk),
+	}, nil
+}
+
+// Add adds op1 to op0 and writes the result on opOut, on both chains.
+func (eval ShadowEvaluator) Add(op0, op1, opOut *ShadowCiphertext) (err error) {
+	if err = eval.eval.Add(op0.Primary, op1.Primary, opOut.Primary); err != nil {
+		return fmt.Errorf("cannot Add: %w", err)
+	}
+	if err = eval.shadowEval.Add(op0.Shadow, op1.Shadow, opOut.Shadow); err != nil {
+		return fmt.Errorf("cannot Add: shadow: %w", err)
+	}
+	return
+}
+
+// Sub subtracts op1 from op0 and writes the result on opOut, on both chains.
+func (eval ShadowEvaluator) Sub(op0, op1, opOut *ShadowCiphertext) (err error) {
+	if err = eval.eval.Sub(op0.Primary, op1.Primary, opOut.Primary); err != nil {
+		return fmt.Errorf("cannot Sub: %w", err)
+	}
+	if err = eval.shadowEval.Sub(op0.Shadow, op1.Shadow, opOut.Shadow); err != nil {
+		return fmt.Errorf("cannot Sub: shadow: %w", err)
+	}
+	return
+}
+
+// MulRelin multiplies op0 with op1 with relinearization and writes the result
+// on opOut, on both chains.
+func (eval ShadowEvaluator) MulRelin(op0, op1, opOut *ShadowCiphertext) (err error) {
+	if err = eval.eval.MulRelin(op0.Primary, op1.Primary, opOut.Primary); err != nil {
+		return fmt.Errorf("cannot MulRelin: %w", err)
+	}
+	if err = eval.shadowEval.MulRelin(op0.Shadow, op1.Shadow, opOut.Shadow); err != nil {
+		return fmt.Errorf("cannot MulRelin: shadow: %w", err)
+	}
+	return
+}
+
+// Rescale divides both ciphertexts by their respective last modulus, keeping
+// the two chains at the same level.
+func (eval ShadowEvaluator) Rescale(op0, opOut *ShadowCiphertext) (err error) {
+	if err = eval.eval.Rescale(op0.Primary, opOut.Primary); err != nil {
+		return fmt.Errorf("cannot Rescale: %w", err)
+	}
+	if err = eval.shadowEval.Rescale(op0.Shadow, opOut.Shadow); err != nil {
+		return fmt.Errorf("cannot Rescale: shadow: %w", err)
+	}
+	return
+}
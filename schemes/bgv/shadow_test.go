@@ -0,0 +1,107 @@
+package bgv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestShadowEvaluation(t *testing.T) {
+
+	p := testInsecure
+	p.PlaintextModulus = testPlaintextModulus[1]
+	params, err := NewParametersFromLiteral(p)
+	require.NoError(t, err)
+
+	// Independent chain of tiny moduli, with the same ring degree, plaintext
+	// modulus and number of moduli as the primary parameters.
+	shadowParams, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN:             p.LogN,
+		LogQ:             []int{30, 30, 30, 30, 30},
+		LogP:             []int{40},
+		PlaintextModulus: p.PlaintextModulus,
+	})
+	require.NoError(t, err)
+
+	kgen := rlwe.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	shadowKgen := rlwe.NewKeyGenerator(shadowParams)
+	shadowSk := shadowKgen.GenSecretKeyNew()
+
+	enc, err := NewShadowEncryptor(params, shadowParams, sk, shadowSk)
+	require.NoError(t, err)
+	dec, err := NewShadowDecryptor(params, shadowParams, sk, shadowSk)
+	require.NoError(t, err)
+	eval, err := NewShadowEvaluator(params, shadowParams,
+		rlwe.NewMemEvaluationKeySet(kgen.GenRelinearizationKeyNew(sk)),
+		rlwe.NewMemEvaluationKeySet(shadowKgen.GenRelinearizationKeyNew(shadowSk)))
+	require.NoError(t, err)
+
+	T := params.PlaintextModulus()
+	slots := params.MaxSlots()
+
+	newTestVector := func() (values []uint64, ct *ShadowCiphertext) {
+		values = make([]uint64, slots)
+		for i := range values {
+			values[i] = sampling.RandUint64() % T
+		}
+		ct, err := enc.EncryptNew(values)
+		require.NoError(t, err)
+		return values, ct
+	}
+
+	t.Run("Parameters", func(t *testing.T) {
+		mismatched := p
+		mismatched.PlaintextModulus = testPlaintextModulus[0]
+		mismatchedParams, err := NewParametersFromLiteral(mismatched)
+		require.NoError(t, err)
+		_, err = NewShadowEncryptor(params, mismatchedParams, sk, shadowSk)
+		require.Error(t, err)
+	})
+
+	t.Run("EncryptDecrypt", func(t *testing.T) {
+		values, ct := newTestVector()
+		have, err := dec.DecryptNew(ct)
+		require.NoError(t, err)
+		require.Equal(t, values, have)
+	})
+
+	t.Run("Evaluate", func(t *testing.T) {
+
+		values0, ct0 := newTestVector()
+		values1, ct1 := newTestVector()
+
+		rT := params.RingT().SubRings[0]
+
+		want := make([]uint64, slots)
+		rT.Add(values0, values1, want)
+		rT.MulCoeffsBarrett(want, values0, want)
+		rT.MulCoeffsBarrett(want, values1, want)
+
+		opOut := ct0.CopyNew()
+		require.NoError(t, eval.Add(ct0, ct1, opOut))
+		require.NoError(t, eval.MulRelin(opOut, ct0, opOut))
+		require.NoError(t, eval.MulRelin(opOut, ct1, opOut))
+		require.NoError(t, eval.Rescale(opOut, opOut))
+
+		have, err := dec.DecryptNew(opOut)
+		require.NoError(t, err)
+		require.Equal(t, want, have)
+	})
+
+	t.Run("FaultDetection", func(t *testing.T) {
+
+		_, ct := newTestVector()
+
+		// A fault on the primary chain leaves the shadow chain untouched and
+		// is caught by the cross-check at decryption.
+		q := params.RingQ().SubRings[0].Modulus
+		ct.Primary.Value[0].Coeffs[0][0] = (ct.Primary.Value[0].Coeffs[0][0] + 1) % q
+
+		_, err := dec.DecryptNew(ct)
+		require.Error(t, err)
+	})
+}
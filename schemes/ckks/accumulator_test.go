@@ -0,0 +1,142 @@
+package ckks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestCompensatedAccumulator(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN:            10,
+		LogQ:            []int{55, 45, 45},
+		LogP:            []int{60},
+		LogDefaultScale: 45,
+	})
+	require.NoError(t, err)
+
+	tc := NewTestContext(params.ParametersLiteral())
+	eval := tc.Evl.WithKey(rlwe.NewMemEvaluationKeySet(tc.Kgen.GenRelinearizationKeyNew(tc.Sk)))
+
+	slots := params.MaxSlots()
+	terms := 256
+
+	// The terms are unrescaled products, at the square of the default scale.
+	want := make([]float64, slots)
+	newTerm := func() *rlwe.Ciphertext {
+		a := make([]float64, slots)
+		b := make([]float64, slots)
+		for i := range a {
+			a[i] = sampling.RandFloat64(-1, 1)
+			b[i] = sampling.RandFloat64(-1, 1)
+			want[i] += a[i] * b[i]
+		}
+		encrypt := func(values []float64) *rlwe.Ciphertext {
+			pt := NewPlaintext(params, params.MaxLevel())
+			require.NoError(t, tc.Ecd.Encode(values, pt))
+			ct, err := tc.Enc.EncryptNew(pt)
+			require.NoError(t, err)
+			return ct
+		}
+		ct, err := eval.MulRelinNew(encrypt(a), encrypt(b))
+		require.NoError(t, err)
+		return ct
+	}
+
+	cts := make([]*rlwe.Ciphertext, terms)
+	for i := range cts {
+		cts[i] = newTerm()
+	}
+
+	t.Run("PrecisionGain", func(t *testing.T) {
+
+		// Naive flow: each term is rescaled before being added, accumulating
+		// one rounding per term.
+		naive := cts[0].CopyNew()
+		require.NoError(t, eval.Rescale(naive, naive))
+		for _, ct := range cts[1:] {
+			tmp := ct.CopyNew()
+			require.NoError(t, eval.Rescale(tmp, tmp))
+			require.NoError(t, eval.Add(naive, tmp, naive))
+		}
+
+		acc := NewCompensatedAccumulator(eval)
+		for _, ct := range cts {
+			require.NoError(t, acc.Add(ct))
+		}
+		compensated, err := acc.ValueNew()
+		require.NoError(t, err)
+
+		naivePrec := GetPrecisionStats(params, tc.Ecd, tc.Dec, want, naive, 0, false).AVGLog2Prec.Real
+		compensatedPrec := GetPrecisionStats(params, tc.Ecd, tc.Dec, want, compensated, 0, false).AVGLog2Prec.Real
+
+		t.Logf("naive: %.2f bits, compensated: %.2f bits", naivePrec, compensatedPrec)
+
+		require.Greater(t, compensatedPrec, naivePrec)
+	})
+
+	t.Run("Fold", func(t *testing.T) {
+
+		// Folding in the middle of the accumulation bounds the carry headroom
+		// without changing the result.
+		acc := NewCompensatedAccumulator(eval)
+		for i, ct := range cts {
+			require.NoError(t, acc.Add(ct))
+			if (i+1)%64 == 0 {
+				require.NoError(t, acc.Fold())
+			}
+		}
+		opOut, err := acc.ValueNew()
+		require.NoError(t, err)
+
+		VerifyTestVectors(params, tc.Ecd, tc.Dec, want, opOut, 30, 0, false, t)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+
+		acc := NewCompensatedAccumulator(eval)
+
+		_, err := acc.ValueNew()
+		require.Error(t, err)
+
+		require.NoError(t, acc.Add(cts[0]))
+
+		// Term at a different level.
+		lower := cts[1].CopyNew()
+		require.NoError(t, eval.Rescale(lower, lower))
+		require.Error(t, acc.Add(lower))
+
+		acc.Reset()
+		_, err = acc.ValueNew()
+		require.Error(t, err)
+	})
+}
+
+func BenchmarkCompensatedAccumulator(b *testing.B) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN:            12,
+		LogQ:            []int{55, 45, 45},
+		LogP:            []int{60},
+		LogDefaultScale: 45,
+	})
+	require.NoError(b, err)
+
+	tc := NewTestContext(params.ParametersLiteral())
+	eval := tc.Evl
+
+	ct := tc.Enc.EncryptZeroNew(params.MaxLevel())
+
+	acc := NewCompensatedAccumulator(eval)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := acc.Add(ct); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package ckks
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/utils"
+)
+
+// BlockFloatPlaintext is a plaintext in block-floating-point representation:
+// the encoded slot values are mantissas normalized to [-1, 1], and the
+// represented values are the slots multiplied by 2^Exponent. Storing the
+// block exponent outside of the encoding extends the usable dynamic range of
+// the scheme beyond what a single scaling factor allows, which is relevant
+// for scientific workloads mixing very large and very small magnitudes
+// across ciphertexts.
+type BlockFloatPlaintext struct {
+	*rlwe.Plaintext
+	Exponent int
+}
+
+// BlockFloatCiphertext is a ciphertext in block-floating-point representation.
+// See [BlockFloatPlaintext].
+type BlockFloatCiphertext struct {
+	*rlwe.Ciphertext
+	Exponent int
+}
+
+// NewBlockFloatPlaintext allocates a new [BlockFloatPlaintext] at the given level,
+// with exponent zero.
+func NewBlockFloatPlaintext(params Parameters, level int) *BlockFloatPlaintext {
+	return &BlockFloatPlaintext{Plaintext: NewPlaintext(params, level)}
+}
+
+// NewBlockFloatCiphertext allocates a new [BlockFloatCiphertext] of the given
+// degree at the given level, with exponent zero.
+func NewBlockFloatCiphertext(params Parameters, degree, level int) *BlockFloatCiphertext {
+	return &BlockFloatCiphertext{Ciphertext: NewCiphertext(params, degree, level)}
+}
+
+// EncodeBlockFloat encodes a slice of float64 on the target [BlockFloatPlaintext],
+// after normalizing the values to a common block exponent: the encoded slots are
+// the mantissas values * 2^-Exponent in [-1, 1], with Exponent the smallest
+// integer such that all the mantissas are at most one in magnitude. The
+// represented values are recovered with [Encoder.DecodeBlockFloat].
+func (ecd Encoder) EncodeBlockFloat(values []float64, pt *BlockFloatPlaintext) (err error) {
+
+	var max float64
+	for _, v := range values {
+		max = math.Max(max, math.Abs(v))
+	}
+
+	if math.IsInf(max, 0) || math.IsNaN(max) {
+		return fmt.Errorf("cannot EncodeBlockFloat: values must be finite")
+	}
+
+	pt.Exponent = 0
+	if max > 0 {
+		pt.Exponent = int(math.Ceil(math.Log2(max)))
+	}
+
+	mantissas := make([]float64, len(values))
+	scale := math.Exp2(float64(-pt.Exponent))
+	for i, v := range values {
+		mantissas[i] = v * scale
+	}
+
+	return ecd.Encode(mantissas, pt.Plaintext)
+}
+
+// DecodeBlockFloat decodes the target [BlockFloatPlaintext] on a slice of
+// float64, denormalizing the mantissas by the block exponent.
+func (ecd Encoder) DecodeBlockFloat(pt *BlockFloatPlaintext, values []float64) (err error) {
+
+	if err = ecd.Decode(pt.Plaintext, values); err != nil {
+		return fmt.Errorf("cannot DecodeBlockFloat: %w", err)
+	}
+
+	scale := math.Exp2(float64(pt.Exponent))
+	for i := range values {
+		values[i] *= scale
+	}
+
+	return
+}
+
+// AddBlockFloat adds op0 to op1 and writes the result on opOut, tracking the
+// block exponents: the operand with the smaller exponent is aligned to the
+// larger one by a plaintext-scale reinterpretation, which is exact and free of
+// homomorphic operations, and opOut carries the larger exponent.
+func (eval Evaluator) AddBlockFloat(op0, op1, opOut *BlockFloatCiphertext) (err error) {
+
+	e := utils.Max(op0.Exponent, op1.Exponent)
+
+	if err = eval.Add(alignBlockExponent(op0, e), alignBlockExponent(op1, e), opOut.Ciphertext); err != nil {
+		return fmt.Errorf("cannot AddBlockFloat: %w", err)
+	}
+
+	opOut.Exponent = e
+
+	return
+}
+
+// SubBlockFloat subtracts op1 from op0 and writes the result on opOut, tracking
+// the block exponents as in [Evaluator.AddBlockFloat].
+func (eval Evaluator) SubBlockFloat(op0, op1, opOut *BlockFloatCiphertext) (err error) {
+
+	e := utils.Max(op0.Exponent, op1.Exponent)
+
+	if err = eval.Sub(alignBlockExponent(op0, e), alignBlockExponent(op1, e), opOut.Ciphertext); err != nil {
+		return fmt.Errorf("cannot SubBlockFloat: %w", err)
+	}
+
+	opOut.Exponent = e
+
+	return
+}
+
+// MulRelinBlockFloat multiplies op0 with op1 with relinearization and writes
+// the result on opOut. The block exponents add up: the mantissas product is at
+// most one in magnitude, so no renormalization is required.
+func (eval Evaluator) MulRelinBlockFloat(op0, op1, opOut *BlockFloatCiphertext) (err error) {
+
+	if err = eval.MulRelin(op0.Ciphertext, op1.Ciphertext, opOut.Ciphertext); err != nil {
+		return fmt.Errorf("cannot MulRelinBlockFloat: %w", err)
+	}
+
+	opOut.Exponent = op0.Exponent + op1.Exponent
+
+	return
+}
+
+// RescaleBlockFloat rescales op0 on opOut. The represented values, and hence
+// the block exponent, are unchanged.
+func (eval Evaluator) RescaleBlockFloat(op0, opOut *BlockFloatCiphertext) (err error) {
+
+	if err = eval.Rescale(op0.Ciphertext, opOut.Ciphertext); err != nil {
+		return fmt.Errorf("cannot RescaleBlockFloat: %w", err)
+	}
+
+	opOut.Exponent = op0.Exponent
+
+	return
+}
+
+// alignBlockExponent returns a shallow view of ct whose mantissas are
+// reinterpreted at the block exponent e >= ct.Exponent. Dividing the mantissas
+// by 2^(e - ct.Exponent) is folded into the plaintext scale, leaving the
+// ciphertext coefficients untouched.
+func alignBlockExponent(ct *BlockFloatCiphertext, e int) *rlwe.Ciphertext {
+
+	if ct.Exponent == e {
+		return ct.Ciphertext
+	}
+
+	view := &rlwe.Ciphertext{Element: ct.Element}
+	meta := *ct.MetaData
+	meta.Scale = meta.Scale.Mul(rlwe.NewScale(math.Exp2(float64(e - ct.Exponent))))
+	view.MetaData = &meta
+
+	return view
+}
@@ -0,0 +1,110 @@
+package ckks
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestBlockFloat(t *testing.T) {
+
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN:            12,
+		LogQ:            []int{55, 45, 45},
+		LogP:            []int{60},
+		LogDefaultScale: 45,
+	})
+	require.NoError(t, err)
+
+	ecd := NewEncoder(params)
+	kgen := rlwe.NewKeyGenerator(params)
+	sk := kgen.GenSecretKeyNew()
+	enc := rlwe.NewEncryptor(params, sk)
+	dec := rlwe.NewDecryptor(params, sk)
+	eval := NewEvaluator(params, rlwe.NewMemEvaluationKeySet(kgen.GenRelinearizationKeyNew(sk)))
+
+	slots := params.MaxSlots()
+
+	// Values spanning a dynamic range well beyond a single scaling factor.
+	newTestVector := func(magnitude float64) (values []float64, ct *BlockFloatCiphertext) {
+		values = make([]float64, slots)
+		for i := range values {
+			values[i] = magnitude * (2*sampling.RandFloat64(0, 1) - 1)
+		}
+		pt := NewBlockFloatPlaintext(params, params.MaxLevel())
+		require.NoError(t, ecd.EncodeBlockFloat(values, pt))
+		ct = NewBlockFloatCiphertext(params, 1, params.MaxLevel())
+		require.NoError(t, enc.Encrypt(pt.Plaintext, ct.Ciphertext))
+		ct.Exponent = pt.Exponent
+		return
+	}
+
+	decode := func(ct *BlockFloatCiphertext) []float64 {
+		pt := &BlockFloatPlaintext{Plaintext: dec.DecryptNew(ct.Ciphertext), Exponent: ct.Exponent}
+		values := make([]float64, slots)
+		require.NoError(t, ecd.DecodeBlockFloat(pt, values))
+		return values
+	}
+
+	requireClose := func(want, have []float64, magnitude float64) {
+		for i := range want {
+			require.InDelta(t, want[i], have[i], magnitude*1e-9)
+		}
+	}
+
+	t.Run("Encoder", func(t *testing.T) {
+
+		values, ct := newTestVector(math.Exp2(40))
+		require.Equal(t, 40, ct.Exponent)
+		requireClose(values, decode(ct), math.Exp2(40))
+
+		// The zero vector encodes with exponent zero.
+		pt := NewBlockFloatPlaintext(params, params.MaxLevel())
+		require.NoError(t, ecd.EncodeBlockFloat(make([]float64, slots), pt))
+		require.Equal(t, 0, pt.Exponent)
+
+		// Non-finite values are rejected.
+		require.Error(t, ecd.EncodeBlockFloat([]float64{math.Inf(1)}, pt))
+	})
+
+	t.Run("Add", func(t *testing.T) {
+
+		// Operands with very different magnitudes: the exponents are aligned
+		// through the plaintext scale, without homomorphic operations.
+		values0, ct0 := newTestVector(math.Exp2(30))
+		values1, ct1 := newTestVector(math.Exp2(-10))
+
+		want := make([]float64, slots)
+		for i := range want {
+			want[i] = values0[i] + values1[i]
+		}
+
+		opOut := NewBlockFloatCiphertext(params, 1, params.MaxLevel())
+		require.NoError(t, eval.AddBlockFloat(ct0, ct1, opOut))
+		require.Equal(t, ct0.Exponent, opOut.Exponent)
+
+		requireClose(want, decode(opOut), math.Exp2(30))
+	})
+
+	t.Run("Mul", func(t *testing.T) {
+
+		values0, ct0 := newTestVector(math.Exp2(35))
+		values1, ct1 := newTestVector(math.Exp2(20))
+
+		want := make([]float64, slots)
+		for i := range want {
+			want[i] = values0[i] * values1[i]
+		}
+
+		opOut := NewBlockFloatCiphertext(params, 1, params.MaxLevel())
+		require.NoError(t, eval.MulRelinBlockFloat(ct0, ct1, opOut))
+		require.Equal(t, ct0.Exponent+ct1.Exponent, opOut.Exponent)
+		require.NoError(t, eval.RescaleBlockFloat(opOut, opOut))
+
+		requireClose(want, decode(opOut), math.Exp2(float64(opOut.Exponent)))
+	})
+}
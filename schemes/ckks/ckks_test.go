@@ -497,6 +497,33 @@ func testEvaluatorRescale(tc *TestContext, t *testing.T) {
 
 		VerifyTestVectors(tc.Params, tc.Ecd, tc.Dec, values, ciphertext, tc.Params.LogDefaultScale(), 0, *printPrecisionStats, t)
 	})
+
+	t.Run(name("Evaluator/RescaleToLevel", tc), func(t *testing.T) {
+
+		if tc.Params.MaxLevel() < 2 {
+			t.Skip("skipping test for params max level < 2")
+		}
+
+		values, _, ciphertext := tc.NewTestVector(-1-1i, 1+1i)
+
+		nbRescales := 2
+
+		for i := 0; i < nbRescales; i++ {
+			constant := tc.Params.RingQ().SubRings[ciphertext.Level()-i].Modulus
+			require.NoError(t, tc.Evl.Mul(ciphertext, constant, ciphertext))
+			ciphertext.Scale = ciphertext.Scale.Mul(rlwe.NewScale(constant))
+		}
+
+		targetLevel := ciphertext.Level() - nbRescales
+
+		require.NoError(t, tc.Evl.RescaleToLevel(ciphertext, targetLevel, ciphertext))
+		require.Equal(t, targetLevel, ciphertext.Level())
+
+		VerifyTestVectors(tc.Params, tc.Ecd, tc.Dec, values, ciphertext, tc.Params.LogDefaultScale(), 0, *printPrecisionStats, t)
+
+		require.Error(t, tc.Evl.RescaleToLevel(ciphertext, -1, ciphertext))
+		require.Error(t, tc.Evl.RescaleToLevel(ciphertext, ciphertext.Level()+1, ciphertext))
+	})
 }
 
 func testEvaluatorMul(tc *TestContext, t *testing.T) {
@@ -565,6 +592,27 @@ func testEvaluatorMul(tc *TestContext, t *testing.T) {
 		VerifyTestVectors(tc.Params, tc.Ecd, tc.Dec, values1, ciphertext1, tc.Params.LogDefaultScale(), 0, *printPrecisionStats, t)
 	})
 
+	t.Run(name("Evaluator/Mul/Ct/PlaintextOperand", tc), func(t *testing.T) {
+
+		values1, plaintext1, ciphertext1 := tc.NewTestVector(-1-1i, 1+1i)
+
+		op := rlwe.NewPlaintextOperand(tc.Params, plaintext1)
+
+		mul := bignum.NewComplexMultiplier()
+
+		for i := range values1 {
+			mul.Mul(values1[i], values1[i], values1[i])
+		}
+
+		// The pre-formatted operand is reusable across calls.
+		ciphertext2, err := tc.Evl.MulNew(ciphertext1, op)
+		require.NoError(t, err)
+		require.NoError(t, tc.Evl.Mul(ciphertext1, op, ciphertext1))
+		require.True(t, ciphertext1.Equal(ciphertext2))
+
+		VerifyTestVectors(tc.Params, tc.Ecd, tc.Dec, values1, ciphertext1, tc.Params.LogDefaultScale(), 0, *printPrecisionStats, t)
+	})
+
 	t.Run(name("Evaluator/Mul/Ct/Ct/Degree0", tc), func(t *testing.T) {
 
 		values1, plaintext1, _ := tc.NewTestVector(-1-1i, 1+1i)
@@ -698,6 +746,31 @@ func testEvaluatorMulThenAdd(tc *TestContext, t *testing.T) {
 		VerifyTestVectors(tc.Params, tc.Ecd, tc.Dec, values1, ciphertext1, tc.Params.LogDefaultScale(), 0, *printPrecisionStats, t)
 	})
 
+	t.Run(name("Evaluator/MulThenAdd/PlaintextOperand", tc), func(t *testing.T) {
+
+		values1, plaintext1, ciphertext1 := tc.NewTestVector(-1, 1)
+		values2, _, ciphertext2 := tc.NewTestVector(-1, 1)
+
+		op := rlwe.NewPlaintextOperand(tc.Params, plaintext1)
+
+		mul := bignum.NewComplexMultiplier()
+
+		tmp := new(bignum.Complex)
+		tmp[0] = new(big.Float)
+		tmp[1] = new(big.Float)
+
+		for i := range values1 {
+			mul.Mul(values2[i], values1[i], tmp)
+			values1[i].Add(values1[i], tmp)
+		}
+
+		require.NoError(t, tc.Evl.MulThenAdd(ciphertext2, op, ciphertext1))
+
+		require.Equal(t, ciphertext1.Degree(), 1)
+
+		VerifyTestVectors(tc.Params, tc.Ecd, tc.Dec, values1, ciphertext1, tc.Params.LogDefaultScale(), 0, *printPrecisionStats, t)
+	})
+
 	t.Run(name("Evaluator/MulRelinThenAdd/Ct", tc), func(t *testing.T) {
 
 		// opOut = opOut + op1 * op0
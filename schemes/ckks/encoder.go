@@ -182,6 +182,49 @@ func (ecd Encoder) DecodePublic(pt *rlwe.Plaintext, values FloatSlice, logprec f
 	return ecd.decodePublic(pt, values, logprec)
 }
 
+// DecodeInPlaceUnsafe decodes a batched plaintext and returns the decoded slots as a view
+// over the encoder's internal scratch buffer, avoiding the per-call output allocation and
+// final copy of [Encoder.Decode]. It is only supported for encoders with an encoding
+// precision of at most 53 bits (complex128 arithmetic).
+//
+// UNSAFE: the returned slice aliases internal scratch and is only valid until the next call
+// to any method of the encoder; it must not be modified or retained. Use [Encoder.ShallowCopy]
+// to decode concurrently.
+func (ecd Encoder) DecodeInPlaceUnsafe(pt *rlwe.Plaintext) (values []complex128, err error) {
+
+	buffCmplx, ok := ecd.buffCmplx.([]complex128)
+	if !ok {
+		return nil, fmt.Errorf("cannot DecodeInPlaceUnsafe: only supported for an encoding precision <= 53 bits")
+	}
+
+	if !pt.IsBatched {
+		return nil, fmt.Errorf("cannot DecodeInPlaceUnsafe: only supported for batched plaintexts")
+	}
+
+	logSlots := pt.LogDimensions.Cols
+	slots := 1 << logSlots
+
+	if maxLogCols := ecd.parameters.LogMaxDimensions().Cols; logSlots > maxLogCols || logSlots < 0 {
+		return nil, fmt.Errorf("cannot DecodeInPlaceUnsafe: ensure that %d <= logSlots (%d) <= %d", 0, logSlots, maxLogCols)
+	}
+
+	if pt.IsNTT {
+		ecd.parameters.RingQ().AtLevel(pt.Level()).INTT(pt.Value, ecd.buff)
+	} else {
+		ecd.buff.CopyLvl(pt.Level(), pt.Value)
+	}
+
+	if err = ecd.plaintextToComplex(pt.Level(), pt.Scale, logSlots, ecd.buff, buffCmplx); err != nil {
+		return nil, err
+	}
+
+	if err = ecd.FFT(buffCmplx[:slots], logSlots); err != nil {
+		return nil, err
+	}
+
+	return buffCmplx[:slots], nil
+}
+
 // Embed is a generic method to encode a [FloatSlice] on the target polyOut.
 // This method it as the core of the slot encoding.
 // Values are encoded according to the provided metadata.
@@ -0,0 +1,288 @@
+package ckks
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/ring"
+)
+
+// CompactEncoder is a lightweight variant of [Encoder] for low-memory embedded
+// clients that only need to encode and encrypt small vectors. It is restricted
+// to scales of at most 2^52 and relies on pure float64/uint64 arithmetic,
+// avoiding math/big entirely: neither the constructor nor the encoding path
+// allocates big.Int or big.Float values. Decoding is only supported at level 0
+// (a single RNS modulus), as decoding larger moduli requires the CRT
+// reconstruction of the full [Encoder], which should be used on servers.
+type CompactEncoder struct {
+	parameters Parameters
+
+	buff      ring.Poly
+	m         int
+	rotGroup  []int
+	roots     []complex128
+	buffCmplx []complex128
+}
+
+// MaxCompactScale is the largest scale supported by the [CompactEncoder]:
+// above 2^52, the 53-bit float64 mantissa cannot represent the quantized
+// coefficients exactly and the fixed-point conversion requires math/big.
+const MaxCompactScale = float64(1 << 52)
+
+// NewCompactEncoder creates a new [CompactEncoder] from the target parameters.
+// Returns an error if the default scale of the parameters is larger than
+// [MaxCompactScale].
+func NewCompactEncoder(parameters Parameters) (*CompactEncoder, error) {
+
+	if scale := parameters.DefaultScale().Float64(); scale > MaxCompactScale {
+		return nil, fmt.Errorf("cannot NewCompactEncoder: default scale (2^%.2f) is larger than 2^52, use Encoder instead", math.Log2(scale))
+	}
+
+	m := int(parameters.RingQ().NthRoot())
+
+	rotGroup := make([]int, m>>2)
+	fivePows := 1
+	for i := 0; i < m>>2; i++ {
+		rotGroup[i] = fivePows
+		fivePows *= int(GaloisGen)
+		fivePows &= (m - 1)
+	}
+
+	return &CompactEncoder{
+		parameters: parameters,
+		buff:       parameters.RingQ().NewPoly(),
+		m:          m,
+		rotGroup:   rotGroup,
+		roots:      GetRootsComplex128(m),
+		buffCmplx:  make([]complex128, m>>2),
+	}, nil
+}
+
+// GetParameters returns the parameters of the target [CompactEncoder].
+func (ecd CompactEncoder) GetParameters() Parameters {
+	return ecd.parameters
+}
+
+// Encode encodes a []float64 or []complex128 on the target batched plaintext,
+// at the level and scale of the plaintext. Returns an error if the plaintext
+// is not batched, if its scale is larger than [MaxCompactScale] or if a scaled
+// value overflows the uint64 fixed-point representation.
+// The imaginary part will be discarded if ringType == ring.ConjugateInvariant.
+func (ecd *CompactEncoder) Encode(values interface{}, pt *rlwe.Plaintext) (err error) {
+
+	if !pt.IsBatched {
+		return fmt.Errorf("cannot Encode: CompactEncoder only supports batched plaintexts")
+	}
+
+	if scale := pt.Scale.Float64(); scale > MaxCompactScale {
+		return fmt.Errorf("cannot Encode: scale (2^%.2f) is larger than 2^52, use Encoder instead", math.Log2(scale))
+	}
+
+	logSlots := pt.LogDimensions.Cols
+	slots := 1 << logSlots
+
+	if maxLogCols := ecd.parameters.LogMaxDimensions().Cols; logSlots < 0 || logSlots > maxLogCols {
+		return fmt.Errorf("cannot Encode: ensure that %d <= logSlots (%d) <= %d", 0, logSlots, maxLogCols)
+	}
+
+	buffCmplx := ecd.buffCmplx
+
+	var lenValues int
+	switch values := values.(type) {
+	case []float64:
+
+		lenValues = len(values)
+
+		if lenValues > slots {
+			return fmt.Errorf("cannot Encode: ensure that #values (%d) <= slots (%d)", lenValues, slots)
+		}
+
+		for i := range values {
+			buffCmplx[i] = complex(values[i], 0)
+		}
+
+	case []complex128:
+
+		lenValues = len(values)
+
+		if lenValues > slots {
+			return fmt.Errorf("cannot Encode: ensure that #values (%d) <= slots (%d)", lenValues, slots)
+		}
+
+		if ecd.parameters.RingType() == ring.ConjugateInvariant {
+			for i := range values {
+				buffCmplx[i] = complex(real(values[i]), 0)
+			}
+		} else {
+			copy(buffCmplx[:lenValues], values)
+		}
+
+	default:
+		return fmt.Errorf("cannot Encode: values.(type) must be []float64 or []complex128, but is %T", values)
+	}
+
+	for i := lenValues; i < slots; i++ {
+		buffCmplx[i] = 0
+	}
+
+	if logSlots < 4 {
+		SpecialIFFTDouble(buffCmplx[:slots], slots, ecd.m, ecd.rotGroup, ecd.roots)
+	} else {
+		SpecialiFFTDoubleUnrolled8(buffCmplx[:slots], slots, ecd.m, ecd.rotGroup, ecd.roots)
+	}
+
+	ringQ := ecd.parameters.RingQ().AtLevel(pt.Level())
+
+	if err = complex128ToFixedPointCompact(ringQ, buffCmplx[:slots], pt.Scale.Float64(), pt.Value.Coeffs); err != nil {
+		return fmt.Errorf("cannot Encode: %w", err)
+	}
+
+	rlwe.NTTSparseAndMontgomery(ringQ, pt.MetaData, pt.Value)
+
+	return
+}
+
+// Decode decodes the input batched plaintext on a []float64 or []complex128.
+// It is only supported at level 0, where the coefficients fit a single RNS
+// modulus: decoding at a higher level requires the CRT reconstruction of the
+// full [Encoder].
+func (ecd *CompactEncoder) Decode(pt *rlwe.Plaintext, values interface{}) (err error) {
+
+	if !pt.IsBatched {
+		return fmt.Errorf("cannot Decode: CompactEncoder only supports batched plaintexts")
+	}
+
+	if pt.Level() != 0 {
+		return fmt.Errorf("cannot Decode: CompactEncoder only supports decoding at level 0, but plaintext is at level %d", pt.Level())
+	}
+
+	logSlots := pt.LogDimensions.Cols
+	slots := 1 << logSlots
+
+	if maxLogCols := ecd.parameters.LogMaxDimensions().Cols; logSlots < 0 || logSlots > maxLogCols {
+		return fmt.Errorf("cannot Decode: ensure that %d <= logSlots (%d) <= %d", 0, logSlots, maxLogCols)
+	}
+
+	ringQ := ecd.parameters.RingQ().AtLevel(0)
+
+	if pt.IsNTT {
+		ringQ.INTT(pt.Value, ecd.buff)
+	} else {
+		ecd.buff.CopyLvl(0, pt.Value)
+	}
+
+	buffCmplx := ecd.buffCmplx
+
+	isreal := ecd.parameters.RingType() == ring.ConjugateInvariant
+	if err = polyToComplexNoCRT(ecd.buff.Coeffs[0], buffCmplx[:slots], pt.Scale, logSlots, isreal, ringQ); err != nil {
+		return fmt.Errorf("cannot Decode: %w", err)
+	}
+
+	if logSlots < 4 {
+		SpecialFFTDouble(buffCmplx[:slots], slots, ecd.m, ecd.rotGroup, ecd.roots)
+	} else {
+		SpecialFFTDoubleUL8(buffCmplx[:slots], slots, ecd.m, ecd.rotGroup, ecd.roots)
+	}
+
+	switch values := values.(type) {
+	case []float64:
+		for i := range values[:min(len(values), slots)] {
+			values[i] = real(buffCmplx[i])
+		}
+	case []complex128:
+		copy(values, buffCmplx[:slots])
+	default:
+		return fmt.Errorf("cannot Decode: values.(type) must be []float64 or []complex128, but is %T", values)
+	}
+
+	return
+}
+
+// ShallowCopy returns a lightweight copy of the target object that can be used
+// concurrently with the receiver.
+func (ecd CompactEncoder) ShallowCopy() *CompactEncoder {
+	return &CompactEncoder{
+		parameters: ecd.parameters,
+		buff:       ecd.parameters.RingQ().NewPoly(),
+		m:          ecd.m,
+		rotGroup:   ecd.rotGroup,
+		roots:      ecd.roots,
+		buffCmplx:  make([]complex128, ecd.m>>2),
+	}
+}
+
+// complex128ToFixedPointCompact encodes a vector of complex128 on a CRT
+// polynomial using uint64 arithmetic only. Unlike [Complex128ToFixedPointCRT],
+// it never falls back to math/big: a scaled value whose magnitude exceeds 2^63
+// is reported as an error instead.
+func complex128ToFixedPointCompact(r *ring.Ring, values []complex128, scale float64, coeffs [][]uint64) (err error) {
+
+	for i, v := range values {
+		if err = singleFloat64ToFixedPointCompact(r, i, real(v), scale, coeffs); err != nil {
+			return
+		}
+	}
+
+	var start int
+	if r.Type() == ring.Standard {
+		slots := len(values)
+		for i, v := range values {
+			if err = singleFloat64ToFixedPointCompact(r, i+slots, imag(v), scale, coeffs); err != nil {
+				return
+			}
+		}
+
+		start = 2 * slots
+
+	} else {
+		start = len(values)
+	}
+
+	for i := start; i < len(coeffs[0]); i++ {
+		for j := range coeffs {
+			coeffs[j][i] = 0
+		}
+	}
+
+	return
+}
+
+// singleFloat64ToFixedPointCompact encodes a single float64 on a CRT
+// polynomial in the i-th coefficient using uint64 arithmetic only.
+func singleFloat64ToFixedPointCompact(r *ring.Ring, i int, value, scale float64, coeffs [][]uint64) error {
+
+	if value == 0 {
+		for j := range coeffs {
+			coeffs[j][i] = 0
+		}
+		return nil
+	}
+
+	scaled := math.Abs(value) * scale
+
+	if scaled >= float64(1<<63) {
+		return fmt.Errorf("scaled value (2^%.2f) overflows the compact fixed-point representation", math.Log2(scaled))
+	}
+
+	c := uint64(scaled + 0.5)
+
+	moduli := r.ModuliChain()[:r.Level()+1]
+	brc := r.BRedConstants()
+
+	if value < 0 {
+		for j, qi := range moduli {
+			if cQi := ring.BRedAdd(c, qi, brc[j]); cQi != 0 {
+				coeffs[j][i] = qi - cQi
+			} else {
+				coeffs[j][i] = 0
+			}
+		}
+	} else {
+		for j, qi := range moduli {
+			coeffs[j][i] = ring.BRedAdd(c, qi, brc[j])
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package ckks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+func TestCompactEncoder(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN:            10,
+		LogQ:            []int{50, 45},
+		LogP:            []int{60},
+		LogDefaultScale: 40,
+	})
+	require.NoError(t, err)
+
+	ecd, err := NewCompactEncoder(params)
+	require.NoError(t, err)
+
+	slots := params.MaxSlots()
+	values := make([]float64, slots)
+	for i := range values {
+		values[i] = float64(i%17)/17 - 0.5
+	}
+
+	t.Run("MatchesEncoder", func(t *testing.T) {
+
+		// The compact fixed-point path must produce the exact same plaintext
+		// as the full encoder.
+		ptWant := NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, NewEncoder(params).Encode(values, ptWant))
+
+		ptHave := NewPlaintext(params, params.MaxLevel())
+		require.NoError(t, ecd.Encode(values, ptHave))
+
+		require.True(t, ptWant.Equal(ptHave))
+	})
+
+	t.Run("DecodeLevelZero", func(t *testing.T) {
+
+		pt := NewPlaintext(params, 0)
+		require.NoError(t, ecd.Encode(values, pt))
+
+		have := make([]float64, slots)
+		require.NoError(t, ecd.Decode(pt, have))
+
+		VerifyTestVectors(params, NewEncoder(params), nil, values, have, params.LogDefaultScale(), 0, false, t)
+
+		// Decoding above level 0 requires the CRT reconstruction of the full
+		// encoder.
+		require.Error(t, ecd.Decode(NewPlaintext(params, params.MaxLevel()), have))
+	})
+
+	t.Run("ScaleTooLarge", func(t *testing.T) {
+
+		pt := NewPlaintext(params, params.MaxLevel())
+		pt.Scale = rlwe.NewScale(uint64(1) << 60)
+		require.Error(t, ecd.Encode(values, pt))
+
+		largeScale, err := NewParametersFromLiteral(ParametersLiteral{
+			LogN:            10,
+			LogQ:            []int{60, 60},
+			LogP:            []int{61},
+			LogDefaultScale: 60,
+		})
+		require.NoError(t, err)
+
+		_, err = NewCompactEncoder(largeScale)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,34 @@
+package ckks
+
+// DeterministicEncodingPrecision is the default encoding precision of the encoder returned
+// by [NewDeterministicEncoder].
+const DeterministicEncodingPrecision uint = 128
+
+// NewDeterministicEncoder instantiates a new [Encoder] whose Encode path is deterministic
+// and platform-independent: the twiddle-factor generation, the FFT and the final rounding
+// are all carried out in exactly-rounded arbitrary-precision arithmetic (see [big.Float])
+// with a fixed rounding strategy and a fixed, ordered summation, so that encoding the same
+// values always produces bit-identical plaintext polynomials, regardless of the architecture
+// (e.g. amd64 vs arm64).
+//
+// The default float64 path of [NewEncoder] does not provide this guarantee, as the compiler
+// is free to contract floating-point expressions into fused multiply-adds on architectures
+// that support them. Bit-identical plaintexts matter when multiple parties must encode the
+// same public constants and obtain identical polynomials, e.g. in multiparty protocols.
+//
+// The optional precision argument overrides [DeterministicEncodingPrecision]; it must be
+// strictly greater than 53, as a precision of at most 53 bits selects the float64 path.
+// The deterministic path is around an order of magnitude slower than the float64 path.
+func NewDeterministicEncoder(parameters Parameters, precision ...uint) (ecd *Encoder) {
+
+	prec := DeterministicEncodingPrecision
+	if len(precision) != 0 && precision[0] != 0 {
+		prec = precision[0]
+	}
+
+	if prec <= 53 {
+		panic("cannot NewDeterministicEncoder: precision must be strictly greater than 53 bits")
+	}
+
+	return NewEncoder(parameters, prec)
+}
@@ -0,0 +1,38 @@
+package ckks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestDeterministicEncoder(t *testing.T) {
+
+	tc := NewTestContext(testInsecurePrec45)
+
+	params := tc.Params
+
+	values := make([]float64, params.MaxSlots())
+	for i := range values {
+		values[i] = sampling.RandFloat64(-1, 1)
+	}
+
+	// Two independently instantiated deterministic encoders must produce
+	// bit-identical plaintext polynomials.
+	ptA := NewPlaintext(params, params.MaxLevel())
+	ptB := NewPlaintext(params, params.MaxLevel())
+
+	require.NoError(t, NewDeterministicEncoder(params).Encode(values, ptA))
+	require.NoError(t, NewDeterministicEncoder(params).Encode(values, ptB))
+
+	require.True(t, ptA.Value.Equal(&ptB.Value))
+
+	// The deterministic path must agree with the float64 path up to its precision
+	have := make([]float64, params.MaxSlots())
+	require.NoError(t, tc.Ecd.Decode(ptA, have))
+	VerifyTestVectors(params, tc.Ecd, nil, values, have, params.LogDefaultScale(), 0, false, t)
+
+	require.Panics(t, func() { NewDeterministicEncoder(params, 53) })
+}
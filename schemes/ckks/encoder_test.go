@@ -0,0 +1,30 @@
+package ckks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeInPlaceUnsafe(t *testing.T) {
+
+	tc := NewTestContext(testInsecurePrec45)
+
+	params := tc.Params
+
+	values, pt, _ := tc.NewTestVector(complex(-1, -1), complex(1, 1))
+
+	have, err := tc.Ecd.DecodeInPlaceUnsafe(pt)
+	require.NoError(t, err)
+	require.Len(t, have, params.MaxSlots())
+
+	VerifyTestVectors(params, tc.Ecd, nil, values, have, params.LogDefaultScale(), 0, false, t)
+
+	t.Run("DecodeInPlaceUnsafe/HighPrecision", func(t *testing.T) {
+		// The scratch-view decode path requires complex128 arithmetic
+		tc := NewTestContext(testInsecurePrec90)
+		_, pt, _ := tc.NewTestVector(complex(-1, -1), complex(1, 1))
+		_, err := tc.Ecd.DecodeInPlaceUnsafe(pt)
+		require.Error(t, err)
+	})
+}
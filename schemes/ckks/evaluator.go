@@ -601,6 +601,56 @@ func (eval Evaluator) RescaleTo(op0 *rlwe.Ciphertext, minScale rlwe.Scale, opOut
 	return nil
 }
 
+// RescaleToLevel divides op0 by the op0.Level()-targetLevel last primes of the moduli chain and returns
+// the result at targetLevel in opOut. The consecutive rescalings are fused in a single pass over the
+// data (one call to [ring.Ring.DivRoundByLastModulusManyNTT]), which is measurably faster than repeated
+// calls to [Evaluator.Rescale] when dropping several levels at once.
+// Returns an error if:
+//   - Either op0 or opOut MetaData are nil
+//   - targetLevel is not in [0, op0.Level()]
+func (eval Evaluator) RescaleToLevel(op0 *rlwe.Ciphertext, targetLevel int, opOut *rlwe.Ciphertext) (err error) {
+
+	if op0.MetaData == nil || opOut.MetaData == nil {
+		return fmt.Errorf("cannot RescaleToLevel: op0.MetaData or opOut.MetaData is nil")
+	}
+
+	if targetLevel < 0 || targetLevel > op0.Level() {
+		return fmt.Errorf("cannot RescaleToLevel: targetLevel must be in [0, op0.Level()=%d] but is %d", op0.Level(), targetLevel)
+	}
+
+	nbRescales := op0.Level() - targetLevel
+
+	if nbRescales == 0 {
+		if op0 != opOut {
+			opOut.Resize(op0.Degree(), targetLevel)
+			opOut.Copy(op0)
+		}
+		return
+	}
+
+	if op0 != opOut {
+		opOut.Resize(op0.Degree(), targetLevel)
+	}
+
+	*opOut.MetaData = *op0.MetaData
+
+	ringQ := eval.GetParameters().RingQ().AtLevel(op0.Level())
+
+	for i := 0; i < nbRescales; i++ {
+		opOut.Scale = opOut.Scale.Div(rlwe.NewScale(ringQ.SubRings[op0.Level()-i].Modulus))
+	}
+
+	for i := range opOut.Value {
+		ringQ.DivRoundByLastModulusManyNTT(nbRescales, op0.Value[i], eval.buffQ[0], opOut.Value[i])
+	}
+
+	if op0 == opOut {
+		opOut.Resize(op0.Degree(), targetLevel)
+	}
+
+	return
+}
+
 // MulNew multiplies op0 with op1 without relinearization and returns the result in a newly created element opOut.
 //
 // op1.(type) can be
@@ -624,6 +674,10 @@ func (eval Evaluator) MulNew(op0 *rlwe.Ciphertext, op1 rlwe.Operand) (opOut *rlw
 //
 // Passing an invalid type will return an error.
 //
+// Plaintexts that are reused as multiplicands across many calls can be
+// pre-formatted with [rlwe.NewPlaintextOperand] to skip the internal per-call
+// domain switching.
+//
 // If op1.(type) == [rlwe.ElementInterface][[ring.Poly]]:
 //   - The procedure will return an error if either op0 or op1 are have a degree higher than 1.
 //   - The procedure will return an error if opOut.Degree != op0.Degree + op1.Degree.
@@ -860,13 +914,21 @@ func (eval Evaluator) mulRelin(op0 *rlwe.Ciphertext, op1 *rlwe.Element[ring.Poly
 		var c0 ring.Poly
 		var c1 []ring.Poly
 		if op0.Degree() == 0 {
-			c0 = eval.buffQ[0]
-			ringQ.MForm(op0.Value[0], c0)
+			if op0.IsMontgomery {
+				c0 = op0.Value[0]
+			} else {
+				c0 = eval.buffQ[0]
+				ringQ.MForm(op0.Value[0], c0)
+			}
 			c1 = op1.El().Value
 
 		} else {
-			c0 = eval.buffQ[0]
-			ringQ.MForm(op1.El().Value[0], c0)
+			if op1.IsMontgomery {
+				c0 = op1.El().Value[0]
+			} else {
+				c0 = eval.buffQ[0]
+				ringQ.MForm(op1.El().Value[0], c0)
+			}
 			c1 = op0.Value
 		}
 
@@ -1168,7 +1230,11 @@ func (eval Evaluator) mulRelinThenAdd(op0 *rlwe.Ciphertext, op1 *rlwe.Element[ri
 
 		c00 := eval.buffQ[0]
 
-		ringQ.MForm(op1.El().Value[0], c00)
+		if op1.IsMontgomery {
+			c00 = op1.El().Value[0]
+		} else {
+			ringQ.MForm(op1.El().Value[0], c00)
+		}
 		for i := range op0.Value {
 			ringQ.MulCoeffsMontgomeryThenAdd(op0.Value[i], c00, opOut.Value[i])
 		}
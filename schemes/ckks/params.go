@@ -74,6 +74,28 @@ func (p ParametersLiteral) GetRLWEParametersLiteral() rlwe.ParametersLiteral {
 	}
 }
 
+// Downscale derives from the receiver a set of functionally-equivalent but INSECURE
+// parameters of ring degree 2^{targetLogN} intended for fast testing, keeping the
+// ring type and the default scale unchanged. See [rlwe.ParametersLiteral.Downscale].
+func (p ParametersLiteral) Downscale(targetLogN int) (ParametersLiteral, error) {
+	rp, err := p.GetRLWEParametersLiteral().Downscale(targetLogN)
+	if err != nil {
+		return ParametersLiteral{}, err
+	}
+	return ParametersLiteral{
+		LogN:            rp.LogN,
+		LogNthRoot:      rp.LogNthRoot,
+		Q:               rp.Q,
+		P:               rp.P,
+		LogQ:            rp.LogQ,
+		LogP:            rp.LogP,
+		Xe:              rp.Xe,
+		Xs:              rp.Xs,
+		RingType:        rp.RingType,
+		LogDefaultScale: p.LogDefaultScale,
+	}, nil
+}
+
 // Parameters represents a parameter set for the CKKS cryptosystem. Its fields are private and
 // immutable. See [ParametersLiteral] for user-specified parameters.
 type Parameters struct {
@@ -0,0 +1,92 @@
+package ckks
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// CircuitProfile describes a homomorphic circuit by the operations that
+// dominate its output noise, enabling the a-priori precision estimation of
+// [EstimatePrecision] without trial decryptions.
+type CircuitProfile struct {
+	// Depth is the multiplicative depth of the circuit, i.e. the number of
+	// rescalings from the maximum level down to the output level.
+	Depth int
+	// Rotations is the total number of key-switched automorphisms
+	// (rotations and conjugations) evaluated in the circuit.
+	Rotations int
+	// CtCtMuls is the total number of ciphertext-ciphertext multiplications
+	// (each followed by a relinearization) evaluated in the circuit.
+	CtCtMuls int
+}
+
+// EstimatePrecision returns the predicted end-to-end output precision in bits
+// of a circuit with the given profile evaluated under the given parameters,
+// i.e. the expected value of -log2 of the slot-wise error relative to the
+// default scale.
+//
+// The prediction is a first-order average-case estimate: the output noise
+// variance is modeled as the fresh public-key encryption noise, plus one
+// rescaling rounding term per level of depth, plus one key-switching term per
+// rotation and per relinearization, all mapped to the slots through the
+// canonical embedding. Messages are assumed of unit magnitude at the default
+// scale; circuits that grow the message magnitude lose the corresponding bits
+// on top of this estimate.
+//
+// Returns an error if the parameters are invalid or if the circuit depth
+// exceeds the level budget of the parameters.
+func EstimatePrecision(paramsLit ParametersLiteral, profile CircuitProfile) (bits float64, err error) {
+
+	params, err := NewParametersFromLiteral(paramsLit)
+	if err != nil {
+		return 0, fmt.Errorf("cannot EstimatePrecision: %w", err)
+	}
+
+	if profile.Depth < 0 || profile.Rotations < 0 || profile.CtCtMuls < 0 {
+		return 0, fmt.Errorf("cannot EstimatePrecision: negative operation count in the circuit profile")
+	}
+
+	if profile.Depth > params.MaxLevel() {
+		return 0, fmt.Errorf("cannot EstimatePrecision: circuit depth %d exceeds the maximum level %d", profile.Depth, params.MaxLevel())
+	}
+
+	N := float64(params.N())
+
+	// Fresh public-key encryption noise.
+	sigmaFresh := params.NoiseFreshPK()
+
+	// Rounding noise added by each rescaling: rounding the two ciphertext
+	// polynomials adds an error of variance (1 + h)/12 after decryption,
+	// with h the Hamming weight of the secret.
+	sigma2Round := (1 + float64(params.XsHammingWeight())) / 12.0
+
+	// Additive key-switching noise (hybrid decomposition): the gadget error
+	// of each of the d decomposition blocks is scaled by a uniform factor of
+	// at most max(Qi), then divided by P during the final ModDown.
+	d := float64(params.BaseRNSDecompositionVectorSize(params.MaxLevelQ(), 0))
+	sigmaErr := params.NoiseFreshSK()
+	maxQi := 0.0
+	for _, qi := range params.Q() {
+		maxQi = math.Max(maxQi, float64(qi))
+	}
+	ratio := 1.0
+	if params.PCount() > 0 {
+		pF, _ := new(big.Float).SetInt(params.PBigInt()).Float64()
+		ratio = maxQi / pF
+	}
+	sigma2KS := d * (N / 12.0) * sigmaErr * sigmaErr * ratio * ratio
+
+	keySwitches := float64(profile.Rotations + profile.CtCtMuls)
+
+	// Total coefficient-domain noise variance; the noise introduced above the
+	// output level is attenuated by the subsequent rescalings, so summing the
+	// per-operation terms at the output level is a (tight) upper bound.
+	sigma2 := sigmaFresh*sigmaFresh + float64(profile.Depth)*sigma2Round + keySwitches*sigma2KS
+
+	// The canonical embedding spreads a coefficient-domain noise of standard
+	// deviation sigma to slot-wise errors of standard deviation sigma*sqrt(N).
+	sigmaSlots := math.Sqrt(sigma2 * N)
+
+	return params.DefaultScale().Log2() - math.Log2(sigmaSlots), nil
+}
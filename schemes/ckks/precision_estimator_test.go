@@ -0,0 +1,51 @@
+package ckks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimatePrecision(t *testing.T) {
+
+	paramsLit := ParametersLiteral{
+		LogN:            12,
+		LogQ:            []int{55, 45, 45, 45},
+		LogP:            []int{61},
+		LogDefaultScale: 45,
+	}
+
+	t.Run("Estimate", func(t *testing.T) {
+
+		bits, err := EstimatePrecision(paramsLit, CircuitProfile{Depth: 2, Rotations: 4, CtCtMuls: 2})
+		require.NoError(t, err)
+
+		// The estimate must predict a usable precision, but cannot exceed
+		// the scale itself.
+		require.Greater(t, bits, 10.0)
+		require.Less(t, bits, 45.0)
+	})
+
+	t.Run("Monotonicity", func(t *testing.T) {
+
+		shallow, err := EstimatePrecision(paramsLit, CircuitProfile{Depth: 1})
+		require.NoError(t, err)
+
+		deep, err := EstimatePrecision(paramsLit, CircuitProfile{Depth: 3, Rotations: 16, CtCtMuls: 8})
+		require.NoError(t, err)
+
+		require.GreaterOrEqual(t, shallow, deep)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+
+		_, err := EstimatePrecision(paramsLit, CircuitProfile{Depth: 4})
+		require.Error(t, err)
+
+		_, err = EstimatePrecision(paramsLit, CircuitProfile{Rotations: -1})
+		require.Error(t, err)
+
+		_, err = EstimatePrecision(ParametersLiteral{LogN: 1}, CircuitProfile{})
+		require.Error(t, err)
+	})
+}
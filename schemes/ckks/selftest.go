@@ -0,0 +1,123 @@
+package ckks
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+)
+
+// SelfTest runs a short known-answer test of the scheme under the given
+// parameters: key generation, encryption, addition, relinearized
+// multiplication, rescaling and rotation are evaluated on fixed test vectors
+// and checked against the expected results, up to an error tolerance derived
+// from the default scale. It returns an error identifying the first failing
+// operation, enabling services to catch miscompiled assembly or broken CPU
+// feature paths at startup, before serving traffic.
+func SelfTest(params Parameters) (err error) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("self-test panic: %v", r)
+		}
+	}()
+
+	slots := params.MaxSlots()
+
+	// Loose tolerance: a genuinely broken kernel yields garbage far above it,
+	// while a healthy evaluation stays well below.
+	tolerance := math.Exp2(-float64(params.LogDefaultScale()) / 2)
+
+	kgen := rlwe.NewKeyGenerator(params)
+	sk, pk := kgen.GenKeyPairNew()
+
+	galEl := params.GaloisElementForRotation(1)
+	evk := rlwe.NewMemEvaluationKeySet(kgen.GenRelinearizationKeyNew(sk), kgen.GenGaloisKeysNew([]uint64{galEl}, sk)...)
+
+	ecd := NewEncoder(params)
+	enc := rlwe.NewEncryptor(params, pk)
+	dec := rlwe.NewDecryptor(params, sk)
+	eval := NewEvaluator(params, evk)
+
+	values0 := make([]float64, slots)
+	values1 := make([]float64, slots)
+	for i := range values0 {
+		values0[i] = float64(i%17)/17 - 0.5
+		values1[i] = float64(i%23)/23 - 0.5
+	}
+
+	encrypt := func(values []float64) (*rlwe.Ciphertext, error) {
+		pt := NewPlaintext(params, params.MaxLevel())
+		if err := ecd.Encode(values, pt); err != nil {
+			return nil, err
+		}
+		return enc.EncryptNew(pt)
+	}
+
+	check := func(op string, ct *rlwe.Ciphertext, want []float64) error {
+		have := make([]float64, slots)
+		if err := ecd.Decode(dec.DecryptNew(ct), have); err != nil {
+			return fmt.Errorf("self-test failed: %s: %w", op, err)
+		}
+		for i := range want {
+			if diff := math.Abs(have[i] - want[i]); diff > tolerance {
+				return fmt.Errorf("self-test failed: %s: slot %d holds %f, expected %f (tolerance %e)", op, i, have[i], want[i], tolerance)
+			}
+		}
+		return nil
+	}
+
+	var ct0, ct1 *rlwe.Ciphertext
+	if ct0, err = encrypt(values0); err != nil {
+		return fmt.Errorf("self-test failed: encrypt: %w", err)
+	}
+	if ct1, err = encrypt(values1); err != nil {
+		return fmt.Errorf("self-test failed: encrypt: %w", err)
+	}
+
+	// Encrypt/Decrypt
+	if err = check("encrypt/decrypt", ct0, values0); err != nil {
+		return
+	}
+
+	// Add
+	want := make([]float64, slots)
+	for i := range want {
+		want[i] = values0[i] + values1[i]
+	}
+
+	ctAdd, err := eval.AddNew(ct0, ct1)
+	if err != nil {
+		return fmt.Errorf("self-test failed: add: %w", err)
+	}
+	if err = check("add", ctAdd, want); err != nil {
+		return
+	}
+
+	// MulRelin followed by Rescale
+	for i := range want {
+		want[i] = values0[i] * values1[i]
+	}
+
+	ctMul, err := eval.MulRelinNew(ct0, ct1)
+	if err != nil {
+		return fmt.Errorf("self-test failed: mul: %w", err)
+	}
+
+	if err = eval.Rescale(ctMul, ctMul); err != nil {
+		return fmt.Errorf("self-test failed: rescale: %w", err)
+	}
+	if err = check("mul/rescale", ctMul, want); err != nil {
+		return
+	}
+
+	// Rotation by one slot
+	copy(want, values0[1:])
+	want[slots-1] = values0[0]
+
+	ctRot, err := eval.RotateNew(ct0, 1)
+	if err != nil {
+		return fmt.Errorf("self-test failed: rotate: %w", err)
+	}
+	return check("rotate", ctRot, want)
+}
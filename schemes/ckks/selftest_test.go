@@ -0,0 +1,20 @@
+package ckks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelfTest(t *testing.T) {
+
+	// Insecure parameters used for the sole purpose of fast testing.
+	params, err := NewParametersFromLiteral(ParametersLiteral{
+		LogN:            10,
+		LogQ:            []int{55, 45, 45},
+		LogP:            []int{61},
+		LogDefaultScale: 45,
+	})
+	require.NoError(t, err)
+	require.NoError(t, SelfTest(params))
+}
@@ -0,0 +1,75 @@
+package ckks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tuneinsight/lattigo/v6/core/rlwe"
+	"github.com/tuneinsight/lattigo/v6/utils/sampling"
+)
+
+func TestTraceSum(t *testing.T) {
+
+	tc := NewTestContext(testInsecurePrec45)
+
+	params := tc.Params
+
+	slots := params.MaxSlots()
+
+	values := make([]float64, slots)
+	for i := range values {
+		values[i] = sampling.RandFloat64(-1, 1)
+	}
+
+	pt := NewPlaintext(params, params.MaxLevel())
+	require.NoError(t, tc.Ecd.Encode(values, pt))
+	ct, err := tc.Enc.EncryptNew(pt)
+	require.NoError(t, err)
+
+	t.Run("TraceSum/AllSlots", func(t *testing.T) {
+
+		// For n = slots, every slot of the output holds the sum of all the slots
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+
+		want := make([]float64, slots)
+		for i := range want {
+			want[i] = sum
+		}
+
+		eval := tc.Evl.WithKey(rlwe.NewMemEvaluationKeySet(nil, tc.Kgen.GenGaloisKeysNew(rlwe.GaloisElementsForTraceSum(params, slots, false), tc.Sk)...))
+
+		res := NewCiphertext(params, 1, ct.Level())
+		require.NoError(t, eval.TraceSum(ct, slots, false, res))
+
+		have := make([]float64, slots)
+		require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(res), have))
+
+		VerifyTestVectors(params, tc.Ecd, nil, want, have, params.LogDefaultScale()-params.LogMaxSlots(), 0, false, t)
+	})
+
+	t.Run("TraceSum/FirstSlotOnly", func(t *testing.T) {
+
+		n := 8
+
+		eval := tc.Evl.WithKey(rlwe.NewMemEvaluationKeySet(nil, tc.Kgen.GenGaloisKeysNew(rlwe.GaloisElementsForTraceSum(params, n, true), tc.Sk)...))
+
+		res := NewCiphertext(params, 1, ct.Level())
+		require.NoError(t, eval.TraceSum(ct, n, true, res))
+
+		have := make([]float64, slots)
+		require.NoError(t, tc.Ecd.Decode(tc.Dec.DecryptNew(res), have))
+
+		// Only the first slot of each group of n slots is significant
+		for i := 0; i < slots; i += n {
+			var want float64
+			for j := i; j < i+n; j++ {
+				want += values[j]
+			}
+			require.InDelta(t, want, have[i], 1e-8)
+		}
+	})
+}
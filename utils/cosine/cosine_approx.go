@@ -15,38 +15,53 @@ import (
 )
 
 const (
+	// EncodingPrecision is the precision in bits used by [ApproximateCos].
 	EncodingPrecision = uint(256)
 )
 
 var (
 	log2TwoPi = math.Log2(2 * math.Pi)
-	aQuarter  = bignum.NewFloat(0.25, EncodingPrecision)
-	pi        = bignum.Pi(EncodingPrecision)
 )
 
+// IntervalScaling determines how the approximation interval is compressed
+// before the Chebyshev solve: the function approximated is
+// cos(2*pi*(x-0.25)/2^scaling) over [-K, K]/2^scaling, to be followed by
+// scaling double-angle evaluations. A scaling of 0 approximates
+// cos(2*pi*(x-0.25)) directly.
+type IntervalScaling int
+
 // ApproximateCos computes a polynomial approximation of degree "degree" in Chebyshev basis of the function
-// cos(2*pi*x/2^"scnum") in the range -"K" to "K"
+// cos(2*pi*x/2^"scnum") in the range -"K" to "K", with [EncodingPrecision] bits of precision.
 // The nodes of the Chebyshev approximation are are located from -dev to +dev at each integer value between -K and -K
 func ApproximateCos(K, degree int, dev float64, scnum int) []*big.Float {
+	return Approximate(K, degree, dev, IntervalScaling(scnum), EncodingPrecision)
+}
+
+// Approximate computes a polynomial approximation of degree "degree" in Chebyshev basis of the function
+// cos(2*pi*x/2^"scaling") in the range -"K" to "K", with coefficients of "prec" bits of precision.
+// The nodes of the Chebyshev approximation are located from -dev to +dev at each integer value between -K and K.
+// The coefficients are returned as []*big.Float without down-casting, so that they can be consumed
+// by high-precision evaluation paths without truncation.
+func Approximate(K, degree int, dev float64, scaling IntervalScaling, prec uint) []*big.Float {
 
 	// Gets the list of degree per interval and the total degree
-	deg, totdeg := genDegrees(degree, K, dev)
+	deg, totdeg := GenDegrees(degree, K, dev)
 
 	// Generates the nodes for each interval, updates the total degree if needed
-	nodes, y := genNodes(deg, dev, totdeg, K, scnum)
+	nodes, y := GenNodes(deg, dev, totdeg, K, scaling, prec)
 
 	// Solves the linear system and returns the coefficients
-	return solve(totdeg, K, scnum, nodes, y)[:totdeg]
+	return Solve(totdeg, K, scaling, nodes, y, prec)[:totdeg]
 }
 
 // y = cos(2 * pi * (x - 0.25)/r)
-func cos2PiXMinusQuarterOverR(x, r *big.Float) (y *big.Float) {
+func cos2PiXMinusQuarterOverR(x, r *big.Float, prec uint) (y *big.Float) {
 	//y = 2 * pi
-	y = bignum.NewFloat(2.0, EncodingPrecision)
-	y.Mul(y, pi)
+	y = bignum.NewFloat(2.0, prec)
+	y.Mul(y, bignum.Pi(prec))
 
 	// x = (x - 0.25)/r
-	x.Sub(x, aQuarter)
+	x.Sub(x, bignum.NewFloat(0.25, prec))
 	x.Quo(x, r)
 
 	// y = 2 * pi * (x - 0.25)/r
@@ -76,9 +91,9 @@ func maxIndex(array []float64) (maxind int) {
 	return
 }
 
-// genDegrees returns the optimal list of nodes for each of the 0 <= i < K intervals [i +/- dev]
-// such that the sum of the nodes of all intervals is equal to degree.
-func genDegrees(degree, K int, dev float64) ([]int, int) {
+// GenDegrees returns the optimal list of nodes for each of the 0 <= i < K intervals [i +/- dev]
+// such that the sum of the nodes of all intervals is equal to degree, along with the total degree.
+func GenDegrees(degree, K int, dev float64) ([]int, int) {
 
 	var degbdd = degree + 1
 
@@ -157,12 +172,16 @@ func genDegrees(degree, K int, dev float64) ([]int, int) {
 	return deg, totdeg
 }
 
-func genNodes(deg []int, dev float64, totdeg, K, scnum int) ([]*big.Float, []*big.Float) {
+// GenNodes generates the totdeg interpolation nodes of the intervals [i +/- 1/dev] (see [GenDegrees])
+// and their images y[i] = cos(2*pi*(nodes[i]-0.25)/2^scaling), with prec bits of precision.
+func GenNodes(deg []int, dev float64, totdeg, K int, scaling IntervalScaling, prec uint) ([]*big.Float, []*big.Float) {
 
-	var scfac = bignum.NewFloat(1<<scnum, EncodingPrecision)
+	pi := bignum.Pi(prec)
+
+	var scfac = bignum.NewFloat(float64(int64(1)<<scaling), prec)
 
 	// Interval [i+e, i-e] with e = 1/dev
-	var intersize = bignum.NewFloat(1.0/dev, EncodingPrecision)
+	var intersize = bignum.NewFloat(1.0/dev, prec)
 
 	// ===================
 	// Allocates the nodes
@@ -171,7 +190,7 @@ func genNodes(deg []int, dev float64, totdeg, K, scnum int) ([]*big.Float, []*bi
 	// nodes
 	var nodes = make([]*big.Float, totdeg)
 	for i := range nodes {
-		nodes[i] = bignum.NewFloat(0, EncodingPrecision)
+		nodes[i] = bignum.NewFloat(0, prec)
 	}
 
 	// Ensures deg[0] is even
@@ -185,8 +204,8 @@ func genNodes(deg []int, dev float64, totdeg, K, scnum int) ([]*big.Float, []*bi
 	tmp := new(big.Float)
 	for i := K - 1; i > 0; i-- {
 
-		twodegi := bignum.NewFloat(2*deg[i], EncodingPrecision)
-		iF := bignum.NewFloat(i, EncodingPrecision)
+		twodegi := bignum.NewFloat(2*deg[i], prec)
+		iF := bignum.NewFloat(i, prec)
 
 		// For each node in the interval
 		for j := 0; j < deg[i]; j++ {
@@ -227,16 +246,21 @@ func genNodes(deg []int, dev float64, totdeg, K, scnum int) ([]*big.Float, []*bi
 	var y = make([]*big.Float, totdeg)
 	for i := 0; i < totdeg; i++ {
 		// y[i] = cos(2*pi*(nodes[i]-0.25)/r)
-		y[i] = cos2PiXMinusQuarterOverR(nodes[i], scfac)
+		y[i] = cos2PiXMinusQuarterOverR(nodes[i], scfac, prec)
 	}
 
 	return nodes, y
 }
 
-func solve(totdeg, K, scnum int, nodes, y []*big.Float) []*big.Float {
+// Solve interpolates the nodes generated by [GenNodes] and returns the coefficients of the
+// interpolant in the Chebyshev basis of the interval [-K, K]/2^scaling, with prec bits of
+// precision.
+func Solve(totdeg, K int, scaling IntervalScaling, nodes, y []*big.Float, prec uint) []*big.Float {
+
+	pi := bignum.Pi(prec)
 
 	// 2^r
-	scfac := bignum.NewFloat(float64(int(1<<scnum)), EncodingPrecision)
+	scfac := bignum.NewFloat(float64(int64(1)<<scaling), prec)
 
 	tmp := new(big.Float)
 
@@ -261,7 +285,7 @@ func solve(totdeg, K, scnum int, nodes, y []*big.Float) []*big.Float {
 	var x = make([]*big.Float, totdeg)
 	for i := 0; i < totdeg; i++ {
 		// x[i] = K
-		x[i] = bignum.NewFloat(float64(K), EncodingPrecision)
+		x[i] = bignum.NewFloat(float64(K), prec)
 
 		// x[i] = K/r
 		x[i].Quo(x[i], scfac)
@@ -292,7 +316,7 @@ func solve(totdeg, K, scnum int, nodes, y []*big.Float) []*big.Float {
 	// Constructs the totdeg x totdeg linear system using x
 	for i := 0; i < totdeg; i++ {
 
-		T[i][0] = bignum.NewFloat(1.0, EncodingPrecision)
+		T[i][0] = bignum.NewFloat(1.0, prec)
 
 		T[i][1] = new(big.Float).Set(x[i])
 
@@ -302,7 +326,7 @@ func solve(totdeg, K, scnum int, nodes, y []*big.Float) []*big.Float {
 
 		for j := 2; j < totdeg; j++ {
 
-			T[i][j] = bignum.NewFloat(2.0, EncodingPrecision)
+			T[i][j] = bignum.NewFloat(2.0, prec)
 
 			tmp.Quo(KBig, scfac)
 			tmp.Quo(x[i], tmp)
@@ -349,7 +373,7 @@ func solve(totdeg, K, scnum int, nodes, y []*big.Float) []*big.Float {
 		}
 
 		p[i].Quo(p[i], T[i][i])
-		T[i][i] = bignum.NewFloat(1.0, EncodingPrecision)
+		T[i][i] = bignum.NewFloat(1.0, prec)
 
 		for j := i + 1; j < totdeg; j++ {
 			tmp.Mul(T[j][i], p[i])
@@ -358,7 +382,7 @@ func solve(totdeg, K, scnum int, nodes, y []*big.Float) []*big.Float {
 				tmp.Mul(T[j][i], T[i][l])
 				T[j][l].Sub(T[j][l], tmp)
 			}
-			T[j][i] = bignum.NewFloat(0.0, EncodingPrecision)
+			T[j][i] = bignum.NewFloat(0.0, prec)
 		}
 	}
 
@@ -2,6 +2,7 @@ package sampling
 
 import (
 	"crypto/rand"
+	"encoding/binary"
 	"fmt"
 	"io"
 
@@ -28,6 +29,7 @@ type KeyedPRNG struct {
 func NewKeyedPRNG(key []byte) (*KeyedPRNG, error) {
 	var err error
 	prng := new(KeyedPRNG)
+	prng.key = append([]byte(nil), key...)
 	prng.xof, err = blake2b.NewXOF(blake2b.OutputLengthUnknown, key)
 	return prng, err
 }
@@ -54,6 +56,30 @@ func (prng *KeyedPRNG) Key() (key []byte) {
 	return
 }
 
+// Fork derives a new [KeyedPRNG] from the key of the receiver and a domain-separation
+// label. The derivation is deterministic: two parties holding identically keyed PRNGs
+// and forking with the same label obtain identical streams, while any two distinct
+// labels yield computationally independent streams. Forks can be nested to build a
+// hierarchy of streams (e.g. one per protocol, then one per round) from a single
+// shared key, and are independent of the read position of the receiver.
+func (prng *KeyedPRNG) Fork(label string) (*KeyedPRNG, error) {
+
+	h, err := blake2b.New512(nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot Fork: %w", err)
+	}
+
+	// child key = H(len(key) || key || label), binding both the key and the label
+	var size [8]byte
+	binary.LittleEndian.PutUint64(size[:], uint64(len(prng.key)))
+
+	h.Write(size[:])
+	h.Write(prng.key)
+	h.Write([]byte(label))
+
+	return NewKeyedPRNG(h.Sum(nil))
+}
+
 // Read reads bytes from the KeyedPRNG on sum.
 func (prng *KeyedPRNG) Read(sum []byte) (n int, err error) {
 	return prng.xof.Read(sum)
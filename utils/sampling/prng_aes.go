@@ -0,0 +1,101 @@
+package sampling
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+)
+
+// AESPRNG is a structure storing the parameters used to securely and deterministically generate shared
+// sequences of random bytes among different parties using AES-128/256 in CTR mode. It implements the
+// same [PRNG] interface as [KeyedPRNG] and can be used as a drop-in replacement wherever the expansion
+// of public randomness must rely on an approved primitive (e.g. FIPS 140); on amd64 and arm64 the
+// keystream generation is hardware accelerated (AES-NI).
+type AESPRNG struct {
+	key    []byte
+	label  []byte
+	iv     [aes.BlockSize]byte
+	stream cipher.Stream
+}
+
+// NewKeyedAESPRNG creates a new instance of [AESPRNG] from an explicit key and a domain-separation
+// label. The key must be 16 (AES-128) or 32 (AES-256) bytes long. Two instances with the same key but
+// different labels produce independent streams, allowing a single shared key to seed the randomness
+// of several protocol instances or sub-protocols.
+func NewKeyedAESPRNG(key, label []byte) (*AESPRNG, error) {
+
+	if len(key) != 16 && len(key) != 32 {
+		return nil, fmt.Errorf("invalid key: must be 16 or 32 bytes but is %d", len(key))
+	}
+
+	prng := &AESPRNG{
+		key:   append([]byte{}, key...),
+		label: append([]byte{}, label...),
+	}
+
+	// Domain separation: the CTR IV is derived from the label, so that the streams
+	// of two instances with the same key and different labels are independent.
+	digest := sha256.Sum256(prng.label)
+	copy(prng.iv[:], digest[:])
+
+	if err := prng.init(); err != nil {
+		return nil, err
+	}
+
+	return prng, nil
+}
+
+// NewAESPRNG creates an [AESPRNG] keyed with 32 bytes from rand.Read and an empty domain-separation
+// label, for instances where no key should be provided by the user.
+func NewAESPRNG() (*AESPRNG, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("crypto rand error: %w", err)
+	}
+	return NewKeyedAESPRNG(key, nil)
+}
+
+func (prng *AESPRNG) init() error {
+	block, err := aes.NewCipher(prng.key)
+	if err != nil {
+		return fmt.Errorf("cannot initialize AES: %w", err)
+	}
+	prng.stream = cipher.NewCTR(block, prng.iv[:])
+	return nil
+}
+
+// Key returns a copy of the key used to seed the PRNG.
+// This value can be used with [NewKeyedAESPRNG], together with the label, to instantiate
+// a new PRNG that will produce the same stream of bytes.
+func (prng *AESPRNG) Key() (key []byte) {
+	key = make([]byte, len(prng.key))
+	copy(key, prng.key)
+	return
+}
+
+// Label returns a copy of the domain-separation label of the PRNG.
+func (prng *AESPRNG) Label() (label []byte) {
+	label = make([]byte, len(prng.label))
+	copy(label, prng.label)
+	return
+}
+
+// Read reads bytes from the AESPRNG on sum.
+func (prng *AESPRNG) Read(sum []byte) (n int, err error) {
+	for i := range sum {
+		sum[i] = 0
+	}
+	prng.stream.XORKeyStream(sum, sum)
+	return len(sum), nil
+}
+
+// Reset resets the PRNG to its initial state.
+func (prng *AESPRNG) Reset() {
+
+	// Sanity check, this error should not happen (the key was already validated).
+	if err := prng.init(); err != nil {
+		panic(err)
+	}
+}
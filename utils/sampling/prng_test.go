@@ -32,4 +32,86 @@ func Test_PRNG(t *testing.T) {
 		require.Equal(t, sum0, sum1)
 	})
 
+	t.Run("Fork", func(t *testing.T) {
+
+		key := []byte{0x49, 0x0a, 0x42, 0x3d, 0x97, 0x9d, 0xc1, 0x07, 0xa1, 0xd7, 0xe9, 0x7b, 0x3b, 0xce, 0xa1, 0xdb,
+			0x42, 0xf3, 0xa6, 0xd5, 0x75, 0xd2, 0x0c, 0x92, 0xb7, 0x35, 0xce, 0x0c, 0xee, 0x09, 0x7c, 0x98}
+
+		Ha, _ := sampling.NewKeyedPRNG(key)
+		Hb, _ := sampling.NewKeyedPRNG(key)
+
+		// Forks are independent of the read position of the parent.
+		buff := make([]byte, 512)
+		Hb.Read(buff)
+
+		Fa, err := Ha.Fork("round-1")
+		require.NoError(t, err)
+		Fb, err := Hb.Fork("round-1")
+		require.NoError(t, err)
+
+		sum0 := make([]byte, 512)
+		sum1 := make([]byte, 512)
+
+		Fa.Read(sum0)
+		Fb.Read(sum1)
+
+		require.Equal(t, sum0, sum1)
+
+		// Distinct labels yield distinct streams.
+		Fc, err := Ha.Fork("round-2")
+		require.NoError(t, err)
+
+		Fc.Read(sum1)
+		require.NotEqual(t, sum0, sum1)
+
+		// Forks can be nested and remain deterministic.
+		Fa1, err := Fa.Fork("sub")
+		require.NoError(t, err)
+		Fb1, err := Fb.Fork("sub")
+		require.NoError(t, err)
+
+		Fa1.Read(sum0)
+		Fb1.Read(sum1)
+
+		require.Equal(t, sum0, sum1)
+	})
+
+	t.Run("AESPRNG", func(t *testing.T) {
+
+		key := []byte{0x49, 0x0a, 0x42, 0x3d, 0x97, 0x9d, 0xc1, 0x07, 0xa1, 0xd7, 0xe9, 0x7b, 0x3b, 0xce, 0xa1, 0xdb,
+			0x42, 0xf3, 0xa6, 0xd5, 0x75, 0xd2, 0x0c, 0x92, 0xb7, 0x35, 0xce, 0x0c, 0xee, 0x09, 0x7c, 0x98}
+
+		label := []byte("test")
+
+		Ha, err := sampling.NewKeyedAESPRNG(key, label)
+		require.NoError(t, err)
+		Hb, err := sampling.NewKeyedAESPRNG(key, label)
+		require.NoError(t, err)
+
+		sum0 := make([]byte, 512)
+		sum1 := make([]byte, 512)
+
+		for i := 0; i < 128; i++ {
+			Hb.Read(sum1)
+		}
+
+		Hb.Reset()
+
+		Ha.Read(sum0)
+		Hb.Read(sum1)
+
+		require.Equal(t, sum0, sum1)
+
+		// A different label yields an independent stream under the same key.
+		Hc, err := sampling.NewKeyedAESPRNG(key, []byte("other"))
+		require.NoError(t, err)
+
+		Hc.Read(sum1)
+		require.NotEqual(t, sum0, sum1)
+
+		// Only AES-128 and AES-256 keys are accepted.
+		_, err = sampling.NewKeyedAESPRNG(key[:24], nil)
+		require.Error(t, err)
+	})
+
 }
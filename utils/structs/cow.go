@@ -0,0 +1,80 @@
+package structs
+
+import "golang.org/x/exp/constraints"
+
+// CopyOnWriteVector is a copy-on-write wrapper around a [Vector]: snapshots
+// share the backing components until one side is mutated, so snapshotting
+// large vectors (e.g. of polynomials) for retries is O(1) and the deep copy
+// is only paid by the side that actually writes.
+type CopyOnWriteVector[T any] struct {
+	v     Vector[T]
+	owned bool
+}
+
+// NewCopyOnWriteVector wraps the provided vector, taking ownership of it: the
+// caller must not mutate v through the original reference afterwards.
+func NewCopyOnWriteVector[T any](v Vector[T]) *CopyOnWriteVector[T] {
+	return &CopyOnWriteVector[T]{v: v, owned: true}
+}
+
+// Snapshot returns a snapshot sharing the backing components with the
+// receiver in O(1); both sides deep-copy on their next mutable access.
+func (c *CopyOnWriteVector[T]) Snapshot() *CopyOnWriteVector[T] {
+	c.owned = false
+	return &CopyOnWriteVector[T]{v: c.v}
+}
+
+// Value returns a read-only view of the wrapped vector, which must not be
+// mutated: writes must go through [CopyOnWriteVector.Mutable].
+func (c *CopyOnWriteVector[T]) Value() Vector[T] {
+	return c.v
+}
+
+// Mutable returns the wrapped vector for writing, first deep-copying it if it
+// is shared with a snapshot. If T is a struct, this method requires that T
+// implements [CopyNewer].
+func (c *CopyOnWriteVector[T]) Mutable() Vector[T] {
+	if !c.owned {
+		c.v = c.v.CopyNew()
+		c.owned = true
+	}
+	return c.v
+}
+
+// CopyOnWriteMap is a copy-on-write wrapper around a [Map]: snapshots share
+// the backing components until one side is mutated, so snapshotting protocol
+// state for retries is O(1) and the deep copy is only paid by the side that
+// actually writes.
+type CopyOnWriteMap[K constraints.Integer, T any] struct {
+	m     Map[K, T]
+	owned bool
+}
+
+// NewCopyOnWriteMap wraps the provided map, taking ownership of it: the
+// caller must not mutate m through the original reference afterwards.
+func NewCopyOnWriteMap[K constraints.Integer, T any](m Map[K, T]) *CopyOnWriteMap[K, T] {
+	return &CopyOnWriteMap[K, T]{m: m, owned: true}
+}
+
+// Snapshot returns a snapshot sharing the backing components with the
+// receiver in O(1); both sides deep-copy on their next mutable access.
+func (c *CopyOnWriteMap[K, T]) Snapshot() *CopyOnWriteMap[K, T] {
+	c.owned = false
+	return &CopyOnWriteMap[K, T]{m: c.m}
+}
+
+// Value returns a read-only view of the wrapped map, which must not be
+// mutated: writes must go through [CopyOnWriteMap.Mutable].
+func (c *CopyOnWriteMap[K, T]) Value() Map[K, T] {
+	return c.m
+}
+
+// Mutable returns the wrapped map for writing, first deep-copying it if it is
+// shared with a snapshot. This method requires that T implements [CopyNewer].
+func (c *CopyOnWriteMap[K, T]) Mutable() Map[K, T] {
+	if !c.owned {
+		c.m = *c.m.CopyNew()
+		c.owned = true
+	}
+	return c.m
+}
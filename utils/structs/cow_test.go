@@ -0,0 +1,111 @@
+package structs
+
+import (
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testEntry is a minimal struct component implementing the interfaces
+// required by the copy-on-write, equality and hashing helpers.
+type testEntry struct {
+	V uint64
+}
+
+func (e testEntry) CopyNew() *testEntry {
+	return &testEntry{V: e.V}
+}
+
+func (e testEntry) Equal(other *testEntry) bool {
+	return e.V == other.V
+}
+
+func (e *testEntry) WriteTo(w io.Writer) (int64, error) {
+	var buff [8]byte
+	binary.LittleEndian.PutUint64(buff[:], e.V)
+	n, err := w.Write(buff[:])
+	return int64(n), err
+}
+
+func TestCopyOnWriteVector(t *testing.T) {
+
+	v := Vector[uint64]{1, 2, 3}
+
+	orig := NewCopyOnWriteVector(v)
+	snap := orig.Snapshot()
+
+	// Snapshotting is O(1): both sides still share the backing slice.
+	require.Equal(t, &orig.Value()[0], &snap.Value()[0])
+
+	orig.Mutable()[0] = 42
+
+	require.Equal(t, uint64(42), orig.Value()[0])
+	require.Equal(t, uint64(1), snap.Value()[0])
+
+	// Once owned, further mutable accesses do not copy again.
+	require.Equal(t, &orig.Mutable()[0], &orig.Mutable()[0])
+}
+
+func TestCopyOnWriteMap(t *testing.T) {
+
+	m := Map[uint64, testEntry]{0: {V: 1}, 1: {V: 2}}
+
+	orig := NewCopyOnWriteMap(m)
+	snap := orig.Snapshot()
+
+	require.Equal(t, orig.Value()[0], snap.Value()[0])
+
+	orig.Mutable()[0] = &testEntry{V: 42}
+
+	require.Equal(t, uint64(42), orig.Value()[0].V)
+	require.Equal(t, uint64(1), snap.Value()[0].V)
+}
+
+func TestMapEqual(t *testing.T) {
+
+	m0 := Map[uint64, testEntry]{0: {V: 1}, 1: {V: 2}}
+	m1 := Map[uint64, testEntry]{0: {V: 1}, 1: {V: 2}}
+
+	require.True(t, m0.Equal(m1))
+
+	m1[1] = &testEntry{V: 3}
+	require.False(t, m0.Equal(m1))
+
+	delete(m1, 1)
+	require.False(t, m0.Equal(m1))
+}
+
+func TestHash(t *testing.T) {
+
+	v0 := Vector[uint64]{1, 2, 3}
+	v1 := Vector[uint64]{1, 2, 3}
+
+	h0, err := v0.Hash()
+	require.NoError(t, err)
+	h1, err := v1.Hash()
+	require.NoError(t, err)
+	require.Equal(t, h0, h1)
+
+	v1[2] = 4
+	h1, err = v1.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, h0, h1)
+
+	m0 := Map[uint64, testEntry]{0: {V: 1}, 1: {V: 2}}
+	m1 := Map[uint64, testEntry]{1: {V: 2}, 0: {V: 1}}
+
+	// The map serialization iterates the keys in sorted order, so the
+	// digest is independent of the insertion order.
+	d0, err := m0.Hash()
+	require.NoError(t, err)
+	d1, err := m1.Hash()
+	require.NoError(t, err)
+	require.Equal(t, d0, d1)
+
+	m1[0] = &testEntry{V: 3}
+	d1, err = m1.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, d0, d1)
+}
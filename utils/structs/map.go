@@ -30,6 +30,36 @@ func (m Map[K, T]) CopyNew() *Map[K, T] {
 	return &mcpy
 }
 
+// Equal performs a deep equal.
+// This method requires that T implements [Equatable].
+func (m Map[K, T]) Equal(other Map[K, T]) bool {
+
+	if e, isEquatable := any(new(T)).(Equatable[T]); !isEquatable {
+		panic(fmt.Errorf("map component of type %T does not comply to %T", new(T), e))
+	}
+
+	if len(m) != len(other) {
+		return false
+	}
+
+	for key, value := range m {
+		otherValue, ok := other[key]
+		if !ok || !any(value).(Equatable[T]).Equal(otherValue) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Hash returns the sha256 digest of the serialized map, providing a cheap
+// deterministic fingerprint of its content, e.g. to detect state divergence
+// without a full comparison. This method requires that T implements
+// [io.WriterTo].
+func (m Map[K, T]) Hash() (digest [32]byte, err error) {
+	return hashWriterTo(&m)
+}
+
 // WriteTo writes the object on an io.Writer. It implements the io.WriterTo
 // interface, and will write exactly object.BinarySize() bytes on w.
 //
@@ -72,7 +102,7 @@ func (m *Map[K, T]) WriteTo(w io.Writer) (n int64, err error) {
 			n += inc
 		}
 
-		return
+		return n, w.Flush()
 
 	default:
 		return m.WriteTo(bufio.NewWriter(w))
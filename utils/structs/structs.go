@@ -1,10 +1,25 @@
 // Package structs implements helpers to generalize vectors and matrices of structs, as well as their serialization.
 package structs
 
+import (
+	"crypto/sha256"
+	"io"
+)
+
 type Equatable[T any] interface {
 	Equal(*T) bool
 }
 
+// hashWriterTo returns the sha256 digest of the serialized object.
+func hashWriterTo(wt io.WriterTo) (digest [32]byte, err error) {
+	h := sha256.New()
+	if _, err = wt.WriteTo(h); err != nil {
+		return [32]byte{}, err
+	}
+	copy(digest[:], h.Sum(nil))
+	return
+}
+
 type CopyNewer[V any] interface {
 	CopyNew() *V
 }
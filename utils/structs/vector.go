@@ -280,3 +280,11 @@ func (v Vector[T]) Equal(other Vector[T]) (isEqual bool) {
 		return true
 	}
 }
+
+// Hash returns the sha256 digest of the serialized vector, providing a cheap
+// deterministic fingerprint of its content, e.g. to detect state divergence
+// without a full comparison. If T is a struct, this method requires that T
+// implements [io.WriterTo].
+func (v Vector[T]) Hash() (digest [32]byte, err error) {
+	return hashWriterTo(v)
+}